@@ -24,6 +24,245 @@ const (
 	OAuth2Scopes     = "OAuth2.Scopes"
 )
 
+// Defines values for ArtistAnalyticsGranularity.
+const (
+	ArtistAnalyticsGranularityDay   ArtistAnalyticsGranularity = "day"
+	ArtistAnalyticsGranularityMonth ArtistAnalyticsGranularity = "month"
+	ArtistAnalyticsGranularityWeek  ArtistAnalyticsGranularity = "week"
+)
+
+// Defines values for ChartKind.
+const (
+	ChartKindCountry ChartKind = "country"
+	ChartKindGenre   ChartKind = "genre"
+	ChartKindGlobal  ChartKind = "global"
+)
+
+// Defines values for ChartEntryKind.
+const (
+	ChartEntryKindCountry ChartEntryKind = "country"
+	ChartEntryKindGenre   ChartEntryKind = "genre"
+	ChartEntryKindGlobal  ChartEntryKind = "global"
+)
+
+// Defines values for ContributorContributionType.
+const (
+	Composer ContributorContributionType = "composer"
+	Featured ContributorContributionType = "featured"
+	Mixer    ContributorContributionType = "mixer"
+	Primary  ContributorContributionType = "primary"
+	Producer ContributorContributionType = "producer"
+	Writer   ContributorContributionType = "writer"
+)
+
+// Defines values for EntityVersionAction.
+const (
+	Create   EntityVersionAction = "create"
+	Delete   EntityVersionAction = "delete"
+	Rollback EntityVersionAction = "rollback"
+	Update   EntityVersionAction = "update"
+)
+
+// Defines values for EntityVersionEntityType.
+const (
+	EntityVersionEntityTypeAlbum    EntityVersionEntityType = "album"
+	EntityVersionEntityTypeArtist   EntityVersionEntityType = "artist"
+	EntityVersionEntityTypePlaylist EntityVersionEntityType = "playlist"
+	EntityVersionEntityTypeSong     EntityVersionEntityType = "song"
+)
+
+// Defines values for ExportJobStatus.
+const (
+	ExportJobStatusCompleted ExportJobStatus = "completed"
+	ExportJobStatusFailed    ExportJobStatus = "failed"
+	ExportJobStatusPending   ExportJobStatus = "pending"
+	ExportJobStatusRunning   ExportJobStatus = "running"
+)
+
+// Defines values for ExportRequestDatasets.
+const (
+	Purchases ExportRequestDatasets = "purchases"
+	Royalties ExportRequestDatasets = "royalties"
+	Streams   ExportRequestDatasets = "streams"
+	Tips      ExportRequestDatasets = "tips"
+)
+
+// Defines values for ExportRequestFormats.
+const (
+	Csv     ExportRequestFormats = "csv"
+	Parquet ExportRequestFormats = "parquet"
+)
+
+// Defines values for LabelArtistStatus.
+const (
+	LabelArtistStatusActive  LabelArtistStatus = "active"
+	LabelArtistStatusPending LabelArtistStatus = "pending"
+)
+
+// Defines values for LabelMemberRole.
+const (
+	Manager LabelMemberRole = "manager"
+	Member  LabelMemberRole = "member"
+	Owner   LabelMemberRole = "owner"
+)
+
+// Defines values for ListeningHistoryItemContextType.
+const (
+	ListeningHistoryItemContextTypeAlbum    ListeningHistoryItemContextType = "album"
+	ListeningHistoryItemContextTypeArtist   ListeningHistoryItemContextType = "artist"
+	ListeningHistoryItemContextTypePlaylist ListeningHistoryItemContextType = "playlist"
+)
+
+// Defines values for PaymentProvider.
+const (
+	Fake        PaymentProvider = "fake"
+	Flutterwave PaymentProvider = "flutterwave"
+	Paystack    PaymentProvider = "paystack"
+	Stripe      PaymentProvider = "stripe"
+)
+
+// Defines values for PaymentPurpose.
+const (
+	PaymentPurposeAlbumPurchase PaymentPurpose = "album_purchase"
+	PaymentPurposeSongPurchase  PaymentPurpose = "song_purchase"
+	PaymentPurposeTip           PaymentPurpose = "tip"
+)
+
+// Defines values for PaymentStatus.
+const (
+	PaymentStatusCompleted PaymentStatus = "completed"
+	PaymentStatusFailed    PaymentStatus = "failed"
+	PaymentStatusPending   PaymentStatus = "pending"
+)
+
+// Defines values for PurchasePaymentStatus.
+const (
+	PurchasePaymentStatusCompleted PurchasePaymentStatus = "completed"
+	PurchasePaymentStatusFailed    PurchasePaymentStatus = "failed"
+	PurchasePaymentStatusPending   PurchasePaymentStatus = "pending"
+)
+
+// Defines values for RecentPlayKind.
+const (
+	RecentPlayKindAlbum    RecentPlayKind = "album"
+	RecentPlayKindPlaylist RecentPlayKind = "playlist"
+	RecentPlayKindSong     RecentPlayKind = "song"
+)
+
+// Defines values for SearchClickRequestResultType.
+const (
+	SearchClickRequestResultTypeAlbum    SearchClickRequestResultType = "album"
+	SearchClickRequestResultTypeArtist   SearchClickRequestResultType = "artist"
+	SearchClickRequestResultTypePlaylist SearchClickRequestResultType = "playlist"
+	SearchClickRequestResultTypeSong     SearchClickRequestResultType = "song"
+)
+
+// Defines values for SearchSuggestionType.
+const (
+	SearchSuggestionTypeAlbum    SearchSuggestionType = "album"
+	SearchSuggestionTypeArtist   SearchSuggestionType = "artist"
+	SearchSuggestionTypePlaylist SearchSuggestionType = "playlist"
+	SearchSuggestionTypeSong     SearchSuggestionType = "song"
+)
+
+// Defines values for StreamContextType.
+const (
+	StreamContextTypeAlbum    StreamContextType = "album"
+	StreamContextTypeArtist   StreamContextType = "artist"
+	StreamContextTypePlaylist StreamContextType = "playlist"
+)
+
+// Defines values for StreamInvalidReason.
+const (
+	Duplicate StreamInvalidReason = "duplicate"
+	Preview   StreamInvalidReason = "preview"
+	TooShort  StreamInvalidReason = "too_short"
+)
+
+// Defines values for StreamStatus.
+const (
+	StreamStatusInvalid    StreamStatus = "invalid"
+	StreamStatusQualified  StreamStatus = "qualified"
+	StreamStatusRejected   StreamStatus = "rejected"
+	StreamStatusSuspicious StreamStatus = "suspicious"
+)
+
+// Defines values for StreamReviewDecision.
+const (
+	StreamReviewDecisionApprove StreamReviewDecision = "approve"
+	StreamReviewDecisionReject  StreamReviewDecision = "reject"
+)
+
+// Defines values for TagKind.
+const (
+	TagKindMood TagKind = "mood"
+	TagKindTag  TagKind = "tag"
+)
+
+// Defines values for TerritoryRuleMode.
+const (
+	TerritoryRuleModeAllow TerritoryRuleMode = "allow"
+	TerritoryRuleModeDeny  TerritoryRuleMode = "deny"
+)
+
+// Defines values for TerritoryRulesMode.
+const (
+	TerritoryRulesModeAllow TerritoryRulesMode = "allow"
+	TerritoryRulesModeDeny  TerritoryRulesMode = "deny"
+)
+
+// Defines values for TipPaymentStatus.
+const (
+	TipPaymentStatusCompleted TipPaymentStatus = "completed"
+	TipPaymentStatusFailed    TipPaymentStatus = "failed"
+	TipPaymentStatusPending   TipPaymentStatus = "pending"
+)
+
+// Defines values for VerificationEventAction.
+const (
+	VerificationEventActionApproved    VerificationEventAction = "approved"
+	VerificationEventActionNeedsInfo   VerificationEventAction = "needs_info"
+	VerificationEventActionRejected    VerificationEventAction = "rejected"
+	VerificationEventActionResubmitted VerificationEventAction = "resubmitted"
+	VerificationEventActionRevoked     VerificationEventAction = "revoked"
+	VerificationEventActionSubmitted   VerificationEventAction = "submitted"
+)
+
+// Defines values for VerificationEvidenceKind.
+const (
+	Document VerificationEvidenceKind = "document"
+	Link     VerificationEvidenceKind = "link"
+)
+
+// Defines values for VerificationRequestStatus.
+const (
+	VerificationRequestStatusApproved  VerificationRequestStatus = "approved"
+	VerificationRequestStatusNeedsInfo VerificationRequestStatus = "needs_info"
+	VerificationRequestStatusPending   VerificationRequestStatus = "pending"
+	VerificationRequestStatusRejected  VerificationRequestStatus = "rejected"
+	VerificationRequestStatusRevoked   VerificationRequestStatus = "revoked"
+)
+
+// Defines values for VerificationReviewDecision.
+const (
+	VerificationReviewDecisionApprove   VerificationReviewDecision = "approve"
+	VerificationReviewDecisionNeedsInfo VerificationReviewDecision = "needs_info"
+	VerificationReviewDecisionReject    VerificationReviewDecision = "reject"
+)
+
+// Defines values for WrappedReportKind.
+const (
+	WrappedReportKindArtist   WrappedReportKind = "artist"
+	WrappedReportKindListener WrappedReportKind = "listener"
+)
+
+// Defines values for GetArtistsArtistIdAnalyticsParamsGranularity.
+const (
+	GetArtistsArtistIdAnalyticsParamsGranularityDay   GetArtistsArtistIdAnalyticsParamsGranularity = "day"
+	GetArtistsArtistIdAnalyticsParamsGranularityMonth GetArtistsArtistIdAnalyticsParamsGranularity = "month"
+	GetArtistsArtistIdAnalyticsParamsGranularityWeek  GetArtistsArtistIdAnalyticsParamsGranularity = "week"
+)
+
 // Defines values for PostFlagsJSONBodyTargetType.
 const (
 	PostFlagsJSONBodyTargetTypeAlbum PostFlagsJSONBodyTargetType = "album"
@@ -35,6 +274,7 @@ const (
 	GetSearchAlbumsParamsSortPopularity  GetSearchAlbumsParamsSort = "popularity"
 	GetSearchAlbumsParamsSortPrice       GetSearchAlbumsParamsSort = "price"
 	GetSearchAlbumsParamsSortReleaseDate GetSearchAlbumsParamsSort = "release_date"
+	GetSearchAlbumsParamsSortRelevance   GetSearchAlbumsParamsSort = "relevance"
 	GetSearchAlbumsParamsSortTitle       GetSearchAlbumsParamsSort = "title"
 )
 
@@ -49,12 +289,14 @@ const (
 const (
 	GetSearchGenresParamsSortName       GetSearchGenresParamsSort = "name"
 	GetSearchGenresParamsSortPopularity GetSearchGenresParamsSort = "popularity"
+	GetSearchGenresParamsSortRelevance  GetSearchGenresParamsSort = "relevance"
 )
 
 // Defines values for GetSearchPlaylistsParamsSort.
 const (
 	GetSearchPlaylistsParamsSortCreatedAt  GetSearchPlaylistsParamsSort = "created_at"
 	GetSearchPlaylistsParamsSortPopularity GetSearchPlaylistsParamsSort = "popularity"
+	GetSearchPlaylistsParamsSortRelevance  GetSearchPlaylistsParamsSort = "relevance"
 	GetSearchPlaylistsParamsSortTitle      GetSearchPlaylistsParamsSort = "title"
 	GetSearchPlaylistsParamsSortUpdatedAt  GetSearchPlaylistsParamsSort = "updated_at"
 )
@@ -64,6 +306,7 @@ const (
 	Duration    GetSearchSongsParamsSort = "duration"
 	Popularity  GetSearchSongsParamsSort = "popularity"
 	ReleaseDate GetSearchSongsParamsSort = "release_date"
+	Relevance   GetSearchSongsParamsSort = "relevance"
 	Title       GetSearchSongsParamsSort = "title"
 )
 
@@ -73,6 +316,35 @@ const (
 	Desc GetSearchSongsParamsOrder = "desc"
 )
 
+// Defines values for PostStreamsJSONBodyContextType.
+const (
+	PostStreamsJSONBodyContextTypeAlbum    PostStreamsJSONBodyContextType = "album"
+	PostStreamsJSONBodyContextTypeArtist   PostStreamsJSONBodyContextType = "artist"
+	PostStreamsJSONBodyContextTypePlaylist PostStreamsJSONBodyContextType = "playlist"
+)
+
+// Defines values for PostStreamsJSONBodyEvent.
+const (
+	End      PostStreamsJSONBodyEvent = "end"
+	Progress PostStreamsJSONBodyEvent = "progress"
+	Start    PostStreamsJSONBodyEvent = "start"
+)
+
+// Defines values for GetTagsParamsKind.
+const (
+	GetTagsParamsKindMood GetTagsParamsKind = "mood"
+	GetTagsParamsKindTag  GetTagsParamsKind = "tag"
+)
+
+// Defines values for GetVerificationRequestsParamsStatus.
+const (
+	Approved  GetVerificationRequestsParamsStatus = "approved"
+	NeedsInfo GetVerificationRequestsParamsStatus = "needs_info"
+	Pending   GetVerificationRequestsParamsStatus = "pending"
+	Rejected  GetVerificationRequestsParamsStatus = "rejected"
+	Revoked   GetVerificationRequestsParamsStatus = "revoked"
+)
+
 // Album defines model for Album.
 type Album struct {
 	ArtistId      openapi_types.UUID  `json:"artistId"`
@@ -83,30 +355,232 @@ type Album struct {
 	IsFlagged     *bool               `json:"is_flagged,omitempty"`
 	Price         *int                `json:"price,omitempty"`
 	ReleaseDate   *openapi_types.Date `json:"releaseDate,omitempty"`
-	Title         string              `json:"title"`
-	UpdatedAt     *time.Time          `json:"updatedAt,omitempty"`
+
+	// Relevance Full-text search rank, only present in search results
+	Relevance *float64   `json:"relevance,omitempty"`
+	Title     string     `json:"title"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// AlbumSearchSection defines model for AlbumSearchSection.
+type AlbumSearchSection struct {
+	Error  *string       `json:"error,omitempty"`
+	Facets *SearchFacets `json:"facets,omitempty"`
+
+	// Failed The section errored or timed out; items are empty
+	Failed *bool   `json:"failed,omitempty"`
+	Items  []Album `json:"items"`
+
+	// NextCursor Pass as cursor to fetch the next page of this section
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Total      int64   `json:"total"`
+}
+
+// AnalyticsChange Change of each total from the previous period as a share of the previous total, e.g. 0.5 for half as much again. Left out when the previous period had none.
+type AnalyticsChange struct {
+	Listeners *float64 `json:"listeners,omitempty"`
+	Purchases *float64 `json:"purchases,omitempty"`
+
+	// Revenue Amount per currency
+	Revenue *CurrencyAmounts `json:"revenue,omitempty"`
+	Streams *float64         `json:"streams,omitempty"`
+	Tips    *float64         `json:"tips,omitempty"`
+}
+
+// AnalyticsPoint defines model for AnalyticsPoint.
+type AnalyticsPoint struct {
+	Listeners *int64 `json:"listeners,omitempty"`
+
+	// Period First day of the day, week (from Monday) or month
+	Period    *openapi_types.Date `json:"period,omitempty"`
+	Purchases *int64              `json:"purchases,omitempty"`
+
+	// Revenue Amount per currency
+	Revenue *CurrencyAmounts `json:"revenue,omitempty"`
+	Streams *int64           `json:"streams,omitempty"`
+	Tips    *int64           `json:"tips,omitempty"`
+}
+
+// AnalyticsTotals defines model for AnalyticsTotals.
+type AnalyticsTotals struct {
+	// CompletionRate Share of listens that played through to the end of the song
+	CompletionRate *float64 `json:"completion_rate,omitempty"`
+
+	// Listeners Distinct listeners over the whole period
+	Listeners *int64 `json:"listeners,omitempty"`
+
+	// Listens Tracked plays and skips, the base of the completion and skip rates
+	Listens   *int64 `json:"listens,omitempty"`
+	Purchases *int64 `json:"purchases,omitempty"`
+
+	// Revenue Amount per currency
+	Revenue *CurrencyAmounts `json:"revenue,omitempty"`
+
+	// SkipRate Share of listens stopped before they counted as a play
+	SkipRate *float64 `json:"skip_rate,omitempty"`
+	Streams  *int64   `json:"streams,omitempty"`
+	Tips     *int64   `json:"tips,omitempty"`
 }
 
 // Artist defines model for Artist.
 type Artist struct {
-	ArtistName       string              `json:"artistName"`
-	CreatedAt        *time.Time          `json:"createdAt,omitempty"`
-	Id               *openapi_types.UUID `json:"id,omitempty"`
-	MonthlyListeners *int                `json:"monthlyListeners,omitempty"`
-	UpdatedAt        *time.Time          `json:"updatedAt,omitempty"`
-	UserId           openapi_types.UUID  `json:"userId"`
-	Verified         *bool               `json:"verified,omitempty"`
-	WalletBalance    *int                `json:"walletBalance,omitempty"`
+	ArtistName string              `json:"artistName"`
+	CreatedAt  *time.Time          `json:"createdAt,omitempty"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+
+	// MonthlyListeners Distinct listeners over the last 28 days, including songs the artist is credited on; computed daily
+	MonthlyListeners *int `json:"monthlyListeners,omitempty"`
+
+	// Relevance Full-text search rank, only present in search results
+	Relevance     *float64           `json:"relevance,omitempty"`
+	UpdatedAt     *time.Time         `json:"updatedAt,omitempty"`
+	UserId        openapi_types.UUID `json:"userId"`
+	Verified      *bool              `json:"verified,omitempty"`
+	WalletBalance *int               `json:"walletBalance,omitempty"`
+}
+
+// ArtistAnalytics defines model for ArtistAnalytics.
+type ArtistAnalytics struct {
+	ArtistId *openapi_types.UUID `json:"artist_id,omitempty"`
+
+	// Change Change of each total from the previous period as a share of the previous total, e.g. 0.5 for half as much again. Left out when the previous period had none.
+	Change           *AnalyticsChange            `json:"change,omitempty"`
+	Countries        *[]StreamShare              `json:"countries,omitempty"`
+	Devices          *[]StreamShare              `json:"devices,omitempty"`
+	From             *openapi_types.Date         `json:"from,omitempty"`
+	Granularity      *ArtistAnalyticsGranularity `json:"granularity,omitempty"`
+	MonthlyListeners *int                        `json:"monthly_listeners,omitempty"`
+
+	// MonthlyListenersHistory Monthly listeners as of each day of the range they were computed for
+	MonthlyListenersHistory *[]DailyListenerCount `json:"monthly_listeners_history,omitempty"`
+	Previous                *AnalyticsTotals      `json:"previous,omitempty"`
+	PreviousFrom            *openapi_types.Date   `json:"previous_from,omitempty"`
+	PreviousTo              *openapi_types.Date   `json:"previous_to,omitempty"`
+	Series                  *[]AnalyticsPoint     `json:"series,omitempty"`
+	Songs                   *[]SongAnalytics      `json:"songs,omitempty"`
+	To                      *openapi_types.Date   `json:"to,omitempty"`
+	Totals                  *AnalyticsTotals      `json:"totals,omitempty"`
+}
+
+// ArtistAnalyticsGranularity defines model for ArtistAnalytics.Granularity.
+type ArtistAnalyticsGranularity string
+
+// ArtistRoyaltyTotal defines model for ArtistRoyaltyTotal.
+type ArtistRoyaltyTotal struct {
+	Amount     *int64              `json:"amount,omitempty"`
+	ArtistId   *openapi_types.UUID `json:"artistId,omitempty"`
+	ArtistName *string             `json:"artistName,omitempty"`
+	Currency   *string             `json:"currency,omitempty"`
+	PaidAmount *int64              `json:"paidAmount,omitempty"`
+}
+
+// ArtistSearchSection defines model for ArtistSearchSection.
+type ArtistSearchSection struct {
+	Error  *string       `json:"error,omitempty"`
+	Facets *SearchFacets `json:"facets,omitempty"`
+
+	// Failed The section errored or timed out; items are empty
+	Failed *bool    `json:"failed,omitempty"`
+	Items  []Artist `json:"items"`
+
+	// NextCursor Pass as cursor to fetch the next page of this section
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Total      int64   `json:"total"`
+}
+
+// ArtistWrapped defines model for ArtistWrapped.
+type ArtistWrapped struct {
+	Countries       *int64            `json:"countries,omitempty"`
+	Listeners       *int64            `json:"listeners,omitempty"`
+	MinutesListened *int64            `json:"minutes_listened,omitempty"`
+	Streams         *int64            `json:"streams,omitempty"`
+	TopCountries    *[]WrappedCountry `json:"top_countries,omitempty"`
+	TopSongs        *[]WrappedSong    `json:"top_songs,omitempty"`
+}
+
+// Chart defines model for Chart.
+type Chart struct {
+	Entries *[]ChartEntry       `json:"entries,omitempty"`
+	Kind    *ChartKind          `json:"kind,omitempty"`
+	Scope   *string             `json:"scope,omitempty"`
+	Week    *openapi_types.Date `json:"week,omitempty"`
+}
+
+// ChartKind defines model for Chart.Kind.
+type ChartKind string
+
+// ChartEntry defines model for ChartEntry.
+type ChartEntry struct {
+	Kind         *ChartEntryKind `json:"kind,omitempty"`
+	PeakPosition *int            `json:"peak_position,omitempty"`
+	Position     *int            `json:"position,omitempty"`
+
+	// PreviousPosition Position the week before, null for new entries and re-entries
+	PreviousPosition *int `json:"previous_position"`
+
+	// Scope Country code or genre ID, empty on the global chart
+	Scope   *string             `json:"scope,omitempty"`
+	Song    *Song               `json:"song,omitempty"`
+	SongId  *openapi_types.UUID `json:"song_id,omitempty"`
+	Streams *int64              `json:"streams,omitempty"`
+
+	// Week Monday the chart week starts
+	Week         *time.Time `json:"week,omitempty"`
+	WeeksOnChart *int       `json:"weeks_on_chart,omitempty"`
 }
 
+// ChartEntryKind defines model for ChartEntry.Kind.
+type ChartEntryKind string
+
 // Contributor defines model for Contributor.
 type Contributor struct {
-	ArtistId          openapi_types.UUID `json:"artistId"`
-	ContributionType  string             `json:"contributionType"`
-	CreatedAt         *time.Time         `json:"createdAt,omitempty"`
-	RoyaltyPercentage *int               `json:"royaltyPercentage,omitempty"`
+	Artist   *Artist            `json:"artist,omitempty"`
+	ArtistId openapi_types.UUID `json:"artistId"`
+
+	// ContributionType Credit role; primary and featured credits appear in the song's display artist
+	ContributionType  ContributorContributionType `json:"contributionType"`
+	CreatedAt         *time.Time                  `json:"createdAt,omitempty"`
+	RoyaltyPercentage *int                        `json:"royaltyPercentage,omitempty"`
+}
+
+// ContributorContributionType Credit role; primary and featured credits appear in the song's display artist
+type ContributorContributionType string
+
+// CurrencyAmounts Amount per currency
+type CurrencyAmounts map[string]float64
+
+// DailyListenerCount defines model for DailyListenerCount.
+type DailyListenerCount struct {
+	Day       *time.Time `json:"day,omitempty"`
+	Listeners *int64     `json:"listeners,omitempty"`
+}
+
+// EntityVersion defines model for EntityVersion.
+type EntityVersion struct {
+	Action  EntityVersionAction `json:"action"`
+	ActorId *openapi_types.UUID `json:"actorId,omitempty"`
+
+	// Changes Field-level diff against the previous version keyed by column name
+	Changes *map[string]struct {
+		New *interface{} `json:"new,omitempty"`
+		Old *interface{} `json:"old,omitempty"`
+	} `json:"changes,omitempty"`
+	CreatedAt  *time.Time              `json:"createdAt,omitempty"`
+	EntityId   openapi_types.UUID      `json:"entityId"`
+	EntityType EntityVersionEntityType `json:"entityType"`
+	Id         *openapi_types.UUID     `json:"id,omitempty"`
+
+	// Snapshot Column values of the record at this version
+	Snapshot *map[string]interface{} `json:"snapshot,omitempty"`
+	Version  int                     `json:"version"`
 }
 
+// EntityVersionAction defines model for EntityVersion.Action.
+type EntityVersionAction string
+
+// EntityVersionEntityType defines model for EntityVersion.EntityType.
+type EntityVersionEntityType string
+
 // Error defines model for Error.
 type Error struct {
 	Code    int     `json:"code"`
@@ -114,12 +588,247 @@ type Error struct {
 	Message string  `json:"message"`
 }
 
+// ExportFile defines model for ExportFile.
+type ExportFile struct {
+	Bytes   *int64              `json:"bytes,omitempty"`
+	Dataset *string             `json:"dataset,omitempty"`
+	Day     *openapi_types.Date `json:"day,omitempty"`
+	Format  *string             `json:"format,omitempty"`
+	Path    *string             `json:"path,omitempty"`
+	Rows    *int64              `json:"rows,omitempty"`
+}
+
+// ExportJob defines model for ExportJob.
+type ExportJob struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Datasets  *[]string  `json:"datasets,omitempty"`
+
+	// DownloadUrl Signed link to a zip archive of every file, once completed
+	DownloadUrl   *string             `json:"download_url,omitempty"`
+	Error         *string             `json:"error,omitempty"`
+	Files         *[]ExportFile       `json:"files,omitempty"`
+	FinishedAt    *time.Time          `json:"finished_at,omitempty"`
+	Formats       *[]string           `json:"formats,omitempty"`
+	From          *time.Time          `json:"from,omitempty"`
+	Id            *openapi_types.UUID `json:"id,omitempty"`
+	LinkExpiresAt *time.Time          `json:"link_expires_at,omitempty"`
+	RequestedById *openapi_types.UUID `json:"requested_by_id,omitempty"`
+	StartedAt     *time.Time          `json:"started_at,omitempty"`
+	Status        *ExportJobStatus    `json:"status,omitempty"`
+	To            *time.Time          `json:"to,omitempty"`
+}
+
+// ExportJobStatus defines model for ExportJob.Status.
+type ExportJobStatus string
+
+// ExportRequest defines model for ExportRequest.
+type ExportRequest struct {
+	// Datasets All of them when missing. Royalties are monthly; each month's are written on its
+	// first day in the range.
+	Datasets *[]ExportRequestDatasets `json:"datasets,omitempty"`
+
+	// Formats Both when missing
+	Formats *[]ExportRequestFormats `json:"formats,omitempty"`
+	From    openapi_types.Date      `json:"from"`
+
+	// To Last day exported, at most 366 days after from and no later than today
+	To openapi_types.Date `json:"to"`
+}
+
+// ExportRequestDatasets defines model for ExportRequest.Datasets.
+type ExportRequestDatasets string
+
+// ExportRequestFormats defines model for ExportRequest.Formats.
+type ExportRequestFormats string
+
+// FacetCount defines model for FacetCount.
+type FacetCount struct {
+	Count int64  `json:"count"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// FederatedSearchResult defines model for FederatedSearchResult.
+type FederatedSearchResult struct {
+	Albums     *AlbumSearchSection  `json:"albums,omitempty"`
+	Artists    *ArtistSearchSection `json:"artists,omitempty"`
+	DidYouMean *[]SearchSuggestion  `json:"did_you_mean,omitempty"`
+	Genres     *GenreSearchSection  `json:"genres,omitempty"`
+
+	// Partial At least one section failed or timed out
+	Partial   bool                   `json:"partial"`
+	Playlists *PlaylistSearchSection `json:"playlists,omitempty"`
+	Query     string                 `json:"query"`
+
+	// SearchId Identifies this search when reporting clicks; only set on the first page
+	SearchId *openapi_types.UUID `json:"search_id,omitempty"`
+	Songs    *SongSearchSection  `json:"songs,omitempty"`
+}
+
 // Genre defines model for Genre.
 type Genre struct {
 	Description *string             `json:"description,omitempty"`
 	Id          *openapi_types.UUID `json:"id,omitempty"`
 	ImageUrl    *string             `json:"imageUrl,omitempty"`
 	Name        string              `json:"name"`
+
+	// ParentId Parent genre when this is a sub-genre
+	ParentId *openapi_types.UUID `json:"parentId,omitempty"`
+
+	// Popularity Streams over the last 30 days, set when sorting by popularity
+	Popularity *int64 `json:"popularity,omitempty"`
+
+	// Relevance Full-text search rank, only present in search results
+	Relevance *float64 `json:"relevance,omitempty"`
+}
+
+// GenreSearchSection defines model for GenreSearchSection.
+type GenreSearchSection struct {
+	Error  *string       `json:"error,omitempty"`
+	Facets *SearchFacets `json:"facets,omitempty"`
+
+	// Failed The section errored or timed out; items are empty
+	Failed *bool   `json:"failed,omitempty"`
+	Items  []Genre `json:"items"`
+
+	// NextCursor Pass as cursor to fetch the next page of this section
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Total      int64   `json:"total"`
+}
+
+// Label defines model for Label.
+type Label struct {
+	Artists     *[]LabelArtist      `json:"artists,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	Id          *openapi_types.UUID `json:"id,omitempty"`
+	LogoUrl     *string             `json:"logoUrl,omitempty"`
+	Name        string              `json:"name"`
+}
+
+// LabelArtist defines model for LabelArtist.
+type LabelArtist struct {
+	ArtistId openapi_types.UUID `json:"artistId"`
+
+	// CanManageCatalog Create and edit songs and albums for the artist
+	CanManageCatalog *bool `json:"canManageCatalog,omitempty"`
+	CanViewAnalytics *bool `json:"canViewAnalytics,omitempty"`
+
+	// CanViewRoyalties Include the artist in the label's royalty reports
+	CanViewRoyalties *bool               `json:"canViewRoyalties,omitempty"`
+	LabelId          *openapi_types.UUID `json:"labelId,omitempty"`
+	Status           *LabelArtistStatus  `json:"status,omitempty"`
+}
+
+// LabelArtistStatus defines model for LabelArtist.Status.
+type LabelArtistStatus string
+
+// LabelArtistPermissions defines model for LabelArtistPermissions.
+type LabelArtistPermissions struct {
+	// CanManageCatalog Create and edit songs and albums for the artist
+	CanManageCatalog *bool `json:"canManageCatalog,omitempty"`
+	CanViewAnalytics *bool `json:"canViewAnalytics,omitempty"`
+
+	// CanViewRoyalties Include the artist in the label's royalty reports
+	CanViewRoyalties *bool `json:"canViewRoyalties,omitempty"`
+}
+
+// LabelMember defines model for LabelMember.
+type LabelMember struct {
+	LabelId *openapi_types.UUID `json:"labelId,omitempty"`
+	Role    *LabelMemberRole    `json:"role,omitempty"`
+	UserId  openapi_types.UUID  `json:"userId"`
+}
+
+// LabelMemberRole defines model for LabelMember.Role.
+type LabelMemberRole string
+
+// LabelRoyaltyReport defines model for LabelRoyaltyReport.
+type LabelRoyaltyReport struct {
+	Artists *[]ArtistRoyaltyTotal `json:"artists,omitempty"`
+	LabelId *openapi_types.UUID   `json:"labelId,omitempty"`
+	Month   *int                  `json:"month,omitempty"`
+
+	// Totals Royalty amount per currency
+	Totals *map[string]int64 `json:"totals,omitempty"`
+	Year   *int              `json:"year,omitempty"`
+}
+
+// ListenerWrapped defines model for ListenerWrapped.
+type ListenerWrapped struct {
+	DaysListened *int `json:"days_listened,omitempty"`
+
+	// LongestStreak Longest run of consecutive UTC days with plays
+	LongestStreak   *WrappedStreak      `json:"longest_streak,omitempty"`
+	MinutesListened *int64              `json:"minutes_listened,omitempty"`
+	Streams         *int64              `json:"streams,omitempty"`
+	TopArtists      *[]WrappedTopArtist `json:"top_artists,omitempty"`
+	TopGenres       *[]WrappedGenre     `json:"top_genres,omitempty"`
+	TopSongs        *[]WrappedSong      `json:"top_songs,omitempty"`
+}
+
+// ListeningHistoryItem defines model for ListeningHistoryItem.
+type ListeningHistoryItem struct {
+	Completed       *bool                            `json:"completed,omitempty"`
+	ContextId       *openapi_types.UUID              `json:"context_id,omitempty"`
+	ContextType     *ListeningHistoryItemContextType `json:"context_type,omitempty"`
+	DeviceType      *string                          `json:"device_type,omitempty"`
+	ListenedSeconds *int                             `json:"listened_seconds,omitempty"`
+	PlayedAt        *time.Time                       `json:"played_at,omitempty"`
+	Song            *Song                            `json:"song,omitempty"`
+	SongId          *openapi_types.UUID              `json:"song_id,omitempty"`
+	StreamId        *openapi_types.UUID              `json:"stream_id,omitempty"`
+}
+
+// ListeningHistoryItemContextType defines model for ListeningHistoryItem.ContextType.
+type ListeningHistoryItemContextType string
+
+// ListeningHistorySettings defines model for ListeningHistorySettings.
+type ListeningHistorySettings struct {
+	// Paused New plays are left out of the history and recommendations while paused; they still count as plays
+	Paused *bool `json:"paused,omitempty"`
+}
+
+// Payment defines model for Payment.
+type Payment struct {
+	// Amount In minor units of the currency, e.g. kobo
+	Amount *int64 `json:"amount,omitempty"`
+
+	// CheckoutUrl Page the payer finishes paying on, for redirect flows
+	CheckoutUrl *string `json:"checkout_url,omitempty"`
+
+	// ClientSecret Lets the payer's app confirm a Stripe payment; only sent when it's made
+	ClientSecret  *string             `json:"client_secret,omitempty"`
+	CreatedAt     *time.Time          `json:"created_at,omitempty"`
+	Currency      *string             `json:"currency,omitempty"`
+	FailureReason *string             `json:"failure_reason,omitempty"`
+	Id            *openapi_types.UUID `json:"id,omitempty"`
+	Provider      *PaymentProvider    `json:"provider,omitempty"`
+	ProviderRef   *string             `json:"provider_ref,omitempty"`
+	Purpose       *PaymentPurpose     `json:"purpose,omitempty"`
+
+	// RefundDue Paid for what another payment had already paid for; owed back to the payer
+	RefundDue *bool          `json:"refund_due,omitempty"`
+	SettledAt *time.Time     `json:"settled_at,omitempty"`
+	Status    *PaymentStatus `json:"status,omitempty"`
+
+	// TargetId The song purchase, album purchase or tip paid for
+	TargetId *openapi_types.UUID `json:"target_id,omitempty"`
+	UserId   *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// PaymentProvider defines model for Payment.Provider.
+type PaymentProvider string
+
+// PaymentPurpose defines model for Payment.Purpose.
+type PaymentPurpose string
+
+// PaymentStatus defines model for Payment.Status.
+type PaymentStatus string
+
+// PlaybackSession Returned for playback events
+type PlaybackSession struct {
+	// SessionId Also the ID of the stream once the play is recorded
+	SessionId *openapi_types.UUID `json:"session_id,omitempty"`
 }
 
 // Playlist defines model for Playlist.
@@ -129,21 +838,141 @@ type Playlist struct {
 	Description   *string             `json:"description,omitempty"`
 	Id            *openapi_types.UUID `json:"id,omitempty"`
 	IsPublic      *bool               `json:"isPublic,omitempty"`
-	Title         string              `json:"title"`
-	UpdatedAt     *time.Time          `json:"updatedAt,omitempty"`
-	UserId        openapi_types.UUID  `json:"userId"`
+
+	// Relevance Full-text search rank, only present in search results
+	Relevance *float64           `json:"relevance,omitempty"`
+	Title     string             `json:"title"`
+	UpdatedAt *time.Time         `json:"updatedAt,omitempty"`
+	UserId    openapi_types.UUID `json:"userId"`
+}
+
+// PlaylistSearchSection defines model for PlaylistSearchSection.
+type PlaylistSearchSection struct {
+	Error  *string       `json:"error,omitempty"`
+	Facets *SearchFacets `json:"facets,omitempty"`
+
+	// Failed The section errored or timed out; items are empty
+	Failed *bool      `json:"failed,omitempty"`
+	Items  []Playlist `json:"items"`
+
+	// NextCursor Pass as cursor to fetch the next page of this section
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Total      int64   `json:"total"`
 }
 
 // Purchase defines model for Purchase.
 type Purchase struct {
-	Currency      string              `json:"currency"`
-	Id            *openapi_types.UUID `json:"id,omitempty"`
-	PaymentStatus string              `json:"paymentStatus"`
-	PurchasePrice int                 `json:"purchasePrice"`
-	PurchasedAt   *time.Time          `json:"purchasedAt,omitempty"`
-	UserId        openapi_types.UUID  `json:"userId"`
+	// CountryCode Where the buyer was, resolved from their address
+	CountryCode *string             `json:"countryCode,omitempty"`
+	Currency    string              `json:"currency"`
+	Id          *openapi_types.UUID `json:"id,omitempty"`
+	Payment     *Payment            `json:"payment,omitempty"`
+
+	// PaymentStatus Only completed purchases are owned
+	PaymentStatus PurchasePaymentStatus `json:"paymentStatus"`
+	PurchasePrice int                   `json:"purchasePrice"`
+	PurchasedAt   *time.Time            `json:"purchasedAt,omitempty"`
+	UserId        openapi_types.UUID    `json:"userId"`
+}
+
+// PurchasePaymentStatus Only completed purchases are owned
+type PurchasePaymentStatus string
+
+// RecentPlay A song, album or playlist, at the last time it was played
+type RecentPlay struct {
+	Album  *Album              `json:"album,omitempty"`
+	ItemId *openapi_types.UUID `json:"item_id,omitempty"`
+
+	// Kind Songs played from an album or playlist are listed under it
+	Kind     *RecentPlayKind `json:"kind,omitempty"`
+	PlayedAt *time.Time      `json:"played_at,omitempty"`
+	Playlist *Playlist       `json:"playlist,omitempty"`
+	Song     *Song           `json:"song,omitempty"`
+}
+
+// RecentPlayKind Songs played from an album or playlist are listed under it
+type RecentPlayKind string
+
+// SearchClickRequest defines model for SearchClickRequest.
+type SearchClickRequest struct {
+	// Position 1-based rank of the result in the list shown
+	Position   *int                         `json:"position,omitempty"`
+	ResultId   openapi_types.UUID           `json:"result_id"`
+	ResultType SearchClickRequestResultType `json:"result_type"`
+
+	// SearchId The search_id or X-Search-Id header of the search the result came from
+	SearchId openapi_types.UUID `json:"search_id"`
+}
+
+// SearchClickRequestResultType defines model for SearchClickRequest.ResultType.
+type SearchClickRequestResultType string
+
+// SearchClickThrough defines model for SearchClickThrough.
+type SearchClickThrough struct {
+	ClickThroughRate *float64   `json:"click_through_rate,omitempty"`
+	ClickedSearches  *int64     `json:"clicked_searches,omitempty"`
+	Clicks           *int64     `json:"clicks,omitempty"`
+	From             *time.Time `json:"from,omitempty"`
+
+	// MeanClickPosition Average rank of the first result opened
+	MeanClickPosition *float64   `json:"mean_click_position,omitempty"`
+	Searches          *int64     `json:"searches,omitempty"`
+	To                *time.Time `json:"to,omitempty"`
+}
+
+// SearchFacets defines model for SearchFacets.
+type SearchFacets struct {
+	Genres *[]FacetCount `json:"genres,omitempty"`
+
+	// PriceRanges Fixed buckets in whole naira, the unit of song and album prices (0, 1-499, 500-999, 1000-4999, 5000+)
+	PriceRanges  *[]FacetCount `json:"price_ranges,omitempty"`
+	ReleaseYears *[]FacetCount `json:"release_years,omitempty"`
 }
 
+// SearchQueryStat defines model for SearchQueryStat.
+type SearchQueryStat struct {
+	AverageResults *float64 `json:"average_results,omitempty"`
+
+	// ClickThroughRate Share of the searches followed by at least one click
+	ClickThroughRate *float64 `json:"click_through_rate,omitempty"`
+	Clicks           *int64   `json:"clicks,omitempty"`
+	Query            *string  `json:"query,omitempty"`
+
+	// Searchers Distinct users and logged-out visitors
+	Searchers *int64 `json:"searchers,omitempty"`
+	Searches  *int64 `json:"searches,omitempty"`
+}
+
+// SearchSectionInfo defines model for SearchSectionInfo.
+type SearchSectionInfo struct {
+	Error  *string       `json:"error,omitempty"`
+	Facets *SearchFacets `json:"facets,omitempty"`
+
+	// Failed The section errored or timed out; items are empty
+	Failed *bool `json:"failed,omitempty"`
+
+	// NextCursor Pass as cursor to fetch the next page of this section
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Total      int64   `json:"total"`
+}
+
+// SearchSuggestion defines model for SearchSuggestion.
+type SearchSuggestion struct {
+	Id       openapi_types.UUID `json:"id"`
+	ImageUrl *string            `json:"image_url,omitempty"`
+
+	// Score Trigram similarity for corrections, popularity-weighted rank for autocomplete
+	Score float64 `json:"score"`
+
+	// Subtitle Artist for songs and albums, owner for playlists
+	Subtitle *string              `json:"subtitle,omitempty"`
+	Text     string               `json:"text"`
+	Type     SearchSuggestionType `json:"type"`
+}
+
+// SearchSuggestionType defines model for SearchSuggestion.Type.
+type SearchSuggestionType string
+
 // Song defines model for Song.
 type Song struct {
 	AlbumId       *openapi_types.UUID `json:"albumId,omitempty"`
@@ -152,6 +981,10 @@ type Song struct {
 	AudioUrl      string              `json:"audioUrl"`
 	CoverImageUrl *string             `json:"coverImageUrl,omitempty"`
 	CreatedAt     *time.Time          `json:"createdAt,omitempty"`
+	Credits       *[]Contributor      `json:"credits,omitempty"`
+
+	// DisplayArtist Primary and featured artists formatted for display
+	DisplayArtist *string `json:"display_artist,omitempty"`
 
 	// Duration Duration in seconds
 	Duration    int                 `json:"duration"`
@@ -162,11 +995,195 @@ type Song struct {
 	PreviewUrl  *string             `json:"previewUrl,omitempty"`
 	Price       int                 `json:"price"`
 	ReleaseDate openapi_types.Date  `json:"releaseDate"`
-	Title       string              `json:"title"`
-	UpdatedAt   *time.Time          `json:"updatedAt,omitempty"`
+
+	// Relevance Full-text search rank, only present in search results
+	Relevance *float64   `json:"relevance,omitempty"`
+	Title     string     `json:"title"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
-// User defines model for User.
+// SongAnalytics defines model for SongAnalytics.
+type SongAnalytics struct {
+	AverageListenedSeconds *float64 `json:"average_listened_seconds,omitempty"`
+	CompletionRate         *float64 `json:"completion_rate,omitempty"`
+	Completions            *int64   `json:"completions,omitempty"`
+	Listens                *int64   `json:"listens,omitempty"`
+	Purchases              *int64   `json:"purchases,omitempty"`
+
+	// Revenue Amount per currency
+	Revenue  *CurrencyAmounts    `json:"revenue,omitempty"`
+	SkipRate *float64            `json:"skip_rate,omitempty"`
+	Skips    *int64              `json:"skips,omitempty"`
+	SongId   *openapi_types.UUID `json:"song_id,omitempty"`
+	Streams  *int64              `json:"streams,omitempty"`
+	Title    *string             `json:"title,omitempty"`
+}
+
+// SongSearchSection defines model for SongSearchSection.
+type SongSearchSection struct {
+	Error  *string       `json:"error,omitempty"`
+	Facets *SearchFacets `json:"facets,omitempty"`
+
+	// Failed The section errored or timed out; items are empty
+	Failed *bool  `json:"failed,omitempty"`
+	Items  []Song `json:"items"`
+
+	// NextCursor Pass as cursor to fetch the next page of this section
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Total      int64   `json:"total"`
+}
+
+// Stream defines model for Stream.
+type Stream struct {
+	// ClaimedCountry The country the client said it was in, unverified
+	ClaimedCountry *string             `json:"claimed_country,omitempty"`
+	ClientIp       *string             `json:"client_ip,omitempty"`
+	ContextId      *openapi_types.UUID `json:"context_id,omitempty"`
+	ContextType    *StreamContextType  `json:"context_type,omitempty"`
+	CountryCode    *string             `json:"country_code,omitempty"`
+	CreatedAt      *time.Time          `json:"created_at,omitempty"`
+	DeviceId       *string             `json:"device_id,omitempty"`
+	DeviceType     *string             `json:"device_type,omitempty"`
+	FraudScore     *float64            `json:"fraud_score,omitempty"`
+
+	// FraudSignals Comma-separated signals behind the fraud score; looping, ip_burst, device_burst or inactive
+	FraudSignals *string `json:"fraud_signals,omitempty"`
+
+	// HiddenFromHistory Left out of the listener's history; it still counts as a play
+	HiddenFromHistory *bool                `json:"hidden_from_history,omitempty"`
+	Id                *openapi_types.UUID  `json:"id,omitempty"`
+	InvalidReason     *StreamInvalidReason `json:"invalid_reason,omitempty"`
+	IsPreview         *bool                `json:"is_preview,omitempty"`
+	ListenedSeconds   *int                 `json:"listened_seconds,omitempty"`
+
+	// Region ISO 3166-2 subdivision, when the GeoIP database has it
+	Region     *string             `json:"region,omitempty"`
+	ReviewedAt *time.Time          `json:"reviewed_at,omitempty"`
+	ReviewerId *openapi_types.UUID `json:"reviewer_id,omitempty"`
+	Song       *Song               `json:"song,omitempty"`
+	SongId     *openapi_types.UUID `json:"song_id,omitempty"`
+
+	// Status Only qualified streams count as plays, in charts and for royalties
+	Status *StreamStatus       `json:"status,omitempty"`
+	User   *User               `json:"user,omitempty"`
+	UserId *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// StreamContextType defines model for Stream.ContextType.
+type StreamContextType string
+
+// StreamInvalidReason defines model for Stream.InvalidReason.
+type StreamInvalidReason string
+
+// StreamStatus Only qualified streams count as plays, in charts and for royalties
+type StreamStatus string
+
+// StreamReview defines model for StreamReview.
+type StreamReview struct {
+	// Decision Approved streams count as plays; rejected ones are kept but never count
+	Decision StreamReviewDecision `json:"decision"`
+}
+
+// StreamReviewDecision Approved streams count as plays; rejected ones are kept but never count
+type StreamReviewDecision string
+
+// StreamShare defines model for StreamShare.
+type StreamShare struct {
+	Share   *float64 `json:"share,omitempty"`
+	Streams *int64   `json:"streams,omitempty"`
+
+	// Value Country code or device type, empty when unknown
+	Value *string `json:"value,omitempty"`
+}
+
+// Tag defines model for Tag.
+type Tag struct {
+	Id   *openapi_types.UUID `json:"id,omitempty"`
+	Kind *TagKind            `json:"kind,omitempty"`
+	Name string              `json:"name"`
+	Slug *string             `json:"slug,omitempty"`
+}
+
+// TagKind defines model for Tag.Kind.
+type TagKind string
+
+// TagAssignment defines model for TagAssignment.
+type TagAssignment struct {
+	Moods *[]string `json:"moods,omitempty"`
+	Tags  *[]string `json:"tags,omitempty"`
+}
+
+// TerritoryRule defines model for TerritoryRule.
+type TerritoryRule struct {
+	AlbumId     *openapi_types.UUID `json:"album_id,omitempty"`
+	CountryCode *string             `json:"country_code,omitempty"`
+	CreatedAt   *time.Time          `json:"created_at,omitempty"`
+	CreatedById *openapi_types.UUID `json:"created_by_id,omitempty"`
+
+	// EndsAt In force for good when missing
+	EndsAt *time.Time          `json:"ends_at,omitempty"`
+	Id     *openapi_types.UUID `json:"id,omitempty"`
+
+	// Mode Listeners whose country can't be resolved can't play an item with any rule of either mode in force
+	Mode   *TerritoryRuleMode  `json:"mode,omitempty"`
+	SongId *openapi_types.UUID `json:"song_id,omitempty"`
+
+	// StartsAt In force from the start when missing
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+}
+
+// TerritoryRuleMode Listeners whose country can't be resolved can't play an item with any rule of either mode in force
+type TerritoryRuleMode string
+
+// TerritoryRules defines model for TerritoryRules.
+type TerritoryRules struct {
+	CountryCodes []string           `json:"countryCodes"`
+	EndsAt       *time.Time         `json:"endsAt,omitempty"`
+	Mode         TerritoryRulesMode `json:"mode"`
+	StartsAt     *time.Time         `json:"startsAt,omitempty"`
+}
+
+// TerritoryRulesMode defines model for TerritoryRules.Mode.
+type TerritoryRulesMode string
+
+// Tip defines model for Tip.
+type Tip struct {
+	Amount        *int                `json:"amount,omitempty"`
+	ArtistId      *openapi_types.UUID `json:"artist_id,omitempty"`
+	Currency      *string             `json:"currency,omitempty"`
+	Id            *openapi_types.UUID `json:"id,omitempty"`
+	Message       *string             `json:"message,omitempty"`
+	Payment       *Payment            `json:"payment,omitempty"`
+	PaymentStatus *TipPaymentStatus   `json:"payment_status,omitempty"`
+	SenderId      *openapi_types.UUID `json:"sender_id,omitempty"`
+}
+
+// TipPaymentStatus defines model for Tip.PaymentStatus.
+type TipPaymentStatus string
+
+// TrendingSearch defines model for TrendingSearch.
+type TrendingSearch struct {
+	Query *string `json:"query,omitempty"`
+
+	// Searches Searches in the last 24 hours
+	Searches *int64 `json:"searches,omitempty"`
+}
+
+// TrendingSong defines model for TrendingSong.
+type TrendingSong struct {
+	// Baseline Average daily streams over the week before
+	Baseline    *float64            `json:"baseline,omitempty"`
+	Listeners   *int64              `json:"listeners,omitempty"`
+	RefreshedAt *time.Time          `json:"refreshed_at,omitempty"`
+	Score       *float64            `json:"score,omitempty"`
+	Song        *Song               `json:"song,omitempty"`
+	SongId      *openapi_types.UUID `json:"song_id,omitempty"`
+
+	// Streams Streams over the last 24 hours
+	Streams *int64 `json:"streams,omitempty"`
+}
+
+// User defines model for User.
 type User struct {
 	Bio             *string             `json:"bio,omitempty"`
 	CreatedAt       *time.Time          `json:"createdAt,omitempty"`
@@ -182,15 +1199,165 @@ type User struct {
 	Username        string              `json:"username"`
 }
 
+// VerificationEvent defines model for VerificationEvent.
+type VerificationEvent struct {
+	Action    *VerificationEventAction `json:"action,omitempty"`
+	ActorId   *openapi_types.UUID      `json:"actorId,omitempty"`
+	ArtistId  *openapi_types.UUID      `json:"artistId,omitempty"`
+	CreatedAt *time.Time               `json:"createdAt,omitempty"`
+	Id        *openapi_types.UUID      `json:"id,omitempty"`
+	Notes     *string                  `json:"notes,omitempty"`
+	RequestId *openapi_types.UUID      `json:"requestId,omitempty"`
+}
+
+// VerificationEventAction defines model for VerificationEvent.Action.
+type VerificationEventAction string
+
+// VerificationEvidence defines model for VerificationEvidence.
+type VerificationEvidence struct {
+	ContentType *string                   `json:"contentType,omitempty"`
+	FileName    *string                   `json:"fileName,omitempty"`
+	Id          *openapi_types.UUID       `json:"id,omitempty"`
+	Kind        *VerificationEvidenceKind `json:"kind,omitempty"`
+	Size        *int64                    `json:"size,omitempty"`
+	Url         *string                   `json:"url,omitempty"`
+}
+
+// VerificationEvidenceKind defines model for VerificationEvidence.Kind.
+type VerificationEvidenceKind string
+
+// VerificationRequest defines model for VerificationRequest.
+type VerificationRequest struct {
+	ArtistId    *openapi_types.UUID        `json:"artistId,omitempty"`
+	CreatedAt   *time.Time                 `json:"createdAt,omitempty"`
+	Events      *[]VerificationEvent       `json:"events,omitempty"`
+	Evidence    *[]VerificationEvidence    `json:"evidence,omitempty"`
+	Id          *openapi_types.UUID        `json:"id,omitempty"`
+	Message     *string                    `json:"message,omitempty"`
+	ReviewNotes *string                    `json:"reviewNotes,omitempty"`
+	ReviewedAt  *time.Time                 `json:"reviewedAt,omitempty"`
+	ReviewerId  *openapi_types.UUID        `json:"reviewerId,omitempty"`
+	Status      *VerificationRequestStatus `json:"status,omitempty"`
+}
+
+// VerificationRequestStatus defines model for VerificationRequest.Status.
+type VerificationRequestStatus string
+
+// VerificationReview defines model for VerificationReview.
+type VerificationReview struct {
+	Decision VerificationReviewDecision `json:"decision"`
+
+	// Notes Shown to the artist; required when asking for more information
+	Notes *string `json:"notes,omitempty"`
+}
+
+// VerificationReviewDecision defines model for VerificationReview.Decision.
+type VerificationReviewDecision string
+
+// VerificationRevocation defines model for VerificationRevocation.
+type VerificationRevocation struct {
+	// Notes Reason for revoking verification
+	Notes string `json:"notes"`
+}
+
+// WrappedCountry defines model for WrappedCountry.
+type WrappedCountry struct {
+	CountryCode *string `json:"country_code,omitempty"`
+	Listeners   *int64  `json:"listeners,omitempty"`
+	Streams     *int64  `json:"streams,omitempty"`
+}
+
+// WrappedDocument defines model for WrappedDocument.
+type WrappedDocument struct {
+	Artist   *ArtistWrapped      `json:"artist,omitempty"`
+	From     *openapi_types.Date `json:"from,omitempty"`
+	Listener *ListenerWrapped    `json:"listener,omitempty"`
+
+	// Through Last day counted
+	Through *openapi_types.Date `json:"through,omitempty"`
+}
+
+// WrappedGenre defines model for WrappedGenre.
+type WrappedGenre struct {
+	GenreId *openapi_types.UUID `json:"genre_id,omitempty"`
+	Name    *string             `json:"name,omitempty"`
+	Streams *int64              `json:"streams,omitempty"`
+}
+
+// WrappedReport defines model for WrappedReport.
+type WrappedReport struct {
+	Document    *WrappedDocument   `json:"document,omitempty"`
+	GeneratedAt *time.Time         `json:"generated_at,omitempty"`
+	Kind        *WrappedReportKind `json:"kind,omitempty"`
+
+	// SubjectId User or artist ID
+	SubjectId *openapi_types.UUID `json:"subject_id,omitempty"`
+
+	// Version Version of the document's layout; fields are only added within a version
+	Version *int `json:"version,omitempty"`
+	Year    *int `json:"year,omitempty"`
+}
+
+// WrappedReportKind defines model for WrappedReport.Kind.
+type WrappedReportKind string
+
+// WrappedSong defines model for WrappedSong.
+type WrappedSong struct {
+	// Listeners Only on artist reports
+	Listeners *int64              `json:"listeners,omitempty"`
+	Minutes   *int64              `json:"minutes,omitempty"`
+	SongId    *openapi_types.UUID `json:"song_id,omitempty"`
+	Streams   *int64              `json:"streams,omitempty"`
+	Title     *string             `json:"title,omitempty"`
+}
+
+// WrappedStreak Longest run of consecutive UTC days with plays
+type WrappedStreak struct {
+	Days    *int                `json:"days,omitempty"`
+	From    *openapi_types.Date `json:"from,omitempty"`
+	Through *openapi_types.Date `json:"through,omitempty"`
+}
+
+// WrappedTopArtist defines model for WrappedTopArtist.
+type WrappedTopArtist struct {
+	ArtistId *openapi_types.UUID `json:"artist_id,omitempty"`
+	Minutes  *int64              `json:"minutes,omitempty"`
+	Name     *string             `json:"name,omitempty"`
+	Streams  *int64              `json:"streams,omitempty"`
+
+	// TopFanPercent Where the listener ranks among everyone who played the artist that year; 1 means their top 1%
+	TopFanPercent *float64 `json:"top_fan_percent,omitempty"`
+}
+
+// ZeroResultQuery defines model for ZeroResultQuery.
+type ZeroResultQuery struct {
+	LastSearchedAt *time.Time `json:"last_searched_at,omitempty"`
+	Query          *string    `json:"query,omitempty"`
+	Searchers      *int64     `json:"searchers,omitempty"`
+	Searches       *int64     `json:"searches,omitempty"`
+}
+
 // AlbumId defines model for albumId.
 type AlbumId = openapi_types.UUID
 
 // ArtistId defines model for artistId.
 type ArtistId = openapi_types.UUID
 
+// ChartWeek defines model for chartWeek.
+type ChartWeek = openapi_types.Date
+
+// EvidenceId defines model for evidenceId.
+type EvidenceId = openapi_types.UUID
+
+// ExportJobId defines model for exportJobId.
+type ExportJobId = openapi_types.UUID
+
 // GenreId defines model for genreId.
 type GenreId = openapi_types.UUID
 
+// LabelId defines model for labelId.
+type LabelId = openapi_types.UUID
+
 // Limit defines model for limit.
 type Limit = int
 
@@ -200,12 +1367,30 @@ type Page = int
 // PlaylistId defines model for playlistId.
 type PlaylistId = openapi_types.UUID
 
+// RequestId defines model for requestId.
+type RequestId = openapi_types.UUID
+
 // SongId defines model for songId.
 type SongId = openapi_types.UUID
 
+// StreamId defines model for streamId.
+type StreamId = openapi_types.UUID
+
+// TagId defines model for tagId.
+type TagId = openapi_types.UUID
+
+// Tags defines model for tags.
+type Tags = string
+
 // UserId defines model for userId.
 type UserId = openapi_types.UUID
 
+// Version defines model for version.
+type Version = int
+
+// WrappedYear defines model for wrappedYear.
+type WrappedYear = int
+
 // GetAlbumsParams defines parameters for GetAlbums.
 type GetAlbumsParams struct {
 	// Page Page integer
@@ -216,6 +1401,21 @@ type GetAlbumsParams struct {
 
 	// Artist Filter by artist ID
 	Artist *openapi_types.UUID `form:"artist,omitempty" json:"artist,omitempty"`
+
+	// Genre Filter by genre ID, including its sub-genres
+	Genre *openapi_types.UUID `form:"genre,omitempty" json:"genre,omitempty"`
+
+	// Tags Comma-separated tag or mood slugs; only items carrying all of them are returned
+	Tags *Tags `form:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// GetAlbumsAlbumIdHistoryParams defines parameters for GetAlbumsAlbumIdHistory.
+type GetAlbumsAlbumIdHistoryParams struct {
+	// Page Page integer
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of items per page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
 // GetArtistsParams defines parameters for GetArtists.
@@ -230,6 +1430,31 @@ type GetArtistsParams struct {
 	Verified *bool `form:"verified,omitempty" json:"verified,omitempty"`
 }
 
+// GetArtistsArtistIdAnalyticsParams defines parameters for GetArtistsArtistIdAnalytics.
+type GetArtistsArtistIdAnalyticsParams struct {
+	// From First day of the range
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the range, today by default
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+
+	// Days Length of the range in days when from isn't given
+	Days        *int                                          `form:"days,omitempty" json:"days,omitempty"`
+	Granularity *GetArtistsArtistIdAnalyticsParamsGranularity `form:"granularity,omitempty" json:"granularity,omitempty"`
+}
+
+// GetArtistsArtistIdAnalyticsParamsGranularity defines parameters for GetArtistsArtistIdAnalytics.
+type GetArtistsArtistIdAnalyticsParamsGranularity string
+
+// GetArtistsArtistIdHistoryParams defines parameters for GetArtistsArtistIdHistory.
+type GetArtistsArtistIdHistoryParams struct {
+	// Page Page integer
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of items per page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetArtistsArtistIdSongsParams defines parameters for GetArtistsArtistIdSongs.
 type GetArtistsArtistIdSongsParams struct {
 	// Page Page integer
@@ -239,6 +1464,57 @@ type GetArtistsArtistIdSongsParams struct {
 	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// PostArtistsArtistIdVerificationMultipartBody defines parameters for PostArtistsArtistIdVerification.
+type PostArtistsArtistIdVerificationMultipartBody struct {
+	// Documents PDF, PNG or JPEG files of at most 10MB each
+	Documents *[]openapi_types.File `json:"documents,omitempty"`
+	Links     *[]string             `json:"links,omitempty"`
+	Message   *string               `json:"message,omitempty"`
+}
+
+// GetChartsCountriesCountryCodeParams defines parameters for GetChartsCountriesCountryCode.
+type GetChartsCountriesCountryCodeParams struct {
+	// Week Any day of the chart week, the latest week published by default
+	Week *ChartWeek `form:"week,omitempty" json:"week,omitempty"`
+}
+
+// GetChartsGenresGenreIdParams defines parameters for GetChartsGenresGenreId.
+type GetChartsGenresGenreIdParams struct {
+	// Week Any day of the chart week, the latest week published by default
+	Week *ChartWeek `form:"week,omitempty" json:"week,omitempty"`
+}
+
+// GetChartsGlobalParams defines parameters for GetChartsGlobal.
+type GetChartsGlobalParams struct {
+	// Week Any day of the chart week, the latest week published by default
+	Week *ChartWeek `form:"week,omitempty" json:"week,omitempty"`
+}
+
+// GetChartsTrendingParams defines parameters for GetChartsTrending.
+type GetChartsTrendingParams struct {
+	// GenreId Only songs of this genre or its sub-genres
+	GenreId *openapi_types.UUID `form:"genreId,omitempty" json:"genreId,omitempty"`
+	Limit   *int                `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetExportsParams defines parameters for GetExports.
+type GetExportsParams struct {
+	// Page Page integer
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of items per page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetExportsJobIdDownloadParams defines parameters for GetExportsJobIdDownload.
+type GetExportsJobIdDownloadParams struct {
+	Expires   int64  `form:"expires" json:"expires"`
+	Signature string `form:"signature" json:"signature"`
+
+	// File Path of one file in the job, e.g. streams/day=2025-01-31/streams.parquet
+	File *string `form:"file,omitempty" json:"file,omitempty"`
+}
+
 // PostFlagsJSONBody defines parameters for PostFlags.
 type PostFlagsJSONBody struct {
 	Description *string                     `json:"description,omitempty"`
@@ -250,12 +1526,30 @@ type PostFlagsJSONBody struct {
 // PostFlagsJSONBodyTargetType defines parameters for PostFlags.
 type PostFlagsJSONBodyTargetType string
 
+// GetLabelsLabelIdRoyaltiesParams defines parameters for GetLabelsLabelIdRoyalties.
+type GetLabelsLabelIdRoyaltiesParams struct {
+	Year  *int `form:"year,omitempty" json:"year,omitempty"`
+	Month *int `form:"month,omitempty" json:"month,omitempty"`
+}
+
 // PostLoginJSONBody defines parameters for PostLogin.
 type PostLoginJSONBody struct {
 	Email    openapi_types.Email `json:"email"`
 	Password string              `json:"password"`
 }
 
+// PostPaymentsWebhooksProviderJSONBody defines parameters for PostPaymentsWebhooksProvider.
+type PostPaymentsWebhooksProviderJSONBody = map[string]interface{}
+
+// GetPlaylistsPlaylistIdHistoryParams defines parameters for GetPlaylistsPlaylistIdHistory.
+type GetPlaylistsPlaylistIdHistoryParams struct {
+	// Page Page integer
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of items per page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // PostPlaylistsPlaylistIdSongsJSONBody defines parameters for PostPlaylistsPlaylistIdSongs.
 type PostPlaylistsPlaylistIdSongsJSONBody struct {
 	SongId openapi_types.UUID `json:"songId"`
@@ -263,17 +1557,25 @@ type PostPlaylistsPlaylistIdSongsJSONBody struct {
 
 // PostPurchasesAlbumsJSONBody defines parameters for PostPurchasesAlbums.
 type PostPurchasesAlbumsJSONBody struct {
-	AlbumId         openapi_types.UUID `json:"albumId"`
-	PaymentMethodId string             `json:"paymentMethodId"`
-	UserId          openapi_types.UUID `json:"userId"`
+	AlbumId openapi_types.UUID `json:"albumId"`
+
+	// PaymentMethodId Card or authorization saved with the provider, charged straight away
+	PaymentMethodId *string `json:"paymentMethodId,omitempty"`
+
+	// Provider Payment provider to charge through, stripe, paystack, flutterwave or fake; the server's default when left out
+	Provider *string            `json:"provider,omitempty"`
+	UserId   openapi_types.UUID `json:"userId"`
 }
 
 // PostPurchasesSongsJSONBody defines parameters for PostPurchasesSongs.
 type PostPurchasesSongsJSONBody struct {
-	// PaymentMethodId Stripe payment method ID
-	PaymentMethodId string             `json:"paymentMethodId"`
-	SongId          openapi_types.UUID `json:"songId"`
-	UserId          openapi_types.UUID `json:"userId"`
+	// PaymentMethodId Card or authorization saved with the provider, charged straight away. Without one the buyer finishes paying on the payment's checkout_url, or in their app with its client_secret.
+	PaymentMethodId *string `json:"paymentMethodId,omitempty"`
+
+	// Provider Payment provider to charge through, stripe, paystack, flutterwave or fake; the server's default when left out
+	Provider *string            `json:"provider,omitempty"`
+	SongId   openapi_types.UUID `json:"songId"`
+	UserId   openapi_types.UUID `json:"userId"`
 }
 
 // GetSearchParams defines parameters for GetSearch.
@@ -284,6 +1586,12 @@ type GetSearchParams struct {
 	// Types Comma-separated list of content types to search (songs,albums,artists,playlists,genres)
 	Types *string `form:"types,omitempty" json:"types,omitempty"`
 
+	// Cursor A section's next_cursor from a previous response; continues that section only
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Facets Include genre, release year and price range counts in the songs and albums sections
+	Facets *bool `form:"facets,omitempty" json:"facets,omitempty"`
+
 	// Page Page integer
 	Page *Page `form:"page,omitempty" json:"page,omitempty"`
 
@@ -293,7 +1601,7 @@ type GetSearchParams struct {
 
 // GetSearchAlbumsParams defines parameters for GetSearchAlbums.
 type GetSearchAlbumsParams struct {
-	// Query Search term (album title)
+	// Query Search term, matched against the album title, description, artist and genre
 	Query *string `form:"query,omitempty" json:"query,omitempty"`
 
 	// Artist Filter by artist name or ID
@@ -302,7 +1610,7 @@ type GetSearchAlbumsParams struct {
 	// Genre Filter by genre name or ID
 	Genre *string `form:"genre,omitempty" json:"genre,omitempty"`
 
-	// Sort Sort field (popularity,release_date,price)
+	// Sort Sort field; results are ordered by relevance when a query is given and no sort is set
 	Sort *GetSearchAlbumsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
 
 	// Page Page integer
@@ -315,9 +1623,42 @@ type GetSearchAlbumsParams struct {
 // GetSearchAlbumsParamsSort defines parameters for GetSearchAlbums.
 type GetSearchAlbumsParamsSort string
 
+// GetSearchAnalyticsClickThroughParams defines parameters for GetSearchAnalyticsClickThrough.
+type GetSearchAnalyticsClickThroughParams struct {
+	// From Start of the period, defaults to 30 days before to
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the period, defaults to now
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetSearchAnalyticsTopQueriesParams defines parameters for GetSearchAnalyticsTopQueries.
+type GetSearchAnalyticsTopQueriesParams struct {
+	// From Start of the period, defaults to 30 days before to
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the period, defaults to now
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Number of items per page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetSearchAnalyticsZeroResultsParams defines parameters for GetSearchAnalyticsZeroResults.
+type GetSearchAnalyticsZeroResultsParams struct {
+	// From Start of the period, defaults to 30 days before to
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the period, defaults to now
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Number of items per page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetSearchArtistsParams defines parameters for GetSearchArtists.
 type GetSearchArtistsParams struct {
-	// Query Search term (artist name)
+	// Query Search term (artist name), tolerant of misspellings
 	Query *string `form:"query,omitempty" json:"query,omitempty"`
 
 	// Genre Filter by genre name or ID
@@ -336,12 +1677,17 @@ type GetSearchArtistsParams struct {
 // GetSearchArtistsParamsSort defines parameters for GetSearchArtists.
 type GetSearchArtistsParamsSort string
 
+// GetSearchDidYouMeanParams defines parameters for GetSearchDidYouMean.
+type GetSearchDidYouMeanParams struct {
+	Query string `form:"query" json:"query"`
+}
+
 // GetSearchGenresParams defines parameters for GetSearchGenres.
 type GetSearchGenresParams struct {
 	// Query Search term (genre name)
 	Query *string `form:"query,omitempty" json:"query,omitempty"`
 
-	// Sort Sort field (popularity,name)
+	// Sort Sort field; results are ordered by relevance when a query is given and no sort is set
 	Sort *GetSearchGenresParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
 }
 
@@ -359,7 +1705,7 @@ type GetSearchPlaylistsParams struct {
 	// IsPublic Filter by public/private status
 	IsPublic *bool `form:"isPublic,omitempty" json:"isPublic,omitempty"`
 
-	// Sort Sort field (popularity,created_at,updated_at)
+	// Sort Sort field; results are ordered by relevance when a query is given and no sort is set
 	Sort *GetSearchPlaylistsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
 
 	// Page Page integer
@@ -374,16 +1720,16 @@ type GetSearchPlaylistsParamsSort string
 
 // GetSearchSongsParams defines parameters for GetSearchSongs.
 type GetSearchSongsParams struct {
-	// Query Search term (title or lyrics)
+	// Query Search term, matched against the title and every credited artist
 	Query *string `form:"query,omitempty" json:"query,omitempty"`
 
-	// Artist Filter by artist name or ID
+	// Artist Filter by the name of any credited artist
 	Artist *string `form:"artist,omitempty" json:"artist,omitempty"`
 
 	// Genre Filter by genre name or ID
 	Genre *string `form:"genre,omitempty" json:"genre,omitempty"`
 
-	// Sort Sort field (popularity,release_date,duration)
+	// Sort Sort field; results are ordered by relevance when a query is given and no sort is set
 	Sort *GetSearchSongsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
 
 	// Order Sort order
@@ -402,6 +1748,23 @@ type GetSearchSongsParamsSort string
 // GetSearchSongsParamsOrder defines parameters for GetSearchSongs.
 type GetSearchSongsParamsOrder string
 
+// GetSearchSuggestParams defines parameters for GetSearchSuggest.
+type GetSearchSuggestParams struct {
+	// Query Partial search term
+	Query string `form:"query" json:"query"`
+
+	// Types Comma-separated list of content types (songs,albums,artists,playlists)
+	Types *string `form:"types,omitempty" json:"types,omitempty"`
+
+	// Limit Maximum number of suggestions
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetSearchTrendingParams defines parameters for GetSearchTrending.
+type GetSearchTrendingParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetSongsParams defines parameters for GetSongs.
 type GetSongsParams struct {
 	// Page Page integer
@@ -410,7 +1773,7 @@ type GetSongsParams struct {
 	// Limit Number of items per page
 	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
 
-	// Genre Filter by genre ID
+	// Genre Filter by genre ID, including its sub-genres
 	Genre *string `form:"genre,omitempty" json:"genre,omitempty"`
 
 	// Artist Filter by artist ID
@@ -418,22 +1781,85 @@ type GetSongsParams struct {
 
 	// Album Filter by album ID
 	Album *string `form:"album,omitempty" json:"album,omitempty"`
+
+	// Tags Comma-separated tag or mood slugs; only items carrying all of them are returned
+	Tags *Tags `form:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// GetSongsSongIdHistoryParams defines parameters for GetSongsSongIdHistory.
+type GetSongsSongIdHistoryParams struct {
+	// Page Page integer
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of items per page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
 // PostStreamsJSONBody defines parameters for PostStreams.
 type PostStreamsJSONBody struct {
-	CountryCode *string            `json:"countryCode,omitempty"`
-	DeviceType  *string            `json:"deviceType,omitempty"`
-	IsPreview   *bool              `json:"isPreview,omitempty"`
-	SongId      openapi_types.UUID `json:"songId"`
+	// ContextId ID of the album, playlist or artist; required with contextType
+	ContextId *openapi_types.UUID `json:"contextId,omitempty"`
+
+	// ContextType What the song is played from; listed in the listener's recently played
+	ContextType *PostStreamsJSONBodyContextType `json:"contextType,omitempty"`
+
+	// CountryCode The country the client says it is in. It is stored as unverified metadata; the country is always resolved from the client address.
+	CountryCode *string `json:"countryCode,omitempty"`
+
+	// DeviceId Stable identifier of the playing device
+	DeviceId   *string `json:"deviceId,omitempty"`
+	DeviceType *string `json:"deviceType,omitempty"`
+
+	// Event Playback event. Players send start, then progress every 15 to 30 seconds while playing, then end; the play is recorded when it ends, or 30 minutes after the last event. Without an event the request reports one finished play.
+	Event     *PostStreamsJSONBodyEvent `json:"event,omitempty"`
+	IsPreview *bool                     `json:"isPreview,omitempty"`
+
+	// ListenedSeconds Seconds actually played, since the start event for playback events; streams under 30 seconds don't count as plays
+	ListenedSeconds *int `json:"listenedSeconds,omitempty"`
+
+	// PositionSeconds Current position in the song, or where playback stopped
+	PositionSeconds *int `json:"positionSeconds,omitempty"`
+
+	// SessionId Session returned for the start event; required for progress and end
+	SessionId *openapi_types.UUID `json:"sessionId,omitempty"`
+	SongId    openapi_types.UUID  `json:"songId"`
+}
+
+// PostStreamsJSONBodyContextType defines parameters for PostStreams.
+type PostStreamsJSONBodyContextType string
+
+// PostStreamsJSONBodyEvent defines parameters for PostStreams.
+type PostStreamsJSONBodyEvent string
+
+// GetStreamsSuspiciousParams defines parameters for GetStreamsSuspicious.
+type GetStreamsSuspiciousParams struct {
+	// Page Page integer
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of items per page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetTagsParams defines parameters for GetTags.
+type GetTagsParams struct {
+	// Kind Only return tags of this kind
+	Kind *GetTagsParamsKind `form:"kind,omitempty" json:"kind,omitempty"`
 }
 
+// GetTagsParamsKind defines parameters for GetTags.
+type GetTagsParamsKind string
+
 // PostTipsJSONBody defines parameters for PostTips.
 type PostTipsJSONBody struct {
-	Amount          int                `json:"amount"`
-	ArtistId        openapi_types.UUID `json:"artistId"`
-	Message         *string            `json:"message,omitempty"`
-	PaymentMethodId string             `json:"paymentMethodId"`
+	Amount   int                `json:"amount"`
+	ArtistId openapi_types.UUID `json:"artistId"`
+	Message  *string            `json:"message,omitempty"`
+
+	// PaymentMethodId Card or authorization saved with the provider, charged straight away
+	PaymentMethodId *string `json:"paymentMethodId,omitempty"`
+
+	// Provider Payment provider to charge through, stripe, paystack, flutterwave or fake; the server's default when left out
+	Provider *string `json:"provider,omitempty"`
 }
 
 // GetUsersParams defines parameters for GetUsers.
@@ -454,6 +1880,38 @@ type GetUsersUserIdLibraryPurchasesParams struct {
 	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// GetUsersUserIdListeningHistoryParams defines parameters for GetUsersUserIdListeningHistory.
+type GetUsersUserIdListeningHistoryParams struct {
+	// Page Page integer
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of items per page
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PutUsersUserIdListeningHistorySettingsJSONBody defines parameters for PutUsersUserIdListeningHistorySettings.
+type PutUsersUserIdListeningHistorySettingsJSONBody struct {
+	Paused bool `json:"paused"`
+}
+
+// GetUsersUserIdRecentlyPlayedParams defines parameters for GetUsersUserIdRecentlyPlayed.
+type GetUsersUserIdRecentlyPlayedParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetVerificationRequestsParams defines parameters for GetVerificationRequests.
+type GetVerificationRequestsParams struct {
+	// Page Page integer
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of items per page
+	Limit  *Limit                               `form:"limit,omitempty" json:"limit,omitempty"`
+	Status *GetVerificationRequestsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetVerificationRequestsParamsStatus defines parameters for GetVerificationRequests.
+type GetVerificationRequestsParamsStatus string
+
 // PostAlbumsJSONRequestBody defines body for PostAlbums for application/json ContentType.
 type PostAlbumsJSONRequestBody = Album
 
@@ -463,18 +1921,57 @@ type PutAlbumsAlbumIdJSONRequestBody = Album
 // PostAlbumsAlbumIdContributorsJSONRequestBody defines body for PostAlbumsAlbumIdContributors for application/json ContentType.
 type PostAlbumsAlbumIdContributorsJSONRequestBody = Contributor
 
+// PutAlbumsAlbumIdTagsJSONRequestBody defines body for PutAlbumsAlbumIdTags for application/json ContentType.
+type PutAlbumsAlbumIdTagsJSONRequestBody = TagAssignment
+
+// PostAlbumsAlbumIdTerritoriesJSONRequestBody defines body for PostAlbumsAlbumIdTerritories for application/json ContentType.
+type PostAlbumsAlbumIdTerritoriesJSONRequestBody = TerritoryRules
+
 // PostArtistsJSONRequestBody defines body for PostArtists for application/json ContentType.
 type PostArtistsJSONRequestBody = Artist
 
 // PutArtistsArtistIdJSONRequestBody defines body for PutArtistsArtistId for application/json ContentType.
 type PutArtistsArtistIdJSONRequestBody = Artist
 
+// PutArtistsArtistIdLabelsLabelIdJSONRequestBody defines body for PutArtistsArtistIdLabelsLabelId for application/json ContentType.
+type PutArtistsArtistIdLabelsLabelIdJSONRequestBody = LabelArtistPermissions
+
+// PostArtistsArtistIdVerificationMultipartRequestBody defines body for PostArtistsArtistIdVerification for multipart/form-data ContentType.
+type PostArtistsArtistIdVerificationMultipartRequestBody PostArtistsArtistIdVerificationMultipartBody
+
+// PostArtistsArtistIdVerificationRevokeJSONRequestBody defines body for PostArtistsArtistIdVerificationRevoke for application/json ContentType.
+type PostArtistsArtistIdVerificationRevokeJSONRequestBody = VerificationRevocation
+
+// PostExportsJSONRequestBody defines body for PostExports for application/json ContentType.
+type PostExportsJSONRequestBody = ExportRequest
+
 // PostFlagsJSONRequestBody defines body for PostFlags for application/json ContentType.
 type PostFlagsJSONRequestBody PostFlagsJSONBody
 
+// PostGenresJSONRequestBody defines body for PostGenres for application/json ContentType.
+type PostGenresJSONRequestBody = Genre
+
+// PutGenresGenreIdJSONRequestBody defines body for PutGenresGenreId for application/json ContentType.
+type PutGenresGenreIdJSONRequestBody = Genre
+
+// PostLabelsJSONRequestBody defines body for PostLabels for application/json ContentType.
+type PostLabelsJSONRequestBody = Label
+
+// PutLabelsLabelIdJSONRequestBody defines body for PutLabelsLabelId for application/json ContentType.
+type PutLabelsLabelIdJSONRequestBody = Label
+
+// PostLabelsLabelIdArtistsJSONRequestBody defines body for PostLabelsLabelIdArtists for application/json ContentType.
+type PostLabelsLabelIdArtistsJSONRequestBody = LabelArtist
+
+// PostLabelsLabelIdMembersJSONRequestBody defines body for PostLabelsLabelIdMembers for application/json ContentType.
+type PostLabelsLabelIdMembersJSONRequestBody = LabelMember
+
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody PostLoginJSONBody
 
+// PostPaymentsWebhooksProviderJSONRequestBody defines body for PostPaymentsWebhooksProvider for application/json ContentType.
+type PostPaymentsWebhooksProviderJSONRequestBody = PostPaymentsWebhooksProviderJSONBody
+
 // PutPlaylistsPlaylistIdJSONRequestBody defines body for PutPlaylistsPlaylistId for application/json ContentType.
 type PutPlaylistsPlaylistIdJSONRequestBody = Playlist
 
@@ -487,6 +1984,9 @@ type PostPurchasesAlbumsJSONRequestBody PostPurchasesAlbumsJSONBody
 // PostPurchasesSongsJSONRequestBody defines body for PostPurchasesSongs for application/json ContentType.
 type PostPurchasesSongsJSONRequestBody PostPurchasesSongsJSONBody
 
+// PostSearchClicksJSONRequestBody defines body for PostSearchClicks for application/json ContentType.
+type PostSearchClicksJSONRequestBody = SearchClickRequest
+
 // PostSongsJSONRequestBody defines body for PostSongs for application/json ContentType.
 type PostSongsJSONRequestBody = Song
 
@@ -496,9 +1996,21 @@ type PutSongsSongIdJSONRequestBody = Song
 // PostSongsSongIdContributorsJSONRequestBody defines body for PostSongsSongIdContributors for application/json ContentType.
 type PostSongsSongIdContributorsJSONRequestBody = Contributor
 
+// PutSongsSongIdTagsJSONRequestBody defines body for PutSongsSongIdTags for application/json ContentType.
+type PutSongsSongIdTagsJSONRequestBody = TagAssignment
+
+// PostSongsSongIdTerritoriesJSONRequestBody defines body for PostSongsSongIdTerritories for application/json ContentType.
+type PostSongsSongIdTerritoriesJSONRequestBody = TerritoryRules
+
 // PostStreamsJSONRequestBody defines body for PostStreams for application/json ContentType.
 type PostStreamsJSONRequestBody PostStreamsJSONBody
 
+// PostStreamsStreamIdReviewJSONRequestBody defines body for PostStreamsStreamIdReview for application/json ContentType.
+type PostStreamsStreamIdReviewJSONRequestBody = StreamReview
+
+// PostTagsJSONRequestBody defines body for PostTags for application/json ContentType.
+type PostTagsJSONRequestBody = Tag
+
 // PostTipsJSONRequestBody defines body for PostTips for application/json ContentType.
 type PostTipsJSONRequestBody PostTipsJSONBody
 
@@ -508,9 +2020,15 @@ type PostUsersJSONRequestBody = User
 // PutUsersUserIdJSONRequestBody defines body for PutUsersUserId for application/json ContentType.
 type PutUsersUserIdJSONRequestBody = User
 
+// PutUsersUserIdListeningHistorySettingsJSONRequestBody defines body for PutUsersUserIdListeningHistorySettings for application/json ContentType.
+type PutUsersUserIdListeningHistorySettingsJSONRequestBody PutUsersUserIdListeningHistorySettingsJSONBody
+
 // PostUsersUserIdPlaylistsJSONRequestBody defines body for PostUsersUserIdPlaylists for application/json ContentType.
 type PostUsersUserIdPlaylistsJSONRequestBody = Playlist
 
+// PostVerificationRequestsRequestIdReviewJSONRequestBody defines body for PostVerificationRequestsRequestIdReview for application/json ContentType.
+type PostVerificationRequestsRequestIdReviewJSONRequestBody = VerificationReview
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// List all albums
@@ -534,9 +2052,27 @@ type ServerInterface interface {
 	// Add contributor to album
 	// (POST /albums/{albumId}/contributors)
 	PostAlbumsAlbumIdContributors(c *fiber.Ctx, albumId AlbumId) error
+	// Get album change history
+	// (GET /albums/{albumId}/history)
+	GetAlbumsAlbumIdHistory(c *fiber.Ctx, albumId AlbumId, params GetAlbumsAlbumIdHistoryParams) error
+	// Roll album back to a prior version
+	// (POST /albums/{albumId}/history/{version}/rollback)
+	PostAlbumsAlbumIdHistoryVersionRollback(c *fiber.Ctx, albumId AlbumId, version Version) error
 	// Get album's songs
 	// (GET /albums/{albumId}/songs)
 	GetAlbumsAlbumIdSongs(c *fiber.Ctx, albumId AlbumId) error
+	// Get the tags and moods attached to a album
+	// (GET /albums/{albumId}/tags)
+	GetAlbumsAlbumIdTags(c *fiber.Ctx, albumId AlbumId) error
+	// Replace the tags and moods attached to a album
+	// (PUT /albums/{albumId}/tags)
+	PutAlbumsAlbumIdTags(c *fiber.Ctx, albumId AlbumId) error
+	// Territory rules of the album
+	// (GET /albums/{albumId}/territories)
+	GetAlbumsAlbumIdTerritories(c *fiber.Ctx, albumId AlbumId) error
+	// License the album in, or keep it out of, countries
+	// (POST /albums/{albumId}/territories)
+	PostAlbumsAlbumIdTerritories(c *fiber.Ctx, albumId AlbumId) error
 	// List all artists
 	// (GET /artists)
 	GetArtists(c *fiber.Ctx, params GetArtistsParams) error
@@ -549,21 +2085,135 @@ type ServerInterface interface {
 	// Update artist
 	// (PUT /artists/{artistId})
 	PutArtistsArtistId(c *fiber.Ctx, artistId ArtistId) error
+	// Streams, listeners, purchases, tips and revenue for an artist
+	// (GET /artists/{artistId}/analytics)
+	GetArtistsArtistIdAnalytics(c *fiber.Ctx, artistId ArtistId, params GetArtistsArtistIdAnalyticsParams) error
+	// Get artist change history
+	// (GET /artists/{artistId}/history)
+	GetArtistsArtistIdHistory(c *fiber.Ctx, artistId ArtistId, params GetArtistsArtistIdHistoryParams) error
+	// Roll artist back to a prior version
+	// (POST /artists/{artistId}/history/{version}/rollback)
+	PostArtistsArtistIdHistoryVersionRollback(c *fiber.Ctx, artistId ArtistId, version Version) error
+	// Labels linked to the artist, including pending invitations
+	// (GET /artists/{artistId}/labels)
+	GetArtistsArtistIdLabels(c *fiber.Ctx, artistId ArtistId) error
+	// Leave a label or decline its invitation
+	// (DELETE /artists/{artistId}/labels/{labelId})
+	DeleteArtistsArtistIdLabelsLabelId(c *fiber.Ctx, artistId ArtistId, labelId LabelId) error
+	// Change what a label may do for the artist
+	// (PUT /artists/{artistId}/labels/{labelId})
+	PutArtistsArtistIdLabelsLabelId(c *fiber.Ctx, artistId ArtistId, labelId LabelId) error
+	// Accept a label's invitation
+	// (POST /artists/{artistId}/labels/{labelId}/accept)
+	PostArtistsArtistIdLabelsLabelIdAccept(c *fiber.Ctx, artistId ArtistId, labelId LabelId) error
+	// Live plays, tips and purchases of an artist's songs and albums
+	// (GET /artists/{artistId}/live)
+	GetArtistsArtistIdLive(c *fiber.Ctx, artistId ArtistId) error
 	// Get artist's songs
 	// (GET /artists/{artistId}/songs)
 	GetArtistsArtistIdSongs(c *fiber.Ctx, artistId ArtistId, params GetArtistsArtistIdSongsParams) error
+	// List the artist's verification requests
+	// (GET /artists/{artistId}/verification)
+	GetArtistsArtistIdVerification(c *fiber.Ctx, artistId ArtistId) error
+	// Request verification
+	// (POST /artists/{artistId}/verification)
+	PostArtistsArtistIdVerification(c *fiber.Ctx, artistId ArtistId) error
+	// Revoke an artist's verification
+	// (POST /artists/{artistId}/verification/revoke)
+	PostArtistsArtistIdVerificationRevoke(c *fiber.Ctx, artistId ArtistId) error
+	// An artist's year in review
+	// (GET /artists/{artistId}/wrapped/{year})
+	GetArtistsArtistIdWrappedYear(c *fiber.Ctx, artistId ArtistId, year WrappedYear) error
+	// Weekly Top 50 of a country
+	// (GET /charts/countries/{countryCode})
+	GetChartsCountriesCountryCode(c *fiber.Ctx, countryCode string, params GetChartsCountriesCountryCodeParams) error
+	// Weekly Top 50 of a genre
+	// (GET /charts/genres/{genreId})
+	GetChartsGenresGenreId(c *fiber.Ctx, genreId GenreId, params GetChartsGenresGenreIdParams) error
+	// Weekly global Top 50
+	// (GET /charts/global)
+	GetChartsGlobal(c *fiber.Ctx, params GetChartsGlobalParams) error
+	// Trending songs
+	// (GET /charts/trending)
+	GetChartsTrending(c *fiber.Ctx, params GetChartsTrendingParams) error
+	// List export jobs, latest first
+	// (GET /exports)
+	GetExports(c *fiber.Ctx, params GetExportsParams) error
+	// Export analytics data for a range of days
+	// (POST /exports)
+	PostExports(c *fiber.Ctx) error
+	// An export job, with its download link once completed
+	// (GET /exports/{jobId})
+	GetExportsJobId(c *fiber.Ctx, jobId ExportJobId) error
+	// Download a completed export through its signed link
+	// (GET /exports/{jobId}/download)
+	GetExportsJobIdDownload(c *fiber.Ctx, jobId ExportJobId, params GetExportsJobIdDownloadParams) error
 	// Flag content
 	// (POST /flags)
 	PostFlags(c *fiber.Ctx) error
 	// List all genres
 	// (GET /genres)
 	GetGenres(c *fiber.Ctx) error
+	// Create a genre or sub-genre
+	// (POST /genres)
+	PostGenres(c *fiber.Ctx) error
+	// Delete genre
+	// (DELETE /genres/{genreId})
+	DeleteGenresGenreId(c *fiber.Ctx, genreId GenreId) error
 	// Get genre by ID
 	// (GET /genres/{genreId})
 	GetGenresGenreId(c *fiber.Ctx, genreId GenreId) error
+	// Update genre
+	// (PUT /genres/{genreId})
+	PutGenresGenreId(c *fiber.Ctx, genreId GenreId) error
+	// List the direct sub-genres of a genre
+	// (GET /genres/{genreId}/subgenres)
+	GetGenresGenreIdSubgenres(c *fiber.Ctx, genreId GenreId) error
+	// Labels the current user belongs to
+	// (GET /labels)
+	GetLabels(c *fiber.Ctx) error
+	// Create a label owned by the current user
+	// (POST /labels)
+	PostLabels(c *fiber.Ctx) error
+	// Delete label
+	// (DELETE /labels/{labelId})
+	DeleteLabelsLabelId(c *fiber.Ctx, labelId LabelId) error
+	// Get label with its active roster
+	// (GET /labels/{labelId})
+	GetLabelsLabelId(c *fiber.Ctx, labelId LabelId) error
+	// Update label
+	// (PUT /labels/{labelId})
+	PutLabelsLabelId(c *fiber.Ctx, labelId LabelId) error
+	// List linked and invited artists
+	// (GET /labels/{labelId}/artists)
+	GetLabelsLabelIdArtists(c *fiber.Ctx, labelId LabelId) error
+	// Invite an artist to the label
+	// (POST /labels/{labelId}/artists)
+	PostLabelsLabelIdArtists(c *fiber.Ctx, labelId LabelId) error
+	// Remove an artist from the label
+	// (DELETE /labels/{labelId}/artists/{artistId})
+	DeleteLabelsLabelIdArtistsArtistId(c *fiber.Ctx, labelId LabelId, artistId ArtistId) error
+	// List label members
+	// (GET /labels/{labelId}/members)
+	GetLabelsLabelIdMembers(c *fiber.Ctx, labelId LabelId) error
+	// Add a member or change their role
+	// (POST /labels/{labelId}/members)
+	PostLabelsLabelIdMembers(c *fiber.Ctx, labelId LabelId) error
+	// Remove a member
+	// (DELETE /labels/{labelId}/members/{userId})
+	DeleteLabelsLabelIdMembersUserId(c *fiber.Ctx, labelId LabelId, userId UserId) error
+	// Aggregated royalties for the label's roster
+	// (GET /labels/{labelId}/royalties)
+	GetLabelsLabelIdRoyalties(c *fiber.Ctx, labelId LabelId, params GetLabelsLabelIdRoyaltiesParams) error
 	// User login credentials
 	// (POST /login)
 	PostLogin(c *fiber.Ctx) error
+	// Receive a payment provider's webhook
+	// (POST /payments/webhooks/{provider})
+	PostPaymentsWebhooksProvider(c *fiber.Ctx, provider string) error
+	// Get a payment of the current user
+	// (GET /payments/{paymentId})
+	GetPaymentsPaymentId(c *fiber.Ctx, paymentId openapi_types.UUID) error
 	// Delete playlist
 	// (DELETE /playlists/{playlistId})
 	DeletePlaylistsPlaylistId(c *fiber.Ctx, playlistId PlaylistId) error
@@ -573,6 +2223,12 @@ type ServerInterface interface {
 	// Update playlist
 	// (PUT /playlists/{playlistId})
 	PutPlaylistsPlaylistId(c *fiber.Ctx, playlistId PlaylistId) error
+	// Get playlist change history
+	// (GET /playlists/{playlistId}/history)
+	GetPlaylistsPlaylistIdHistory(c *fiber.Ctx, playlistId PlaylistId, params GetPlaylistsPlaylistIdHistoryParams) error
+	// Roll playlist back to a prior version
+	// (POST /playlists/{playlistId}/history/{version}/rollback)
+	PostPlaylistsPlaylistIdHistoryVersionRollback(c *fiber.Ctx, playlistId PlaylistId, version Version) error
 	// Get playlist songs
 	// (GET /playlists/{playlistId}/songs)
 	GetPlaylistsPlaylistIdSongs(c *fiber.Ctx, playlistId PlaylistId) error
@@ -594,9 +2250,24 @@ type ServerInterface interface {
 	// Search albums with advanced filters
 	// (GET /search/albums)
 	GetSearchAlbums(c *fiber.Ctx, params GetSearchAlbumsParams) error
+	// Search click-through rate
+	// (GET /search/analytics/click-through)
+	GetSearchAnalyticsClickThrough(c *fiber.Ctx, params GetSearchAnalyticsClickThroughParams) error
+	// Most searched queries
+	// (GET /search/analytics/top-queries)
+	GetSearchAnalyticsTopQueries(c *fiber.Ctx, params GetSearchAnalyticsTopQueriesParams) error
+	// Queries that found nothing
+	// (GET /search/analytics/zero-results)
+	GetSearchAnalyticsZeroResults(c *fiber.Ctx, params GetSearchAnalyticsZeroResultsParams) error
 	// Search artists with advanced filters
 	// (GET /search/artists)
 	GetSearchArtists(c *fiber.Ctx, params GetSearchArtistsParams) error
+	// Report a search result being opened
+	// (POST /search/clicks)
+	PostSearchClicks(c *fiber.Ctx) error
+	// Spelling corrections for a search query
+	// (GET /search/did-you-mean)
+	GetSearchDidYouMean(c *fiber.Ctx, params GetSearchDidYouMeanParams) error
 	// Search genres
 	// (GET /search/genres)
 	GetSearchGenres(c *fiber.Ctx, params GetSearchGenresParams) error
@@ -606,6 +2277,12 @@ type ServerInterface interface {
 	// Search songs with advanced filters
 	// (GET /search/songs)
 	GetSearchSongs(c *fiber.Ctx, params GetSearchSongsParams) error
+	// Search-as-you-type suggestions
+	// (GET /search/suggest)
+	GetSearchSuggest(c *fiber.Ctx, params GetSearchSuggestParams) error
+	// Trending searches
+	// (GET /search/trending)
+	GetSearchTrending(c *fiber.Ctx, params GetSearchTrendingParams) error
 	// List all songs
 	// (GET /songs)
 	GetSongs(c *fiber.Ctx, params GetSongsParams) error
@@ -621,15 +2298,54 @@ type ServerInterface interface {
 	// Update song
 	// (PUT /songs/{songId})
 	PutSongsSongId(c *fiber.Ctx, songId SongId) error
+	// Chart history of a song
+	// (GET /songs/{songId}/charts)
+	GetSongsSongIdCharts(c *fiber.Ctx, songId SongId) error
 	// Get song contributors
 	// (GET /songs/{songId}/contributors)
 	GetSongsSongIdContributors(c *fiber.Ctx, songId SongId) error
 	// Add contributor to song
 	// (POST /songs/{songId}/contributors)
 	PostSongsSongIdContributors(c *fiber.Ctx, songId SongId) error
+	// Get song change history
+	// (GET /songs/{songId}/history)
+	GetSongsSongIdHistory(c *fiber.Ctx, songId SongId, params GetSongsSongIdHistoryParams) error
+	// Roll song back to a prior version
+	// (POST /songs/{songId}/history/{version}/rollback)
+	PostSongsSongIdHistoryVersionRollback(c *fiber.Ctx, songId SongId, version Version) error
+	// Get the tags and moods attached to a song
+	// (GET /songs/{songId}/tags)
+	GetSongsSongIdTags(c *fiber.Ctx, songId SongId) error
+	// Replace the tags and moods attached to a song
+	// (PUT /songs/{songId}/tags)
+	PutSongsSongIdTags(c *fiber.Ctx, songId SongId) error
+	// Territory rules of the song
+	// (GET /songs/{songId}/territories)
+	GetSongsSongIdTerritories(c *fiber.Ctx, songId SongId) error
+	// License the song in, or keep it out of, countries
+	// (POST /songs/{songId}/territories)
+	PostSongsSongIdTerritories(c *fiber.Ctx, songId SongId) error
 	// Record a stream
 	// (POST /streams)
 	PostStreams(c *fiber.Ctx) error
+	// Streams held back as likely fraud
+	// (GET /streams/suspicious)
+	GetStreamsSuspicious(c *fiber.Ctx, params GetStreamsSuspiciousParams) error
+	// Approve or reject a suspicious stream
+	// (POST /streams/{streamId}/review)
+	PostStreamsStreamIdReview(c *fiber.Ctx, streamId StreamId) error
+	// List tags and moods
+	// (GET /tags)
+	GetTags(c *fiber.Ctx, params GetTagsParams) error
+	// Create a tag or mood
+	// (POST /tags)
+	PostTags(c *fiber.Ctx) error
+	// Delete a tag or mood
+	// (DELETE /tags/{tagId})
+	DeleteTagsTagId(c *fiber.Ctx, tagId TagId) error
+	// Delete a territory rule
+	// (DELETE /territories/{ruleId})
+	DeleteTerritoriesRuleId(c *fiber.Ctx, ruleId openapi_types.UUID) error
 	// Send tip to artist
 	// (POST /tips)
 	PostTips(c *fiber.Ctx) error
@@ -657,12 +2373,45 @@ type ServerInterface interface {
 	// Get user's purchased songs
 	// (GET /users/{userId}/library/songs)
 	GetUsersUserIdLibrarySongs(c *fiber.Ctx, userId UserId) error
+	// Clear user's listening history
+	// (DELETE /users/{userId}/listening-history)
+	DeleteUsersUserIdListeningHistory(c *fiber.Ctx, userId UserId) error
+	// Get user's listening history
+	// (GET /users/{userId}/listening-history)
+	GetUsersUserIdListeningHistory(c *fiber.Ctx, userId UserId, params GetUsersUserIdListeningHistoryParams) error
+	// Get user's listening history settings
+	// (GET /users/{userId}/listening-history/settings)
+	GetUsersUserIdListeningHistorySettings(c *fiber.Ctx, userId UserId) error
+	// Pause or resume user's listening history
+	// (PUT /users/{userId}/listening-history/settings)
+	PutUsersUserIdListeningHistorySettings(c *fiber.Ctx, userId UserId) error
+	// Delete a play from user's listening history
+	// (DELETE /users/{userId}/listening-history/{streamId})
+	DeleteUsersUserIdListeningHistoryStreamId(c *fiber.Ctx, userId UserId, streamId StreamId) error
 	// Get user's playlists
 	// (GET /users/{userId}/playlists)
 	GetUsersUserIdPlaylists(c *fiber.Ctx, userId UserId) error
 	// Create a new playlist
 	// (POST /users/{userId}/playlists)
 	PostUsersUserIdPlaylists(c *fiber.Ctx, userId UserId) error
+	// Get user's recently played songs, albums and playlists
+	// (GET /users/{userId}/recently-played)
+	GetUsersUserIdRecentlyPlayed(c *fiber.Ctx, userId UserId, params GetUsersUserIdRecentlyPlayedParams) error
+	// Get user's year in review
+	// (GET /users/{userId}/wrapped/{year})
+	GetUsersUserIdWrappedYear(c *fiber.Ctx, userId UserId, year WrappedYear) error
+	// Verification review queue
+	// (GET /verification-requests)
+	GetVerificationRequests(c *fiber.Ctx, params GetVerificationRequestsParams) error
+	// Get a verification request
+	// (GET /verification-requests/{requestId})
+	GetVerificationRequestsRequestId(c *fiber.Ctx, requestId RequestId) error
+	// Download an uploaded verification document
+	// (GET /verification-requests/{requestId}/evidence/{evidenceId})
+	GetVerificationRequestsRequestIdEvidenceEvidenceId(c *fiber.Ctx, requestId RequestId, evidenceId EvidenceId) error
+	// Approve, reject or ask for more information
+	// (POST /verification-requests/{requestId}/review)
+	PostVerificationRequestsRequestIdReview(c *fiber.Ctx, requestId RequestId) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -678,7 +2427,1931 @@ func (siw *ServerInterfaceWrapper) GetAlbums(c *fiber.Ctx) error {
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAlbumsParams
+	var params GetAlbumsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "artist" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "artist", query, &params.Artist)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artist: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "genre" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "genre", query, &params.Genre)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genre: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "tags" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tags", query, &params.Tags)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter tags: %w", err).Error())
+	}
+
+	return siw.Handler.GetAlbums(c, params)
+}
+
+// PostAlbums operation middleware
+func (siw *ServerInterfaceWrapper) PostAlbums(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+
+	return siw.Handler.PostAlbums(c)
+}
+
+// DeleteAlbumsAlbumId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAlbumsAlbumId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+
+	return siw.Handler.DeleteAlbumsAlbumId(c, albumId)
+}
+
+// GetAlbumsAlbumId operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumsAlbumId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	return siw.Handler.GetAlbumsAlbumId(c, albumId)
+}
+
+// PutAlbumsAlbumId operation middleware
+func (siw *ServerInterfaceWrapper) PutAlbumsAlbumId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+
+	return siw.Handler.PutAlbumsAlbumId(c, albumId)
+}
+
+// GetAlbumsAlbumIdContributors operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumsAlbumIdContributors(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	return siw.Handler.GetAlbumsAlbumIdContributors(c, albumId)
+}
+
+// PostAlbumsAlbumIdContributors operation middleware
+func (siw *ServerInterfaceWrapper) PostAlbumsAlbumIdContributors(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+
+	return siw.Handler.PostAlbumsAlbumIdContributors(c, albumId)
+}
+
+// GetAlbumsAlbumIdHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumsAlbumIdHistory(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAlbumsAlbumIdHistoryParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetAlbumsAlbumIdHistory(c, albumId, params)
+}
+
+// PostAlbumsAlbumIdHistoryVersionRollback operation middleware
+func (siw *ServerInterfaceWrapper) PostAlbumsAlbumIdHistoryVersionRollback(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version Version
+
+	err = runtime.BindStyledParameter("simple", false, "version", c.Params("version"), &version)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter version: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostAlbumsAlbumIdHistoryVersionRollback(c, albumId, version)
+}
+
+// GetAlbumsAlbumIdSongs operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumsAlbumIdSongs(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	return siw.Handler.GetAlbumsAlbumIdSongs(c, albumId)
+}
+
+// GetAlbumsAlbumIdTags operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumsAlbumIdTags(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	return siw.Handler.GetAlbumsAlbumIdTags(c, albumId)
+}
+
+// PutAlbumsAlbumIdTags operation middleware
+func (siw *ServerInterfaceWrapper) PutAlbumsAlbumIdTags(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+
+	return siw.Handler.PutAlbumsAlbumIdTags(c, albumId)
+}
+
+// GetAlbumsAlbumIdTerritories operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumsAlbumIdTerritories(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetAlbumsAlbumIdTerritories(c, albumId)
+}
+
+// PostAlbumsAlbumIdTerritories operation middleware
+func (siw *ServerInterfaceWrapper) PostAlbumsAlbumIdTerritories(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "albumId" -------------
+	var albumId AlbumId
+
+	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostAlbumsAlbumIdTerritories(c, albumId)
+}
+
+// GetArtists operation middleware
+func (siw *ServerInterfaceWrapper) GetArtists(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetArtistsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "verified" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "verified", query, &params.Verified)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter verified: %w", err).Error())
+	}
+
+	return siw.Handler.GetArtists(c, params)
+}
+
+// PostArtists operation middleware
+func (siw *ServerInterfaceWrapper) PostArtists(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+
+	return siw.Handler.PostArtists(c)
+}
+
+// GetArtistsArtistId operation middleware
+func (siw *ServerInterfaceWrapper) GetArtistsArtistId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	return siw.Handler.GetArtistsArtistId(c, artistId)
+}
+
+// PutArtistsArtistId operation middleware
+func (siw *ServerInterfaceWrapper) PutArtistsArtistId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+
+	return siw.Handler.PutArtistsArtistId(c, artistId)
+}
+
+// GetArtistsArtistIdAnalytics operation middleware
+func (siw *ServerInterfaceWrapper) GetArtistsArtistIdAnalytics(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetArtistsArtistIdAnalyticsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "days", query, &params.Days)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter days: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "granularity" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "granularity", query, &params.Granularity)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter granularity: %w", err).Error())
+	}
+
+	return siw.Handler.GetArtistsArtistIdAnalytics(c, artistId, params)
+}
+
+// GetArtistsArtistIdHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetArtistsArtistIdHistory(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetArtistsArtistIdHistoryParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetArtistsArtistIdHistory(c, artistId, params)
+}
+
+// PostArtistsArtistIdHistoryVersionRollback operation middleware
+func (siw *ServerInterfaceWrapper) PostArtistsArtistIdHistoryVersionRollback(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version Version
+
+	err = runtime.BindStyledParameter("simple", false, "version", c.Params("version"), &version)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter version: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostArtistsArtistIdHistoryVersionRollback(c, artistId, version)
+}
+
+// GetArtistsArtistIdLabels operation middleware
+func (siw *ServerInterfaceWrapper) GetArtistsArtistIdLabels(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetArtistsArtistIdLabels(c, artistId)
+}
+
+// DeleteArtistsArtistIdLabelsLabelId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteArtistsArtistIdLabelsLabelId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteArtistsArtistIdLabelsLabelId(c, artistId, labelId)
+}
+
+// PutArtistsArtistIdLabelsLabelId operation middleware
+func (siw *ServerInterfaceWrapper) PutArtistsArtistIdLabelsLabelId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutArtistsArtistIdLabelsLabelId(c, artistId, labelId)
+}
+
+// PostArtistsArtistIdLabelsLabelIdAccept operation middleware
+func (siw *ServerInterfaceWrapper) PostArtistsArtistIdLabelsLabelIdAccept(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostArtistsArtistIdLabelsLabelIdAccept(c, artistId, labelId)
+}
+
+// GetArtistsArtistIdLive operation middleware
+func (siw *ServerInterfaceWrapper) GetArtistsArtistIdLive(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetArtistsArtistIdLive(c, artistId)
+}
+
+// GetArtistsArtistIdSongs operation middleware
+func (siw *ServerInterfaceWrapper) GetArtistsArtistIdSongs(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetArtistsArtistIdSongsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetArtistsArtistIdSongs(c, artistId, params)
+}
+
+// GetArtistsArtistIdVerification operation middleware
+func (siw *ServerInterfaceWrapper) GetArtistsArtistIdVerification(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetArtistsArtistIdVerification(c, artistId)
+}
+
+// PostArtistsArtistIdVerification operation middleware
+func (siw *ServerInterfaceWrapper) PostArtistsArtistIdVerification(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+
+	return siw.Handler.PostArtistsArtistIdVerification(c, artistId)
+}
+
+// PostArtistsArtistIdVerificationRevoke operation middleware
+func (siw *ServerInterfaceWrapper) PostArtistsArtistIdVerificationRevoke(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostArtistsArtistIdVerificationRevoke(c, artistId)
+}
+
+// GetArtistsArtistIdWrappedYear operation middleware
+func (siw *ServerInterfaceWrapper) GetArtistsArtistIdWrappedYear(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	// ------------- Path parameter "year" -------------
+	var year WrappedYear
+
+	err = runtime.BindStyledParameter("simple", false, "year", c.Params("year"), &year)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter year: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetArtistsArtistIdWrappedYear(c, artistId, year)
+}
+
+// GetChartsCountriesCountryCode operation middleware
+func (siw *ServerInterfaceWrapper) GetChartsCountriesCountryCode(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "countryCode" -------------
+	var countryCode string
+
+	err = runtime.BindStyledParameter("simple", false, "countryCode", c.Params("countryCode"), &countryCode)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter countryCode: %w", err).Error())
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChartsCountriesCountryCodeParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "week" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "week", query, &params.Week)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter week: %w", err).Error())
+	}
+
+	return siw.Handler.GetChartsCountriesCountryCode(c, countryCode, params)
+}
+
+// GetChartsGenresGenreId operation middleware
+func (siw *ServerInterfaceWrapper) GetChartsGenresGenreId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "genreId" -------------
+	var genreId GenreId
+
+	err = runtime.BindStyledParameter("simple", false, "genreId", c.Params("genreId"), &genreId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genreId: %w", err).Error())
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChartsGenresGenreIdParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "week" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "week", query, &params.Week)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter week: %w", err).Error())
+	}
+
+	return siw.Handler.GetChartsGenresGenreId(c, genreId, params)
+}
+
+// GetChartsGlobal operation middleware
+func (siw *ServerInterfaceWrapper) GetChartsGlobal(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChartsGlobalParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "week" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "week", query, &params.Week)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter week: %w", err).Error())
+	}
+
+	return siw.Handler.GetChartsGlobal(c, params)
+}
+
+// GetChartsTrending operation middleware
+func (siw *ServerInterfaceWrapper) GetChartsTrending(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChartsTrendingParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "genreId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "genreId", query, &params.GenreId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genreId: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetChartsTrending(c, params)
+}
+
+// GetExports operation middleware
+func (siw *ServerInterfaceWrapper) GetExports(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExportsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetExports(c, params)
+}
+
+// PostExports operation middleware
+func (siw *ServerInterfaceWrapper) PostExports(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostExports(c)
+}
+
+// GetExportsJobId operation middleware
+func (siw *ServerInterfaceWrapper) GetExportsJobId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId ExportJobId
+
+	err = runtime.BindStyledParameter("simple", false, "jobId", c.Params("jobId"), &jobId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter jobId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetExportsJobId(c, jobId)
+}
+
+// GetExportsJobIdDownload operation middleware
+func (siw *ServerInterfaceWrapper) GetExportsJobIdDownload(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId ExportJobId
+
+	err = runtime.BindStyledParameter("simple", false, "jobId", c.Params("jobId"), &jobId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter jobId: %w", err).Error())
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExportsJobIdDownloadParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "expires" -------------
+
+	if paramValue := c.Query("expires"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument expires is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "expires", query, &params.Expires)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter expires: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "signature" -------------
+
+	if paramValue := c.Query("signature"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument signature is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "signature", query, &params.Signature)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter signature: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "file" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "file", query, &params.File)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter file: %w", err).Error())
+	}
+
+	return siw.Handler.GetExportsJobIdDownload(c, jobId, params)
+}
+
+// PostFlags operation middleware
+func (siw *ServerInterfaceWrapper) PostFlags(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+
+	return siw.Handler.PostFlags(c)
+}
+
+// GetGenres operation middleware
+func (siw *ServerInterfaceWrapper) GetGenres(c *fiber.Ctx) error {
+
+	return siw.Handler.GetGenres(c)
+}
+
+// PostGenres operation middleware
+func (siw *ServerInterfaceWrapper) PostGenres(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostGenres(c)
+}
+
+// DeleteGenresGenreId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteGenresGenreId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "genreId" -------------
+	var genreId GenreId
+
+	err = runtime.BindStyledParameter("simple", false, "genreId", c.Params("genreId"), &genreId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genreId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteGenresGenreId(c, genreId)
+}
+
+// GetGenresGenreId operation middleware
+func (siw *ServerInterfaceWrapper) GetGenresGenreId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "genreId" -------------
+	var genreId GenreId
+
+	err = runtime.BindStyledParameter("simple", false, "genreId", c.Params("genreId"), &genreId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genreId: %w", err).Error())
+	}
+
+	return siw.Handler.GetGenresGenreId(c, genreId)
+}
+
+// PutGenresGenreId operation middleware
+func (siw *ServerInterfaceWrapper) PutGenresGenreId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "genreId" -------------
+	var genreId GenreId
+
+	err = runtime.BindStyledParameter("simple", false, "genreId", c.Params("genreId"), &genreId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genreId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutGenresGenreId(c, genreId)
+}
+
+// GetGenresGenreIdSubgenres operation middleware
+func (siw *ServerInterfaceWrapper) GetGenresGenreIdSubgenres(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "genreId" -------------
+	var genreId GenreId
+
+	err = runtime.BindStyledParameter("simple", false, "genreId", c.Params("genreId"), &genreId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genreId: %w", err).Error())
+	}
+
+	return siw.Handler.GetGenresGenreIdSubgenres(c, genreId)
+}
+
+// GetLabels operation middleware
+func (siw *ServerInterfaceWrapper) GetLabels(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetLabels(c)
+}
+
+// PostLabels operation middleware
+func (siw *ServerInterfaceWrapper) PostLabels(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostLabels(c)
+}
+
+// DeleteLabelsLabelId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLabelsLabelId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteLabelsLabelId(c, labelId)
+}
+
+// GetLabelsLabelId operation middleware
+func (siw *ServerInterfaceWrapper) GetLabelsLabelId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	return siw.Handler.GetLabelsLabelId(c, labelId)
+}
+
+// PutLabelsLabelId operation middleware
+func (siw *ServerInterfaceWrapper) PutLabelsLabelId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutLabelsLabelId(c, labelId)
+}
+
+// GetLabelsLabelIdArtists operation middleware
+func (siw *ServerInterfaceWrapper) GetLabelsLabelIdArtists(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetLabelsLabelIdArtists(c, labelId)
+}
+
+// PostLabelsLabelIdArtists operation middleware
+func (siw *ServerInterfaceWrapper) PostLabelsLabelIdArtists(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostLabelsLabelIdArtists(c, labelId)
+}
+
+// DeleteLabelsLabelIdArtistsArtistId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLabelsLabelIdArtistsArtistId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	// ------------- Path parameter "artistId" -------------
+	var artistId ArtistId
+
+	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteLabelsLabelIdArtistsArtistId(c, labelId, artistId)
+}
+
+// GetLabelsLabelIdMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetLabelsLabelIdMembers(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetLabelsLabelIdMembers(c, labelId)
+}
+
+// PostLabelsLabelIdMembers operation middleware
+func (siw *ServerInterfaceWrapper) PostLabelsLabelIdMembers(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostLabelsLabelIdMembers(c, labelId)
+}
+
+// DeleteLabelsLabelIdMembersUserId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLabelsLabelIdMembersUserId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteLabelsLabelIdMembersUserId(c, labelId, userId)
+}
+
+// GetLabelsLabelIdRoyalties operation middleware
+func (siw *ServerInterfaceWrapper) GetLabelsLabelIdRoyalties(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "labelId" -------------
+	var labelId LabelId
+
+	err = runtime.BindStyledParameter("simple", false, "labelId", c.Params("labelId"), &labelId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter labelId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLabelsLabelIdRoyaltiesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "year" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year", query, &params.Year)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter year: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "month" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "month", query, &params.Month)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter month: %w", err).Error())
+	}
+
+	return siw.Handler.GetLabelsLabelIdRoyalties(c, labelId, params)
+}
+
+// PostLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin(c *fiber.Ctx) error {
+
+	return siw.Handler.PostLogin(c)
+}
+
+// PostPaymentsWebhooksProvider operation middleware
+func (siw *ServerInterfaceWrapper) PostPaymentsWebhooksProvider(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameter("simple", false, "provider", c.Params("provider"), &provider)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter provider: %w", err).Error())
+	}
+
+	return siw.Handler.PostPaymentsWebhooksProvider(c, provider)
+}
+
+// GetPaymentsPaymentId operation middleware
+func (siw *ServerInterfaceWrapper) GetPaymentsPaymentId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "paymentId" -------------
+	var paymentId openapi_types.UUID
+
+	err = runtime.BindStyledParameter("simple", false, "paymentId", c.Params("paymentId"), &paymentId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter paymentId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
+
+	return siw.Handler.GetPaymentsPaymentId(c, paymentId)
+}
+
+// DeletePlaylistsPlaylistId operation middleware
+func (siw *ServerInterfaceWrapper) DeletePlaylistsPlaylistId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "playlistId" -------------
+	var playlistId PlaylistId
+
+	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+
+	return siw.Handler.DeletePlaylistsPlaylistId(c, playlistId)
+}
+
+// GetPlaylistsPlaylistId operation middleware
+func (siw *ServerInterfaceWrapper) GetPlaylistsPlaylistId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "playlistId" -------------
+	var playlistId PlaylistId
+
+	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+	}
+
+	return siw.Handler.GetPlaylistsPlaylistId(c, playlistId)
+}
+
+// PutPlaylistsPlaylistId operation middleware
+func (siw *ServerInterfaceWrapper) PutPlaylistsPlaylistId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "playlistId" -------------
+	var playlistId PlaylistId
+
+	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+
+	return siw.Handler.PutPlaylistsPlaylistId(c, playlistId)
+}
+
+// GetPlaylistsPlaylistIdHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetPlaylistsPlaylistIdHistory(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "playlistId" -------------
+	var playlistId PlaylistId
+
+	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPlaylistsPlaylistIdHistoryParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetPlaylistsPlaylistIdHistory(c, playlistId, params)
+}
+
+// PostPlaylistsPlaylistIdHistoryVersionRollback operation middleware
+func (siw *ServerInterfaceWrapper) PostPlaylistsPlaylistIdHistoryVersionRollback(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "playlistId" -------------
+	var playlistId PlaylistId
+
+	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version Version
+
+	err = runtime.BindStyledParameter("simple", false, "version", c.Params("version"), &version)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter version: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostPlaylistsPlaylistIdHistoryVersionRollback(c, playlistId, version)
+}
+
+// GetPlaylistsPlaylistIdSongs operation middleware
+func (siw *ServerInterfaceWrapper) GetPlaylistsPlaylistIdSongs(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "playlistId" -------------
+	var playlistId PlaylistId
+
+	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+	}
+
+	return siw.Handler.GetPlaylistsPlaylistIdSongs(c, playlistId)
+}
+
+// PostPlaylistsPlaylistIdSongs operation middleware
+func (siw *ServerInterfaceWrapper) PostPlaylistsPlaylistIdSongs(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "playlistId" -------------
+	var playlistId PlaylistId
+
+	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+
+	return siw.Handler.PostPlaylistsPlaylistIdSongs(c, playlistId)
+}
+
+// DeletePlaylistsPlaylistIdSongsSongId operation middleware
+func (siw *ServerInterfaceWrapper) DeletePlaylistsPlaylistIdSongsSongId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "playlistId" -------------
+	var playlistId PlaylistId
+
+	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+	}
+
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
+
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+
+	return siw.Handler.DeletePlaylistsPlaylistIdSongsSongId(c, playlistId, songId)
+}
+
+// PostPurchasesAlbums operation middleware
+func (siw *ServerInterfaceWrapper) PostPurchasesAlbums(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+
+	return siw.Handler.PostPurchasesAlbums(c)
+}
+
+// PostPurchasesSongs operation middleware
+func (siw *ServerInterfaceWrapper) PostPurchasesSongs(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+
+	return siw.Handler.PostPurchasesSongs(c)
+}
+
+// GetSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetSearch(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "query" -------------
+
+	if paramValue := c.Query("query"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument query is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "query", query, &params.Query)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "types" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "types", query, &params.Types)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter types: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", query, &params.Cursor)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter cursor: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "facets" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "facets", query, &params.Facets)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter facets: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetSearch(c, params)
+}
+
+// GetSearchAlbums operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchAlbums(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchAlbumsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "query" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "query", query, &params.Query)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "artist" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "artist", query, &params.Artist)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artist: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "genre" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "genre", query, &params.Genre)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genre: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetSearchAlbums(c, params)
+}
+
+// GetSearchAnalyticsClickThrough operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchAnalyticsClickThrough(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchAnalyticsClickThroughParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	return siw.Handler.GetSearchAnalyticsClickThrough(c, params)
+}
+
+// GetSearchAnalyticsTopQueries operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchAnalyticsTopQueries(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchAnalyticsTopQueriesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetSearchAnalyticsTopQueries(c, params)
+}
+
+// GetSearchAnalyticsZeroResults operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchAnalyticsZeroResults(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchAnalyticsZeroResultsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetSearchAnalyticsZeroResults(c, params)
+}
+
+// GetSearchArtists operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchArtists(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchArtistsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "query" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "query", query, &params.Query)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "genre" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "genre", query, &params.Genre)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genre: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetSearchArtists(c, params)
+}
+
+// PostSearchClicks operation middleware
+func (siw *ServerInterfaceWrapper) PostSearchClicks(c *fiber.Ctx) error {
+
+	return siw.Handler.PostSearchClicks(c)
+}
+
+// GetSearchDidYouMean operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchDidYouMean(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchDidYouMeanParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "query" -------------
+
+	if paramValue := c.Query("query"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument query is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "query", query, &params.Query)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
+	}
+
+	return siw.Handler.GetSearchDidYouMean(c, params)
+}
+
+// GetSearchGenres operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchGenres(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchGenresParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "query" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "query", query, &params.Query)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
+	}
+
+	return siw.Handler.GetSearchGenres(c, params)
+}
+
+// GetSearchPlaylists operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchPlaylists(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchPlaylistsParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -686,155 +4359,124 @@ func (siw *ServerInterfaceWrapper) GetAlbums(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "page" -------------
+	// ------------- Optional query parameter "query" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	err = runtime.BindQueryParameter("form", true, false, "query", query, &params.Query)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "owner" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "owner", query, &params.Owner)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter owner: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "artist" -------------
+	// ------------- Optional query parameter "isPublic" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "artist", query, &params.Artist)
+	err = runtime.BindQueryParameter("form", true, false, "isPublic", query, &params.IsPublic)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artist: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter isPublic: %w", err).Error())
 	}
 
-	return siw.Handler.GetAlbums(c, params)
-}
-
-// PostAlbums operation middleware
-func (siw *ServerInterfaceWrapper) PostAlbums(c *fiber.Ctx) error {
-
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
-
-	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
-
-	return siw.Handler.PostAlbums(c)
-}
-
-// DeleteAlbumsAlbumId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteAlbumsAlbumId(c *fiber.Ctx) error {
-
-	var err error
-
-	// ------------- Path parameter "albumId" -------------
-	var albumId AlbumId
+	// ------------- Optional query parameter "sort" -------------
 
-	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
 	}
 
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
-
-	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
-
-	return siw.Handler.DeleteAlbumsAlbumId(c, albumId)
-}
-
-// GetAlbumsAlbumId operation middleware
-func (siw *ServerInterfaceWrapper) GetAlbumsAlbumId(c *fiber.Ctx) error {
+	// ------------- Optional query parameter "page" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
 
-	// ------------- Path parameter "albumId" -------------
-	var albumId AlbumId
+	// ------------- Optional query parameter "limit" -------------
 
-	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	return siw.Handler.GetAlbumsAlbumId(c, albumId)
+	return siw.Handler.GetSearchPlaylists(c, params)
 }
 
-// PutAlbumsAlbumId operation middleware
-func (siw *ServerInterfaceWrapper) PutAlbumsAlbumId(c *fiber.Ctx) error {
+// GetSearchSongs operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchSongs(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "albumId" -------------
-	var albumId AlbumId
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchSongsParams
 
-	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
-
-	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
-
-	return siw.Handler.PutAlbumsAlbumId(c, albumId)
-}
-
-// GetAlbumsAlbumIdContributors operation middleware
-func (siw *ServerInterfaceWrapper) GetAlbumsAlbumIdContributors(c *fiber.Ctx) error {
+	// ------------- Optional query parameter "query" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "query", query, &params.Query)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
+	}
 
-	// ------------- Path parameter "albumId" -------------
-	var albumId AlbumId
+	// ------------- Optional query parameter "artist" -------------
 
-	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	err = runtime.BindQueryParameter("form", true, false, "artist", query, &params.Artist)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artist: %w", err).Error())
 	}
 
-	return siw.Handler.GetAlbumsAlbumIdContributors(c, albumId)
-}
-
-// PostAlbumsAlbumIdContributors operation middleware
-func (siw *ServerInterfaceWrapper) PostAlbumsAlbumIdContributors(c *fiber.Ctx) error {
+	// ------------- Optional query parameter "genre" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "genre", query, &params.Genre)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genre: %w", err).Error())
+	}
 
-	// ------------- Path parameter "albumId" -------------
-	var albumId AlbumId
+	// ------------- Optional query parameter "sort" -------------
 
-	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
 	}
 
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
-
-	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+	// ------------- Optional query parameter "order" -------------
 
-	return siw.Handler.PostAlbumsAlbumIdContributors(c, albumId)
-}
+	err = runtime.BindQueryParameter("form", true, false, "order", query, &params.Order)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter order: %w", err).Error())
+	}
 
-// GetAlbumsAlbumIdSongs operation middleware
-func (siw *ServerInterfaceWrapper) GetAlbumsAlbumIdSongs(c *fiber.Ctx) error {
+	// ------------- Optional query parameter "page" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
 
-	// ------------- Path parameter "albumId" -------------
-	var albumId AlbumId
+	// ------------- Optional query parameter "limit" -------------
 
-	err = runtime.BindStyledParameter("simple", false, "albumId", c.Params("albumId"), &albumId)
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter albumId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	return siw.Handler.GetAlbumsAlbumIdSongs(c, albumId)
+	return siw.Handler.GetSearchSongs(c, params)
 }
 
-// GetArtists operation middleware
-func (siw *ServerInterfaceWrapper) GetArtists(c *fiber.Ctx) error {
+// GetSearchSuggest operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchSuggest(c *fiber.Ctx) error {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetArtistsParams
+	var params GetSearchSuggestParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -842,91 +4484,69 @@ func (siw *ServerInterfaceWrapper) GetArtists(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "page" -------------
+	// ------------- Required query parameter "query" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
-	}
+	if paramValue := c.Query("query"); paramValue != "" {
 
-	// ------------- Optional query parameter "limit" -------------
+	} else {
+		err = fmt.Errorf("Query argument query is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	err = runtime.BindQueryParameter("form", true, true, "query", query, &params.Query)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "verified" -------------
+	// ------------- Optional query parameter "types" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "verified", query, &params.Verified)
+	err = runtime.BindQueryParameter("form", true, false, "types", query, &params.Types)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter verified: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter types: %w", err).Error())
 	}
 
-	return siw.Handler.GetArtists(c, params)
-}
-
-// PostArtists operation middleware
-func (siw *ServerInterfaceWrapper) PostArtists(c *fiber.Ctx) error {
-
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
-
-	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
-
-	return siw.Handler.PostArtists(c)
-}
-
-// GetArtistsArtistId operation middleware
-func (siw *ServerInterfaceWrapper) GetArtistsArtistId(c *fiber.Ctx) error {
-
-	var err error
-
-	// ------------- Path parameter "artistId" -------------
-	var artistId ArtistId
+	// ------------- Optional query parameter "limit" -------------
 
-	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	return siw.Handler.GetArtistsArtistId(c, artistId)
+	return siw.Handler.GetSearchSuggest(c, params)
 }
 
-// PutArtistsArtistId operation middleware
-func (siw *ServerInterfaceWrapper) PutArtistsArtistId(c *fiber.Ctx) error {
+// GetSearchTrending operation middleware
+func (siw *ServerInterfaceWrapper) GetSearchTrending(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "artistId" -------------
-	var artistId ArtistId
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchTrendingParams
 
-	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "limit" -------------
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
 
-	return siw.Handler.PutArtistsArtistId(c, artistId)
+	return siw.Handler.GetSearchTrending(c, params)
 }
 
-// GetArtistsArtistIdSongs operation middleware
-func (siw *ServerInterfaceWrapper) GetArtistsArtistIdSongs(c *fiber.Ctx) error {
+// GetSongs operation middleware
+func (siw *ServerInterfaceWrapper) GetSongs(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "artistId" -------------
-	var artistId ArtistId
-
-	err = runtime.BindStyledParameter("simple", false, "artistId", c.Params("artistId"), &artistId)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artistId: %w", err).Error())
-	}
-
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetArtistsArtistIdSongsParams
+	var params GetSongsParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -948,152 +4568,140 @@ func (siw *ServerInterfaceWrapper) GetArtistsArtistIdSongs(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	return siw.Handler.GetArtistsArtistIdSongs(c, artistId, params)
-}
-
-// PostFlags operation middleware
-func (siw *ServerInterfaceWrapper) PostFlags(c *fiber.Ctx) error {
-
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
-
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+	// ------------- Optional query parameter "genre" -------------
 
-	return siw.Handler.PostFlags(c)
-}
+	err = runtime.BindQueryParameter("form", true, false, "genre", query, &params.Genre)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genre: %w", err).Error())
+	}
 
-// GetGenres operation middleware
-func (siw *ServerInterfaceWrapper) GetGenres(c *fiber.Ctx) error {
+	// ------------- Optional query parameter "artist" -------------
 
-	return siw.Handler.GetGenres(c)
-}
+	err = runtime.BindQueryParameter("form", true, false, "artist", query, &params.Artist)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artist: %w", err).Error())
+	}
 
-// GetGenresGenreId operation middleware
-func (siw *ServerInterfaceWrapper) GetGenresGenreId(c *fiber.Ctx) error {
+	// ------------- Optional query parameter "album" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "album", query, &params.Album)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter album: %w", err).Error())
+	}
 
-	// ------------- Path parameter "genreId" -------------
-	var genreId GenreId
+	// ------------- Optional query parameter "tags" -------------
 
-	err = runtime.BindStyledParameter("simple", false, "genreId", c.Params("genreId"), &genreId)
+	err = runtime.BindQueryParameter("form", true, false, "tags", query, &params.Tags)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genreId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter tags: %w", err).Error())
 	}
 
-	return siw.Handler.GetGenresGenreId(c, genreId)
+	return siw.Handler.GetSongs(c, params)
 }
 
-// PostLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostLogin(c *fiber.Ctx) error {
+// PostSongs operation middleware
+func (siw *ServerInterfaceWrapper) PostSongs(c *fiber.Ctx) error {
 
-	return siw.Handler.PostLogin(c)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+
+	return siw.Handler.PostSongs(c)
 }
 
-// DeletePlaylistsPlaylistId operation middleware
-func (siw *ServerInterfaceWrapper) DeletePlaylistsPlaylistId(c *fiber.Ctx) error {
+// DeleteSongsSongId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSongsSongId(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "playlistId" -------------
-	var playlistId PlaylistId
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
 	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
 
-	return siw.Handler.DeletePlaylistsPlaylistId(c, playlistId)
+	return siw.Handler.DeleteSongsSongId(c, songId)
 }
 
-// GetPlaylistsPlaylistId operation middleware
-func (siw *ServerInterfaceWrapper) GetPlaylistsPlaylistId(c *fiber.Ctx) error {
+// GetSongsSongId operation middleware
+func (siw *ServerInterfaceWrapper) GetSongsSongId(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "playlistId" -------------
-	var playlistId PlaylistId
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
 	}
 
-	return siw.Handler.GetPlaylistsPlaylistId(c, playlistId)
+	return siw.Handler.GetSongsSongId(c, songId)
 }
 
-// PutPlaylistsPlaylistId operation middleware
-func (siw *ServerInterfaceWrapper) PutPlaylistsPlaylistId(c *fiber.Ctx) error {
+// PutSongsSongId operation middleware
+func (siw *ServerInterfaceWrapper) PutSongsSongId(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "playlistId" -------------
-	var playlistId PlaylistId
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
 	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
 
-	return siw.Handler.PutPlaylistsPlaylistId(c, playlistId)
+	return siw.Handler.PutSongsSongId(c, songId)
 }
 
-// GetPlaylistsPlaylistIdSongs operation middleware
-func (siw *ServerInterfaceWrapper) GetPlaylistsPlaylistIdSongs(c *fiber.Ctx) error {
+// GetSongsSongIdCharts operation middleware
+func (siw *ServerInterfaceWrapper) GetSongsSongIdCharts(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "playlistId" -------------
-	var playlistId PlaylistId
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
 	}
 
-	return siw.Handler.GetPlaylistsPlaylistIdSongs(c, playlistId)
+	return siw.Handler.GetSongsSongIdCharts(c, songId)
 }
 
-// PostPlaylistsPlaylistIdSongs operation middleware
-func (siw *ServerInterfaceWrapper) PostPlaylistsPlaylistIdSongs(c *fiber.Ctx) error {
+// GetSongsSongIdContributors operation middleware
+func (siw *ServerInterfaceWrapper) GetSongsSongIdContributors(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "playlistId" -------------
-	var playlistId PlaylistId
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
 	}
 
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
-
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
-
-	return siw.Handler.PostPlaylistsPlaylistIdSongs(c, playlistId)
+	return siw.Handler.GetSongsSongIdContributors(c, songId)
 }
 
-// DeletePlaylistsPlaylistIdSongsSongId operation middleware
-func (siw *ServerInterfaceWrapper) DeletePlaylistsPlaylistIdSongsSongId(c *fiber.Ctx) error {
+// PostSongsSongIdContributors operation middleware
+func (siw *ServerInterfaceWrapper) PostSongsSongIdContributors(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "playlistId" -------------
-	var playlistId PlaylistId
-
-	err = runtime.BindStyledParameter("simple", false, "playlistId", c.Params("playlistId"), &playlistId)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter playlistId: %w", err).Error())
-	}
-
 	// ------------- Path parameter "songId" -------------
 	var songId SongId
 
@@ -1104,38 +4712,28 @@ func (siw *ServerInterfaceWrapper) DeletePlaylistsPlaylistIdSongsSongId(c *fiber
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
 
-	return siw.Handler.DeletePlaylistsPlaylistIdSongsSongId(c, playlistId, songId)
+	return siw.Handler.PostSongsSongIdContributors(c, songId)
 }
 
-// PostPurchasesAlbums operation middleware
-func (siw *ServerInterfaceWrapper) PostPurchasesAlbums(c *fiber.Ctx) error {
-
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
+// GetSongsSongIdHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetSongsSongIdHistory(c *fiber.Ctx) error {
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+	var err error
 
-	return siw.Handler.PostPurchasesAlbums(c)
-}
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-// PostPurchasesSongs operation middleware
-func (siw *ServerInterfaceWrapper) PostPurchasesSongs(c *fiber.Ctx) error {
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
+	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
-
-	return siw.Handler.PostPurchasesSongs(c)
-}
-
-// GetSearch operation middleware
-func (siw *ServerInterfaceWrapper) GetSearch(c *fiber.Ctx) error {
-
-	var err error
-
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSearchParams
+	var params GetSongsSongIdHistoryParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -1143,28 +4741,6 @@ func (siw *ServerInterfaceWrapper) GetSearch(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	// ------------- Required query parameter "query" -------------
-
-	if paramValue := c.Query("query"); paramValue != "" {
-
-	} else {
-		err = fmt.Errorf("Query argument query is required, but not found")
-		c.Status(fiber.StatusBadRequest).JSON(err)
-		return err
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "query", query, &params.Query)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
-	}
-
-	// ------------- Optional query parameter "types" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "types", query, &params.Types)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter types: %w", err).Error())
-	}
-
 	// ------------- Optional query parameter "page" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
@@ -1179,158 +4755,126 @@ func (siw *ServerInterfaceWrapper) GetSearch(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	return siw.Handler.GetSearch(c, params)
+	return siw.Handler.GetSongsSongIdHistory(c, songId, params)
 }
 
-// GetSearchAlbums operation middleware
-func (siw *ServerInterfaceWrapper) GetSearchAlbums(c *fiber.Ctx) error {
+// PostSongsSongIdHistoryVersionRollback operation middleware
+func (siw *ServerInterfaceWrapper) PostSongsSongIdHistoryVersionRollback(c *fiber.Ctx) error {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSearchAlbumsParams
-
-	var query url.Values
-	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
-	}
-
-	// ------------- Optional query parameter "query" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "query", query, &params.Query)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
-	}
-
-	// ------------- Optional query parameter "artist" -------------
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-	err = runtime.BindQueryParameter("form", true, false, "artist", query, &params.Artist)
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artist: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "genre" -------------
+	// ------------- Path parameter "version" -------------
+	var version Version
 
-	err = runtime.BindQueryParameter("form", true, false, "genre", query, &params.Genre)
+	err = runtime.BindStyledParameter("simple", false, "version", c.Params("version"), &version)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genre: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter version: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "sort" -------------
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
-	}
+	return siw.Handler.PostSongsSongIdHistoryVersionRollback(c, songId, version)
+}
 
-	// ------------- Optional query parameter "page" -------------
+// GetSongsSongIdTags operation middleware
+func (siw *ServerInterfaceWrapper) GetSongsSongIdTags(c *fiber.Ctx) error {
 
-	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
-	}
+	var err error
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
 	}
 
-	return siw.Handler.GetSearchAlbums(c, params)
+	return siw.Handler.GetSongsSongIdTags(c, songId)
 }
 
-// GetSearchArtists operation middleware
-func (siw *ServerInterfaceWrapper) GetSearchArtists(c *fiber.Ctx) error {
+// PutSongsSongIdTags operation middleware
+func (siw *ServerInterfaceWrapper) PutSongsSongIdTags(c *fiber.Ctx) error {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSearchArtistsParams
-
-	var query url.Values
-	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
-	}
-
-	// ------------- Optional query parameter "query" -------------
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-	err = runtime.BindQueryParameter("form", true, false, "query", query, &params.Query)
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "genre" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "genre", query, &params.Genre)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genre: %w", err).Error())
-	}
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	// ------------- Optional query parameter "sort" -------------
+	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
 
-	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
-	}
+	return siw.Handler.PutSongsSongIdTags(c, songId)
+}
 
-	// ------------- Optional query parameter "page" -------------
+// GetSongsSongIdTerritories operation middleware
+func (siw *ServerInterfaceWrapper) GetSongsSongIdTerritories(c *fiber.Ctx) error {
 
-	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
-	}
+	var err error
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
 	}
 
-	return siw.Handler.GetSearchArtists(c, params)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetSongsSongIdTerritories(c, songId)
 }
 
-// GetSearchGenres operation middleware
-func (siw *ServerInterfaceWrapper) GetSearchGenres(c *fiber.Ctx) error {
+// PostSongsSongIdTerritories operation middleware
+func (siw *ServerInterfaceWrapper) PostSongsSongIdTerritories(c *fiber.Ctx) error {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSearchGenresParams
+	// ------------- Path parameter "songId" -------------
+	var songId SongId
 
-	var query url.Values
-	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "query" -------------
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	err = runtime.BindQueryParameter("form", true, false, "query", query, &params.Query)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
-	}
+	return siw.Handler.PostSongsSongIdTerritories(c, songId)
+}
 
-	// ------------- Optional query parameter "sort" -------------
+// PostStreams operation middleware
+func (siw *ServerInterfaceWrapper) PostStreams(c *fiber.Ctx) error {
 
-	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
-	}
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return siw.Handler.GetSearchGenres(c, params)
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+
+	return siw.Handler.PostStreams(c)
 }
 
-// GetSearchPlaylists operation middleware
-func (siw *ServerInterfaceWrapper) GetSearchPlaylists(c *fiber.Ctx) error {
+// GetStreamsSuspicious operation middleware
+func (siw *ServerInterfaceWrapper) GetStreamsSuspicious(c *fiber.Ctx) error {
 
 	var err error
 
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSearchPlaylistsParams
+	var params GetStreamsSuspiciousParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -1338,58 +4882,48 @@ func (siw *ServerInterfaceWrapper) GetSearchPlaylists(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "query" -------------
+	// ------------- Optional query parameter "page" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "query", query, &params.Query)
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "owner" -------------
+	// ------------- Optional query parameter "limit" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "owner", query, &params.Owner)
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter owner: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "isPublic" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "isPublic", query, &params.IsPublic)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter isPublic: %w", err).Error())
-	}
+	return siw.Handler.GetStreamsSuspicious(c, params)
+}
 
-	// ------------- Optional query parameter "sort" -------------
+// PostStreamsStreamIdReview operation middleware
+func (siw *ServerInterfaceWrapper) PostStreamsStreamIdReview(c *fiber.Ctx) error {
 
-	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
-	}
+	var err error
 
-	// ------------- Optional query parameter "page" -------------
+	// ------------- Path parameter "streamId" -------------
+	var streamId StreamId
 
-	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	err = runtime.BindStyledParameter("simple", false, "streamId", c.Params("streamId"), &streamId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter streamId: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
-	}
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return siw.Handler.GetSearchPlaylists(c, params)
+	return siw.Handler.PostStreamsStreamIdReview(c, streamId)
 }
 
-// GetSearchSongs operation middleware
-func (siw *ServerInterfaceWrapper) GetSearchSongs(c *fiber.Ctx) error {
+// GetTags operation middleware
+func (siw *ServerInterfaceWrapper) GetTags(c *fiber.Ctx) error {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSearchSongsParams
+	var params GetTagsParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -1397,65 +4931,79 @@ func (siw *ServerInterfaceWrapper) GetSearchSongs(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "query" -------------
+	// ------------- Optional query parameter "kind" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "query", query, &params.Query)
+	err = runtime.BindQueryParameter("form", true, false, "kind", query, &params.Kind)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter query: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter kind: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "artist" -------------
+	return siw.Handler.GetTags(c, params)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "artist", query, &params.Artist)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artist: %w", err).Error())
-	}
+// PostTags operation middleware
+func (siw *ServerInterfaceWrapper) PostTags(c *fiber.Ctx) error {
 
-	// ------------- Optional query parameter "genre" -------------
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	err = runtime.BindQueryParameter("form", true, false, "genre", query, &params.Genre)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genre: %w", err).Error())
-	}
+	return siw.Handler.PostTags(c)
+}
 
-	// ------------- Optional query parameter "sort" -------------
+// DeleteTagsTagId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTagsTagId(c *fiber.Ctx) error {
 
-	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
-	}
+	var err error
 
-	// ------------- Optional query parameter "order" -------------
+	// ------------- Path parameter "tagId" -------------
+	var tagId TagId
 
-	err = runtime.BindQueryParameter("form", true, false, "order", query, &params.Order)
+	err = runtime.BindStyledParameter("simple", false, "tagId", c.Params("tagId"), &tagId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter order: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter tagId: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "page" -------------
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
-	}
+	return siw.Handler.DeleteTagsTagId(c, tagId)
+}
 
-	// ------------- Optional query parameter "limit" -------------
+// DeleteTerritoriesRuleId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTerritoriesRuleId(c *fiber.Ctx) error {
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	var err error
+
+	// ------------- Path parameter "ruleId" -------------
+	var ruleId openapi_types.UUID
+
+	err = runtime.BindStyledParameter("simple", false, "ruleId", c.Params("ruleId"), &ruleId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter ruleId: %w", err).Error())
 	}
 
-	return siw.Handler.GetSearchSongs(c, params)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteTerritoriesRuleId(c, ruleId)
 }
 
-// GetSongs operation middleware
-func (siw *ServerInterfaceWrapper) GetSongs(c *fiber.Ctx) error {
+// PostTips operation middleware
+func (siw *ServerInterfaceWrapper) PostTips(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+
+	return siw.Handler.PostTips(c)
+}
+
+// GetUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsers(c *fiber.Ctx) error {
 
 	var err error
 
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSongsParams
+	var params GetUsersParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -1477,161 +5025,197 @@ func (siw *ServerInterfaceWrapper) GetSongs(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "genre" -------------
+	return siw.Handler.GetUsers(c, params)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "genre", query, &params.Genre)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter genre: %w", err).Error())
-	}
+// PostUsers operation middleware
+func (siw *ServerInterfaceWrapper) PostUsers(c *fiber.Ctx) error {
 
-	// ------------- Optional query parameter "artist" -------------
+	return siw.Handler.PostUsers(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "artist", query, &params.Artist)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter artist: %w", err).Error())
-	}
+// DeleteUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersUserId(c *fiber.Ctx) error {
 
-	// ------------- Optional query parameter "album" -------------
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, false, "album", query, &params.Album)
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter album: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
 	}
 
-	return siw.Handler.GetSongs(c, params)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+
+	return siw.Handler.DeleteUsersUserId(c, userId)
 }
 
-// PostSongs operation middleware
-func (siw *ServerInterfaceWrapper) PostSongs(c *fiber.Ctx) error {
+// GetUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
 
-	return siw.Handler.PostSongs(c)
+	return siw.Handler.GetUsersUserId(c, userId)
 }
 
-// DeleteSongsSongId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteSongsSongId(c *fiber.Ctx) error {
+// PutUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersUserId(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "songId" -------------
-	var songId SongId
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
 
-	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
 	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
 
-	return siw.Handler.DeleteSongsSongId(c, songId)
+	return siw.Handler.PutUsersUserId(c, userId)
 }
 
-// GetSongsSongId operation middleware
-func (siw *ServerInterfaceWrapper) GetSongsSongId(c *fiber.Ctx) error {
+// GetUsersUserIdLibraryAlbums operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdLibraryAlbums(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "songId" -------------
-	var songId SongId
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
 
-	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
 	}
 
-	return siw.Handler.GetSongsSongId(c, songId)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
+
+	return siw.Handler.GetUsersUserIdLibraryAlbums(c, userId)
 }
 
-// PutSongsSongId operation middleware
-func (siw *ServerInterfaceWrapper) PutSongsSongId(c *fiber.Ctx) error {
+// GetUsersUserIdLibraryPurchases operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdLibraryPurchases(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "songId" -------------
-	var songId SongId
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
 
-	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
 	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
 
-	return siw.Handler.PutSongsSongId(c, songId)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersUserIdLibraryPurchasesParams
 
-// GetSongsSongIdContributors operation middleware
-func (siw *ServerInterfaceWrapper) GetSongsSongIdContributors(c *fiber.Ctx) error {
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
 
-	var err error
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
 
-	// ------------- Path parameter "songId" -------------
-	var songId SongId
+	// ------------- Optional query parameter "limit" -------------
 
-	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	return siw.Handler.GetSongsSongIdContributors(c, songId)
+	return siw.Handler.GetUsersUserIdLibraryPurchases(c, userId, params)
 }
 
-// PostSongsSongIdContributors operation middleware
-func (siw *ServerInterfaceWrapper) PostSongsSongIdContributors(c *fiber.Ctx) error {
+// GetUsersUserIdLibrarySongs operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdLibrarySongs(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "songId" -------------
-	var songId SongId
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
 
-	err = runtime.BindStyledParameter("simple", false, "songId", c.Params("songId"), &songId)
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter songId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
 	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"artist:write"})
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
 
-	return siw.Handler.PostSongsSongIdContributors(c, songId)
+	return siw.Handler.GetUsersUserIdLibrarySongs(c, userId)
 }
 
-// PostStreams operation middleware
-func (siw *ServerInterfaceWrapper) PostStreams(c *fiber.Ctx) error {
-
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
+// DeleteUsersUserIdListeningHistory operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersUserIdListeningHistory(c *fiber.Ctx) error {
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
+	var err error
 
-	return siw.Handler.PostStreams(c)
-}
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
 
-// PostTips operation middleware
-func (siw *ServerInterfaceWrapper) PostTips(c *fiber.Ctx) error {
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
 	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
 
-	return siw.Handler.PostTips(c)
+	return siw.Handler.DeleteUsersUserIdListeningHistory(c, userId)
 }
 
-// GetUsers operation middleware
-func (siw *ServerInterfaceWrapper) GetUsers(c *fiber.Ctx) error {
+// GetUsersUserIdListeningHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdListeningHistory(c *fiber.Ctx) error {
 
 	var err error
 
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+	}
+
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersParams
+	var params GetUsersUserIdListeningHistoryParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -1653,17 +5237,31 @@ func (siw *ServerInterfaceWrapper) GetUsers(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	return siw.Handler.GetUsers(c, params)
+	return siw.Handler.GetUsersUserIdListeningHistory(c, userId, params)
 }
 
-// PostUsers operation middleware
-func (siw *ServerInterfaceWrapper) PostUsers(c *fiber.Ctx) error {
+// GetUsersUserIdListeningHistorySettings operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdListeningHistorySettings(c *fiber.Ctx) error {
 
-	return siw.Handler.PostUsers(c)
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
+
+	return siw.Handler.GetUsersUserIdListeningHistorySettings(c, userId)
 }
 
-// DeleteUsersUserId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteUsersUserId(c *fiber.Ctx) error {
+// PutUsersUserIdListeningHistorySettings operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersUserIdListeningHistorySettings(c *fiber.Ctx) error {
 
 	var err error
 
@@ -1679,11 +5277,11 @@ func (siw *ServerInterfaceWrapper) DeleteUsersUserId(c *fiber.Ctx) error {
 
 	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
 
-	return siw.Handler.DeleteUsersUserId(c, userId)
+	return siw.Handler.PutUsersUserIdListeningHistorySettings(c, userId)
 }
 
-// GetUsersUserId operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserId(c *fiber.Ctx) error {
+// DeleteUsersUserIdListeningHistoryStreamId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersUserIdListeningHistoryStreamId(c *fiber.Ctx) error {
 
 	var err error
 
@@ -1695,15 +5293,41 @@ func (siw *ServerInterfaceWrapper) GetUsersUserId(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
 	}
 
+	// ------------- Path parameter "streamId" -------------
+	var streamId StreamId
+
+	err = runtime.BindStyledParameter("simple", false, "streamId", c.Params("streamId"), &streamId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter streamId: %w", err).Error())
+	}
+
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
+	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
 
-	return siw.Handler.GetUsersUserId(c, userId)
+	return siw.Handler.DeleteUsersUserIdListeningHistoryStreamId(c, userId, streamId)
 }
 
-// PutUsersUserId operation middleware
-func (siw *ServerInterfaceWrapper) PutUsersUserId(c *fiber.Ctx) error {
+// GetUsersUserIdPlaylists operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdPlaylists(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetUsersUserIdPlaylists(c, userId)
+}
+
+// PostUsersUserIdPlaylists operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdPlaylists(c *fiber.Ctx) error {
 
 	var err error
 
@@ -1719,11 +5343,11 @@ func (siw *ServerInterfaceWrapper) PutUsersUserId(c *fiber.Ctx) error {
 
 	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
 
-	return siw.Handler.PutUsersUserId(c, userId)
+	return siw.Handler.PostUsersUserIdPlaylists(c, userId)
 }
 
-// GetUsersUserIdLibraryAlbums operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserIdLibraryAlbums(c *fiber.Ctx) error {
+// GetUsersUserIdRecentlyPlayed operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdRecentlyPlayed(c *fiber.Ctx) error {
 
 	var err error
 
@@ -1739,11 +5363,27 @@ func (siw *ServerInterfaceWrapper) GetUsersUserIdLibraryAlbums(c *fiber.Ctx) err
 
 	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
 
-	return siw.Handler.GetUsersUserIdLibraryAlbums(c, userId)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersUserIdRecentlyPlayedParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetUsersUserIdRecentlyPlayed(c, userId, params)
 }
 
-// GetUsersUserIdLibraryPurchases operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserIdLibraryPurchases(c *fiber.Ctx) error {
+// GetUsersUserIdWrappedYear operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdWrappedYear(c *fiber.Ctx) error {
 
 	var err error
 
@@ -1755,12 +5395,30 @@ func (siw *ServerInterfaceWrapper) GetUsersUserIdLibraryPurchases(c *fiber.Ctx)
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
 	}
 
+	// ------------- Path parameter "year" -------------
+	var year WrappedYear
+
+	err = runtime.BindStyledParameter("simple", false, "year", c.Params("year"), &year)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter year: %w", err).Error())
+	}
+
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
 	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
 
+	return siw.Handler.GetUsersUserIdWrappedYear(c, userId, year)
+}
+
+// GetVerificationRequests operation middleware
+func (siw *ServerInterfaceWrapper) GetVerificationRequests(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersUserIdLibraryPurchasesParams
+	var params GetVerificationRequestsParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -1782,67 +5440,76 @@ func (siw *ServerInterfaceWrapper) GetUsersUserIdLibraryPurchases(c *fiber.Ctx)
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	return siw.Handler.GetUsersUserIdLibraryPurchases(c, userId, params)
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", query, &params.Status)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter status: %w", err).Error())
+	}
+
+	return siw.Handler.GetVerificationRequests(c, params)
 }
 
-// GetUsersUserIdLibrarySongs operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserIdLibrarySongs(c *fiber.Ctx) error {
+// GetVerificationRequestsRequestId operation middleware
+func (siw *ServerInterfaceWrapper) GetVerificationRequestsRequestId(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "userId" -------------
-	var userId UserId
+	// ------------- Path parameter "requestId" -------------
+	var requestId RequestId
 
-	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
+	err = runtime.BindStyledParameter("simple", false, "requestId", c.Params("requestId"), &requestId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter requestId: %w", err).Error())
 	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
-
-	return siw.Handler.GetUsersUserIdLibrarySongs(c, userId)
+	return siw.Handler.GetVerificationRequestsRequestId(c, requestId)
 }
 
-// GetUsersUserIdPlaylists operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserIdPlaylists(c *fiber.Ctx) error {
+// GetVerificationRequestsRequestIdEvidenceEvidenceId operation middleware
+func (siw *ServerInterfaceWrapper) GetVerificationRequestsRequestIdEvidenceEvidenceId(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "userId" -------------
-	var userId UserId
+	// ------------- Path parameter "requestId" -------------
+	var requestId RequestId
 
-	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
+	err = runtime.BindStyledParameter("simple", false, "requestId", c.Params("requestId"), &requestId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter requestId: %w", err).Error())
 	}
 
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
+	// ------------- Path parameter "evidenceId" -------------
+	var evidenceId EvidenceId
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:read"})
+	err = runtime.BindStyledParameter("simple", false, "evidenceId", c.Params("evidenceId"), &evidenceId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter evidenceId: %w", err).Error())
+	}
 
-	return siw.Handler.GetUsersUserIdPlaylists(c, userId)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetVerificationRequestsRequestIdEvidenceEvidenceId(c, requestId, evidenceId)
 }
 
-// PostUsersUserIdPlaylists operation middleware
-func (siw *ServerInterfaceWrapper) PostUsersUserIdPlaylists(c *fiber.Ctx) error {
+// PostVerificationRequestsRequestIdReview operation middleware
+func (siw *ServerInterfaceWrapper) PostVerificationRequestsRequestIdReview(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "userId" -------------
-	var userId UserId
+	// ------------- Path parameter "requestId" -------------
+	var requestId RequestId
 
-	err = runtime.BindStyledParameter("simple", false, "userId", c.Params("userId"), &userId)
+	err = runtime.BindStyledParameter("simple", false, "requestId", c.Params("requestId"), &requestId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter requestId: %w", err).Error())
 	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	c.Context().SetUserValue(OAuth2Scopes, []string{"user:write"})
-
-	return siw.Handler.PostUsersUserIdPlaylists(c, userId)
+	return siw.Handler.PostVerificationRequestsRequestIdReview(c, requestId)
 }
 
 // FiberServerOptions provides options for the Fiber server.
@@ -1880,8 +5547,20 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Post(options.BaseURL+"/albums/:albumId/contributors", wrapper.PostAlbumsAlbumIdContributors)
 
+	router.Get(options.BaseURL+"/albums/:albumId/history", wrapper.GetAlbumsAlbumIdHistory)
+
+	router.Post(options.BaseURL+"/albums/:albumId/history/:version/rollback", wrapper.PostAlbumsAlbumIdHistoryVersionRollback)
+
 	router.Get(options.BaseURL+"/albums/:albumId/songs", wrapper.GetAlbumsAlbumIdSongs)
 
+	router.Get(options.BaseURL+"/albums/:albumId/tags", wrapper.GetAlbumsAlbumIdTags)
+
+	router.Put(options.BaseURL+"/albums/:albumId/tags", wrapper.PutAlbumsAlbumIdTags)
+
+	router.Get(options.BaseURL+"/albums/:albumId/territories", wrapper.GetAlbumsAlbumIdTerritories)
+
+	router.Post(options.BaseURL+"/albums/:albumId/territories", wrapper.PostAlbumsAlbumIdTerritories)
+
 	router.Get(options.BaseURL+"/artists", wrapper.GetArtists)
 
 	router.Post(options.BaseURL+"/artists", wrapper.PostArtists)
@@ -1890,22 +5569,102 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Put(options.BaseURL+"/artists/:artistId", wrapper.PutArtistsArtistId)
 
+	router.Get(options.BaseURL+"/artists/:artistId/analytics", wrapper.GetArtistsArtistIdAnalytics)
+
+	router.Get(options.BaseURL+"/artists/:artistId/history", wrapper.GetArtistsArtistIdHistory)
+
+	router.Post(options.BaseURL+"/artists/:artistId/history/:version/rollback", wrapper.PostArtistsArtistIdHistoryVersionRollback)
+
+	router.Get(options.BaseURL+"/artists/:artistId/labels", wrapper.GetArtistsArtistIdLabels)
+
+	router.Delete(options.BaseURL+"/artists/:artistId/labels/:labelId", wrapper.DeleteArtistsArtistIdLabelsLabelId)
+
+	router.Put(options.BaseURL+"/artists/:artistId/labels/:labelId", wrapper.PutArtistsArtistIdLabelsLabelId)
+
+	router.Post(options.BaseURL+"/artists/:artistId/labels/:labelId/accept", wrapper.PostArtistsArtistIdLabelsLabelIdAccept)
+
+	router.Get(options.BaseURL+"/artists/:artistId/live", wrapper.GetArtistsArtistIdLive)
+
 	router.Get(options.BaseURL+"/artists/:artistId/songs", wrapper.GetArtistsArtistIdSongs)
 
+	router.Get(options.BaseURL+"/artists/:artistId/verification", wrapper.GetArtistsArtistIdVerification)
+
+	router.Post(options.BaseURL+"/artists/:artistId/verification", wrapper.PostArtistsArtistIdVerification)
+
+	router.Post(options.BaseURL+"/artists/:artistId/verification/revoke", wrapper.PostArtistsArtistIdVerificationRevoke)
+
+	router.Get(options.BaseURL+"/artists/:artistId/wrapped/:year", wrapper.GetArtistsArtistIdWrappedYear)
+
+	router.Get(options.BaseURL+"/charts/countries/:countryCode", wrapper.GetChartsCountriesCountryCode)
+
+	router.Get(options.BaseURL+"/charts/genres/:genreId", wrapper.GetChartsGenresGenreId)
+
+	router.Get(options.BaseURL+"/charts/global", wrapper.GetChartsGlobal)
+
+	router.Get(options.BaseURL+"/charts/trending", wrapper.GetChartsTrending)
+
+	router.Get(options.BaseURL+"/exports", wrapper.GetExports)
+
+	router.Post(options.BaseURL+"/exports", wrapper.PostExports)
+
+	router.Get(options.BaseURL+"/exports/:jobId", wrapper.GetExportsJobId)
+
+	router.Get(options.BaseURL+"/exports/:jobId/download", wrapper.GetExportsJobIdDownload)
+
 	router.Post(options.BaseURL+"/flags", wrapper.PostFlags)
 
 	router.Get(options.BaseURL+"/genres", wrapper.GetGenres)
 
+	router.Post(options.BaseURL+"/genres", wrapper.PostGenres)
+
+	router.Delete(options.BaseURL+"/genres/:genreId", wrapper.DeleteGenresGenreId)
+
 	router.Get(options.BaseURL+"/genres/:genreId", wrapper.GetGenresGenreId)
 
+	router.Put(options.BaseURL+"/genres/:genreId", wrapper.PutGenresGenreId)
+
+	router.Get(options.BaseURL+"/genres/:genreId/subgenres", wrapper.GetGenresGenreIdSubgenres)
+
+	router.Get(options.BaseURL+"/labels", wrapper.GetLabels)
+
+	router.Post(options.BaseURL+"/labels", wrapper.PostLabels)
+
+	router.Delete(options.BaseURL+"/labels/:labelId", wrapper.DeleteLabelsLabelId)
+
+	router.Get(options.BaseURL+"/labels/:labelId", wrapper.GetLabelsLabelId)
+
+	router.Put(options.BaseURL+"/labels/:labelId", wrapper.PutLabelsLabelId)
+
+	router.Get(options.BaseURL+"/labels/:labelId/artists", wrapper.GetLabelsLabelIdArtists)
+
+	router.Post(options.BaseURL+"/labels/:labelId/artists", wrapper.PostLabelsLabelIdArtists)
+
+	router.Delete(options.BaseURL+"/labels/:labelId/artists/:artistId", wrapper.DeleteLabelsLabelIdArtistsArtistId)
+
+	router.Get(options.BaseURL+"/labels/:labelId/members", wrapper.GetLabelsLabelIdMembers)
+
+	router.Post(options.BaseURL+"/labels/:labelId/members", wrapper.PostLabelsLabelIdMembers)
+
+	router.Delete(options.BaseURL+"/labels/:labelId/members/:userId", wrapper.DeleteLabelsLabelIdMembersUserId)
+
+	router.Get(options.BaseURL+"/labels/:labelId/royalties", wrapper.GetLabelsLabelIdRoyalties)
+
 	router.Post(options.BaseURL+"/login", wrapper.PostLogin)
 
+	router.Post(options.BaseURL+"/payments/webhooks/:provider", wrapper.PostPaymentsWebhooksProvider)
+
+	router.Get(options.BaseURL+"/payments/:paymentId", wrapper.GetPaymentsPaymentId)
+
 	router.Delete(options.BaseURL+"/playlists/:playlistId", wrapper.DeletePlaylistsPlaylistId)
 
 	router.Get(options.BaseURL+"/playlists/:playlistId", wrapper.GetPlaylistsPlaylistId)
 
 	router.Put(options.BaseURL+"/playlists/:playlistId", wrapper.PutPlaylistsPlaylistId)
 
+	router.Get(options.BaseURL+"/playlists/:playlistId/history", wrapper.GetPlaylistsPlaylistIdHistory)
+
+	router.Post(options.BaseURL+"/playlists/:playlistId/history/:version/rollback", wrapper.PostPlaylistsPlaylistIdHistoryVersionRollback)
+
 	router.Get(options.BaseURL+"/playlists/:playlistId/songs", wrapper.GetPlaylistsPlaylistIdSongs)
 
 	router.Post(options.BaseURL+"/playlists/:playlistId/songs", wrapper.PostPlaylistsPlaylistIdSongs)
@@ -1920,14 +5679,28 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Get(options.BaseURL+"/search/albums", wrapper.GetSearchAlbums)
 
+	router.Get(options.BaseURL+"/search/analytics/click-through", wrapper.GetSearchAnalyticsClickThrough)
+
+	router.Get(options.BaseURL+"/search/analytics/top-queries", wrapper.GetSearchAnalyticsTopQueries)
+
+	router.Get(options.BaseURL+"/search/analytics/zero-results", wrapper.GetSearchAnalyticsZeroResults)
+
 	router.Get(options.BaseURL+"/search/artists", wrapper.GetSearchArtists)
 
+	router.Post(options.BaseURL+"/search/clicks", wrapper.PostSearchClicks)
+
+	router.Get(options.BaseURL+"/search/did-you-mean", wrapper.GetSearchDidYouMean)
+
 	router.Get(options.BaseURL+"/search/genres", wrapper.GetSearchGenres)
 
 	router.Get(options.BaseURL+"/search/playlists", wrapper.GetSearchPlaylists)
 
 	router.Get(options.BaseURL+"/search/songs", wrapper.GetSearchSongs)
 
+	router.Get(options.BaseURL+"/search/suggest", wrapper.GetSearchSuggest)
+
+	router.Get(options.BaseURL+"/search/trending", wrapper.GetSearchTrending)
+
 	router.Get(options.BaseURL+"/songs", wrapper.GetSongs)
 
 	router.Post(options.BaseURL+"/songs", wrapper.PostSongs)
@@ -1938,12 +5711,38 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Put(options.BaseURL+"/songs/:songId", wrapper.PutSongsSongId)
 
+	router.Get(options.BaseURL+"/songs/:songId/charts", wrapper.GetSongsSongIdCharts)
+
 	router.Get(options.BaseURL+"/songs/:songId/contributors", wrapper.GetSongsSongIdContributors)
 
 	router.Post(options.BaseURL+"/songs/:songId/contributors", wrapper.PostSongsSongIdContributors)
 
+	router.Get(options.BaseURL+"/songs/:songId/history", wrapper.GetSongsSongIdHistory)
+
+	router.Post(options.BaseURL+"/songs/:songId/history/:version/rollback", wrapper.PostSongsSongIdHistoryVersionRollback)
+
+	router.Get(options.BaseURL+"/songs/:songId/tags", wrapper.GetSongsSongIdTags)
+
+	router.Put(options.BaseURL+"/songs/:songId/tags", wrapper.PutSongsSongIdTags)
+
+	router.Get(options.BaseURL+"/songs/:songId/territories", wrapper.GetSongsSongIdTerritories)
+
+	router.Post(options.BaseURL+"/songs/:songId/territories", wrapper.PostSongsSongIdTerritories)
+
 	router.Post(options.BaseURL+"/streams", wrapper.PostStreams)
 
+	router.Get(options.BaseURL+"/streams/suspicious", wrapper.GetStreamsSuspicious)
+
+	router.Post(options.BaseURL+"/streams/:streamId/review", wrapper.PostStreamsStreamIdReview)
+
+	router.Get(options.BaseURL+"/tags", wrapper.GetTags)
+
+	router.Post(options.BaseURL+"/tags", wrapper.PostTags)
+
+	router.Delete(options.BaseURL+"/tags/:tagId", wrapper.DeleteTagsTagId)
+
+	router.Delete(options.BaseURL+"/territories/:ruleId", wrapper.DeleteTerritoriesRuleId)
+
 	router.Post(options.BaseURL+"/tips", wrapper.PostTips)
 
 	router.Get(options.BaseURL+"/users", wrapper.GetUsers)
@@ -1962,82 +5761,316 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Get(options.BaseURL+"/users/:userId/library/songs", wrapper.GetUsersUserIdLibrarySongs)
 
+	router.Delete(options.BaseURL+"/users/:userId/listening-history", wrapper.DeleteUsersUserIdListeningHistory)
+
+	router.Get(options.BaseURL+"/users/:userId/listening-history", wrapper.GetUsersUserIdListeningHistory)
+
+	router.Get(options.BaseURL+"/users/:userId/listening-history/settings", wrapper.GetUsersUserIdListeningHistorySettings)
+
+	router.Put(options.BaseURL+"/users/:userId/listening-history/settings", wrapper.PutUsersUserIdListeningHistorySettings)
+
+	router.Delete(options.BaseURL+"/users/:userId/listening-history/:streamId", wrapper.DeleteUsersUserIdListeningHistoryStreamId)
+
 	router.Get(options.BaseURL+"/users/:userId/playlists", wrapper.GetUsersUserIdPlaylists)
 
 	router.Post(options.BaseURL+"/users/:userId/playlists", wrapper.PostUsersUserIdPlaylists)
 
+	router.Get(options.BaseURL+"/users/:userId/recently-played", wrapper.GetUsersUserIdRecentlyPlayed)
+
+	router.Get(options.BaseURL+"/users/:userId/wrapped/:year", wrapper.GetUsersUserIdWrappedYear)
+
+	router.Get(options.BaseURL+"/verification-requests", wrapper.GetVerificationRequests)
+
+	router.Get(options.BaseURL+"/verification-requests/:requestId", wrapper.GetVerificationRequestsRequestId)
+
+	router.Get(options.BaseURL+"/verification-requests/:requestId/evidence/:evidenceId", wrapper.GetVerificationRequestsRequestIdEvidenceEvidenceId)
+
+	router.Post(options.BaseURL+"/verification-requests/:requestId/review", wrapper.PostVerificationRequestsRequestIdReview)
+
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+w9627bOLqvQugcYGZw1Nhx2zOtf23abrsZdGaDpsFiMQgKRqJttpKoIalkvIHffcGb",
-	"REnUzZblDGb+NRYvH7/7jeyjF5A4JQlKOPOWj14KKYwRR1T+BaO7LL4MxT9DxAKKU45J4i29y3eArADf",
-	"ICCHeL6Hxc8p5BvP9xIYI2+Zz/Y9in7LMEWht+Q0Q77Hgg2KoVh2RWgMubf0sgyLkXybiqmMU5ysvd3O",
-	"9yDlmPEOIOSYBijM/MPAWKOEonYo5BA3EGb2YTBEOMa8DsEvWXyHqIACcxQzkCIKUrjOQfktQ3RbwKJW",
-	"sXcO0QpmEfeWi7nvxfB3HGextzyfz3MgcMLRGlEJhVy6BsQVXCNghrk31jA59j13bxTBbdRJezPKjXhr",
-	"jcNwz0iybgdEjHADoeceBkDGEG0HQIxwA6DnHgLAzgyWiuFCSr3QF5SkiHKMlL6wZLVjQd8LyD2ilzFc",
-	"oxsaiRnodxinkRi04Txly9ksCJOzOGM4gGl6FpB4JlUKm53Pz2dy+tnXVOC82Iti51YUQY7CC14CLIQc",
-	"PeM4Rq4pJSTbsL0lUYQC8bvAO8kouEOMS+oz10K4HzYw+7KK4HqN5HD9+Y6QCMFEfE8pDlAJktdnr19b",
-	"R19FBHKvLkiC6BGCDL2DvLyAt5gvnj+bnz9bzG0cCrS4IOSYR5UFPki8Mg7+gbnz8FkaDkP8zubRX/We",
-	"lhG4zWeQu68o4GKTC6X9G7jxFykCNtSfNwh8IsE38AYm4Ujs0pPKMUn4Jtp+xIyjRBvZHLDzxcu5S+fu",
-	"gUZbXXQCdY8oXmHFeDkwSj/U+fABRhHib2AEkwo/CvDPXvbgyAqNc+Vk0ctF5rck4RTfZZzQgzWPXgmT",
-	"5LP8aLPHCkGeCdjG4QxKtjDi2ytEA5RwbTsLpO2BMMujqZ3Ehbi/U+pCWUDCMiwv5i9czBciDnHE6jIk",
-	"YEKMo1AqP/AAGUgIByuSucUqRoxVz+99QoxkNEBtUyvnl4AXy7mO/EG6YrUjNyp1OR4EG0hhwBHF/0Eh",
-	"uNsC8VmKBsAJ4zSLpZt8gJIfZu2k18hmlATfehq6pKbshKLrxGjSJHNXxrlycM9w622cMTZbzE9owa+F",
-	"qQaXICL3CHACIqmNAScHme+r7C7CQWmnFYyYU4k6TOnPW/Ae3hOKOQLXTb7EMe1Ak1ZWsDqZI6PBBjKH",
-	"nAUZpSgJtuUj3ly/OwDDKdwK8bvmkGcVZSRC2Ahxt8pONZRXNQfqfGH7T0XsUleBZo1pMV+G3C+wWkWG",
-	"iziChxxmsgjoOxE+yKSqweyL0CRyJxmKWq6s5UeqHyClcCunZiEm/ZWIdLRnP756PZMTz+L0eR/9sYe6",
-	"KnY6tqbKKDRqqhzcvdNfAE4AQwFJQqEXctgXz390sauVq+hWXCOFJxHcsrckS7jDpXX6tClF9xg97EMO",
-	"PbUn6euR0/nZYpTI6eWz85d7Rk4XD4iRWKn6SQKnqohaXGeJoG9lqlKtdmwkuDTNDUMO7/IOk4qBE7SU",
-	"NpeCFSUx+AU9gH8T+m0kIUIxxBVW+ko2yd/0n4KFbFqp4Y51Vpi6gsafyCY5SH6KKLXbP4igC4R3BLkN",
-	"I2MPhIadZ6/P3JAEqexhefL/nS+ev3j5/z++ej13SxRZ4QgNVKfCrrHZ+eL5TM/vqVD3dHrqrrBAyZeQ",
-	"dAtPwQIWKaxV/Zx7crJaZKjLyM73GAoyivn2OtggbSHfIEgRvcj4RsqL/Ou9Od5P//psUqWSN+TXAnCB",
-	"ZJWUw8mK1O3GxdUlWBEKYpjANU7WQFJCJeuZr5JVvkwYMh/AJATG12Bnucu39N5S+BCBi6tLTyYJmFr7",
-	"/Gx+NhdYJilKYIq9pfdc/uTLrKM8m07UiX+ukSSc0A1S3Qi75H1A/EKN8Eslh18fvf+laOUtvf+ZFYWJ",
-	"WTFkJpPIO79znMpyi4FlzLzHEUdUBneScuDyXUO6Oi8q9E+S3go+YilJmCLxYj5XsVLCkbKMME0jHEhE",
-	"zL4yZfKL9XOnyXU4nX6dqdxrzZHaVUMe70JGNoCsNN0VI2ZxDOnWW3ofxUcYRear73G4FjTwNGluhagT",
-	"5iDfFWEF/XQa4A0Jt4MO2+OMZcHkNEO7GobPj7FpBZHiA9A2SWD+haJredQbGJqUSEnkJVfbwv7rrWDL",
-	"f4o/FkU+Z/kgYj/vVjBRQaS3clMAQYIe8lpbjU4730jc7FG7+DsFoAiL6vR7J39X0y/yKt0wQTShhIPp",
-	"XzgUkkShgkej8Hl91HtC73AYomQ8BKqjNqPO71BQR0DPfCqONZk7ie5GohSJt7J++IC4QptQllJLuvRD",
-	"5lIP2ajIO6l6mYxY2s2ZUjZu5JbD1MosKNLwPey75oC39qSTiVIv82qXGXoY2Y/axJbQ0iRIQRkNe9jb",
-	"8fE5vnSVMDitCa9tXSaW9RnAMJzamF+Eoc0CgJOBsqeKzH2FzqSRn7K0yfzHADGT0H3HdLn9QMOWr1PD",
-	"v8qf5nRQmZNWxOshpwtnTBUXMJUUdgc1ea3XDmuqCYiJ4hgVXw0LZDSamyKZnAo5PfUvHbo1n3cUb0Mf",
-	"dOJoxtq1gk4V+D6BeEYBotNBTqpZ4jd7NMnMXQ9JvCgSnwP1n5l4XM+9izqdvrsa1qrj1JCa926LRJP7",
-	"Pi4STytTE1LthE68yVb1laEezkSZCfZ0J3JO8Mc2hk/TQZHn7fZQ+oqvy0fJrZXtpKwiqMjZbObeyyH7",
-	"C2RrM0298QlB1vCJQ7pGfUvLanDep5VksUCC7rZV/vNtZznMbFhaLofRkbLva6vrAQZKODC10tFta8YQ",
-	"desBQVxgSFkwy88k1Gyg+UQ1FbXJ/Qc1YgrpUn1ag1xADX+DB7g2sJvz68PYZ5896iLnrhsLH4py6CC1",
-	"Z8qoR3UiNPbq2FLtbF0uhBrVpoLkKWoORAmlEVnjpF3tfJRDxlI7eZ23u5RrV0bz0fmPXTrDLNtW2Bvd",
-	"+yiflZNvyK1BM110b2MPWZjf7ZxQlznhOgsCxNgqi4Cip2SZ8WIR1YHq2PgmgRnfENlw6QOc3MMIhyIy",
-	"CVHCMYyqci6OpEAsDbJsY8Y34tfAVnlF3+FjcR+kR4HEdD+yK/sWycAAv5jar1Ji9jpWsaTZguhSiXWh",
-	"xmA1R0RrweT46BpPdeaNrQ6mtCjQrkDzgW061JyopkbLSG2KxI6C1PHjsTI+p4vIetHxSFFZsyTpmKxD",
-	"kppVU3ds5mCL/eKzMQXuKAGVRAXASYHNccSxGlVVxLHRmzke5sfwjoprisOajvW80QIhgRJVYgGcVEg3",
-	"TTx0Eep7Kfb++wni7FGhZz+XQXLHtbkCuj+PdOdFNBH7+RmSQhTF5B6FqiGzTKUp9OQnub0iUxmEFkKZ",
-	"TjWrx6xFWs3og9uV9m+k1836PyO+IeFl2OjPH3RZAFqtFuXtDpDpcUy0uSbiMtH6GyiucEyoIvLdYVIr",
-	"xOaMU2O73Db34DpjGcZhOgcjVWSaU5wioMeBWA7UXmeV43pbihGYkxXa7y/eHMqb5mGBJs5kCNJg0+Ys",
-	"XqsRNeNTYR45CnBE44bisfmz+RWBGj/UE6RxDJ8xJCDhKMyzepqaQCzAhMlWpwLfq1Zl3bisqxh+bqx9",
-	"ldH7oQFguZr7yQnPtbCLo59a1cJhh9ihvcP55ZCDi/f6Agk7NANcvP7Rf6kry3+prpYr7QNiFN/jhMPo",
-	"E2JZxMtXEF8snLe2uxNvisupXrISrkTkDkZGEmBACWMyyV0SFkszaDm31UKPRnw1q6kdv1FHgO9VT5u8",
-	"LPBDh8YYoCFqDfpiKUDooEb9AbuoHHfnJuZxnwF7XBPKwQqjKATfpyTNIihsgq/vU30JIUe+vGTVhD1G",
-	"aMNDPV6xnufnNbHSj/Y21m2u6nXeP4qio4XUHabppAxb9BskryYpEUMebLAIcV03K7SUqG/gAfMNgOE9",
-	"TAIRaUnG65Ta7o4zLbZNfWdtclvI1VHkdnKJisxzKn7bmQ6SpnwLs96fQIIaTfzIIuTs6TMypD7uJUTd",
-	"lW41K6939xehgsVHlKAG7j4aTzfx8WnZrtEhHJfrnG0Emsa1JgIXd5X803YGK1JYQ3gsz1RLky1UqTX4",
-	"KIqbPCSIAnPNtVV5y6H77pPKp1JmKcX3kKP2huX8YZXWhuW+sqR7Xr9A7uui0BfIjyJcxU5efoVZ/fFn",
-	"8cDawrFxZbkQRac4p5b4tUl0Z9FNTWqo9rRIci7A0ZbigP0VLO0ZLJkXK6aIl6zXMdoE1nEIQsPGJ1DN",
-	"NxeIYikLOCj/kj/+8XTFCgZIPyjcla6qPiumn5CpP8bS86UN8+5DPTVde7mkTxLsCYHnyKw9GehcI4Ya",
-	"jNasWyndtnjpj2M5dJO2y2qovoNhcUenAdmvUWC0O2JKfY+ptg96TmPI6jLX2Ly4rt41r/2keluKbmYX",
-	"A+bNzNUWFdPu39aecmjFsft401boij0d7Qunv8JWqc3ZFzKGto4c0icyvPPjdE9xNKDMb9eaoyNmPg2L",
-	"dvWQykFtDWuyOabaO2ppgoa+0dGQdko1MhGNTndrr7f66P3shkX3gx6JGEtwTvjmhpSchic3epvSsVH5",
-	"13Mbp3xuo03eOEWwq53wWg8aq6NLRmx0+1Y/xR7D3z+iZC0OunC+aH2PA2RuQrreoFYPo5byCo2PTD6Z",
-	"VmGJU0BRQGg4bZPVJ7kngEAR3+YM+YM4vuIOjtMO1vgsRozWXhqbUL7HK7WDHmq2HuHfo1G1+X8i0BCf",
-	"psWv0gOvbrO5HyrmFCYMBoZ0PVIddb32GaeAoYQDlt+bi7ZT8u01SkLAcSpfEKpe/JeMqLhWPvDZ5i3c",
-	"MESPnpCYxIVQlx2HhN4KOYf7fLfO2D1j5USRwnS7w2GocQwvQV8GndQ9KPas3PpkiA6P3d0BuP5Pr6po",
-	"zrl/9qi6kHsE4HLuTf76/iCR0K3O/QJwef5TXe9sQJjfriNGR8t8Gi7rCr/loEr4PQjVFMGwimkRfIiP",
-	"tbDdUgINYftoyD6lBpmItqe61tlb5cwifEch3fZowrXo/lFN2vN97LEkbtynqE3Env9HKnnj5BFo1yaS",
-	"37E6DAUpNerbiZnfBBpGz+Laxr4k/WM+oFRcsRnOJiflD7DBjBNZZRrEH51VwDpv7FcXnFTUh17bLqTM",
-	"egjrVIJeLaS10rFXY59Fw5buvqdEwOZ2rBYiFo1VJyCfo22r7yX98clz6iczzqd9MuNoldVm76oU1nVd",
-	"BJdb0nt3811EAhhtiJyd0Uj/fynL2Sz/sHw1f7WQlNWrP5pOAt1tKoDWv5j/rNX+Le+NL34JY5x4u9vd",
-	"fwMAAP//YJrE+VJ9AAA=",
+	"H4sIAAAAAAAC/+y9+5PbNrIw+q+gdO8tn62P83Scs7Hrq7qOneR4y87OsZ3Nl7PrmkAkJCFDAQwAzkTr",
+	"8v9+qxsACZKgSOoxY9/dX6ZGEt7daPS7P85SuS6kYMLo2dOPs4IqumaGKfxE83m5fpXBvxnTqeKF4VLM",
+	"ns5evSRyQcyKEWwyS2Ycvi6oWc2SmaBrNnta9U5miv1ecsWy2VOjSpbMdLpiawrDLqRaUzN7OitLDi3N",
+	"poCu2igulrNPn5IZVYZrM7AIbNOzCt9/v2WkK6rMz4zddNfxXGxIRjd+MdiS3DF2k+DnnBqm7RekKOc5",
+	"1yuWkfmGZGxBy7xa9u8lU5t63dB+Fl1jRg2LrpHd8oyJlG0/LN+KcMN6IBcMtN+psT8Kqcxf5NwuKTLV",
+	"b3K+9yxLJtTAprFJfLO+935ryOmc5dvXoFgqVUawZXwpfpA9l8LX3HQX8mO5njMFiwHAa1IwRQq6ZD34",
+	"Z0cJZ/b4+vTyPJmt6R98Xa5nTy/Oz6tFcGHYkilcBQ7dWcQVXTLim8UndmuKzHsRnyinm3yQRPhW8YMP",
+	"xtjv7KEvG1rLLVN8wVMKPxDXI76uerj9lqWlWG5fE7SIr8H13XMBRjE68JTYNj2L8P33W4ahA8dgaM8p",
+	"2J57z667k7+Q6zU90QyeXsMyWAKRiqylzIjOy6V+RqTIN+7WplSpDRdLQvPcLXpNqGJEMVMqwbKeW4Vz",
+	"h8tlf9B1kcNP6YrneQLP1Ingy5WJrr3UTG0/OmgRPzvXd7/Du2VK45TtFfzN/kCEpW9cBMT2kYbnWCwZ",
+	"WXFtpNrEF+jH3rbCCOW5U7QoWPYLo6q7LPjWH80G/udw1285u4uvAdpsXcCaC0txL8+jJPeTb4w49hz5",
+	"MuDolCyYMpzh1yE3NXDmySyVt0y9WtMl+0nlTZxZGVPop2dnaSZO16XmKS2K01Suz5Dp02cX5xdn2P30",
+	"twJuVD2X4tGpFAPkf246nM6J4WsW69I47nBtL2SesxS+h/OXpSJzpg1SOB0biI87Da6vFzldLlkWoMNc",
+	"ypxRAb8XiqessZJvTr/5Jtj6IpfUzLqQA6DnjGr2kprmALPL88vHJ+cXJ5fns6R5LLEVwjC3VKSRd/f7",
+	"Ms9PDPvDEM2oSldEUXGTWMJSKKaZMICg/kemy9zoxpyynOcMEZRmfxX5xiOoW4W9fbAKw03e2sYPCF1t",
+	"yH9xEwVBWWTTwP8pvCl/d3MGwsKHqoec/8ZSA5PglXiHO3xn8cPKOPlfF7Onf/84+78VW8yezv6vs1ok",
+	"OnM36qzR7ZVYyNmnpH23kEA3/tk2or2gn6p1UqXoprMxO1R3Nx9gP4LmG8NT/QJJXORlwe/hEjCaroiR",
+	"huZkoeTackRAjWSJjCCXGaGaUKJX8JbIRbMF9kwIO12ekvPTJ2QhFVnRfAF91mW6InRJuTglr9nCEFka",
+	"crdiIjrJimZESMFO/yFmSev8gAFjwomeXcTrIFpRqnRFNRvbXrFbJko2BJgXpVJMpJvna1kKo2cV9zJ2",
+	"HsOLcU0/xZDUA/VKcmG6BDx+SFyYr7+KkhZ77hGCwJU2odia0U1ihdT/QBR5I0VGN3+yrIjAl2qQAMUh",
+	"0r+4w0Kkf54ORPqaboXIe7gFugsSWHHO4FyvlaPgzbN+5y+VBZ4mZkUNSiQsI2alZLmE24lgYCJrseQj",
+	"MK6BFM25X3JtuEgNqdoQeJZxgruVzJm7mbNk+HT8RJFp3iua3rAMN6UJFRnRN7zQVgMyp7qiKfVhVa0I",
+	"nJoet4B7xLAbXoyFpzYSGEEyZwupGOxzQ1IYiTmyCscyDpT3idhWa9bDI/5I161X/P2KkbcyvSHfUpEd",
+	"iIkbyXshBco3r3fD85xqQy7/DCROJ4SLNC8zEKGQIwwUiIRrkiqWcYCbFM8QWUv4kFGeAwCrw7i4fIJc",
+	"eA831OLtHp4pm8xghRLfIHisQsMyxtURNVYS8Ml3NM+Z+Zbm/lAah3r6ZATH3GKSKvkywNwPvQhf0fM+",
+	"zL8eiZRpxXZt5fNaXBp0BNKg2lzjVvYTqQLSnS7LmMwydsvTww0H7/8IjXMyWyoqypwqbjbQngkQOf8+",
+	"y5DYOQW2ZR4+9F/q68br1b1AnWbXXpbvXKg3tmlAA6iu2N+A11HIFyOZvmOK1fd8IVGFMeYMX1JeE6QX",
+	"ANHYUXr+dzSWOCYj6Ho9GhxVDyNHtddsEhK2WNPIbq2IPRoLpVhWY8aGG7kNU/Flk064/0l8Kzc0Nxts",
+	"GCESyCOMfKAn6VuaD2/n59QxKc1X+ccffoziAuXZ8/Er7T+Mz0dgxuXsJzHjED9btV2MkQ/o8mhueLws",
+	"tuaiNEx7SpaN7DaRJZTF9fQHxp0J0jG1iV/G4nra/XZjwjWPQq2DcC/ActoFC5u4Fxzmu7593HCRhY/V",
+	"Mpdzms/8q7yZOXti9MnSqSzil/POmYaHrbTxbdv1dva+12oLRm+uC6m5v7oReWr7r/5BCZu1DHruFytQ",
+	"gvrACkEJEWWeo6ZIsDviYIgyn2In7uMsmUErOu/wiyH6+zNvm03wAEgqMwZKCjwG8uplQti6MBvilmQP",
+	"zBrlo28gYOeIh8q3HcscTru0d1HHAquCaTkVEG2oaokB29h46KOvpbhO/e0aQ/tfSIDQvDRS9bHJ4+n1",
+	"RKODm5lL8X4ThTyKaETJnD0jheJrqjaIWAtGTalY5oQ4TYD+0MokBOB7pEnGNYjjtdOIv1tuKDhZN5BV",
+	"UWZlyhQwtIob/Ac3a61da/4HU3D56gc56HwICVlZZuSKqZQJ44zqtci0g7gUeMR0DjsmNrWVI4ABWYa3",
+	"nuZXDcwYoeBo+c7gmOiLULE3kSVEmO0OVoLUMfpUpz3dsRvynTDcbP5WWyVbd6Tilzx6WdjPvCw+g6PI",
+	"Gf6jZJ7PaXoTpeI0NVK9miKSbgVRc5mC3c2efvyUzGQOM0R32lYcszw7ydkty0nGFwur+9emqe53FlVy",
+	"wzbW2SmVebkWBI2dkTl2uBgMATDyYGxjT088SJyG1fuwVRShchb5sLvKSgta6JU0/bCwD177VcNjuqV5",
+	"yXTLcYjCGfPqbGPHGBjJKxLxeJAgBIcTHGsSmMUdNseow3dKxd4IeJYby/jq/KvYy5cxQ3muG01R0+hc",
+	"X1iGdJvcUU2ENGQhy7jycc20blPH2VumZalStq1r6zBw4fVw0S2jV9v3PGfdfc83ZrT8kFFDNTNRfjJG",
+	"zWLb9g0+xkRAs2oeh2NKzjK6+d+X55dPwLT8+OLMfX1aUPV7yUz8Fbrbg1R6L8AImth7f02nGP7tsTUF",
+	"gk6rjo5M3olc0uy6tL4MLZU+XwqWkZyLG7DEUPJPXhCQYPmtNaDeMrUhC54z0M+mlR0j/swzfye64OL5",
+	"BEkmQLSYmo4L9CWddHi21cSzi2qg9tXpw1lfsz8KrpietIWKMlzPN+PZcaqm4pk21JQ6fC8KJsBwMEtm",
+	"qhTC/hciwoLynGXRV8PIsRP336C3duMx1qe+ES3+KvASQ6P8mmvNxfKUWCUXymSKEadkfWZVpfjpkf0F",
+	"uF7DBMhT3Oh/iEVlOPaeVsByWHt+hVHVA+ukoNBw56xWnrOFLUQPrI2DNeI2t/itNKvG3mILSfUtLMIR",
+	"uA/Jjjg/64Vsc02vqTsj6wDNsgRe77XUhjz++mv4RRO6MExZdwwQXoRET3GwWFFBjMxaFsM+dUL4duGa",
+	"cUWxZ+t7mjLTwzynE3Sa1oE5RjeQbYn80lqmbeYHcrqM+JJZxtAr0qoT36IJrLt663Q2yuOmqc6shNOR",
+	"2sdO74xn1xtZXq8ZFeMV33aUcrlk2g/URj9UZwyO9AO06iyqgD3RyCv33JCcAWpKwYi2HYglWqBCMXwN",
+	"/5RmFrPbeaZ4cFFXrmFnXdYlNYY41sTpiHnLwTRjwvAFECrkfm1Te+EVg9sFptw05+mN95TVzHj1j6VW",
+	"zqV8+JXw+s0hbVBray389q63Hg4xzEbIRSh5n1Mjtkc1EE0NU/yfVqyCn9H0SkAGU+UalrqPk+M0b0+L",
+	"pWdKpjcjHT1Fx60AXAriFgzFRNST/gp/cRo/52rGNVjvKdHl/MQHewzutpBFYL5scYT26Wp5ETw+d14E",
+	"gGM4tXYYON+QYLiIM8uX4SrQQmXRZ0uPUJ4HtA3Z27SPaei1f9Riqs7xC8Fhas1nz2EHhvvGdR95a3tG",
+	"rbvlcindJZ5+H9/QWy7IW9Q56EGWoxdBwpMYjRlBpyumkJ+TQkfQY5JKOYjNGiMo9DL9oAW5xd0OQKBP",
+	"4boF9bqb7vJoVLyhgi7ZC2poLpdRzTg1DNlJVJFbByf4aDkkNMc0Aia7z3xKxd84u2t4yfS2qoSIyLuN",
+	"blas4V4lHCGds/yRJk6z7V5xHVnNpz68esOQWnW9YydAWsmcNcLLZms7am0RkHcCP6/x2PE/2+TDPv5S",
+	"ce+l3jtkz3jzFk9pfwoVcXGIsJ9TDtJ6+EQNiLVrxqDFYIuarIFXbuWEjrMcbFxwzhg9lTcw9DoIwNPf",
+	"sN5HhCMJCnhzjZLvzVgTuW18ny4CU9HGrfS9LPr8MOy4tdwyZdie9/tevA4s1LlY/pd1K3tl2LrXybsv",
+	"+AcMaeyP8a6DrrlpGSS8JSKIVnWk+kM0Agrc/qpB+oxc2bVmqRRZj3+d9UOfphk7qtF8XOsxoHzHDPDm",
+	"kbe0oKVmEdHiR3bnfdgVI7mPZ3GGGOd46LwYUrleM5FhGK8mdyueM2LHfWZ9C7XheW4dwcEREccd+cpd",
+	"0c2axRQ1tfdZ+7klay6kIqXgprIcecroYndu5FyO87NPVyy9kaWJa8sxihvGL+iGKeIU0Ro+gigkRYKc",
+	"hmIZVyw1ZJGD+SB2D3LOhAH8VCyyqdfM6HqeR2jQJ6kEHeSaUPLOKF7gj3BWlfAvnGTGzSNN1jRjWyzx",
+	"k9A+dMDr/AhqlFKxa8WonsTNm64bp7zlGVMhYdC4VdQpbLShKDEv8tIYpu7oLfywoDc9XkBuuGu8rB+j",
+	"UTuF1B3D6LVX2XoLafiF4UV0MsUWpciuszIa+8/Rw5bcQfgLFdKsmPLQw8gwmitGsw0pXMtnRN6BnoOm",
+	"Nz4+BjEhyrhqZkx+QBX/WMU+VUtmoqqr987phPiDSywfXn22Srei2u8YnQWwi7tTSNDNwXG+YzoeTf3W",
+	"hZEjoArXGsxfVq/UJEbajhLd/PNcW4i1A/yt/cznZACtjbVvs2yW7LqlPBrIskv8cqXlPLs8f8AY5nco",
+	"ub0iubxlgPr2KSdG7hXAfAXpZ9LGTAua62iYxmcbTPxmQ76nt1Jxw8i7vpjuY8a79EWf2LV+2IKin42+",
+	"rLoz+6jMrvxz0ONIvXkhswj2/LxiNkaOzMsNU+DZkQCSyPyWZdYeZlaMK0KzTDGth17iGjN+evdyj+tR",
+	"1CzX1qNzzeoe76pHpLlRQOzaT6Ai+pa1BNVCNkt2f3f8cFedtAMXl980eTyXbKc/mvJ+70lz5QEw2yca",
+	"u0pvWcqEAfyNPDn42vpH1r1fgOaJ9Z1yOnzYGOEGXYqs8NN52KjPnjEqgh8uyVgRxzt0t4wNSO7tYrxJ",
+	"uLsNK5NwdIoqRcYU4aH/asubbasT2w5CXxG8s2Npy3hBMfayW7r3Akx7va4P/Z7pFycQd5zh41R70sFb",
+	"VCki4Uz1St6JWVKnV7mIm2ag41ggu9bmEO6GW0yjyF/6nwFR/s+JPbKTVxlZMZqxKgONbRaeQUrXjDiH",
+	"gWkXuV5Rc6fhKX3YDs73Nug98nLAr9cuJr4Kvx7hVowdUdUBk4zWLWK3sY2nuUGtGRXXdj/9SPr8likQ",
+	"pkMktTZrByZZMNHkjPvPYOLe93NJstBEn5KIfmWiGjBwTYkGUYKaS1WuzW2H5D9ARizTG2Y0XG2b1kBQ",
+	"rqjNPwAqEThclMUqawjBYTX5j/OEXJx89c03CXlyfn7yDfxzcX5+Dl/Z787/159mySE24vL8XG8YVYc5",
+	"nH7I/HfJ1AYe0i5wqEW6a8+ZT7hhnavZkxmhpjkMrE55bgX5DTzEtQsKDjkOtydd1CFHk+0ZBIBRsVaz",
+	"XC6XLDsBDeAt19xINTJNxaSb2A/DkOXvQHGLk2l1KYelCneBP1W8Zs8rgwshOGfLWeiZy0wHkMfQq6h2",
+	"RoC6Oy2VliqmGdIYqm1/B3l3wYx7rqAjOvBYtOLaL6Y3FHjsoYdvmu3Y/2oFzlodQExxrfH61CFdgk6l",
+	"ityv94ovFV0TzdfcepqgniaVStlD0UnghXJyxyCfn+eBoCUtjfQCRpjP4vz0q/8c98aU80ombz1l1sgL",
+	"s7TtzgmKO6rSKVk3shj82B+mKdN9WypBybdyM+v12QzMJ56jGs8Kt/HAMjIWgrAYD4ooajjuNiI7TIr4",
+	"ntRYXwu6ZhMduGmZcTleCYbwO/vPP39zhh1P18XjMfqvHdRt9UxH1rS5kMDxQcRBAOQYRx4bVHhdh0a2",
+	"KFwsTNEBlNgduPwTPj4xvJ3+bj3HvqfEffyW/KM8P7/82n9+McY3KCsVjfOiL90vVndnLYbBIi4f/2fs",
+	"qQuSLw+TwANlWgRDWuXI3MrJc570BTKzu13w0nUdeQe6SSAvTi8PkgTyycnFky86CeTzO6bl2ups7yUH",
+	"ZJtiBrgfUMQkSABeOI1UCIo+wr8tjZDjr2P29zGsbjef3KRe09JXjG39QJnXxrAkN2OzoB0zhL/C+DGy",
+	"c8eF/AFtAb2eMmPtANZTOqbLoXzNsmufqiIqWbgfrbcCegMQDdZQp5/lIiGlqPKL9bsQ8CKeM+ch/HLc",
+	"nq59DOxBnA+csw/PoiMOuQItFC2z60qq6N6qOp9/oAo9j9w2NxJfCufZtz2buWtI5mzFRWb1WzACwbU8",
+	"I7mUBQelPS+u56UCXb3bC34CSZML53gbOZUVzzImMEVWf1qw1y03Hh/8/0h7jx6QYUN3Hd1I29jlQMYy",
+	"MuKW5jwLfELqXBM+FbiR8lqvpDL4PhU5T5uvToMt8t2ibNE4dy/FllHu79W7v5LHF19/fXIJMRQZB4UH",
+	"OPJUqXx/YPLVFcmooZjPcwW3Mx4rjGuciN6ukxpNnI/mgrbFmPd7SV20jXsbWs5dCbBPmGfFSr/oBFW5",
+	"SNeWmmqcWYUjMxCtdcFTzBYH5wEEtsf2h6n2Bzb/k2bKt93dX8TS9rcV1rWjlVIedyN5XoDbUe9BPSN+",
+	"f0QKZxG9YYUh89IQwSDkBjsEZ0btiNXRDMvy1eo+9G7MpkDs7Ev7rw+esLWKkNyezMiSQAL9fT4jvIel",
+	"uBHWXDUCdu/pcqyyalBqrM2W3lneVsrw0LGf1lLGEbYbZfKaGkZ+jJebSGZQ96LZvlGeYlr4RW+Iynu6",
+	"fK7hgYq7WsJumokp/m5rZcBg43UvvupHMEouTxYcA2ANI3ZTE4aMwpopxeEle1vGslJY/73RTNAR2Bff",
+	"Z3zIPhOZzwvQcXVdSJUy+EuWUmbt6O8Dpx6OObFUmYjB2qRrNjal4pEhc1Y7tNhvbMYpgapycsfNilCx",
+	"IarMbXYJjq6QMBXhbnf/EMH1omBAmSWzjIlN3Eo87YlTZuhofZUAbLzLAQ9iqd7qN9SUX8J0l1EgcfHK",
+	"tr3oXkBApCmqQg/x8YePB7qz4gLnS5qbj5IrXnSPrPYH70s+Ovrab/NuHntZ6iQ8h/Ovuj6Al65mItuH",
+	"D3qv7IxW/O6CobI41pg6R8vFPG65CA2ELROq+6WOloPk5V+RlSzHWR+3Lj9qsQB+PueC9bsnYP7ziper",
+	"CwjU+R53KFQwSom0UGxyjpstYm6XfTt+/scxceX7AvgnHQuHnHPZ8p0FHTc6FbvMIxDv8otUNweysrA1",
+	"5S0V+29yJf5f9xFU6+EObfMkliZJxSoQ/EWuxF52hToeedgBOqexJbyULO48qvWdVNng3rs9V1IwW5ux",
+	"2fl/XVw+/urJ1//552/Oe6JEFjxnE+1t6NtwdnH5+Mz1H2lx29Gfusv3w5FcZ3JELpsKBQJQBKMmFfZU",
+	"YA3AEHs//xaUXfzuNh5d1cnaqMv5mhv7vigWfnICaRYK68lMMJbpaw6KWfj+Vt6wbO+0jtMSqB6tCoeQ",
+	"LrddX0quV7s+r03Q2OKzMfZQGCbM+14lJ89Zb/b0if65Hv6QpWyWzDKZYm6VOHPB/8lGPmij3D+GTqjX",
+	"GfbYeOLCgMZaFroXLiIeswDcO4zqOkcGPgDLapWSP25Be6vpfL6DovMA+SgatCZOjvop0DCSDev8enVz",
+	"jbV92EZK2v6D8k74UD+Lzc+IfxWsFEr1DcSYLrAumWKEC3uGUY+wKRrB1uZlSuPuXj1Lf4s6fhf3eitx",
+	"kWGh38HF2XFjK2sl5+8Tm/sVNlPZ7ik6zU/9C37pieZeGcTdYJNy8vkND6braWV8gM3Uruk9Of1cZa9R",
+	"2fn6jqYn/Re6IowVMUTfU3cg8PVlHMkCsI7IxFBhgXUWsvn8Jslz3TfZQXerFVaXuKFo5ASIS6Bnt93J",
+	"q5djIm8HKxD7Sopuw480yekGPWQXnOWZC/wCQxLNMqBn3Ky4ILSb0Tm4i1MSmIS5L7ZXjowYt6Twx1En",
+	"5Blf1OSL8r9opl3p3nSbw4WoEmGaSqFZWoIJmvz0/oXN3ok6XJ/RoZslJq6QG02/AiK0O42pc7XsWehs",
+	"GogPQpZs5pcFFdeFLXywLY7UYzb6s2lC1xDngRmbpcAql3WtzSoXFZbghLv1jFyQNaNCu6hTIwty8f+M",
+	"UWfFjv5/mJI2S+l/e41g6xZSSA5kdXzTqODIoIbjRyfgCGmpuNm8A1JvB/qWUcXU89Kmgprjp+/94H/5",
+	"+b0vPo9aFvy1ngzUFbaCOXehDi015NUry/BBHi5grFCnUTmXo7NmYuM2EjS8V85qp1VcNmRHo3c5eX71",
+	"Kkht/3R2cXp+eg6nIgsmaMFnT2eP8SubQB33dlYnmF3aPCUAUmTrgI2f/cDMc9sCOim6Zgah0ePLVTc5",
+	"w8ykn5LBdjlfc4MN28FPuWEKA2uCp4zDTz4Lqb2PgW/8+KL7/bPV1Xbqcprc6Dr9pu5Zhs/NOWkVA4eD",
+	"FtZPmJZPF0CsEVKX5+eB0gD+pYV1r+FSnP3mHHLqdexVtbtb2MRGl8qFw1J7bco1Vpix9kNC89z/6s3E",
+	"f585RPpgizNFkO1K6hrbnOLlW5ltJm12xB6b8olRJfvUOeGLY0zaOkj4gThlBZz8VxaurSTgNPMVIxoE",
+	"Cu9gSJr+/gEQ6q/w4bIOGXmKtX1mHz59CIHk0yliBSvvAtiB06fE04ezjy7245NdYM4M68LvJX5vuz+3",
+	"7SeTDTdPDOm/iqVigSO063FH+DjiHS7VHL3pDneAdqv9R5cMkNMjHM/5fWGsL2yCx90LlLouCbR7crGt",
+	"Hb2lHGuledOgdX2FTJrWp69JYn5gxp480OtXL+PnX5QxClMe9PwflELdG7ydheQ+r9dPOOU0ynSW1oFN",
+	"IxgahwEvwk4Pdht3Cdwaeqdfu1e6cSx9FyltHsMOT/bhz/Pwt6txgvfLBXSmbrtJVj9bFcr98gPPsyxE",
+	"ASwUNOnuBR7qo66dS2O5O4YkhxZA7uUKN0vrjbjErmmV9hL3nwDbxiAimSttDkCXP/QQBay55yMJpuLC",
+	"2Ucnin46q6oA2rQ2Y+iIQxC3/7d+gCPiy62HygTOUzFY5bi3sYdbcjsM+aUp0IKT8cyQSyZJSaG4VKHu",
+	"tQm3ZPY8W3PRB78qK/Gom+wT1X3OL2dPIFjvk4mre6St+mU8nxt/W6txInCwh9cDB0MngOE9/eyh8J6O",
+	"AgLsBFVd1kt999MHemkagxFqDAX1pL0mW6S3UdLDAc788DxO0/X/CJLEwWHdkC/OY67bGEdErDJsZ0Ib",
+	"RZhDME9vWZFTl/h1N3yLX3/nUl4ZUE3Mi9ULzg3PgsRWoiC2poMmS0WFQY99LK9BaJoy7RTKFJ4C1CYP",
+	"EJhgOZ85nWlEjIzBQt8BwxZ0Yqv6lr7O/lfnEeXFT4KWZiWxXNU9ImUD8VrrbvCI24W4lr0JozYgFsAN",
+	"5CM16uEI186+21HV4EHZImZsg7rhZyRjwi/qhrGC8DBKlSt9Sp6L6nW07QDwG0BjVLZjmh0jpa2+OMAr",
+	"Hg41j0COm4EhR5A6j3Mn/ioYAsaWIQkvwxYKvZYZS3xrjPajhunP+g695ikTuoHpIoGlN/E2qfG8l4DX",
+	"dUd6OTbX5OFsaT7fAHHed3FTVpCVoMazTnGH+zFL9dRk2WqXcsfcZ5iqoFDB0X0zoOeq+h1F8+s2es/G",
+	"qWDWaNKzz8A8ZRfivPqjUAuu39lH7yr8acRNfF5n1Jn4bPiOxzXEDEFn0BRjm22VUW2TjiUlvBJ9wtBh",
+	"D/Fh79Q9Qu0BDSpVdbyRd+iMhsmgojLIC4i5ssVsKh8yjMD6Ff7+SpzHF/nVyF/xZYWWv0KzX23jZgMb",
+	"Qkw0Q57SZTdPIRRZoBzjMkAmZA4ublD7XgPy2vzonvUAwcZmGrBCjq3WZvNEanC22dipfyu1cWF+dcVv",
+	"YEIBrFQxQpeUCw2J6aZJWtWxxWStCE/bJUl1Fq7dr1WEEfAlzuWi3nAPF+BSaEf8Wfoc9nodi8PZElsC",
+	"HIDmcy7E5zdyz9mZWJpVY24QWqyTIzjcI5ZyDVHsS37LRM86MusKWa/EL/vp4/MgvdDjr7/enmsdVhgb",
+	"P8Dq+DQzWzHdOwnbTxChOvMlCiNBEMd/l2oM7Sd1NfkYkh0sfKQKL/k+QkH05RsrFbhQ1qRywNRJ7XmX",
+	"EMML7UqlYSo4S1hEP3lN3H/9dHaMAa1JIHY2oYXk4V/ShuYo99GNaI6D7rWiDb29OxvSooiytyltAt5M",
+	"tKV55/zPw5jmGOJha1p9uUNzWheOyCLoCVf7te3wcELJLhXBB41r0JxA1Kk+9G2z54VjW117eM1rN14X",
+	"X0i4uOUGt66j4HTHPwDPs4+ulPAYn8gYfF/b7ke9iG6JIy/iay5uiGJrjLbc/RbiMLvq4xi9BcnfstWY",
+	"MCzNuWCoFq4Btx1uo0XWhwLE4UXdvvry9yv6NkhClwRYKTTDi/pA+PXCvsa2UKcX3uiGZDJSQn5vynAG",
+	"0l9hJj3XDZx8bvs/EIm4N8R4jmlQ98WLVxV52BU77HF7xHg0nuL04QO/Zf3GW/KOqVumTt4xYQimEdAu",
+	"E9EpeU5+tdG4Sv9qi5SSlCqFVYhf81v2wv2YuIjLsiCa+/KjqRTC1TWxVZYSgtVFhAGFylpikZrU2nYW",
+	"ealXhMNgtzS3grGWawYBnEvHvGZOLWPrQhuAk7USzpm5Y1i909pLIpOfkquq6rTdUeaTZTKlXUQmdFwn",
+	"oSYGfneWwd/KzBZlXh9UDfOMMJquMLYKKcBK5hmhZMHugi2gBopiSZhxapvXNp3v8Rg3w/4wZ4gQJ7pK",
+	"T92x0lRqgM5VQzyrCtYuGsh0dEtdRyhPZl9dftNt+F5Kq6WDC9QAB6CUo9RcI/BgkCfnj+NJuDXeMMT+",
+	"VYkl0wmoDKfaCG+ZT4Nbif510Uu5qGV/7+wVlIjZSR3Q9sGLFVWs0Z+gFlTiPm05EEw/61I9uwIdUrXr",
+	"c7RE4GHk3tHX7wtXOEz2Hmxgwv62mZgDYYVIDQ/CLh418nSMF0DDjCGfuxgayx80TitUdfMWTH1cpdBr",
+	"G57NarjexhYxYJhukQLM26WJTzbk07RAYRfys89wnlPDtPEzYP0B5AZAyfEPEcs4Y+sLgv21Gplrx2ug",
+	"kw5SOA5jmlIJ/Q/hHuTfS1ayXred46FZn0i1LnPDC6rMGeztJKOGNpEsngIkkkvi6uX3Cbn68QegpH+5",
+	"+u4HAvZoS/4dU3Vx/uZb5CrCooqV3WLOBVVbqn3VCZ6skuTpx8ggPVns2iP0536KRL3fq8dB9LqOu56k",
+	"TlI3ZE1gQfqsMczKNzG3IsVoFrjMgI7/jnJkIuwNO6QPqd1hO6/SZMYhHODM5siaJH22c0XdsM/PrN+T",
+	"z2oUIseVxiGq2bRiB2Zyz7/pbcg1tvVoNlFjjctt8J/DKLRdW31nc6ycfdwwqj718qBvaBYk04a2j3Sk",
+	"jARdGKaqJoSJTD8LnySnVidrGI5r95yw7AEs7i65zC+MqqPyuHfBPEdVujSzXUWILCwBxHhPzY4r/f0o",
+	"yaYxoZPjXLqaiZqaAOObow6TTVvH5Kzy6Dz7GGQo3+o29gJ7vvAdX9TduhgTL0JDaF6s6MllnVffdubQ",
+	"CNKy1H4BaWP0JmEL/QS2p5AfgZB4Hj8zdnNcdMTDi/rfo4cP/jjwrDcObQue4WikKOc51ytXfdKn9W7J",
+	"WbDvfEPey4I8OUdmzs8SIJKF+yyZXcGQaROPbFaYs4+u2t6nAbHdWaGx9aMwrwyqyCDRkxXeuUEH+Bjh",
+	"ssvBTHv27w7GC7fa2ZeHHxGQ4yHUTy6wamJfLPD5fMbhQC7nNB+AvIPtPFZuCROXU1xaQt5IAR5S3jHu",
+	"XYkff3r/4pS8cDWYFAs2Jr3iF7pjqAZUnI0/eg537HqnIs3njwk7XX4LPQf9sSA3rvLAANBtFRW9NTe+",
+	"U8mXpnBxZFy5wgSu4Ga0MEFCqiICNiccuXhCXEa7U4KZD60uUsOvNCcZXyyYYsKQgskiZz55HCATOjpl",
+	"W3HGl1oYeuqCmX1hc7xLqKAcnUnrVTY1l1ZsKKsnjHrVXYbOexex6rr3FK4WVrAYE63m2vvkcCtpTEtl",
+	"NUwhW/egOebgDWB/2DSaWzil71yTI4e63I/HGe7lL3I+Bjy2MflNzg9nTpmsZGT1IhIvaXklf8UcOzGw",
+	"T7P43yUrgSmBUQgoLQA/MAssNVQzW917nNu3l+DmuZwTdPJKiBQMFWhohHRDJq5guPXfphtrdVTyDnIg",
+	"iowp8ivUBX+cuvb4gZ1ldPO/7fe//PLLLydv3py8fOl++jUhRV7CLtZU8AXT5hSQgNitzv2WrCaP2jKe",
+	"XBEH+VPyzv6DJNKJqihrZjYaEuv5gYs4SWVerkXlsM5EFqhf4QR9OR8NjN0Sjo9iCVGWoUUql9T6RfSp",
+	"TusLdQyVih29oY8b0qRcHnhyvF/b7pNVLWfjPIkTj1No97KPx0NcR7f8WjsBy7L+wt7leUGch3n7Zgak",
+	"9uzjb3K+TbZ4LuDSc9VGJ6tTwQD1zMdWINMAFzAqXDhE+wvMN5l8Mw/KYzuUjMSZY+s0Auzc1eVEBNTa",
+	"+VkAl9SEInL4YUWwEbhy5ofoRZofGctA+0iMvGHC6eW4uHmkbXVjUypmFWxeq0ZKYXgOJMximz4lb60B",
+	"iFDyT14QyL/Lb23tP+RJgbRi2A8STE9sgU7+Cv/8moCJmpqV40B9WPlvcv5IY9M+7V2Ipy/9TvfC1x4e",
+	"0u10qw5mRLLh+ODVOW8dfjDW5YraSJfqVa2PMSHsdHnqJRB8LC/PL5+cnF+cPL44c1+fFlT9XrK+UBwX",
+	"edm/oGlXXaaGxd1XBo1ln5LGSP/kxdQBOtTif5poi883okUVrWYxVt9YYbKXXPgHSKqKFtcebduJh1R2",
+	"kmGrQdBpRTF2qSYLLcLTzFjq6QmtO3jK43k1lM4sT+KqA8XIzCLHL7cZlb7HJruzKu3CLMEBRIvVUN3z",
+	"k6FqycbWDbKN37cq5ININEtcofzB0szVhI3hqjV+2N362k0YyIQhAIzlMeKzS81U3FYJwCUelDWKvJGZ",
+	"QwOHJ07G3yIk/uC1AMeX4XCqaWkE3Pp7sggs/dr9/t1mtucQCHZ8eCbe7fF+7fnBpDGNw1D+AE8zuSjK",
+	"gzvcVHkEKv1TpXvqAq5pGI0p9esYj44Pjlfh+0IpzNJXOy/EU4CDrktuU1Blb04sUuRASv1R4R4WQhOy",
+	"Z0/RLY2Fkd02GQRKMkBFjnBk5/d1SYbSOGxX3oGnoEW0dhKHkCT1BMQc8vAelKLdG7DGpqsbTdGOcatc",
+	"voedSN2ZLudj326HOO+qHg92/Y7FBQTWit2uZ+X1mXHFUhMMGLczVpcWYDMcOlrFit5TwOe003Pr3yWa",
+	"E2NJsHK7sUEac5a7tHDBaYUxf71cV3BGR4q8u2+uK5g0FmU7kevaiadyEZp3wpq02+CKwajG6CnBs/sF",
+	"aU6MgMVN7c8S2XH2Y4lw5XFU304MjnBU5/eFuUOsUPRgG6yQxctKj0ptaKGS2sSRsp83OuRpPijduTfo",
+	"TchsdYyL47ie3osToz9j8jY2Q3J3TOJ4sNt1jOQMtuWRsjP4kZ3lFGNqqyA0vf0x7/r/wEhkzlK5ZtXt",
+	"rhyg7JjEhl1rws1p1Jh6BIAeNaT/QdiL/nDtINC6wWnci788dXEZFqOmoSIuPHCX944JO1GMVqrJCUzM",
+	"vokTK8xL9grG25aK5yFzgLzF2QMoVWEG0+DkQgRGU/Y3rv0XQNntUsen3fFHcRTS3phhJ9HsoAA4EiX2",
+	"R/4AlDicuhWFg78QTW/HOMbInO1xpbG0+K5eDhkYHi2OgB7eJUWz3la4romX+uwjCJkDivk3UFWXKU1S",
+	"KhxNI1Rg7eR5aZUyIL+qZ1U0UdDQrNhas/yWRYojRGi6w92fcFXHpOh23yPpucOP/em5G2hPiu7OeTSw",
+	"ldzQ3HA2mH3WPclYEttHhAF4qwFIUWVFino9NWD5tpp2HzjGHCk2Nris40gx6Cdi83yGPWvf5cuBvKNH",
+	"F+fteW36A81cA5ft5aHEw+fLpWJLzEBV44V3YvUZf/oVBYihcsnFdt+H19jkUL4PbE153vBesN9E3BcK",
+	"qvWdVE1fh+rLIccFP2zVYTdXhfM99opeaFE3DlQoDmAiUN6+8Pa2vRZ92RZlTiw8ayfBw/gmKhWv8hg6",
+	"HSaEu2c5VSxjwnCat50N8MXFJTYaBW45pVnBt2nod1HQDWYwOLtj85WUN/rsY6EkBMSrTyHqbvcFxORI",
+	"vl/DIZDrOiaeC20YzU7JO2ZMbovAELcAF6+Ca3A3XxMpEu9/BM7X4PdEee79sH1SHfjecKy/UaBjuVRu",
+	"CmhJ6xnueMocMwGLx6RVCdGyWrl90jMGiYSUX4226S7MigpUHfT5XF+5k/zZdbtygw5FwADiFizBtRua",
+	"3iSQZcsYpu4guSHu+aYn6LOopxjvDrgH27vrFW9VEKqgnDI+hhWtsUkqModF91F8f+ZI9FMpFnxZqgFf",
+	"t7d2GQGiBIjscCC4RY3Ylur2fHT/bfO6fs1MhfIM/MyxiFI9rfWWxQZ+G9pdlJhq6gdWodyVn7yLazGs",
+	"CVqPcFLtiaE6Jp/g9hOjitVPPVyB+z2w/xEKV52pKv9XHHNcvx1LvsHYTxWj2SyW27kCsVxsM31dOYLm",
+	"+QeIuMut8sj/O0p9dOX7XVW9podW1V3HCQ9+rmMV2O93MbSbJn7F4YG6r7bbwY5/XAe8GX6TsatRQ2C7",
+	"WaxquM0y5nfU8RNqHmqfOewoh3p4ZU3zPO/PNDYKjkcq/dJ/k5xJbOAm9ZOmMYUJIoixa3GCED/+RcsT",
+	"+CM4foGCih70liiYhiOTCxX0I86+xQqm4dG0cgXVZf5MChbUZH2wZEENz6YLYA9cByuBRwC4W47QQz62",
+	"R0nqiUdBuKgp6WGe4nbAfespnnJ1DnTyh9Bawa5GBd20lFCu38GiZOBI6qSVTdDdT7AMGD5gV435JxNY",
+	"xJKzj/Z4dhMXEDve2fM9LjV1QBxHTBFCzjxh7bxNKN0Hj+SsEwim5hK2AMoLdq5w98BD51tXlf8Pc898",
+	"9eIx0W1OYH3DzEpmr7Luub6gyia5dDpKXII1LVp3ulCPkWCanaXNYET5cmUgOWY0u6nv0i+h+xZwSeyw",
+	"PiQxIaN0aTZ02Sb7fqR9XTmbzD5nC8yt8w8RW5yzpk2mVKW383kQfHjgvKoexaLih/uNaEMVpPkhrwz8",
+	"v9G+Jk7ik19U5b4hshSde5OYFgsVcWrd0Pdi3XSv75UqscdPHQa5RmSN+IeZyh0CSRuqrAtpEqIqVIL8",
+	"GRh3W3VdYAIhwoNVY/ZA/3uQPhIwacEF1yuvosZHHLaPGmSrPwK976i3ANpcxt3R2hpGX7AmC49mS5JZ",
+	"OG3v0uSJiuUon1xsq3XdLcSecyYgHaJiSy4PRhwr3KGiU1m+o93yn2vOcQRN9HzLYUjivZC5UwI187E0",
+	"uLCOh/MSFcCIckwD3NG8IUIseAQ4z9IbWZrrUuUYXm6BxxWhRVE7LVtYXmuWKmZO45TrsyaroxnAA1Dg",
+	"/ZnGz4oAIxvyb/p7P/TXCgijyS82fxjqS1wegj7aqxlV6ao/GSH+zLTLyuJOlmU+kJ/A3YEPzpCRb07J",
+	"d2ACxu8xXxUQJnkniHbVfVw2IaytjNhLwQqibb4lVz7gD8COJYN6Rr4bogwYfbFiyZpr7czGGaOZLfWm",
+	"fVqD6lL4Wwu/ram6QYRWYA4HNAc0hVbWKKSr0j0KLhzP8zqrdDyVjD2cIauubUUMU+ueNCn+4x6JXF7I",
+	"9ZqeaAYrsaXSrP4hBBOawCy8yX/YLIBWCEmcG1RSiZGJjTT8U8+CcbSeosOxgceUXa4g/UgjAlx7pADJ",
+	"ipICsjTLUlcgfYZ746JENKCmQhQp8k3Puu2Qs0kn+wrrQLqY2IQoljO4WJg8GtCsUDz1ZaIxA7D2d7td",
+	"0afCsJ7VLWjKTM+xLmiuWXWKcylzRsWoLM33rOze9tR9zzDVB8vslXjLNOwt5mljkVRhg7AG9HH9bN5w",
+	"reGZQbjA+3kjgHA1SB3yXs4Bx2JTS0tnc8K6a0ZTJbXGxB+NmxgQZLvXBjUOdAN9GlTbq1ILjCVACVlT",
+	"k66AQtoK9YHsZrjJWUKCvkkVFyOyKt54GwGbcK2+57mB4NyNnwOGgtN99bJnkqq+4k6zLG2w9dAkfpcT",
+	"5ngnFRhbWJ498xiLT4hUGVM2yBWIxi0VKfO8Fc4KjxIWkMfzFZJoGAkr7vUl1IIWjdX5bEPVDIAMsqjr",
+	"wjt6de2q3yO1miUzBHY0LdHnRlCaYponCWP19XhDYpVukP2Ie9IOewJ67T5eJmRM/UVcMZq52JH/c2Iv",
+	"3klMmnyFXnkLjo8XghzvKKKH9XmDYdOcpzf6mc2Bh0kphUssprTlkCYlO/7UJFWOLrjHybJlGSJRBlnF",
+	"TDMsI0qnfHrKM1zpiRMTRxAu3/EF9Hvvug3RMUNV5ThTMMVllnjhEnmbx+c2n6yvByn7Hlol1/GDg1ty",
+	"YviajeFYvhPZttUIedczv5HTZz/mu2xh0gBFBOlfhCBuuGRvcdizB3NoK7TD3LS5IkvhYqnnOuhqZHEC",
+	"QOFMT0DW97L4b9fp36i6dfbPqQwjwhDgtnln6KioZgfkxObXt8jDstCh4t4x/k1jJb9XWDgK2//JlDwJ",
+	"Xs6R6P4/TMm3rte/8f1LwfcaaojzXyi+uyU5rQ8WZ3Fu+gNIP5yVwWF5X/R+r+xE/iOQVP6UECNzpqh1",
+	"5QV1VMFyCDPQx5CR7kN6If9RCw4JZlUWTOkEdztWHKnVQA0hxEspjS+rKfx4/wLSSE9ijYOLIxVyf4ny",
+	"iI/J3EEgsQvsj1b6HqOVnOK9w83qU/KzVDduaqnInbPWUaJZTwwomCQD/vlY2cKCKSZVW4i4zuAgRLFU",
+	"qmxM9DfiOKqten3XHOT6PNdsgCeeYqDXI3OGts6CiUZq+hhcM56dbGR5smZU9NoqfEb5lBqayyWSS7CJ",
+	"Sl3VgrR6F8RqboAb0cbeGlC73DF6c0psrhhbYJOtC+Oa17292Qf7YaUTpMs4T77Zaip4ybNfZPkGNhEP",
+	"z9nbMHCPLPW7crlk2ox0V3at0XKklFOEJxYCjtFoEgP3nIbNXf0Lh0X+cLbhzXA+Stvrh54slFuYgfpJ",
+	"/tPhXvzPW5fY90o/7KPak5zz0G9qNK23w4hOUu8YLlbGtWF0DP2tx2Okn8Bq8uH9Chr/6RhsKWbBwNi1",
+	"QdYUm+46DxblS88KxW+pQW8IU/bx2Vy7uMzIXA272Zd081y6rGsK/VwwkP3wr6LIvwrce4970Yvg8n2B",
+	"7HO4/G3kaDBIw73y8eiAiRZGS5Hgglg/klSxLEjmdwzaBNNaqrQgVIyd8t9GxgMYGbNSVWXkt5CnyA5x",
+	"O30viPstpm2AoQI9A8VP+OWXRxmdG0jcab9JMJsN0PskRgaTGR/nU2lPOoKObTLapcGB4u1zXF4tCHx+",
+	"q4u1mPo8xuPSgqexqvZ++SQ5zDvpQ9C+wDdS2xLLOyiYtBVje3UQb5xSAJasvVEEnh/IjUS4cEFdQBV1",
+	"Erq+wKfaDce6jVm2lzT5eqguWknethYAeip6x0zr6RfUhDdyycyKgWMvAy9d+1rU9BunmkuJvStdBz5i",
+	"uANdu7fD2Bkx7A+zVc3h1jfENVxZT0yia+7hoR0kB9wiD+UPmTSYtKGlv7EZ24gobRpEKC5RIUDPirYU",
+	"sL4IC1hfPlj96j2USF3VUY8O86eIB19Ugj+hGvWL0KB1utuowWAtd2/Gqmxq6zJd2SxSXEQKutvUUpGi",
+	"7a4w++9ds1jFDYqM3DG49qWAC96t3p5L8I0+4QIldj1cv91uub9++5eJeFXhdAvQKaXTf/eG0lbx9J6i",
+	"6Bbo/Ug0KIHtFpk9jXkdFGdevUwIRydo2NT4Svx7+oMeyQ/UPrL9g7tQta1jDxwsAvvzSkcQ1CVCnOop",
+	"TtjOKmARcHtGgX3D8Ia3d7/xWPWckRieoQI5O2cCsEgdD+apSucIdtcO5/EQqujJhGj/fUL7pwfrHykZ",
+	"2LaDs9vsO7JkO909+MGc3w+KDqX8qmLR6qoJBwhZa2QpQRmnnSwsoCU9icIOduwPSYjuCcpHSgi27Ta5",
+	"lGCjCdBZuqLKDDM4FuIvbOOHum6jXltc43fCjPOq+w413cDD5xsMmsX0Pa7gDF6RFdVkRbOE5BS5SWgb",
+	"SjQjbm/j7uH6fDouWyWwBa3qlP2r3chh2oafFEbxeWmkGg3FsMvnDct6pVPyOTXOpIfypc1DmMxMHfoo",
+	"D08OG4d3v+xZZ+pufXP3s03gdL+sGqRtCsCPAbej6eWIlIkBfuyaKtGjxr9omkTY/vFTJFo60JcecRgH",
+	"JqdE7CLGvqkQx+PJtDSILnPXZ5EC0TKqg+kP/WPZ8Ddvws02HXVx39PlZ/42vqejlAqwEVQ7rqXM9G4s",
+	"C1wWtCw0xiLUGIr6UoRLrwA3QpLY/7QP/3y+p8vnWvOlqFObH1asODiUxxYvt6qvne90DFUO8Sy/ZTXn",
+	"vQumxS48U4obqbYVGXpeic7O/diuMWmWPqsKD3m/ZYplRmxmHwokR0dT/odIHizm86YsbqGbt2U+qqB6",
+	"1YGoEk2m843NsqE2QfmV/nIp94eMDYRrLTtkPrZKBK3yGGigxtoQdhguwAU6FCG5tpbyrpYGD8naitgG",
+	"1b/PSMaEX9ENY1gnBZOR4eq40n0FTY6BakcgqyFy6WMIJsfB8b8KhkAhBVMObptBSruWGUt8a/T1pYbp",
+	"z/lOvOYpEzrEXYGp7JqYmNSY20eIjWJ0KHPqO9foUOkBsdsfJurX8tJfbzQeJbW/hlSO4j8jHg+tL4Ub",
+	"7T0gRjLsWRQ27xIJMAWH9ACmd0lxnzn7bmVsdiF3qLxNMV2Xax46zzkTWJDN1u5i9iG6NsTAFzJj8URr",
+	"HkVr1THRdKNdRjguMKcd18QKBIRqUoqqTtSaGZpRQ58FNA1dGml+B4MopmVepQAOpqBZpph2BG1N/3jN",
+	"xBKQ8jKyhYzd8pTFQPvOWJLqPZdUmGyfo7L91uZTCaa4OD/vncSDMGj+JNaa3Toc7eYIR3EFfz8l8Jkp",
+	"TTQTmXXWSWB5ghRKLmH/zt324omLwdYslSLTLveZ24Xrw0T2rNqcTcpnQ8R8vBS00HhlH5+TNRelYZrQ",
+	"hWGq9mRwC/NpLqmw32ADdxODml7MZ77McFILLY+HuJ9ZMvN7wZ+yKBJyfQXpwdjdmJxZVeRp9s6eRgTu",
+	"7phoakqaV5ckIZr7iuG4Orc5CEsqGqDRz4gjU6QUGVPh2WcScjQiLgO2Q0c9C0oxRhwjkEHgsLjeJb9w",
+	"hYR8wzAJGcLsbsUUq5epjSwKlg1O7AIfo7fD/lQnb/T5+4KzCSgfnpLHS3QFF9kY6vcgSeEvD1rfBU7c",
+	"nVbUooOo4qrPs+yZo42/ldmy5UePV9MycOjvo7gxTNQOepg5cU5NuuplIDoJMr/aQmcc9Fv2wstv+krs",
+	"2+elCpEEA4eRkqyp2DinS0dzDmF3TGZPYlzMK+G8t8Bdp7RpIcs8B0yEx6MmWG/h88lz/JyxnG4G+ZnR",
+	"ieGBcII4iYAN+Rj8Avbf4GXOdKkLnnJZ9guT/8WXK9RZKlpmRKdSOadY8Af1vSuqExIZmweWFpDqlGVx",
+	"WdJ2qwc6ttfR/XjH2OMf5VjYPsGDpyxygFmxPLMqR6pJzm9YvrEg7ctr4TDko/0HqxtXT90g//vOdXpr",
+	"u0wW2Fz349nVcQK3uvu2rzvs6KfHFaXIWMq9wWGrVNZsuJuIZSdvUtzzb3obcixgCqmXOTrQK3+YUyoc",
+	"W9oAbIJi8EgC7WpfiR4EHdJ8xxWwbQk43zg2wurnkM3mmtxwkfV46rmfulFKhi5nyQy0e8eIVT6karX2",
+	"yrN6065TXlNZGUDgPW1pjrokwB38kRTY9233rabsaKcHnfL85eSiKA9u7auc8wzFssSIdy04JZ0Lc/bR",
+	"0HFOejDAe7qLSxTOMNIoB8c4wUGvh3TBKDsqh+xmJx1jrYg8+wgs8bjjrHu9xT6j6uQq3/SQRXIjBwhL",
+	"asLheKo8nGtvaDX03DGTqQ1adCDjxYDa7j20OFiVo7WP6auAs8glNTV0AjnXqrhG1p5YM62B343VnP93",
+	"vaRBybw668TD6KGLcLznRfR14cWo2ke4H1d8w8eZP1z9jXd2ffZXCOMzvLBaBKf340aHxTfcfhwD5oKL",
+	"sI+o5/88ynEcRj6HA8INGulgFz41vPD0CkbYytv+hA3+/yAq/+Qqoo9nWe3hHJibqiJRSt2MxbUnvZ3h",
+	"9dA4Bsdrz+d+iVI9Z4sB0EBJpkaixMNJWnXn/TFX2H/2scR6SSNYK+z7k6+uNO1K2ElGMqu4/4eqLd9z",
+	"YMl2GnHwYzm/HywbCibBRjs67OBRK0azWcSBEn7shJAERKDH8etgh/2QFOSeYPtQNeVHk5yznM8VVZsR",
+	"5VACuL+2nfpKo9zTjdunOEV/9EFVacwXnDgG7LZdyUe6u4YalO7otwPT958Iz7pu2a4g/TId3uvygNPR",
+	"5EHxI+L3Pgo/BqPiu7ixW5z8vV71qXXj61tmz+NBL3o7LHwAjtowwcXyJIhnqTnHdspeKKHt3VTQuq0l",
+	"WVBVO/S4UZ7hr9oV5rOGzUXlYGWV5DbgsGvg7PCnr/0adw2imcSvuklImgNEDqfYO8yr/AJW5WFeAS96",
+	"ezVa90Nut+s2oH0wo7NNO8ccnXiO3cGRCv/fmmbMKSbcpOSOgoKixEdGsUq1FDVdHwOsX+ZT0d7+K8NG",
+	"cxdNoD8Yhg6QpJHoOYYqnWlmDJ/y1DQP953v/jnKdL2LHQN9Up3MF4MG9ZJ7yNUIefE4AD5MaXKghPE0",
+	"xg29umu4mzL988S+MSrlz+YpvYLzt04UulyzY1Ct2g1okkKuAxI3yhFfyJbv0BCTdIWejcgOZh3m79i2",
+	"UJwc1FhcNKc9qOqQWgYXN7c3aozKJh9gwJaU8p+TnNSf5nuLrFRt7QiR3F4YimTVro90hF3ioFA4vHay",
+	"Pvf7tXE05+3eSvjteFm3tkhFoZEkiISJwT5yO310zYmLrulz6sVS+XAxiBQpSwg1dSiF4WtGuLHCEA7T",
+	"yBn5ja2olzTlrQEJ6a1b15UP+tmZ+k/M/XgZ5n58ch5EHVw8VB5IexZXOR2VzedtK17qc+WNW3FdVneT",
+	"+CK/Xupuk7Ltb82dokXBsrOPG0bVpy25jzNWP929vHrtVgvDYVDRM4fWiMgu/4LVC3Dt3BLAA8PqF7we",
+	"oc0koNtYrUvwN6dSI1QO8b35ToOL8rPd8i+MqiPySHfBLEcVCN123rqivV30hiUAefHew8flt36UZNOY",
+	"0IUOUYPfH/5WNGfrR3wbeGiP+MQ9Kf0hGX/Ns0DT9TIoMOq71m7ZtPINj9LovwUTv/XzHj/za4yKV9V1",
+	"uq7VRZUJWDCW6WsuFnKWzHxoyQwwGCRO9++tvGEP54QdOdKRCYOqbhUcD81ZtibBK4DRSj1O9lG8PPvo",
+	"/nPSYJ8UEMOtt77nZCSr5jwuxYpCbxy0rBclAz8ykdqqMw05ctd0Q92JdnSfBcJEyW1kxBD8VQ3PSYhw",
+	"5vd99tH/tw96fOfG+K4aax+EGSZN9ZqnopdMDTMnLlSlgWaVO++cC6o2sSoRbbR6KdMS/RHdZPsktanG",
+	"2tXZWt6JXNIMgqnLAv5jWRN5MjfDAbBnTGzZVoTZMdKsTVUOL+E2V/0QAWf7kLSHiUUbIno9kWlucxj+",
+	"60OB54wJXFLGJuK/C05LfGgaGH31DXKMrpCCveDNnG0V2uNU4LAeCzzLZUrzlUTCW6p89nS2MqZ4enZW",
+	"/fD0z+d/vkSkdCN/9HySS536Kam+qZjJ4Luq5HP9Da7s04dP/98AHjRqOWuyAQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,46 @@
+// Command listeners computes every artist's monthly listeners, their distinct
+// listeners over 28 days, as of each UTC day of a range, and sets the artists to
+// the latest count. The server computes each finished day by itself; use this to
+// backfill the history, e.g. after deploying or correcting streams.
+//
+//	go run ./cmd/listeners                                      # yesterday
+//	go run ./cmd/listeners -from 2025-01-01 -to 2025-03-31
+package main
+
+import (
+	"context"
+	"crawl/config"
+	"crawl/repositories"
+	"crawl/services"
+	"flag"
+	"github.com/joho/godotenv"
+	"log"
+	"time"
+)
+
+func main() {
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	from := flag.String("from", yesterday, "first UTC day to compute, as YYYY-MM-DD")
+	to := flag.String("to", yesterday, "last UTC day to compute, as YYYY-MM-DD")
+	flag.Parse()
+
+	first, err := time.Parse("2006-01-02", *from)
+	if err != nil {
+		log.Fatalf("Invalid -from date %q: %v", *from, err)
+	}
+	last, err := time.Parse("2006-01-02", *to)
+	if err != nil {
+		log.Fatalf("Invalid -to date %q: %v", *to, err)
+	}
+
+	_ = godotenv.Load()
+	config.ConnectDatabase()
+
+	started := time.Now()
+	listeners := services.NewMonthlyListenersService(repositories.NewMonthlyListenersRepository(config.DB))
+	days, err := listeners.ComputeRange(context.Background(), first, last)
+	if err != nil {
+		log.Fatalf("Failed after computing %d days: %v", days, err)
+	}
+	log.Printf("✅ Computed monthly listeners for %d days in %s", days, time.Since(started).Round(time.Millisecond))
+}
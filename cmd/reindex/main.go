@@ -0,0 +1,46 @@
+// Command reindex rebuilds the embedded search index from the database. The
+// index file is locked while the server has it open, so stop the server first.
+//
+//	go run ./cmd/reindex              # every kind
+//	go run ./cmd/reindex song artist  # only songs and artists
+package main
+
+import (
+	"crawl/config"
+	"crawl/search"
+	"github.com/joho/godotenv"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	_ = godotenv.Load()
+	config.ConnectDatabase()
+
+	path := config.SearchIndexPath()
+	store, err := search.OpenDiskStore(path)
+	if err != nil {
+		log.Fatal("Failed to open search index:", err)
+	}
+
+	kinds := os.Args[1:]
+	if len(kinds) == 0 {
+		kinds = search.Kinds
+	}
+
+	for _, kind := range kinds {
+		started := time.Now()
+		count, err := search.Reindex(config.DB, store, kind)
+		if err != nil {
+			store.Close()
+			log.Fatalf("Failed to reindex %s documents: %v", kind, err)
+		}
+		log.Printf("🔎 Indexed %d %s documents in %s", count, kind, time.Since(started).Round(time.Millisecond))
+	}
+
+	if err := store.Close(); err != nil {
+		log.Fatal("Failed to close search index:", err)
+	}
+	log.Println("✅ Search index rebuilt at", path)
+}
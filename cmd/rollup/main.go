@@ -0,0 +1,47 @@
+// Command rollup rebuilds the daily stream rollups for a range of UTC days. The
+// server rolls up the last two days every hour by itself; use this to backfill
+// from the first day with streams, or to redo days after streams were corrected.
+// Reads take each day from the rollups only once it has been rolled up, so a
+// backfill can cover any range.
+//
+//	go run ./cmd/rollup                                      # yesterday
+//	go run ./cmd/rollup -from 2025-01-01 -to 2025-03-31
+package main
+
+import (
+	"context"
+	"crawl/config"
+	"crawl/repositories"
+	"crawl/services"
+	"flag"
+	"github.com/joho/godotenv"
+	"log"
+	"time"
+)
+
+func main() {
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	from := flag.String("from", yesterday, "first UTC day to roll up, as YYYY-MM-DD")
+	to := flag.String("to", yesterday, "last UTC day to roll up, as YYYY-MM-DD")
+	flag.Parse()
+
+	first, err := time.Parse("2006-01-02", *from)
+	if err != nil {
+		log.Fatalf("Invalid -from date %q: %v", *from, err)
+	}
+	last, err := time.Parse("2006-01-02", *to)
+	if err != nil {
+		log.Fatalf("Invalid -to date %q: %v", *to, err)
+	}
+
+	_ = godotenv.Load()
+	config.ConnectDatabase()
+
+	started := time.Now()
+	rollups := services.NewRollupService(repositories.NewStreamRollupRepository(config.DB))
+	days, err := rollups.RollupRange(context.Background(), first, last)
+	if err != nil {
+		log.Fatalf("Failed after rolling up %d days: %v", days, err)
+	}
+	log.Printf("✅ Rolled up %d days in %s", days, time.Since(started).Round(time.Millisecond))
+}
@@ -0,0 +1,36 @@
+// Command wrapped makes every listener's and artist's year in review for a
+// year, replacing the reports of the current version made before. The server
+// makes the reports of the year just ended by itself; use this to remake them,
+// e.g. after correcting streams, or to preview a year still under way.
+//
+//	go run ./cmd/wrapped                # last year
+//	go run ./cmd/wrapped -year 2025
+package main
+
+import (
+	"context"
+	"crawl/config"
+	"crawl/repositories"
+	"crawl/services"
+	"flag"
+	"github.com/joho/godotenv"
+	"log"
+	"time"
+)
+
+func main() {
+	year := flag.Int("year", time.Now().UTC().Year()-1, "year to make the reports for")
+	flag.Parse()
+
+	_ = godotenv.Load()
+	config.ConnectDatabase()
+
+	started := time.Now()
+	wrapped := services.NewWrappedService(repositories.NewWrappedRepository(config.DB))
+	run, err := wrapped.GenerateYear(context.Background(), *year)
+	if err != nil {
+		log.Fatalf("Failed after %d listener and %d artist reports: %v", run.Listeners, run.Artists, err)
+	}
+	log.Printf("✅ Made %d listener and %d artist reports for %d in %s",
+		run.Listeners, run.Artists, run.Year, time.Since(started).Round(time.Millisecond))
+}
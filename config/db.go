@@ -32,10 +32,12 @@ func MigrateDatabase() {
 		&models.Role{},
 		&models.Artist{},
 		&models.Genre{},
+		&models.Tag{},
 		&models.Song{},
 		&models.Album{},
 		&models.SongContributor{},
 		&models.AlbumContributor{},
+		&models.Payment{},
 		&models.SongPurchase{},
 		&models.AlbumPurchase{},
 		&models.UserFavorite{},
@@ -45,6 +47,29 @@ func MigrateDatabase() {
 		&models.Stream{},
 		&models.MonthlyRoyalty{},
 		&models.ContentFlag{},
+		&models.EntityVersion{},
+		&models.VerificationRequest{},
+		&models.VerificationEvidence{},
+		&models.VerificationEvent{},
+		&models.Label{},
+		&models.LabelMember{},
+		&models.LabelArtist{},
+		&models.SearchLog{},
+		&models.SearchClick{},
+		&models.StreamRollup{},
+		&models.SongDailyStat{},
+		&models.CountryDailyStat{},
+		&models.DeviceDailyStat{},
+		&models.ArtistDailyListener{},
+		&models.ArtistMonthlyListeners{},
+		&models.ChartWeek{},
+		&models.ChartEntry{},
+		&models.TrendingSong{},
+		&models.WrappedReport{},
+		&models.WrappedYear{},
+		&models.WrappedFan{},
+		&models.TerritoryRule{},
+		&models.ExportJob{},
 	)
 
 	if err != nil {
@@ -52,5 +77,24 @@ func MigrateDatabase() {
 		os.Exit(2)
 	}
 
+	if err := migrateSearch(DB, Fuzzy); err != nil {
+		log.Fatal("Failed to migrate search indexes. \n", err)
+	}
+
+	// Rollups and today's raw reads scan streams by time
+	if err := DB.Exec(`CREATE INDEX IF NOT EXISTS idx_streams_created_at ON streams (created_at)`).Error; err != nil {
+		log.Fatal("Failed to migrate stream indexes. \n", err)
+	}
+	// Listening history reads a listener's streams latest first
+	if err := DB.Exec(`CREATE INDEX IF NOT EXISTS idx_streams_user_created_at ON streams (user_id, created_at DESC)`).Error; err != nil {
+		log.Fatal("Failed to migrate stream indexes. \n", err)
+	}
+
+	// Genre names used to be unique across deleted genres too; the partial
+	// index on the model replaces it
+	if err := DB.Exec(`DROP INDEX IF EXISTS idx_genres_name`).Error; err != nil {
+		log.Fatal("Failed to migrate genre indexes. \n", err)
+	}
+
 	log.Println("✅ Database migration successful")
 }
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"crawl/services"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+var Exports services.ExportSettings
+
+// LoadExportSettings reads how analytics export download links are signed.
+// Links are signed with EXPORT_SIGNING_KEY, which must differ from the JWT
+// secret so a leaked link key can't mint sessions.
+func LoadExportSettings() {
+	key := os.Getenv("EXPORT_SIGNING_KEY")
+	if key == "" {
+		log.Fatal("EXPORT_SIGNING_KEY must be set to sign export download links")
+	}
+	if key == os.Getenv("JWT_SECRET") {
+		log.Fatal("EXPORT_SIGNING_KEY must differ from JWT_SECRET")
+	}
+
+	Exports = services.ExportSettings{
+		SigningKey: []byte(key),
+		LinkTTL:    durationFromEnv("EXPORT_LINK_TTL", 7*24*time.Hour),
+		BaseURL:    strings.TrimSuffix(os.Getenv("EXPORT_BASE_URL"), "/"),
+	}
+	log.Printf("📦 Analytics exports: download links valid for %s", Exports.LinkTTL)
+}
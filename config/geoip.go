@@ -0,0 +1,44 @@
+package config
+
+import (
+	"crawl/geoip"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// GeoIP resolves client addresses to countries; without GEOIP_DB_PATH nothing resolves
+var GeoIP *geoip.Resolver
+
+// GeoIPReloadInterval is how often the database file is checked for a new version
+var GeoIPReloadInterval time.Duration
+
+// ConnectGeoIP opens the GeoIP database at GEOIP_DB_PATH. TRUSTED_PROXIES lists
+// the comma-separated IPs or CIDR ranges of the proxies in front of the server,
+// whose PROXY_HEADER (X-Forwarded-For by default) gives the client address.
+func ConnectGeoIP() {
+	cfg := geoip.Config{
+		DBPath:      os.Getenv("GEOIP_DB_PATH"),
+		ProxyHeader: os.Getenv("PROXY_HEADER"),
+	}
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			cfg.TrustedProxies = append(cfg.TrustedProxies, proxy)
+		}
+	}
+	GeoIPReloadInterval = durationFromEnv("GEOIP_RELOAD_INTERVAL", time.Minute)
+
+	resolver, err := geoip.NewResolver(cfg)
+	if err != nil {
+		log.Fatal("Failed to open GeoIP database:", err)
+	}
+	GeoIP = resolver
+
+	if cfg.DBPath == "" {
+		log.Println("🌍 GeoIP: no database, client countries stay unresolved")
+	} else {
+		log.Printf("🌍 GeoIP: database at %s, checked for updates every %s", cfg.DBPath, GeoIPReloadInterval)
+	}
+	log.Printf("🌍 Trusted proxies: %d", len(cfg.TrustedProxies))
+}
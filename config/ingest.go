@@ -0,0 +1,76 @@
+package config
+
+import (
+	"crawl/ingest"
+	"crawl/services"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+var StreamIngest ingest.Config
+
+// StreamRules decide which ingested streams count as plays
+var StreamRules services.StreamRules
+
+// LoadStreamSettings reads the stream ingestion tuning, falling back to the defaults.
+// Setting STREAM_WAL_DIR makes queued streams survive a crash. Streams the
+// database rejects go to STREAM_DEAD_LETTER_FILE, by default rejected.jsonl in
+// the write-ahead log directory.
+func LoadStreamSettings() {
+	StreamIngest = ingest.DefaultConfig
+	StreamIngest.BufferSize = intFromEnv("STREAM_BUFFER_SIZE", StreamIngest.BufferSize)
+	StreamIngest.BatchSize = intFromEnv("STREAM_BATCH_SIZE", StreamIngest.BatchSize)
+	StreamIngest.FlushInterval = durationFromEnv("STREAM_FLUSH_INTERVAL", StreamIngest.FlushInterval)
+	StreamIngest.EnqueueTimeout = durationFromEnv("STREAM_ENQUEUE_TIMEOUT", StreamIngest.EnqueueTimeout)
+	StreamIngest.WALDir = os.Getenv("STREAM_WAL_DIR")
+	StreamIngest.DeadLetterPath = os.Getenv("STREAM_DEAD_LETTER_FILE")
+	if StreamIngest.DeadLetterPath == "" && StreamIngest.WALDir != "" {
+		StreamIngest.DeadLetterPath = filepath.Join(StreamIngest.WALDir, "rejected.jsonl")
+	}
+
+	durability := "in memory only"
+	if StreamIngest.WALDir != "" {
+		durability = "write-ahead log in " + StreamIngest.WALDir
+	}
+	if StreamIngest.DeadLetterPath != "" {
+		durability += ", rejected streams set aside in " + StreamIngest.DeadLetterPath
+	}
+	log.Printf("🎧 Stream ingestion: buffer %d, batches of %d every %s, %s",
+		StreamIngest.BufferSize, StreamIngest.BatchSize, StreamIngest.FlushInterval, durability)
+
+	StreamRules = services.DefaultStreamRules
+	StreamRules.MinListenSeconds = intFromEnv("STREAM_MIN_SECONDS", StreamRules.MinListenSeconds)
+	StreamRules.DedupeWindow = durationFromEnv("STREAM_DEDUPE_WINDOW", StreamRules.DedupeWindow)
+	StreamRules.LoopLimit = intFromEnv("STREAM_LOOP_LIMIT", StreamRules.LoopLimit)
+	StreamRules.IPBurstLimit = intFromEnv("STREAM_IP_BURST_LIMIT", StreamRules.IPBurstLimit)
+	StreamRules.DeviceBurstLimit = intFromEnv("STREAM_DEVICE_BURST_LIMIT", StreamRules.DeviceBurstLimit)
+	log.Printf("🎧 Play rules: %ds minimum, %s dedupe window, held for review at fraud score %.2f",
+		StreamRules.MinListenSeconds, StreamRules.DedupeWindow, StreamRules.SuspiciousScore)
+}
+
+func intFromEnv(key string, fallback int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value <= 0 {
+		log.Fatalf("%s must be a positive integer, got %q", key, raw)
+	}
+	return value
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil || value <= 0 {
+		log.Fatalf("%s must be a positive duration such as 500ms, got %q", key, raw)
+	}
+	return value
+}
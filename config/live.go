@@ -0,0 +1,20 @@
+package config
+
+import (
+	"crawl/live"
+	"log"
+)
+
+var LiveFeed *live.Hub
+
+// LoadLiveSettings starts the live feed of plays, tips and purchases, tuned
+// from the environment
+func LoadLiveSettings() {
+	cfg := live.DefaultConfig
+	cfg.FlushInterval = durationFromEnv("LIVE_FLUSH_INTERVAL", cfg.FlushInterval)
+	cfg.KeepAlive = durationFromEnv("LIVE_KEEPALIVE_INTERVAL", cfg.KeepAlive)
+	cfg.BufferSize = intFromEnv("LIVE_BUFFER_SIZE", cfg.BufferSize)
+	cfg.MaxPerUser = intFromEnv("LIVE_MAX_CONNECTIONS_PER_USER", cfg.MaxPerUser)
+	LiveFeed = live.NewHub(cfg)
+	log.Printf("📡 Live feed: counters every %s, %d connections per user", cfg.FlushInterval, cfg.MaxPerUser)
+}
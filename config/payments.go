@@ -0,0 +1,76 @@
+package config
+
+import (
+	"crawl/payments"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var Payments *payments.Registry
+
+// LoadPaymentSettings sets up the payment providers with keys in the
+// environment: Stripe with STRIPE_SECRET_KEY and STRIPE_WEBHOOK_SECRET,
+// Paystack with PAYSTACK_SECRET_KEY, and Flutterwave with
+// FLUTTERWAVE_SECRET_KEY and FLUTTERWAVE_WEBHOOK_HASH. Payers come back to
+// PAYMENT_REDIRECT_URL after a provider's checkout page. PAYMENT_PROVIDER
+// picks the default provider, the first configured otherwise.
+//
+// For development, PAYMENT_ALLOW_FAKE=true adds a fake provider that charges
+// no one, the default without any other. Its webhooks are signed with
+// FAKE_PAYMENT_WEBHOOK_SECRET, or a random secret that nothing outside the
+// server can sign with. Without it, the server doesn't start unless a real
+// provider is configured.
+func LoadPaymentSettings() {
+	redirectURL := os.Getenv("PAYMENT_REDIRECT_URL")
+	var providers []payments.PaymentProvider
+	if key := os.Getenv("STRIPE_SECRET_KEY"); key != "" {
+		secret := os.Getenv("STRIPE_WEBHOOK_SECRET")
+		if secret == "" {
+			log.Fatal("STRIPE_WEBHOOK_SECRET must be set to verify Stripe webhooks")
+		}
+		providers = append(providers, payments.NewStripe(key, secret))
+	}
+	if key := os.Getenv("PAYSTACK_SECRET_KEY"); key != "" {
+		providers = append(providers, payments.NewPaystack(key, redirectURL))
+	}
+	if key := os.Getenv("FLUTTERWAVE_SECRET_KEY"); key != "" {
+		hash := os.Getenv("FLUTTERWAVE_WEBHOOK_HASH")
+		if hash == "" {
+			log.Fatal("FLUTTERWAVE_WEBHOOK_HASH must be set to verify Flutterwave webhooks")
+		}
+		providers = append(providers, payments.NewFlutterwave(key, hash, redirectURL))
+	}
+
+	preferred := strings.ToLower(os.Getenv("PAYMENT_PROVIDER"))
+	allowFake, _ := strconv.ParseBool(os.Getenv("PAYMENT_ALLOW_FAKE"))
+	if !allowFake && len(providers) == 0 {
+		log.Fatal("No payment provider is configured; set PAYMENT_ALLOW_FAKE=true to use the fake one in development")
+	}
+	if allowFake {
+		secret := os.Getenv("FAKE_PAYMENT_WEBHOOK_SECRET")
+		if secret == "" {
+			random := make([]byte, 32)
+			rand.Read(random)
+			secret = hex.EncodeToString(random)
+		}
+		providers = append(providers, payments.NewFake(secret))
+		log.Printf("⚠️ Payments: the fake provider charges no one")
+	}
+
+	// The preferred provider goes first, as the default
+	for i, provider := range providers {
+		if provider.Name() == preferred {
+			providers[0], providers[i] = providers[i], providers[0]
+		}
+	}
+	if preferred != "" && providers[0].Name() != preferred {
+		log.Fatalf("PAYMENT_PROVIDER %q isn't configured", preferred)
+	}
+
+	Payments = payments.NewRegistry(providers...)
+	log.Printf("💳 Payments: %s, charging through %s by default", strings.Join(Payments.Names(), ", "), Payments.Names()[0])
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"crawl/repositories"
+	"crawl/search"
+	"fmt"
+	"gorm.io/gorm"
+	"log"
+	"os"
+	"strconv"
+)
+
+var Fuzzy repositories.FuzzySettings
+
+// SearchStore is the embedded search index, nil when searches run against PostgreSQL
+var SearchStore *search.DiskStore
+
+// LoadSearchSettings reads the fuzzy search thresholds, falling back to the defaults
+func LoadSearchSettings() {
+	Fuzzy = repositories.DefaultFuzzySettings
+	Fuzzy.MinSimilarity = similarityFromEnv("SEARCH_MIN_SIMILARITY", Fuzzy.MinSimilarity)
+	Fuzzy.SuggestBelow = similarityFromEnv("SEARCH_SUGGEST_BELOW", Fuzzy.SuggestBelow)
+	log.Printf("🔎 Fuzzy search: min similarity %.2f, suggest below %.2f", Fuzzy.MinSimilarity, Fuzzy.SuggestBelow)
+}
+
+// ConnectSearchIndex opens the embedded search index when SEARCH_BACKEND is
+// "embedded". The default "sql" backend needs nothing beyond the database.
+func ConnectSearchIndex() {
+	switch backend := os.Getenv("SEARCH_BACKEND"); backend {
+	case "", "sql":
+		log.Println("🔎 Search backend: PostgreSQL full-text")
+		return
+	case "embedded":
+	default:
+		log.Fatalf("SEARCH_BACKEND must be sql or embedded, got %q", backend)
+	}
+
+	path := SearchIndexPath()
+	store, err := search.OpenDiskStore(path)
+	if err != nil {
+		log.Fatal("Failed to open search index:", err)
+	}
+
+	SearchStore = store
+	log.Println("🔎 Search backend: embedded index at", path)
+}
+
+// SearchIndexPath is where the embedded search index lives
+func SearchIndexPath() string {
+	if path := os.Getenv("SEARCH_INDEX_PATH"); path != "" {
+		return path
+	}
+	return "search-index/index.db"
+}
+
+func similarityFromEnv(key string, fallback float64) float64 {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value < 0 || value > 1 {
+		log.Fatalf("%s must be a number between 0 and 1, got %q", key, raw)
+	}
+	return value
+}
+
+// searchVectorColumns maps each searchable table to the weighted tsvector expression
+// stored in its search_vector column. Weights: A for names and titles, C for descriptions.
+var searchVectorColumns = []struct {
+	Table      string
+	Expression string
+}{
+	{"songs", `setweight(to_tsvector('simple', search_unaccent(coalesce(title, ''))), 'A')`},
+	{"artists", `setweight(to_tsvector('simple', search_unaccent(coalesce(artist_name, ''))), 'A')`},
+	{"albums", `setweight(to_tsvector('simple', search_unaccent(coalesce(title, ''))), 'A') ||
+		setweight(to_tsvector('simple', search_unaccent(coalesce(description, ''))), 'C')`},
+	{"genres", `setweight(to_tsvector('simple', search_unaccent(coalesce(name, ''))), 'A') ||
+		setweight(to_tsvector('simple', search_unaccent(coalesce(description, ''))), 'C')`},
+	{"playlists", `setweight(to_tsvector('simple', search_unaccent(coalesce(title, ''))), 'A') ||
+		setweight(to_tsvector('simple', search_unaccent(coalesce(description, ''))), 'C')`},
+}
+
+// trigramColumns are the names fuzzy matching compares queries against, indexed
+// the way the match expressions normalise them
+var trigramColumns = []struct {
+	Table  string
+	Column string
+}{
+	{"songs", "title"},
+	{"artists", "artist_name"},
+	{"albums", "title"},
+	{"playlists", "title"},
+}
+
+// migrateSearch installs the full-text search columns and indexes and the pg_trgm
+// extension and indexes used for fuzzy matching. Generated columns need an
+// immutable function, so unaccent is wrapped with its dictionary pinned. The
+// trigram indexes serve the <% operator, which matches at the database's word
+// similarity threshold, so that is set to the configured minimum.
+func migrateSearch(db *gorm.DB, fuzzy repositories.FuzzySettings) error {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS unaccent`,
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+		`CREATE OR REPLACE FUNCTION search_unaccent(text) RETURNS text
+			LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+			AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$`,
+	}
+
+	for _, column := range searchVectorColumns {
+		statements = append(statements,
+			fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS search_vector tsvector
+				GENERATED ALWAYS AS (%s) STORED`, column.Table, column.Expression),
+			fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%s_search_vector ON %s USING GIN (search_vector)`,
+				column.Table, column.Table),
+		)
+	}
+
+	for _, column := range trigramColumns {
+		statements = append(statements,
+			fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%s_%s_trgm ON %s USING GIN (search_unaccent(lower(%s)) gin_trgm_ops)`,
+				column.Table, column.Column, column.Table, column.Column),
+		)
+	}
+
+	// New sessions pick the threshold up from the database; this one sets it too
+	threshold := strconv.FormatFloat(fuzzy.MinSimilarity, 'f', -1, 64)
+	statements = append(statements,
+		`DO $$ BEGIN EXECUTE format('ALTER DATABASE %I SET pg_trgm.word_similarity_threshold = '`+threshold+`, current_database()); END $$`,
+		`SET pg_trgm.word_similarity_threshold = `+threshold,
+	)
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
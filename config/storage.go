@@ -0,0 +1,25 @@
+package config
+
+import (
+	"crawl/storage"
+	"log"
+	"os"
+)
+
+var Blobs storage.BlobStore
+
+// ConnectStorage opens the blob store used for uploads and generated files
+func ConnectStorage() {
+	dir := os.Getenv("BLOB_STORAGE_DIR")
+	if dir == "" {
+		dir = "uploads"
+	}
+
+	store, err := storage.NewLocalStore(dir)
+	if err != nil {
+		log.Fatal("Failed to open blob storage:", err)
+	}
+
+	Blobs = store
+	log.Println("🗄️ Blob storage ready at", dir)
+}
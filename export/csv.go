@@ -0,0 +1,62 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// csvWriter writes RFC 4180 CSV with a header row. Nulls are empty fields,
+// timestamps RFC 3339 in UTC with microseconds and dates YYYY-MM-DD.
+type csvWriter struct {
+	schema Schema
+	w      *csv.Writer
+	record []string
+}
+
+func NewCSVWriter(w io.Writer, schema Schema) (RowWriter, error) {
+	header := make([]string, len(schema.Columns))
+	for i, column := range schema.Columns {
+		header[i] = column.Name
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvWriter{schema: schema, w: cw, record: make([]string, len(header))}, nil
+}
+
+func (w *csvWriter) Write(row []any) error {
+	if err := checkRow(w.schema, row); err != nil {
+		return err
+	}
+	for i, value := range row {
+		w.record[i] = formatCSV(w.schema.Columns[i].Type, value)
+	}
+	return w.w.Write(w.record)
+}
+
+func formatCSV(columnType ColumnType, value any) string {
+	if value == nil {
+		return ""
+	}
+	switch columnType {
+	case Int64:
+		return strconv.FormatInt(value.(int64), 10)
+	case Float64:
+		return strconv.FormatFloat(value.(float64), 'f', -1, 64)
+	case Bool:
+		return strconv.FormatBool(value.(bool))
+	case Timestamp:
+		return value.(time.Time).UTC().Format("2006-01-02T15:04:05.000000Z")
+	case Date:
+		return value.(time.Time).UTC().Format("2006-01-02")
+	}
+	return value.(string)
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
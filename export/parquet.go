@@ -0,0 +1,385 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+)
+
+// ParquetRowGroupSize is how many rows are buffered per row group before they
+// are written out, bounding the memory a file takes to write
+const ParquetRowGroupSize = 64 * 1024
+
+// Parquet physical types, converted types, encodings and page types, as
+// numbered in the format's parquet.thrift
+const (
+	parquetBoolean   = 0
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	convertedUTF8            = 0
+	convertedDate            = 6
+	convertedTimestampMicros = 10
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	pageData = 0
+)
+
+var parquetMagic = []byte("PAR1")
+
+// parquetColumn buffers a column's values in the current row group
+type parquetColumn struct {
+	values  bytes.Buffer // PLAIN-encoded values that aren't null
+	bools   []bool       // values of boolean columns, bit-packed when written
+	defined []bool       // definition levels of optional columns
+}
+
+type parquetChunk struct {
+	offset int64
+	size   int64
+	values int64
+}
+
+type parquetRowGroup struct {
+	chunks []parquetChunk
+	rows   int64
+	size   int64
+}
+
+// parquetWriter writes flat Parquet files: one PLAIN-encoded, uncompressed
+// data page per column chunk, with nulls in optional columns kept in RLE
+// definition levels. That is the simplest layout every reader takes.
+type parquetWriter struct {
+	w       io.Writer
+	schema  Schema
+	offset  int64
+	columns []parquetColumn
+	rows    int64 // in the current row group
+	total   int64
+	groups  []parquetRowGroup
+	err     error
+}
+
+func NewParquetWriter(w io.Writer, schema Schema) RowWriter {
+	pw := &parquetWriter{w: w, schema: schema, columns: make([]parquetColumn, len(schema.Columns))}
+	pw.write(parquetMagic)
+	return pw
+}
+
+// write passes p on, keeping the first error and the file offset
+func (w *parquetWriter) write(p []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.Write(p)
+	w.offset += int64(n)
+	w.err = err
+}
+
+func (w *parquetWriter) Write(row []any) error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := checkRow(w.schema, row); err != nil {
+		return err
+	}
+
+	for i, value := range row {
+		column := &w.columns[i]
+		if w.schema.Columns[i].Optional {
+			column.defined = append(column.defined, value != nil)
+		}
+		if value == nil {
+			continue
+		}
+		switch w.schema.Columns[i].Type {
+		case String:
+			s := value.(string)
+			column.values.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(s))))
+			column.values.WriteString(s)
+		case Int64:
+			column.values.Write(binary.LittleEndian.AppendUint64(nil, uint64(value.(int64))))
+		case Float64:
+			column.values.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(value.(float64))))
+		case Bool:
+			column.bools = append(column.bools, value.(bool))
+		case Timestamp:
+			column.values.Write(binary.LittleEndian.AppendUint64(nil, uint64(value.(time.Time).UnixMicro())))
+		case Date:
+			day := value.(time.Time).UTC()
+			days := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+			column.values.Write(binary.LittleEndian.AppendUint32(nil, uint32(int32(days))))
+		}
+	}
+
+	w.rows++
+	w.total++
+	if w.rows >= ParquetRowGroupSize {
+		w.flushRowGroup()
+	}
+	return w.err
+}
+
+// flushRowGroup writes the buffered rows as a row group
+func (w *parquetWriter) flushRowGroup() {
+	if w.rows == 0 {
+		return
+	}
+	group := parquetRowGroup{rows: w.rows}
+	for i := range w.columns {
+		column := &w.columns[i]
+
+		var page bytes.Buffer
+		if w.schema.Columns[i].Optional {
+			levels := rleLevels(column.defined)
+			page.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(levels))))
+			page.Write(levels)
+		}
+		if w.schema.Columns[i].Type == Bool {
+			page.Write(packBools(column.bools))
+		} else {
+			page.Write(column.values.Bytes())
+		}
+
+		var header thriftWriter
+		header.i32(1, pageData)
+		header.i32(2, int32(page.Len()))
+		header.i32(3, int32(page.Len()))
+		header.structBegin(5)
+		header.i32(1, int32(w.rows))
+		header.i32(2, encodingPlain)
+		header.i32(3, encodingRLE)
+		header.i32(4, encodingRLE)
+		header.structEnd()
+		header.stop()
+
+		chunk := parquetChunk{offset: w.offset, values: w.rows}
+		w.write(header.buf)
+		w.write(page.Bytes())
+		chunk.size = w.offset - chunk.offset
+		group.chunks = append(group.chunks, chunk)
+		group.size += chunk.size
+
+		column.values.Reset()
+		column.bools = column.bools[:0]
+		column.defined = column.defined[:0]
+	}
+	w.groups = append(w.groups, group)
+	w.rows = 0
+}
+
+// Close writes the last row group and the footer holding the file metadata
+func (w *parquetWriter) Close() error {
+	w.flushRowGroup()
+	if w.err != nil {
+		return w.err
+	}
+
+	var meta thriftWriter
+	meta.i32(1, 1)
+
+	meta.listBegin(2, thriftStruct, len(w.schema.Columns)+1)
+	meta.elemBegin()
+	meta.binary(4, w.schema.Name)
+	meta.i32(5, int32(len(w.schema.Columns)))
+	meta.elemEnd()
+	for _, column := range w.schema.Columns {
+		physical, converted := parquetTypes(column.Type)
+		repetition := repetitionRequired
+		if column.Optional {
+			repetition = repetitionOptional
+		}
+		meta.elemBegin()
+		meta.i32(1, physical)
+		meta.i32(3, int32(repetition))
+		meta.binary(4, column.Name)
+		if converted >= 0 {
+			meta.i32(6, converted)
+		}
+		meta.elemEnd()
+	}
+
+	meta.i64(3, w.total)
+
+	meta.listBegin(4, thriftStruct, len(w.groups))
+	for _, group := range w.groups {
+		meta.elemBegin()
+		meta.listBegin(1, thriftStruct, len(group.chunks))
+		for i, chunk := range group.chunks {
+			physical, _ := parquetTypes(w.schema.Columns[i].Type)
+			meta.elemBegin()
+			meta.i64(2, chunk.offset)
+			meta.structBegin(3)
+			meta.i32(1, physical)
+			meta.listBegin(2, thriftI32, 2)
+			meta.elemI32(encodingPlain)
+			meta.elemI32(encodingRLE)
+			meta.listBegin(3, thriftBinary, 1)
+			meta.elemBinary(w.schema.Columns[i].Name)
+			meta.i32(4, 0) // uncompressed
+			meta.i64(5, chunk.values)
+			meta.i64(6, chunk.size)
+			meta.i64(7, chunk.size)
+			meta.i64(9, chunk.offset)
+			meta.structEnd()
+			meta.elemEnd()
+		}
+		meta.i64(2, group.size)
+		meta.i64(3, group.rows)
+		meta.elemEnd()
+	}
+
+	meta.binary(6, "crawl analytics export")
+	meta.stop()
+
+	w.write(meta.buf)
+	w.write(binary.LittleEndian.AppendUint32(nil, uint32(len(meta.buf))))
+	w.write(parquetMagic)
+	return w.err
+}
+
+// parquetTypes returns the physical and converted type of a column type; the
+// converted type is -1 when there is none
+func parquetTypes(columnType ColumnType) (int32, int32) {
+	switch columnType {
+	case Int64:
+		return parquetInt64, -1
+	case Float64:
+		return parquetDouble, -1
+	case Bool:
+		return parquetBoolean, -1
+	case Timestamp:
+		return parquetInt64, convertedTimestampMicros
+	case Date:
+		return parquetInt32, convertedDate
+	}
+	return parquetByteArray, convertedUTF8
+}
+
+// rleLevels encodes definition levels of bit width 1 as runs of the RLE /
+// bit-packing hybrid encoding
+func rleLevels(levels []bool) []byte {
+	var out []byte
+	for start := 0; start < len(levels); {
+		end := start
+		for end < len(levels) && levels[end] == levels[start] {
+			end++
+		}
+		out = binary.AppendUvarint(out, uint64(end-start)<<1)
+		if levels[start] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		start = end
+	}
+	return out
+}
+
+// packBools bit-packs PLAIN boolean values, first value in the lowest bit
+func packBools(values []bool) []byte {
+	out := make([]byte, (len(values)+7)/8)
+	for i, value := range values {
+		if value {
+			out[i/8] |= 1 << (i % 8)
+		}
+	}
+	return out
+}
+
+// Thrift compact protocol type IDs
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes structs in the Thrift compact protocol, the encoding of
+// Parquet's page headers and file metadata. Fields must be written in
+// increasing ID order.
+type thriftWriter struct {
+	buf    []byte
+	last   int16
+	parent []int16
+}
+
+func (t *thriftWriter) field(id int16, fieldType byte) {
+	if delta := id - t.last; delta > 0 && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|fieldType)
+	} else {
+		t.buf = append(t.buf, fieldType)
+		t.buf = binary.AppendVarint(t.buf, int64(id))
+	}
+	t.last = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.buf = binary.AppendVarint(t.buf, int64(v))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.buf = binary.AppendVarint(t.buf, v)
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.elemBinary(s)
+}
+
+func (t *thriftWriter) structBegin(id int16) {
+	t.field(id, thriftStruct)
+	t.elemBegin()
+}
+
+func (t *thriftWriter) structEnd() {
+	t.elemEnd()
+}
+
+func (t *thriftWriter) listBegin(id int16, elemType byte, size int) {
+	t.field(id, thriftList)
+	if size < 15 {
+		t.buf = append(t.buf, byte(size)<<4|elemType)
+	} else {
+		t.buf = append(t.buf, 0xf0|elemType)
+		t.buf = binary.AppendUvarint(t.buf, uint64(size))
+	}
+}
+
+// elemBegin starts a struct inside a list
+func (t *thriftWriter) elemBegin() {
+	t.parent = append(t.parent, t.last)
+	t.last = 0
+}
+
+func (t *thriftWriter) elemEnd() {
+	t.stop()
+	t.last = t.parent[len(t.parent)-1]
+	t.parent = t.parent[:len(t.parent)-1]
+}
+
+func (t *thriftWriter) elemI32(v int32) {
+	t.buf = binary.AppendVarint(t.buf, int64(v))
+}
+
+func (t *thriftWriter) elemBinary(s string) {
+	t.buf = binary.AppendUvarint(t.buf, uint64(len(s)))
+	t.buf = append(t.buf, s...)
+}
+
+// stop ends the outermost struct
+func (t *thriftWriter) stop() {
+	t.buf = append(t.buf, 0)
+}
@@ -0,0 +1,371 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// thriftReader decodes the Thrift compact protocol into structs of field ID to
+// value, lists, int64s and strings, enough to read back what parquetWriter writes
+type thriftReader struct {
+	buf []byte
+	pos int
+	t   *testing.T
+}
+
+func (r *thriftReader) byte() byte {
+	if r.pos >= len(r.buf) {
+		r.t.Fatalf("thrift: read past the end at %d", r.pos)
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) varint() int64 {
+	v, n := binary.Varint(r.buf[r.pos:])
+	if n <= 0 {
+		r.t.Fatalf("thrift: bad varint at %d", r.pos)
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 {
+		r.t.Fatalf("thrift: bad uvarint at %d", r.pos)
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) value(fieldType byte) any {
+	switch fieldType {
+	case thriftI32, thriftI64:
+		return r.varint()
+	case thriftBinary:
+		size := int(r.uvarint())
+		s := string(r.buf[r.pos : r.pos+size])
+		r.pos += size
+		return s
+	case thriftList:
+		header := r.byte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]any, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0f)
+		}
+		return list
+	case thriftStruct:
+		return r.readStruct()
+	}
+	r.t.Fatalf("thrift: unexpected type %d at %d", fieldType, r.pos)
+	return nil
+}
+
+func (r *thriftReader) readStruct() map[int16]any {
+	fields := map[int16]any{}
+	var last int16
+	for {
+		header := r.byte()
+		if header == 0 {
+			return fields
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.varint())
+		}
+		fields[id] = r.value(header & 0x0f)
+		last = id
+	}
+}
+
+// readParquet reads back the rows of a file, checking its framing, and returns
+// them with the file metadata
+func readParquet(t *testing.T, file []byte, schema Schema) ([][]any, map[int16]any) {
+	t.Helper()
+	if !bytes.HasPrefix(file, parquetMagic) || !bytes.HasSuffix(file, parquetMagic) {
+		t.Fatalf("file isn't framed by %q", parquetMagic)
+	}
+	footer := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	metaStart := len(file) - 8 - footer
+	meta := (&thriftReader{buf: file[:len(file)-8], pos: metaStart, t: t}).readStruct()
+
+	var rows [][]any
+	for _, group := range meta[4].([]any) {
+		group := group.(map[int16]any)
+		groupRows := make([][]any, group[3].(int64))
+		for i := range groupRows {
+			groupRows[i] = make([]any, len(schema.Columns))
+		}
+		for c, chunk := range group[1].([]any) {
+			column := schema.Columns[c]
+			chunkMeta := chunk.(map[int16]any)[3].(map[int16]any)
+			reader := &thriftReader{buf: file, pos: int(chunkMeta[9].(int64)), t: t}
+			header := reader.readStruct()
+			page := file[reader.pos : reader.pos+int(header[3].(int64))]
+
+			defined := make([]bool, len(groupRows))
+			for i := range defined {
+				defined[i] = true
+			}
+			if column.Optional {
+				size := int(binary.LittleEndian.Uint32(page))
+				levels := &thriftReader{buf: page[4 : 4+size], t: t}
+				for i := 0; levels.pos < len(levels.buf); {
+					run := int(levels.uvarint() >> 1)
+					value := levels.byte() == 1
+					for ; run > 0; run-- {
+						defined[i] = value
+						i++
+					}
+				}
+				page = page[4+size:]
+			}
+
+			var values int
+			for r, isDefined := range defined {
+				if !isDefined {
+					continue
+				}
+				var value any
+				switch column.Type {
+				case String:
+					size := int(binary.LittleEndian.Uint32(page))
+					value = string(page[4 : 4+size])
+					page = page[4+size:]
+				case Int64:
+					value = int64(binary.LittleEndian.Uint64(page))
+					page = page[8:]
+				case Float64:
+					value = math.Float64frombits(binary.LittleEndian.Uint64(page))
+					page = page[8:]
+				case Bool:
+					value = page[values/8]&(1<<(values%8)) != 0
+				case Timestamp:
+					value = time.UnixMicro(int64(binary.LittleEndian.Uint64(page))).UTC()
+					page = page[8:]
+				case Date:
+					days := int32(binary.LittleEndian.Uint32(page))
+					value = time.Unix(int64(days)*86400, 0).UTC()
+					page = page[4:]
+				}
+				groupRows[r][c] = value
+				values++
+			}
+		}
+		rows = append(rows, groupRows...)
+	}
+	return rows, meta
+}
+
+func TestParquetWriter(t *testing.T) {
+	at := time.Date(2026, 3, 14, 15, 9, 26, 535897000, time.FixedZone("WAT", 3600))
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	allTypes := Schema{Name: "all", Columns: []Column{
+		{Name: "s", Type: String},
+		{Name: "i", Type: Int64},
+		{Name: "f", Type: Float64},
+		{Name: "b", Type: Bool},
+		{Name: "ts", Type: Timestamp},
+		{Name: "d", Type: Date},
+	}}
+	optional := Schema{Name: "optional", Columns: []Column{
+		{Name: "id", Type: Int64},
+		{Name: "note", Type: String, Optional: true},
+		{Name: "flag", Type: Bool, Optional: true},
+	}}
+	flags := Schema{Name: "flags", Columns: []Column{{Name: "flag", Type: Bool}}}
+	counts := Schema{Name: "counts", Columns: []Column{{Name: "n", Type: Int64}}}
+
+	manyFlags := make([][]any, 19)
+	for i := range manyFlags {
+		manyFlags[i] = []any{i%3 == 0}
+	}
+	manyCounts := make([][]any, ParquetRowGroupSize+2)
+	for i := range manyCounts {
+		manyCounts[i] = []any{int64(i)}
+	}
+
+	tests := []struct {
+		name   string
+		schema Schema
+		rows   [][]any
+		// want is the rows read back, when they differ from rows
+		want   [][]any
+		groups int
+	}{
+		{
+			name:   "every type",
+			schema: allTypes,
+			rows: [][]any{
+				{"héllo", int64(-42), 3.25, true, at, day.Add(20 * time.Hour)},
+				{"", int64(math.MaxInt64), -0.5, false, day, day},
+			},
+			want: [][]any{
+				{"héllo", int64(-42), 3.25, true, at.UTC().Truncate(time.Microsecond), day},
+				{"", int64(math.MaxInt64), -0.5, false, day, day},
+			},
+			groups: 1,
+		},
+		{
+			name:   "nulls in runs",
+			schema: optional,
+			rows: [][]any{
+				{int64(1), nil, nil},
+				{int64(2), nil, true},
+				{int64(3), "a", false},
+				{int64(4), "b", nil},
+				{int64(5), nil, true},
+			},
+			groups: 1,
+		},
+		{
+			name:   "booleans past a byte",
+			schema: flags,
+			rows:   manyFlags,
+			groups: 1,
+		},
+		{
+			name:   "no rows",
+			schema: counts,
+			groups: 0,
+		},
+		{
+			name:   "rows past a row group",
+			schema: counts,
+			rows:   manyCounts,
+			groups: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var file bytes.Buffer
+			writer := NewParquetWriter(&file, tt.schema)
+			for _, row := range tt.rows {
+				if err := writer.Write(row); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			rows, meta := readParquet(t, file.Bytes(), tt.schema)
+			if got := meta[3].(int64); got != int64(len(tt.rows)) {
+				t.Errorf("num_rows %d, want %d", got, len(tt.rows))
+			}
+			if got := len(meta[4].([]any)); got != tt.groups {
+				t.Errorf("%d row groups, want %d", got, tt.groups)
+			}
+
+			elements := meta[2].([]any)
+			if len(elements) != len(tt.schema.Columns)+1 {
+				t.Fatalf("%d schema elements, want %d", len(elements), len(tt.schema.Columns)+1)
+			}
+			for i, column := range tt.schema.Columns {
+				element := elements[i+1].(map[int16]any)
+				repetition := int64(repetitionRequired)
+				if column.Optional {
+					repetition = repetitionOptional
+				}
+				if element[4] != column.Name || element[3] != repetition {
+					t.Errorf("schema element %d is %v, want %s with repetition %d", i, element, column.Name, repetition)
+				}
+			}
+
+			want := tt.want
+			if want == nil {
+				want = tt.rows
+			}
+			if len(rows) != len(want) {
+				t.Fatalf("read %d rows, want %d", len(rows), len(want))
+			}
+			for i := range want {
+				if !reflect.DeepEqual(rows[i], want[i]) {
+					t.Errorf("row %d: got %v, want %v", i, rows[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParquetWriterRejectsRows(t *testing.T) {
+	schema := Schema{Name: "plays", Columns: []Column{
+		{Name: "id", Type: String},
+		{Name: "listened", Type: Int64, Optional: true},
+	}}
+
+	tests := []struct {
+		name string
+		row  []any
+		want string
+	}{
+		{name: "too few columns", row: []any{"a"}, want: "plays rows have 2 columns, got 1"},
+		{name: "null in a required column", row: []any{nil, int64(1)}, want: "plays.id can't be null"},
+		{name: "wrong type", row: []any{"a", 1}, want: "plays.listened can't hold a int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := NewParquetWriter(&bytes.Buffer{}, schema)
+			err := writer.Write(tt.row)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRLELevels(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels []bool
+		want   []byte
+	}{
+		{name: "none", levels: nil, want: nil},
+		{name: "one run", levels: []bool{true, true, true}, want: []byte{3 << 1, 1}},
+		{name: "alternating runs", levels: []bool{false, false, true, false}, want: []byte{2 << 1, 0, 1 << 1, 1, 1 << 1, 0}},
+		{name: "long run", levels: make([]bool, 100), want: []byte{0xc8, 0x01, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rleLevels(tt.levels); !bytes.Equal(got, tt.want) {
+				t.Errorf("got %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackBools(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []bool
+		want   []byte
+	}{
+		{name: "none", values: nil, want: []byte{}},
+		{name: "first in the lowest bit", values: []bool{true, false, false}, want: []byte{0x01}},
+		{name: "full byte", values: []bool{false, true, false, true, false, true, false, true}, want: []byte{0xaa}},
+		{name: "past a byte", values: []bool{true, true, true, true, true, true, true, true, true}, want: []byte{0xff, 0x01}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := packBools(tt.values); !bytes.Equal(got, tt.want) {
+				t.Errorf("got %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Package export writes tables of analytics data as CSV and Parquet files with
+// fixed schemas, so downstream tools can load every export the same way.
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// ColumnType is the type of a column's values
+type ColumnType int
+
+const (
+	String    ColumnType = iota // string
+	Int64                       // int64
+	Float64                     // float64
+	Bool                        // bool
+	Timestamp                   // time.Time, kept to the microsecond in UTC
+	Date                        // time.Time, its UTC day
+)
+
+// Column is one field of every row; Optional columns also take nil
+type Column struct {
+	Name     string
+	Type     ColumnType
+	Optional bool
+}
+
+// Schema names a table and its columns, in file order. Columns are only ever
+// added at the end, so files written by older code keep loading.
+type Schema struct {
+	Name    string
+	Columns []Column
+}
+
+// RowWriter writes rows of a schema to a file. Each row holds one value per
+// column, of the column's type. Close finishes the file without closing the
+// underlying writer.
+type RowWriter interface {
+	Write(row []any) error
+	Close() error
+}
+
+// Formats files can be written in
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+// NewWriter starts a file in format
+func NewWriter(format string, w io.Writer, schema Schema) (RowWriter, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w, schema)
+	case FormatParquet:
+		return NewParquetWriter(w, schema), nil
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// checkRow reports a row that doesn't fit the schema
+func checkRow(schema Schema, row []any) error {
+	if len(row) != len(schema.Columns) {
+		return fmt.Errorf("%s rows have %d columns, got %d", schema.Name, len(schema.Columns), len(row))
+	}
+	for i, column := range schema.Columns {
+		value := row[i]
+		if value == nil {
+			if !column.Optional {
+				return fmt.Errorf("%s.%s can't be null", schema.Name, column.Name)
+			}
+			continue
+		}
+		ok := false
+		switch column.Type {
+		case String:
+			_, ok = value.(string)
+		case Int64:
+			_, ok = value.(int64)
+		case Float64:
+			_, ok = value.(float64)
+		case Bool:
+			_, ok = value.(bool)
+		case Timestamp, Date:
+			_, ok = value.(time.Time)
+		}
+		if !ok {
+			return fmt.Errorf("%s.%s can't hold a %T", schema.Name, column.Name, value)
+		}
+	}
+	return nil
+}
+
+// String names the type in manifests
+func (t ColumnType) String() string {
+	switch t {
+	case Int64:
+		return "int64"
+	case Float64:
+		return "double"
+	case Bool:
+		return "bool"
+	case Timestamp:
+		return "timestamp"
+	case Date:
+		return "date"
+	}
+	return "string"
+}
@@ -0,0 +1,263 @@
+// Package geoip resolves where clients are from their IP address, using a local
+// MaxMind-format database such as GeoLite2 Country or City. The database file
+// can be replaced while the server runs; Reload picks up the new one.
+package geoip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// DefaultProxyHeader carries the client address through proxies
+const DefaultProxyHeader = "X-Forwarded-For"
+
+type Config struct {
+	// DBPath is the MaxMind-format database file; without one no address resolves
+	DBPath string
+	// TrustedProxies are the IPs or CIDR ranges of the proxies in front of the
+	// server. The proxy header is only believed from them.
+	TrustedProxies []string
+	// ProxyHeader lists the addresses a request was forwarded for, the client first
+	ProxyHeader string
+}
+
+// Location is where an address is, as ISO 3166 codes
+type Location struct {
+	CountryCode string // ISO 3166-1 alpha-2, e.g. "NG"
+	Region      string // ISO 3166-2 subdivision, e.g. "NG-LA", on databases that have them
+}
+
+// record holds the fields read from Country and City databases
+type record struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	// RegisteredCountry stands in for addresses without a country, such as anycast ones
+	RegisteredCountry struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"registered_country"`
+	Subdivisions []struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"subdivisions"`
+}
+
+// Resolver finds the client address of requests and where it is. It is safe
+// for concurrent use.
+type Resolver struct {
+	path    string
+	header  string
+	trusted []*net.IPNet
+
+	mu      sync.RWMutex
+	reader  *maxminddb.Reader
+	modTime time.Time
+	size    int64
+}
+
+// NewResolver opens the database in cfg, if any. A database that can't be
+// opened is an error, so a bad path is noticed on startup.
+func NewResolver(cfg Config) (*Resolver, error) {
+	r := &Resolver{path: cfg.DBPath, header: cfg.ProxyHeader}
+	if r.header == "" {
+		r.header = DefaultProxyHeader
+	}
+	for _, proxy := range cfg.TrustedProxies {
+		network, err := parseNetwork(proxy)
+		if err != nil {
+			return nil, err
+		}
+		r.trusted = append(r.trusted, network)
+	}
+	if r.path != "" {
+		if err := r.load(); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// parseNetwork reads an IP or CIDR range; a lone IP is a range of one
+func parseNetwork(proxy string) (*net.IPNet, error) {
+	proxy = strings.TrimSpace(proxy)
+	if !strings.Contains(proxy, "/") {
+		ip := net.ParseIP(proxy)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+		}
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, network, err := net.ParseCIDR(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+	}
+	return network, nil
+}
+
+// load opens the database file and swaps it in for the one in use
+func (r *Resolver) load() error {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return err
+	}
+	reader, err := maxminddb.Open(r.path)
+	if err != nil {
+		return fmt.Errorf("open GeoIP database %s: %w", r.path, err)
+	}
+
+	r.mu.Lock()
+	previous := r.reader
+	r.reader, r.modTime, r.size = reader, info.ModTime(), info.Size()
+	r.mu.Unlock()
+
+	// Lookups hold the read lock, so none is still using the previous database
+	if previous != nil {
+		return previous.Close()
+	}
+	return nil
+}
+
+// Reload swaps in the database file when it changed since it was opened. A
+// file that fails to open leaves the database in use in place. Its signature
+// lets it run as a scheduled job.
+func (r *Resolver) Reload(ctx context.Context) error {
+	if r.path == "" {
+		return nil
+	}
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	changed := !info.ModTime().Equal(r.modTime) || info.Size() != r.size
+	r.mu.RUnlock()
+	if !changed {
+		return nil
+	}
+
+	if err := r.load(); err != nil {
+		return err
+	}
+	log.Println("🌍 Reloaded GeoIP database from", r.path)
+	return nil
+}
+
+// Loaded reports whether a database is open
+func (r *Resolver) Loaded() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.reader != nil
+}
+
+// ProxyHeader is the header listing the addresses a request was forwarded for
+func (r *Resolver) ProxyHeader() string {
+	return r.header
+}
+
+func (r *Resolver) isTrusted(ip net.IP) bool {
+	for _, network := range r.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the address of the client behind a request that reached the
+// server from remote with forwarded in its proxy header. The header is read
+// from the right, skipping trusted proxies, so addresses a client puts in it
+// themselves are never taken. Requests from untrusted peers are their own client.
+func (r *Resolver) ClientIP(remote, forwarded string) string {
+	remoteIP := net.ParseIP(remote)
+	if remoteIP == nil || !r.isTrusted(remoteIP) || forwarded == "" {
+		return remote
+	}
+
+	client := remote
+	hops := strings.Split(forwarded, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			// Whatever comes before a malformed entry can't be trusted
+			break
+		}
+		client = hop.String()
+		if !r.isTrusted(hop) {
+			break
+		}
+	}
+	return client
+}
+
+// Lookup returns where ip is, and false when it's unknown or no database is open
+func (r *Resolver) Lookup(ip string) (Location, bool) {
+	address := net.ParseIP(ip)
+	if address == nil {
+		return Location{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.reader == nil {
+		return Location{}, false
+	}
+
+	var found record
+	if err := r.reader.Lookup(address, &found); err != nil {
+		return Location{}, false
+	}
+
+	location := Location{CountryCode: found.Country.ISOCode}
+	if location.CountryCode == "" {
+		location.CountryCode = found.RegisteredCountry.ISOCode
+	}
+	if location.CountryCode == "" {
+		return Location{}, false
+	}
+	if len(found.Subdivisions) > 0 && found.Subdivisions[0].ISOCode != "" {
+		location.Region = location.CountryCode + "-" + found.Subdivisions[0].ISOCode
+	}
+	return location, true
+}
+
+// Locate returns where ip is. Without a database, or for addresses it doesn't
+// know, the location is unknown; what the client claims is never taken for it.
+func (r *Resolver) Locate(ip string) Location {
+	location, _ := r.Lookup(ip)
+	return location
+}
+
+// ClaimedCountry reads a country a client claims to be in, returning "" when it
+// doesn't look like a country code. The claim is unverified, so it's kept only
+// as metadata next to the resolved location.
+func ClaimedCountry(claimed string) string {
+	claimed = strings.ToUpper(strings.TrimSpace(claimed))
+	if len(claimed) != 2 || claimed[0] < 'A' || claimed[0] > 'Z' || claimed[1] < 'A' || claimed[1] > 'Z' {
+		return ""
+	}
+	return claimed
+}
+
+// Close closes the database
+func (r *Resolver) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reader == nil {
+		return nil
+	}
+	err := r.reader.Close()
+	r.reader = nil
+	return err
+}
@@ -0,0 +1,57 @@
+package geoip
+
+import "testing"
+
+func TestClientIP(t *testing.T) {
+	resolver, err := NewResolver(Config{TrustedProxies: []string{"10.0.0.0/8", "192.168.1.1", "fd00::/8"}})
+	if err != nil {
+		t.Fatalf("NewResolver: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded string
+		want      string
+	}{
+		{name: "untrusted peer with a header", remote: "203.0.113.9", forwarded: "198.51.100.7", want: "203.0.113.9"},
+		{name: "trusted proxy without a header", remote: "10.0.0.2", want: "10.0.0.2"},
+		{name: "trusted proxy", remote: "10.0.0.2", forwarded: "198.51.100.7", want: "198.51.100.7"},
+		{name: "lone trusted address", remote: "192.168.1.1", forwarded: "198.51.100.7", want: "198.51.100.7"},
+		{name: "chain of trusted proxies", remote: "10.0.0.2", forwarded: "198.51.100.7, 10.1.1.1, 10.0.0.3", want: "198.51.100.7"},
+		{name: "address spoofed by the client", remote: "10.0.0.2", forwarded: "1.2.3.4, 198.51.100.7", want: "198.51.100.7"},
+		{name: "malformed hop", remote: "10.0.0.2", forwarded: "1.2.3.4, junk, 10.0.0.3", want: "10.0.0.3"},
+		{name: "every hop trusted", remote: "10.0.0.2", forwarded: "10.0.0.5, 10.0.0.3", want: "10.0.0.5"},
+		{name: "ipv6", remote: "fd00::1", forwarded: "2001:db8::1", want: "2001:db8::1"},
+		{name: "unparseable peer", remote: "not-an-ip", forwarded: "198.51.100.7", want: "not-an-ip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolver.ClientIP(tt.remote, tt.forwarded); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewResolverTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxies []string
+		wantErr bool
+	}{
+		{name: "addresses and ranges", proxies: []string{"10.0.0.1", " 172.16.0.0/12 ", "::1"}},
+		{name: "bad address", proxies: []string{"10.0.0.300"}, wantErr: true},
+		{name: "bad range", proxies: []string{"10.0.0.0/33"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewResolver(Config{TrustedProxies: tt.proxies})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
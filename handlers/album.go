@@ -3,12 +3,15 @@ package handlers
 import (
 	"crawl/api"
 	"crawl/models"
+	"crawl/services"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/oapi-codegen/runtime/types"
 )
 
 func (h *Handlers) GetAlbums(c *fiber.Ctx, params api.GetAlbumsParams) error {
-	albums, err := h.Album.GetAllAlbums(c.Context(), params)
+	// Albums that can't be played where the client is are left out
+	albums, err := h.Album.GetAllAlbums(c.Context(), params, h.clientLocation(c).CountryCode)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -37,9 +40,8 @@ func (h *Handlers) PostAlbums(c *fiber.Ctx) error {
 		})
 	}
 
-	// Verify the requesting user is the artist
-	artist, err := h.Artist.GetArtistByID(c.Context(), userID)
-	if err != nil || artist.ID != albumReq.ArtistId {
+	// Verify the requesting user is the artist or manages them through a label
+	if !h.canActForArtist(c, userID, albumReq.ArtistId, models.LabelPermissionCatalog) {
 		return c.Status(fiber.StatusForbidden).JSON(api.Error{
 			Code:    fiber.StatusForbidden,
 			Message: "You can only create albums for yourself",
@@ -80,7 +82,7 @@ func (h *Handlers) PostAlbums(c *fiber.Ctx) error {
 		modelAlbum.ID = *albumReq.Id
 	}
 
-	createdAlbum, err := h.Album.CreateAlbum(c.Context(), modelAlbum)
+	createdAlbum, err := h.Album.CreateAlbum(actorContext(c, userID), modelAlbum)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -100,6 +102,10 @@ func (h *Handlers) GetAlbumsAlbumId(c *fiber.Ctx, albumId types.UUID) error {
 		})
 	}
 
+	if err := h.Territory.CheckAlbum(c.Context(), albumId, h.clientLocation(c).CountryCode); err != nil {
+		return territoryError(c, err, "Failed to fetch album")
+	}
+
 	return c.JSON(album)
 }
 
@@ -120,7 +126,7 @@ func (h *Handlers) PutAlbumsAlbumId(c *fiber.Ctx, albumId types.UUID) error {
 		})
 	}
 
-	// Verify the requesting user is the album's artist
+	// Verify the requesting user is the album's artist or manages them through a label
 	album, err := h.Album.GetAlbumByID(c.Context(), albumId)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(api.Error{
@@ -129,8 +135,7 @@ func (h *Handlers) PutAlbumsAlbumId(c *fiber.Ctx, albumId types.UUID) error {
 		})
 	}
 
-	artist, err := h.Artist.GetArtistByID(c.Context(), userID)
-	if err != nil || artist.ID != album.ArtistID {
+	if !h.canActForArtist(c, userID, album.ArtistID, models.LabelPermissionCatalog) {
 		return c.Status(fiber.StatusForbidden).JSON(api.Error{
 			Code:    fiber.StatusForbidden,
 			Message: "You can only update your own albums",
@@ -172,7 +177,7 @@ func (h *Handlers) PutAlbumsAlbumId(c *fiber.Ctx, albumId types.UUID) error {
 		modelAlbum.ID = *albumReq.Id
 	}
 
-	updatedAlbum, err := h.Album.UpdateAlbum(c.Context(), albumId, modelAlbum)
+	updatedAlbum, err := h.Album.UpdateAlbum(actorContext(c, userID), albumId, modelAlbum)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -192,7 +197,7 @@ func (h *Handlers) DeleteAlbumsAlbumId(c *fiber.Ctx, albumId types.UUID) error {
 		})
 	}
 
-	// Verify the requesting user is the album's artist
+	// Verify the requesting user is the album's artist or manages them through a label
 	album, err := h.Album.GetAlbumByID(c.Context(), albumId)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(api.Error{
@@ -201,15 +206,14 @@ func (h *Handlers) DeleteAlbumsAlbumId(c *fiber.Ctx, albumId types.UUID) error {
 		})
 	}
 
-	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
-	if err != nil || artist.ID != album.ArtistID {
+	if !h.canActForArtist(c, userID, album.ArtistID, models.LabelPermissionCatalog) {
 		return c.Status(fiber.StatusForbidden).JSON(api.Error{
 			Code:    fiber.StatusForbidden,
 			Message: "You can only delete your own albums",
 		})
 	}
 
-	if err := h.Album.DeleteAlbum(c.Context(), albumId); err != nil {
+	if err := h.Album.DeleteAlbum(actorContext(c, userID), albumId); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
 			Message: "Failed to delete album",
@@ -220,7 +224,8 @@ func (h *Handlers) DeleteAlbumsAlbumId(c *fiber.Ctx, albumId types.UUID) error {
 }
 
 func (h *Handlers) GetAlbumsAlbumIdSongs(c *fiber.Ctx, albumId types.UUID) error {
-	songs, err := h.Album.GetAlbumSongs(c.Context(), albumId)
+	// Songs that can't be played where the client is are left out
+	songs, err := h.Album.GetAlbumSongs(c.Context(), albumId, h.clientLocation(c).CountryCode)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -260,7 +265,7 @@ func (h *Handlers) PostAlbumsAlbumIdContributors(c *fiber.Ctx, albumId types.UUI
 		})
 	}
 
-	// Verify the requesting user is the album's artist
+	// Verify the requesting user is the album's artist or manages them through a label
 	album, err := h.Album.GetAlbumByID(c.Context(), albumId)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(api.Error{
@@ -269,8 +274,7 @@ func (h *Handlers) PostAlbumsAlbumIdContributors(c *fiber.Ctx, albumId types.UUI
 		})
 	}
 
-	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
-	if err != nil || artist.ID != album.ArtistID {
+	if !h.canActForArtist(c, userID, album.ArtistID, models.LabelPermissionCatalog) {
 		return c.Status(fiber.StatusForbidden).JSON(api.Error{
 			Code:    fiber.StatusForbidden,
 			Message: "You can only add contributors to your own albums",
@@ -289,7 +293,7 @@ func (h *Handlers) PostAlbumsAlbumIdContributors(c *fiber.Ctx, albumId types.UUI
 	contributor := &models.AlbumContributor{
 		AlbumID:          album.ID,
 		ArtistID:         contributorReq.ArtistId,
-		ContributionType: contributorReq.ContributionType,
+		ContributionType: string(contributorReq.ContributionType),
 	}
 
 	if contributorReq.RoyaltyPercentage != nil {
@@ -298,6 +302,12 @@ func (h *Handlers) PostAlbumsAlbumIdContributors(c *fiber.Ctx, albumId types.UUI
 
 	err = h.Album.AddAlbumContributor(c.Context(), albumId, contributor)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidCreditRole) || errors.Is(err, services.ErrPrimaryArtistCredit) {
+			return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+				Code:    fiber.StatusBadRequest,
+				Message: err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
 			Message: "Failed to add contributor",
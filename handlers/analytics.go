@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"crawl/api"
+	"crawl/models"
+	"crawl/services"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+func (h *Handlers) GetArtistsArtistIdAnalytics(c *fiber.Ctx, artistId types.UUID, params api.GetArtistsArtistIdAnalyticsParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.canActForArtist(c, userID, artistId, models.LabelPermissionAnalytics) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You don't have access to this artist's analytics",
+		})
+	}
+
+	span := services.AnalyticsRange{}
+	if params.From != nil {
+		span.From = &params.From.Time
+	}
+	if params.To != nil {
+		span.To = &params.To.Time
+	}
+	if params.Days != nil {
+		span.Days = *params.Days
+	}
+	if params.Granularity != nil {
+		span.Granularity = string(*params.Granularity)
+	}
+
+	analytics, err := h.Analytics.GetArtistAnalytics(c.Context(), artistId, span)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidAnalyticsRange), errors.Is(err, services.ErrInvalidGranularity):
+			return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+				Code:    fiber.StatusBadRequest,
+				Message: err.Error(),
+			})
+		case errors.Is(err, services.ErrArtistNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(api.Error{
+				Code:    fiber.StatusNotFound,
+				Message: "Artist not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to fetch analytics",
+		})
+	}
+
+	return c.JSON(analytics)
+}
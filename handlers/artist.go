@@ -3,6 +3,8 @@ package handlers
 import (
 	"crawl/api"
 	"crawl/models"
+	"crawl/services"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/oapi-codegen/runtime/types"
 )
@@ -51,23 +53,15 @@ func (h *Handlers) PostArtists(c *fiber.Ctx) error {
 		UserID:     userID,
 	}
 
-	if artistReq.Verified != nil {
-		artist.Verified = *artistReq.Verified
-	}
-
 	if artistReq.WalletBalance != nil {
 		artist.WalletBalance = float64(*artistReq.WalletBalance)
 	}
 
-	if artistReq.MonthlyListeners != nil {
-		artist.MonthlyListeners = *artistReq.MonthlyListeners
-	}
-
 	if artistReq.Id != nil {
 		artist.ID = *artistReq.Id
 	}
 
-	createdArtist, err := h.Artist.CreateArtist(c.Context(), artist)
+	createdArtist, err := h.Artist.CreateArtist(actorContext(c, userID), artist)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -125,23 +119,15 @@ func (h *Handlers) PutArtistsArtistId(c *fiber.Ctx, artistId types.UUID) error {
 	}
 	artist.ArtistName = artistReq.ArtistName
 
-	if artistReq.Verified != nil {
-		artist.Verified = *artistReq.Verified
-	}
-
 	if artistReq.WalletBalance != nil {
 		artist.WalletBalance = float64(*artistReq.WalletBalance)
 	}
 
-	if artistReq.MonthlyListeners != nil {
-		artist.MonthlyListeners = *artistReq.MonthlyListeners
-	}
-
 	if artistReq.Id != nil {
 		artist.ID = *artistReq.Id
 	}
 
-	updatedArtist, err := h.Artist.UpdateArtist(c.Context(), artist.ID, artist)
+	updatedArtist, err := h.Artist.UpdateArtist(actorContext(c, userID), artist.ID, artist)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -153,8 +139,15 @@ func (h *Handlers) PutArtistsArtistId(c *fiber.Ctx, artistId types.UUID) error {
 }
 
 func (h *Handlers) GetArtistsArtistIdSongs(c *fiber.Ctx, artistId types.UUID, params api.GetArtistsArtistIdSongsParams) error {
-	songs, err := h.Artist.GetArtistSongs(c.Context(), artistId, params.Page, params.Limit)
+	// Songs that can't be played where the client is are left out
+	songs, err := h.Artist.GetArtistSongs(c.Context(), artistId, params.Page, params.Limit, h.clientLocation(c).CountryCode)
 	if err != nil {
+		if errors.Is(err, services.ErrArtistNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(api.Error{
+				Code:    fiber.StatusNotFound,
+				Message: "Artist not found",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
 			Message: "Failed to fetch artist songs",
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"context"
 	"crawl/api"
+	"crawl/repositories"
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/oapi-codegen/runtime/types"
 	"strings"
@@ -130,6 +132,34 @@ func (h *Handlers) getUserIDFromToken(c *fiber.Ctx) (types.UUID, error) {
 	return userID, nil
 }
 
+// optionalUserID identifies the caller on endpoints that also serve logged-out
+// users; it is nil without a valid token
+func (h *Handlers) optionalUserID(c *fiber.Ctx) *types.UUID {
+	if c.Get(fiber.HeaderAuthorization) == "" {
+		return nil
+	}
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
+
+// isAdmin reports whether the user holds the admin role
+func (h *Handlers) isAdmin(c *fiber.Ctx, userID types.UUID) bool {
+	admin, err := h.User.IsAdmin(c.Context(), userID)
+	if err != nil {
+		log.Warnf("Failed to check admin role: %s", err.Error())
+		return false
+	}
+	return admin
+}
+
+// actorContext returns the request context tagged with the acting user for the change history
+func actorContext(c *fiber.Ctx, userID types.UUID) context.Context {
+	return repositories.ContextWithActor(c.Context(), userID)
+}
+
 func (h *Handlers) GetRole() {
 	panic("Implement me")
 }
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"crawl/api"
+	"crawl/models"
+	"crawl/services"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime/types"
+	"time"
+)
+
+func chartError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidChartCountry):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrChartNotFound),
+		errors.Is(err, services.ErrChartGenreNotFound),
+		errors.Is(err, services.ErrChartSongNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: fallback,
+	})
+}
+
+func (h *Handlers) getChart(c *fiber.Ctx, kind, scope string, week *api.ChartWeek) error {
+	var day *time.Time
+	if week != nil {
+		day = &week.Time
+	}
+
+	// Songs that can't be played where the client is are left out
+	chart, err := h.Chart.GetChart(c.Context(), kind, scope, day, h.clientLocation(c).CountryCode)
+	if err != nil {
+		return chartError(c, err, "Failed to fetch chart")
+	}
+	return c.JSON(chart)
+}
+
+func (h *Handlers) GetChartsGlobal(c *fiber.Ctx, params api.GetChartsGlobalParams) error {
+	return h.getChart(c, models.ChartGlobal, "", params.Week)
+}
+
+func (h *Handlers) GetChartsCountriesCountryCode(c *fiber.Ctx, countryCode string, params api.GetChartsCountriesCountryCodeParams) error {
+	return h.getChart(c, models.ChartCountry, countryCode, params.Week)
+}
+
+func (h *Handlers) GetChartsGenresGenreId(c *fiber.Ctx, genreId types.UUID, params api.GetChartsGenresGenreIdParams) error {
+	return h.getChart(c, models.ChartGenre, genreId.String(), params.Week)
+}
+
+func (h *Handlers) GetChartsTrending(c *fiber.Ctx, params api.GetChartsTrendingParams) error {
+	trending, err := h.Chart.GetTrending(c.Context(), params.GenreId, params.Limit, h.clientLocation(c).CountryCode)
+	if err != nil {
+		return chartError(c, err, "Failed to fetch trending songs")
+	}
+	return c.JSON(trending)
+}
+
+func (h *Handlers) GetSongsSongIdCharts(c *fiber.Ctx, songId types.UUID) error {
+	entries, err := h.Chart.GetSongHistory(c.Context(), songId)
+	if err != nil {
+		return chartError(c, err, "Failed to fetch chart history")
+	}
+	return c.JSON(entries)
+}
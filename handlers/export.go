@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"crawl/api"
+	"crawl/services"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/valyala/fasthttp"
+	"path"
+)
+
+func exportError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidExportRange),
+		errors.Is(err, services.ErrInvalidExportDataset),
+		errors.Is(err, services.ErrInvalidExportFormat):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidExportLink):
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrExportNotFound),
+		errors.Is(err, services.ErrExportFileNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrExportNotReady):
+		return c.Status(fiber.StatusConflict).JSON(api.Error{
+			Code:    fiber.StatusConflict,
+			Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: fallback,
+	})
+}
+
+func (h *Handlers) PostExports(c *fiber.Ctx) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	var req api.PostExportsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	request := services.ExportRequest{From: req.From.Time, To: req.To.Time}
+	if req.Datasets != nil {
+		for _, dataset := range *req.Datasets {
+			request.Datasets = append(request.Datasets, string(dataset))
+		}
+	}
+	if req.Formats != nil {
+		for _, format := range *req.Formats {
+			request.Formats = append(request.Formats, string(format))
+		}
+	}
+
+	job, err := h.Export.CreateJob(c.Context(), request, userID)
+	if err != nil {
+		return exportError(c, err, "Failed to queue export")
+	}
+	return c.Status(fiber.StatusAccepted).JSON(job)
+}
+
+func (h *Handlers) GetExports(c *fiber.Ctx, params api.GetExportsParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	jobs, err := h.Export.ListJobs(c.Context(), params.Page, params.Limit)
+	if err != nil {
+		return exportError(c, err, "Failed to fetch exports")
+	}
+	return c.JSON(jobs)
+}
+
+func (h *Handlers) GetExportsJobId(c *fiber.Ctx, jobId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	job, err := h.Export.GetJob(c.Context(), jobId)
+	if err != nil {
+		return exportError(c, err, "Failed to fetch export")
+	}
+	return c.JSON(job)
+}
+
+// GetExportsJobIdDownload serves a completed export to whoever holds its signed link
+func (h *Handlers) GetExportsJobIdDownload(c *fiber.Ctx, jobId types.UUID, params api.GetExportsJobIdDownloadParams) error {
+	job, err := h.Export.Download(c.Context(), jobId, params.Expires, params.Signature)
+	if err != nil {
+		return exportError(c, err, "Failed to fetch export")
+	}
+
+	if params.File != nil {
+		content, err := h.Export.OpenFile(c.Context(), job, *params.File)
+		if err != nil {
+			return exportError(c, err, "Failed to fetch export file")
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", path.Base(*params.File)))
+		return c.SendStream(content)
+	}
+
+	// The archive is streamed as it's built, so exports of any size fit
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"export-%s.zip\"", job.ID))
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		if err := h.Export.WriteArchive(context.Background(), job, w); err != nil {
+			log.Warnf("Failed to send export %s: %s", job.ID, err.Error())
+		}
+		w.Flush()
+	}))
+	return nil
+}
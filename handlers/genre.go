@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"crawl/api"
+	"crawl/models"
+	"crawl/services"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/oapi-codegen/runtime/types"
 )
@@ -29,3 +32,150 @@ func (h *Handlers) GetGenresGenreId(c *fiber.Ctx, genreId types.UUID) error {
 
 	return c.JSON(genre)
 }
+
+func (h *Handlers) GetGenresGenreIdSubgenres(c *fiber.Ctx, genreId types.UUID) error {
+	genres, err := h.Genre.GetSubgenres(c.Context(), genreId)
+	if err != nil {
+		if errors.Is(err, services.ErrGenreNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(api.Error{
+				Code:    fiber.StatusNotFound,
+				Message: "Genre not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to fetch sub-genres",
+		})
+	}
+
+	return c.JSON(genres)
+}
+
+func (h *Handlers) PostGenres(c *fiber.Ctx) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	var genreReq api.Genre
+	if err := c.BodyParser(&genreReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	genre, err := h.Genre.CreateGenre(c.Context(), genreFromRequest(genreReq))
+	if err != nil {
+		return genreError(c, err, "Failed to create genre")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(genre)
+}
+
+func (h *Handlers) PutGenresGenreId(c *fiber.Ctx, genreId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	var genreReq api.Genre
+	if err := c.BodyParser(&genreReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	genre, err := h.Genre.UpdateGenre(c.Context(), genreId, genreFromRequest(genreReq))
+	if err != nil {
+		return genreError(c, err, "Failed to update genre")
+	}
+
+	return c.JSON(genre)
+}
+
+func (h *Handlers) DeleteGenresGenreId(c *fiber.Ctx, genreId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	if err := h.Genre.DeleteGenre(c.Context(), genreId); err != nil {
+		return genreError(c, err, "Failed to delete genre")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func genreFromRequest(genreReq api.Genre) *models.Genre {
+	genre := &models.Genre{
+		Name:     genreReq.Name,
+		ParentID: genreReq.ParentId,
+	}
+
+	if genreReq.Description != nil {
+		genre.Description = *genreReq.Description
+	}
+
+	if genreReq.ImageUrl != nil {
+		genre.ImageURL = *genreReq.ImageUrl
+	}
+
+	return genre
+}
+
+func genreError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrGenreNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Genre not found",
+		})
+	case errors.Is(err, services.ErrGenreExists):
+		return c.Status(fiber.StatusConflict).JSON(api.Error{
+			Code:    fiber.StatusConflict,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrGenreNameRequired), errors.Is(err, services.ErrInvalidGenreParent):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: message,
+	})
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"crawl/geoip"
+	"github.com/gofiber/fiber/v2"
+)
+
+// clientIP is the address of the client, read from the proxy header when the
+// request came through a trusted proxy
+func (h *Handlers) clientIP(c *fiber.Ctx) string {
+	return h.geo.ClientIP(c.Context().RemoteIP().String(), c.Get(h.geo.ProxyHeader()))
+}
+
+// clientLocation is where the client is, resolved from their address. It is
+// unknown when the address can't be resolved.
+func (h *Handlers) clientLocation(c *fiber.Ctx) geoip.Location {
+	return h.geo.Locate(h.clientIP(c))
+}
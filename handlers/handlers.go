@@ -1,38 +1,100 @@
 package handlers
 
 import (
+	"crawl/geoip"
+	"crawl/ingest"
+	"crawl/live"
+	"crawl/payments"
 	"crawl/repositories"
+	"crawl/search"
 	"crawl/services"
+	"crawl/storage"
 	"gorm.io/gorm"
 )
 
 type Handlers struct {
-	User       services.UserService
-	Artist     services.ArtistService
-	Album      services.AlbumService
-	Song       services.SongService
-	Genre      services.GenreService
-	Playlist   services.PlaylistService
-	Purchase   services.PurchaseService
-	Stream     services.StreamService
-	Tip        services.TipService
-	Moderation services.ModerationService
-	Auth       services.AuthService
+	User             services.UserService
+	Artist           services.ArtistService
+	Album            services.AlbumService
+	Song             services.SongService
+	Genre            services.GenreService
+	Tag              services.TagService
+	Playlist         services.PlaylistService
+	Purchase         services.PurchaseService
+	Payment          services.PaymentService
+	Stream           services.StreamService
+	ListeningHistory services.ListeningHistoryService
+	Tip              services.TipService
+	Moderation       services.ModerationService
+	Auth             services.AuthService
+	History          services.HistoryService
+	Verification     services.VerificationService
+	Label            services.LabelService
+	Analytics        services.AnalyticsService
+	Chart            services.ChartService
+	Wrapped          services.WrappedService
+	Territory        services.TerritoryService
+	Export           services.ExportService
+	Search           services.SearchService
+	SearchStats      services.SearchAnalyticsService
+
+	// geo finds the client address and country of requests
+	geo *geoip.Resolver
+	// feed fans out plays, tips and purchases to the artists watching live
+	feed *live.Hub
 }
 
-func NewHandlers(db *gorm.DB) *Handlers {
-	repos := repositories.NewRepositories(db)
-	return &Handlers{
-		User:       services.NewUserService(repos.User, repos.Playlist, repos.Artist, repos.SongPurchase, repos.AlbumPurchase),
-		Artist:     services.NewArtistService(repos.Artist, repos.Song, repos.User),
-		Album:      services.NewAlbumService(repos.Album, repos.AlbumContributor, repos.Song),
-		Song:       services.NewSongService(repos.Song, repos.Artist, repos.Genre, repos.Album, repos.Stream, repos.SongContributorRepository),
-		Genre:      services.NewGenreService(repos.Genre),
-		Playlist:   services.NewPlaylistService(repos.Playlist, repos.PlaylistSong, repos.Song),
-		Purchase:   services.NewPurchaseService(repos.AlbumPurchase, repos.SongPurchase, repos.Album, repos.Song),
-		Stream:     services.NewStreamService(repos.Stream, repos.Song),
-		Tip:        services.NewTipService(repos.Tip, repos.User, repos.Artist),
-		Moderation: services.NewModerationService(repos.Moderation),
-		Auth:       services.NewAuthService(repos.User),
+// NewHandlers wires the services together. Searches run against searchStore when
+// one is open, and against PostgreSQL when it is nil. Streams are recorded
+// through the streams pipeline. Client countries are resolved with geo. Plays,
+// tips and purchases are published to feed once paid for. Purchases and tips
+// are charged through providers. Analytics exports are written to blobs and
+// their download links signed with exports.
+func NewHandlers(
+	db *gorm.DB,
+	blobs storage.BlobStore,
+	fuzzy repositories.FuzzySettings,
+	searchStore *search.DiskStore,
+	streams *ingest.Pipeline,
+	geo *geoip.Resolver,
+	feed *live.Hub,
+	providers *payments.Registry,
+	exports services.ExportSettings,
+) *Handlers {
+	repos := repositories.NewRepositories(db, fuzzy)
+	var index search.SearchIndex = search.NewSQLIndex(repos.Song, repos.Album, repos.Artist, repos.Playlist)
+	if searchStore != nil {
+		index = search.NewDiskIndex(searchStore, repos.Song, repos.Album, repos.Artist, repos.Playlist)
+	}
+	payment := services.NewPaymentService(providers, repos.Payment, repos.User, repos.SongPurchase, repos.AlbumPurchase, repos.Tip, repos.Song, repos.Album, feed)
+	h := &Handlers{
+		User:             services.NewUserService(repos.User, repos.Playlist, repos.Artist, repos.SongPurchase, repos.AlbumPurchase),
+		Artist:           services.NewArtistService(repos.Artist, repos.Song, repos.User, index),
+		Album:            services.NewAlbumService(repos.Album, repos.AlbumContributor, repos.Song, index),
+		Song:             services.NewSongService(repos.Song, repos.Artist, repos.Genre, repos.Album, repos.Stream, repos.SongContributorRepository, index),
+		Genre:            services.NewGenreService(repos.Genre),
+		Tag:              services.NewTagService(repos.Tag, repos.Song, repos.Album),
+		Playlist:         services.NewPlaylistService(repos.Playlist, repos.PlaylistSong, repos.Song, index),
+		Purchase:         services.NewPurchaseService(repos.AlbumPurchase, repos.SongPurchase, repos.Album, repos.Song, repos.Territory, payment),
+		Payment:          payment,
+		Stream:           services.NewStreamService(repos.Stream, repos.Song, repos.StreamRollup, repos.Territory, streams, feed),
+		ListeningHistory: services.NewListeningHistoryService(repos.ListeningHistory, repos.User),
+		Tip:              services.NewTipService(repos.Tip, repos.User, repos.Artist, payment),
+		Moderation:       services.NewModerationService(repos.Moderation),
+		Auth:             services.NewAuthService(repos.User),
+		History:          services.NewHistoryService(repos.EntityVersion),
+		Verification:     services.NewVerificationService(repos.Verification, repos.Artist, blobs),
+		Label:            services.NewLabelService(repos.Label, repos.User, repos.Artist, repos.MonthlyRoyalty),
+		Analytics:        services.NewAnalyticsService(repos.Artist, repos.Stream, repos.ArtistSales, repos.MonthlyListeners),
+		Chart:            services.NewChartService(repos.Chart, repos.StreamRollup, repos.Song, repos.Genre),
+		Wrapped:          services.NewWrappedService(repos.Wrapped),
+		Territory:        services.NewTerritoryService(repos.Territory, repos.Song, repos.Album),
+		Export:           services.NewExportService(repos.Export, blobs, exports),
+		SearchStats:      services.NewSearchAnalyticsService(repos.SearchLog),
+		geo:              geo,
+		feed:             feed,
 	}
+	// Global search fans out to the per-type searches above
+	h.Search = services.NewSearchService(repos.Search, fuzzy, h.Song, h.Album, h.Artist, h.Playlist, h.Genre)
+	return h
 }
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"crawl/api"
+	"crawl/models"
+	"crawl/services"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+func (h *Handlers) GetSongsSongIdHistory(c *fiber.Ctx, songId types.UUID, params api.GetSongsSongIdHistoryParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Only the song's artist and admins can see its history
+	song, err := h.Song.GetSongByID(c.Context(), songId)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Song not found",
+		})
+	}
+
+	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
+	if (err != nil || artist.ID != song.ArtistID) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view the history of your own songs",
+		})
+	}
+
+	return h.sendHistory(c, models.EntityTypeSong, songId, params.Page, params.Limit)
+}
+
+func (h *Handlers) PostSongsSongIdHistoryVersionRollback(c *fiber.Ctx, songId types.UUID, version api.Version) error {
+	return h.rollback(c, models.EntityTypeSong, songId, version)
+}
+
+func (h *Handlers) GetAlbumsAlbumIdHistory(c *fiber.Ctx, albumId types.UUID, params api.GetAlbumsAlbumIdHistoryParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Only the album's artist and admins can see its history
+	album, err := h.Album.GetAlbumByID(c.Context(), albumId)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Album not found",
+		})
+	}
+
+	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
+	if (err != nil || artist.ID != album.ArtistID) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view the history of your own albums",
+		})
+	}
+
+	return h.sendHistory(c, models.EntityTypeAlbum, albumId, params.Page, params.Limit)
+}
+
+func (h *Handlers) PostAlbumsAlbumIdHistoryVersionRollback(c *fiber.Ctx, albumId types.UUID, version api.Version) error {
+	return h.rollback(c, models.EntityTypeAlbum, albumId, version)
+}
+
+func (h *Handlers) GetArtistsArtistIdHistory(c *fiber.Ctx, artistId types.UUID, params api.GetArtistsArtistIdHistoryParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Only the artist themselves and admins can see the profile history
+	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
+	if (err != nil || artist.ID != artistId) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view the history of your own artist profile",
+		})
+	}
+
+	return h.sendHistory(c, models.EntityTypeArtist, artistId, params.Page, params.Limit)
+}
+
+func (h *Handlers) PostArtistsArtistIdHistoryVersionRollback(c *fiber.Ctx, artistId types.UUID, version api.Version) error {
+	return h.rollback(c, models.EntityTypeArtist, artistId, version)
+}
+
+func (h *Handlers) GetPlaylistsPlaylistIdHistory(c *fiber.Ctx, playlistId types.UUID, params api.GetPlaylistsPlaylistIdHistoryParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Only the playlist owner and admins can see its history
+	playlist, err := h.Playlist.GetPlaylistByID(c.Context(), playlistId)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Playlist not found",
+		})
+	}
+
+	if playlist.UserID != userID && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view the history of your own playlists",
+		})
+	}
+
+	return h.sendHistory(c, models.EntityTypePlaylist, playlistId, params.Page, params.Limit)
+}
+
+func (h *Handlers) PostPlaylistsPlaylistIdHistoryVersionRollback(c *fiber.Ctx, playlistId types.UUID, version api.Version) error {
+	return h.rollback(c, models.EntityTypePlaylist, playlistId, version)
+}
+
+func (h *Handlers) sendHistory(c *fiber.Ctx, entityType string, entityID types.UUID, page *int, limit *int) error {
+	versions, err := h.History.GetHistory(c.Context(), entityType, entityID, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to fetch history",
+		})
+	}
+
+	return c.JSON(versions)
+}
+
+func (h *Handlers) rollback(c *fiber.Ctx, entityType string, entityID types.UUID, version int) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	if err := h.History.Rollback(actorContext(c, userID), entityType, entityID, version); err != nil {
+		if errors.Is(err, services.ErrVersionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(api.Error{
+				Code:    fiber.StatusNotFound,
+				Message: "Version not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to roll back",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
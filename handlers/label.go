@@ -0,0 +1,511 @@
+package handlers
+
+import (
+	"crawl/api"
+	"crawl/models"
+	"crawl/services"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+func (h *Handlers) GetLabels(c *fiber.Ctx) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	labels, err := h.Label.GetUserLabels(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to fetch labels",
+		})
+	}
+
+	return c.JSON(labels)
+}
+
+func (h *Handlers) PostLabels(c *fiber.Ctx) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	var labelReq api.Label
+	if err := c.BodyParser(&labelReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	label, err := h.Label.CreateLabel(c.Context(), userID, labelFromRequest(labelReq))
+	if err != nil {
+		return labelError(c, err, "Failed to create label")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(label)
+}
+
+func (h *Handlers) GetLabelsLabelId(c *fiber.Ctx, labelId types.UUID) error {
+	label, err := h.Label.GetLabel(c.Context(), labelId)
+	if err != nil {
+		return labelError(c, err, "Failed to fetch label")
+	}
+
+	return c.JSON(label)
+}
+
+func (h *Handlers) PutLabelsLabelId(c *fiber.Ctx, labelId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isLabelManager(c, labelId, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Only label owners and managers can update the label",
+		})
+	}
+
+	var labelReq api.Label
+	if err := c.BodyParser(&labelReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	label, err := h.Label.UpdateLabel(c.Context(), labelId, labelFromRequest(labelReq))
+	if err != nil {
+		return labelError(c, err, "Failed to update label")
+	}
+
+	return c.JSON(label)
+}
+
+func (h *Handlers) DeleteLabelsLabelId(c *fiber.Ctx, labelId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if h.labelRole(c, labelId, userID) != models.LabelRoleOwner {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Only the label owner can delete the label",
+		})
+	}
+
+	if err := h.Label.DeleteLabel(c.Context(), labelId); err != nil {
+		return labelError(c, err, "Failed to delete label")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *Handlers) GetLabelsLabelIdMembers(c *fiber.Ctx, labelId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if h.labelRole(c, labelId, userID) == "" {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Only label members can see the member list",
+		})
+	}
+
+	members, err := h.Label.GetMembers(c.Context(), labelId)
+	if err != nil {
+		return labelError(c, err, "Failed to fetch label members")
+	}
+
+	return c.JSON(members)
+}
+
+func (h *Handlers) PostLabelsLabelIdMembers(c *fiber.Ctx, labelId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isLabelManager(c, labelId, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Only label owners and managers can add members",
+		})
+	}
+
+	var memberReq api.LabelMember
+	if err := c.BodyParser(&memberReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	var role string
+	if memberReq.Role != nil {
+		role = string(*memberReq.Role)
+	}
+
+	member, err := h.Label.AddMember(c.Context(), labelId, memberReq.UserId, role)
+	if err != nil {
+		return labelError(c, err, "Failed to add member")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(member)
+}
+
+func (h *Handlers) DeleteLabelsLabelIdMembersUserId(c *fiber.Ctx, labelId types.UUID, userId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Members can leave on their own; removing others takes a manager
+	if userID != userId && !h.isLabelManager(c, labelId, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Only label owners and managers can remove members",
+		})
+	}
+
+	if err := h.Label.RemoveMember(c.Context(), labelId, userId); err != nil {
+		return labelError(c, err, "Failed to remove member")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *Handlers) GetLabelsLabelIdArtists(c *fiber.Ctx, labelId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if h.labelRole(c, labelId, userID) == "" {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Only label members can see the artist links",
+		})
+	}
+
+	links, err := h.Label.GetArtistLinks(c.Context(), labelId)
+	if err != nil {
+		return labelError(c, err, "Failed to fetch label artists")
+	}
+
+	return c.JSON(links)
+}
+
+func (h *Handlers) PostLabelsLabelIdArtists(c *fiber.Ctx, labelId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isLabelManager(c, labelId, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Only label owners and managers can invite artists",
+		})
+	}
+
+	var linkReq api.LabelArtist
+	if err := c.BodyParser(&linkReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	link := &models.LabelArtist{ArtistID: linkReq.ArtistId}
+	applyLabelPermissions(link, linkReq.CanManageCatalog, linkReq.CanViewAnalytics, linkReq.CanViewRoyalties)
+
+	created, err := h.Label.InviteArtist(c.Context(), labelId, link)
+	if err != nil {
+		return labelError(c, err, "Failed to invite artist")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+func (h *Handlers) DeleteLabelsLabelIdArtistsArtistId(c *fiber.Ctx, labelId types.UUID, artistId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isLabelManager(c, labelId, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Only label owners and managers can remove artists",
+		})
+	}
+
+	if err := h.Label.RemoveArtist(c.Context(), labelId, artistId); err != nil {
+		return labelError(c, err, "Failed to remove artist")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *Handlers) GetLabelsLabelIdRoyalties(c *fiber.Ctx, labelId types.UUID, params api.GetLabelsLabelIdRoyaltiesParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if h.labelRole(c, labelId, userID) == "" {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Only label members can see royalty reports",
+		})
+	}
+
+	report, err := h.Label.GetRoyaltyReport(c.Context(), labelId, params.Year, params.Month)
+	if err != nil {
+		return labelError(c, err, "Failed to build royalty report")
+	}
+
+	return c.JSON(report)
+}
+
+func (h *Handlers) GetArtistsArtistIdLabels(c *fiber.Ctx, artistId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.ownsArtistOrAdmin(c, userID, artistId) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view your own labels",
+		})
+	}
+
+	links, err := h.Label.GetArtistLabels(c.Context(), artistId)
+	if err != nil {
+		return labelError(c, err, "Failed to fetch labels")
+	}
+
+	return c.JSON(links)
+}
+
+func (h *Handlers) PutArtistsArtistIdLabelsLabelId(c *fiber.Ctx, artistId types.UUID, labelId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Only the artist decides what a label may do on their behalf
+	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
+	if err != nil || artist.ID != artistId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only manage your own label permissions",
+		})
+	}
+
+	var permissionsReq api.LabelArtistPermissions
+	if err := c.BodyParser(&permissionsReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	permissions := &models.LabelArtist{}
+	applyLabelPermissions(permissions, permissionsReq.CanManageCatalog, permissionsReq.CanViewAnalytics, permissionsReq.CanViewRoyalties)
+
+	link, err := h.Label.UpdateArtistPermissions(c.Context(), labelId, artistId, permissions)
+	if err != nil {
+		return labelError(c, err, "Failed to update label permissions")
+	}
+
+	return c.JSON(link)
+}
+
+func (h *Handlers) DeleteArtistsArtistIdLabelsLabelId(c *fiber.Ctx, artistId types.UUID, labelId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
+	if err != nil || artist.ID != artistId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only leave labels for yourself",
+		})
+	}
+
+	if err := h.Label.RemoveArtist(c.Context(), labelId, artistId); err != nil {
+		return labelError(c, err, "Failed to leave label")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *Handlers) PostArtistsArtistIdLabelsLabelIdAccept(c *fiber.Ctx, artistId types.UUID, labelId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
+	if err != nil || artist.ID != artistId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only accept invitations for yourself",
+		})
+	}
+
+	link, err := h.Label.AcceptArtistLink(c.Context(), labelId, artistId)
+	if err != nil {
+		return labelError(c, err, "Failed to accept invitation")
+	}
+
+	return c.JSON(link)
+}
+
+// labelRole returns the user's role in the label, or "" when they aren't a member
+func (h *Handlers) labelRole(c *fiber.Ctx, labelID types.UUID, userID types.UUID) string {
+	role, err := h.Label.GetMemberRole(c.Context(), labelID, userID)
+	if err != nil {
+		if !errors.Is(err, services.ErrNotLabelMember) {
+			log.Warnf("Failed to check label membership: %s", err.Error())
+		}
+		return ""
+	}
+	return role
+}
+
+func (h *Handlers) isLabelManager(c *fiber.Ctx, labelID types.UUID, userID types.UUID) bool {
+	role := h.labelRole(c, labelID, userID)
+	return role == models.LabelRoleOwner || role == models.LabelRoleManager
+}
+
+// canActForArtist reports whether userID is the artist or belongs to a label the
+// artist granted permission to
+func (h *Handlers) canActForArtist(c *fiber.Ctx, userID types.UUID, artistID types.UUID, permission string) bool {
+	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
+	if err == nil && artist.ID == artistID {
+		return true
+	}
+
+	allowed, err := h.Label.CanActForArtist(c.Context(), userID, artistID, permission)
+	if err != nil {
+		log.Warnf("Failed to check label permissions: %s", err.Error())
+		return false
+	}
+	return allowed
+}
+
+func labelFromRequest(labelReq api.Label) *models.Label {
+	label := &models.Label{Name: labelReq.Name}
+
+	if labelReq.Description != nil {
+		label.Description = *labelReq.Description
+	}
+
+	if labelReq.LogoUrl != nil {
+		label.LogoURL = *labelReq.LogoUrl
+	}
+
+	return label
+}
+
+func applyLabelPermissions(link *models.LabelArtist, catalog, analytics, royalties *bool) {
+	if catalog != nil {
+		link.CanManageCatalog = *catalog
+	}
+	if analytics != nil {
+		link.CanViewAnalytics = *analytics
+	}
+	if royalties != nil {
+		link.CanViewRoyalties = *royalties
+	}
+}
+
+func labelError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrLabelNotFound),
+		errors.Is(err, services.ErrNotLabelMember),
+		errors.Is(err, services.ErrArtistLinkNotFound),
+		errors.Is(err, services.ErrArtistNotFound),
+		errors.Is(err, services.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrArtistLinkExists):
+		return c.Status(fiber.StatusConflict).JSON(api.Error{
+			Code:    fiber.StatusConflict,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrLabelNameRequired),
+		errors.Is(err, services.ErrInvalidLabelRole),
+		errors.Is(err, services.ErrLabelOwnerImmutable):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: message,
+	})
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"crawl/api"
+	"crawl/services"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+func listeningHistoryError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrHistoryItemNotFound),
+		errors.Is(err, services.ErrHistoryUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: fallback,
+	})
+}
+
+func (h *Handlers) GetUsersUserIdListeningHistory(c *fiber.Ctx, userId types.UUID, params api.GetUsersUserIdListeningHistoryParams) error {
+	requestingUserID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Verify the requesting user is accessing their own history
+	if requestingUserID != userId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only access your own listening history",
+		})
+	}
+
+	items, err := h.ListeningHistory.GetHistory(c.Context(), userId, params.Page, params.Limit)
+	if err != nil {
+		return listeningHistoryError(c, err, "Failed to fetch listening history")
+	}
+	return c.JSON(items)
+}
+
+func (h *Handlers) DeleteUsersUserIdListeningHistory(c *fiber.Ctx, userId types.UUID) error {
+	requestingUserID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Verify the requesting user is accessing their own history
+	if requestingUserID != userId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only access your own listening history",
+		})
+	}
+
+	if _, err := h.ListeningHistory.ClearHistory(c.Context(), userId); err != nil {
+		return listeningHistoryError(c, err, "Failed to clear listening history")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *Handlers) DeleteUsersUserIdListeningHistoryStreamId(c *fiber.Ctx, userId types.UUID, streamId types.UUID) error {
+	requestingUserID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Verify the requesting user is accessing their own history
+	if requestingUserID != userId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only access your own listening history",
+		})
+	}
+
+	if err := h.ListeningHistory.DeleteItem(c.Context(), userId, streamId); err != nil {
+		return listeningHistoryError(c, err, "Failed to delete listening history item")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *Handlers) GetUsersUserIdListeningHistorySettings(c *fiber.Ctx, userId types.UUID) error {
+	requestingUserID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Verify the requesting user is accessing their own history
+	if requestingUserID != userId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only access your own listening history",
+		})
+	}
+
+	settings, err := h.ListeningHistory.GetSettings(c.Context(), userId)
+	if err != nil {
+		return listeningHistoryError(c, err, "Failed to fetch listening history settings")
+	}
+	return c.JSON(settings)
+}
+
+func (h *Handlers) PutUsersUserIdListeningHistorySettings(c *fiber.Ctx, userId types.UUID) error {
+	requestingUserID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Verify the requesting user is accessing their own history
+	if requestingUserID != userId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only access your own listening history",
+		})
+	}
+
+	var settingsReq api.PutUsersUserIdListeningHistorySettingsJSONBody
+	if err := c.BodyParser(&settingsReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	settings, err := h.ListeningHistory.UpdateSettings(c.Context(), userId, services.ListeningHistorySettings{
+		Paused: settingsReq.Paused,
+	})
+	if err != nil {
+		return listeningHistoryError(c, err, "Failed to update listening history settings")
+	}
+	return c.JSON(settings)
+}
+
+func (h *Handlers) GetUsersUserIdRecentlyPlayed(c *fiber.Ctx, userId types.UUID, params api.GetUsersUserIdRecentlyPlayedParams) error {
+	requestingUserID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Verify the requesting user is accessing their own history
+	if requestingUserID != userId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only access your own listening history",
+		})
+	}
+
+	// Songs and albums that can't be played where the client is are left out
+	plays, err := h.ListeningHistory.GetRecentlyPlayed(c.Context(), userId, params.Limit, h.clientLocation(c).CountryCode)
+	if err != nil {
+		return listeningHistoryError(c, err, "Failed to fetch recently played")
+	}
+	return c.JSON(plays)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bufio"
+	"crawl/api"
+	"crawl/live"
+	"crawl/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/valyala/fasthttp"
+	"time"
+)
+
+func (h *Handlers) GetArtistsArtistIdLive(c *fiber.Ctx, artistId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if _, err := h.Artist.GetArtistByID(c.Context(), artistId); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Artist not found",
+		})
+	}
+	if !h.canActForArtist(c, userID, artistId, models.LabelPermissionAnalytics) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You don't have access to this artist's analytics",
+		})
+	}
+
+	sub, err := h.feed.Subscribe(artistId, userID)
+	switch {
+	case errors.Is(err, live.ErrTooManySubscriptions):
+		return c.Status(fiber.StatusTooManyRequests).JSON(api.Error{
+			Code:    fiber.StatusTooManyRequests,
+			Message: err.Error(),
+		})
+	case err != nil:
+		return c.Status(fiber.StatusServiceUnavailable).JSON(api.Error{
+			Code:    fiber.StatusServiceUnavailable,
+			Message: err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	// Keep nginx from buffering the stream
+	c.Set("X-Accel-Buffering", "no")
+
+	cfg := h.feed.Config()
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+		streamCounters(w, sub, cfg)
+	}))
+	return nil
+}
+
+// streamCounters writes the subscription's counters as Server-Sent Events until
+// the subscription ends or the client goes away. Counters are sent at most once
+// per flush interval, and only when they changed.
+func streamCounters(w *bufio.Writer, sub *live.Subscription, cfg live.Config) {
+	counters := live.NewCounters(time.Now())
+	flush := time.NewTicker(cfg.FlushInterval)
+	defer flush.Stop()
+	lastWrite := time.Now()
+
+	// The first counters tell the client it's connected
+	changed := true
+	for {
+		select {
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			counters.Add(event)
+			changed = true
+			continue
+		case <-flush.C:
+		}
+
+		if dropped := sub.Dropped(); dropped != counters.Dropped {
+			counters.Dropped = dropped
+			changed = true
+		}
+		switch {
+		case changed:
+			data, err := json.Marshal(counters)
+			if err != nil {
+				log.Warnf("Failed to encode live counters: %s", err.Error())
+				return
+			}
+			fmt.Fprintf(w, "event: counters\ndata: %s\n\n", data)
+		case time.Since(lastWrite) >= cfg.KeepAlive:
+			w.WriteString(": keep-alive\n\n")
+		default:
+			continue
+		}
+		// Writes fail once the client has disconnected
+		if err := w.Flush(); err != nil {
+			return
+		}
+		changed = false
+		lastWrite = time.Now()
+	}
+}
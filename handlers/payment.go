@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"crawl/api"
+	"crawl/services"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+func paymentError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrNotAvailableInRegion):
+		return territoryError(c, err, fallback)
+	case errors.Is(err, services.ErrUnknownPaymentProvider):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrPaymentDeclined):
+		return c.Status(fiber.StatusPaymentRequired).JSON(api.Error{
+			Code:    fiber.StatusPaymentRequired,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrAlreadyPurchased):
+		return c.Status(fiber.StatusConflict).JSON(api.Error{
+			Code:    fiber.StatusConflict,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrPaymentNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: fallback,
+	})
+}
+
+func (h *Handlers) GetPaymentsPaymentId(c *fiber.Ctx, paymentId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	payment, err := h.Payment.GetPayment(c.Context(), paymentId)
+	if err != nil {
+		return paymentError(c, err, "Failed to fetch payment")
+	}
+	if payment.UserID != userID && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view your own payments",
+		})
+	}
+	return c.JSON(payment)
+}
+
+// PostPaymentsWebhooksProvider settles payments as providers report on them.
+// It takes no token; the provider's signature over the raw body is checked
+// instead.
+func (h *Handlers) PostPaymentsWebhooksProvider(c *fiber.Ctx, provider string) error {
+	header := func(key string) string { return c.Get(key) }
+	err := h.Payment.HandleWebhook(c.Context(), provider, header, c.Body())
+	switch {
+	case err == nil:
+		return c.SendStatus(fiber.StatusOK)
+	case errors.Is(err, services.ErrUnknownPaymentProvider):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidWebhook):
+		log.Warnf("Rejected %s webhook from %s: %s", provider, c.IP(), err.Error())
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+	// Anything else is worth the provider retrying
+	log.Warnf("Failed to handle %s webhook: %s", provider, err.Error())
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: "Failed to handle webhook",
+	})
+}
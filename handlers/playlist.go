@@ -136,7 +136,8 @@ func (h *Handlers) GetPlaylistsPlaylistIdSongs(c *fiber.Ctx, playlistId types.UU
 		}
 	}
 
-	songs, err := h.Playlist.GetPlaylistSongs(c.Context(), playlistId)
+	// Songs that can't be played where the client is are left out
+	songs, err := h.Playlist.GetPlaylistSongs(c.Context(), playlistId, h.clientLocation(c).CountryCode)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -197,7 +198,7 @@ func (h *Handlers) PutPlaylistsPlaylistId(c *fiber.Ctx, playlistId types.UUID) e
 		playlist.IsPublic = false // Default from gorm tag
 	}
 
-	updatedPlaylist, err := h.Playlist.UpdatePlaylist(c.Context(), playlistId, playlist)
+	updatedPlaylist, err := h.Playlist.UpdatePlaylist(actorContext(c, userID), playlistId, playlist)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -233,7 +234,7 @@ func (h *Handlers) DeletePlaylistsPlaylistId(c *fiber.Ctx, playlistId types.UUID
 		})
 	}
 
-	if err := h.Playlist.DeletePlaylist(c.Context(), playlistId); err != nil {
+	if err := h.Playlist.DeletePlaylist(actorContext(c, userID), playlistId); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
 			Message: "Failed to delete playlist",
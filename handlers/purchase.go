@@ -39,13 +39,10 @@ func (h *Handlers) PostPurchasesAlbums(c *fiber.Ctx) error {
 		})
 	}
 
-	// Process payment
-	purchase, err := h.Purchase.PurchaseAlbum(c.Context(), purchaseReq)
+	// Start the payment; the purchase completes when the provider confirms it
+	purchase, err := h.Purchase.PurchaseAlbum(c.Context(), purchaseReq, h.clientLocation(c).CountryCode)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
-			Code:    fiber.StatusInternalServerError,
-			Message: "Failed to process purchase",
-		})
+		return paymentError(c, err, "Failed to process purchase")
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(purchase)
@@ -85,13 +82,10 @@ func (h *Handlers) PostPurchasesSongs(c *fiber.Ctx) error {
 		})
 	}
 
-	// Process payment
-	purchase, err := h.Purchase.PurchaseSong(c.Context(), purchaseReq)
+	// Start the payment; the purchase completes when the provider confirms it
+	purchase, err := h.Purchase.PurchaseSong(c.Context(), purchaseReq, h.clientLocation(c).CountryCode)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
-			Code:    fiber.StatusInternalServerError,
-			Message: "Failed to process purchase",
-		})
+		return paymentError(c, err, "Failed to process purchase")
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(purchase)
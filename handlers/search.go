@@ -3,38 +3,50 @@ package handlers
 import (
 	"crawl/api"
 	"crawl/models"
+	"crawl/services"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
 )
 
-type GlobalSearch struct {
-	songs     []models.Song
-	albums    []models.Album
-	artists   []models.Artist
-	genre     []models.Genre
-	playlists []models.Playlist
-}
-
 func (h *Handlers) GetSearch(c *fiber.Ctx, params api.GetSearchParams) error {
-	songs, _ := h.Song.SearchSongs(c.Context(), &params.Query, nil, nil, nil, nil, params.Page, params.Limit)
-	playlists, _, _ := h.Playlist.SearchPlaylists(c.Context(), &params.Query, nil, nil, nil, *params.Page, *params.Limit)
-	genres, _ := h.Genre.SearchGenres(c.Context(), &params.Query, nil)
-	artists, _ := h.Artist.SearchArtistsByName(c.Context(), params.Query, *params.Page, *params.Limit)
-	albums, _ := h.Album.SearchAlbums(c.Context(), &params.Query, nil, nil, nil, params.Page, params.Limit)
-
-	result := GlobalSearch{
-		songs:     songs,
-		artists:   artists,
-		albums:    albums,
-		genre:     genres,
-		playlists: playlists,
+	result, err := h.Search.Search(c.Context(), services.FederatedSearchRequest{
+		Query:   params.Query,
+		Types:   params.Types,
+		Page:    params.Page,
+		Limit:   params.Limit,
+		Cursor:  params.Cursor,
+		Facets:  params.Facets != nil && *params.Facets,
+		Country: h.clientLocation(c).CountryCode,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrSearchQueryRequired) ||
+			errors.Is(err, services.ErrInvalidSearchType) ||
+			errors.Is(err, services.ErrInvalidCursor) {
+			return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+				Code:    fiber.StatusBadRequest,
+				Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to search",
+		})
 	}
 
+	// Continuing a section through its cursor is paging, not a new search
+	if params.Cursor == nil {
+		result.SearchID = h.logSearch(c, &params.Query, models.SearchScopeAll, params.Page, result.TotalResults())
+	}
 	return c.JSON(result)
 }
 
 func (h *Handlers) GetSearchAlbums(c *fiber.Ctx, params api.GetSearchAlbumsParams) error {
-	albums, err := h.Album.SearchAlbums(c.Context(), params.Query, params.Artist, params.Genre, (*string)(params.Sort), params.Page, params.Limit)
+	// Albums that can't be played where the client is are left out
+	albums, total, err := h.Album.SearchAlbums(c.Context(), params.Query, params.Artist, params.Genre, (*string)(params.Sort), params.Page, params.Limit,
+		h.clientLocation(c).CountryCode)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -42,11 +54,24 @@ func (h *Handlers) GetSearchAlbums(c *fiber.Ctx, params api.GetSearchAlbumsParam
 		})
 	}
 
+	h.logSearch(c, params.Query, models.SearchScopeAlbums, params.Page, total)
 	return c.JSON(albums)
 }
 
 func (h *Handlers) GetSearchArtists(c *fiber.Ctx, params api.GetSearchArtistsParams) error {
-	artists, err := h.Artist.SearchArtistsByName(c.Context(), *params.Query, *params.Page, *params.Limit)
+	var query string
+	if params.Query != nil {
+		query = *params.Query
+	}
+	page, limit := 1, 20
+	if params.Page != nil {
+		page = *params.Page
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	artists, total, err := h.Artist.SearchArtistsByName(c.Context(), query, page, limit)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -54,6 +79,7 @@ func (h *Handlers) GetSearchArtists(c *fiber.Ctx, params api.GetSearchArtistsPar
 		})
 	}
 
+	h.logSearch(c, params.Query, models.SearchScopeArtists, params.Page, total)
 	return c.JSON(artists)
 }
 
@@ -70,7 +96,7 @@ func (h *Handlers) GetSearchGenres(c *fiber.Ctx, params api.GetSearchGenresParam
 }
 
 func (h *Handlers) GetSearchPlaylists(c *fiber.Ctx, params api.GetSearchPlaylistsParams) error {
-	playlists, _, err := h.Playlist.SearchPlaylists(c.Context(), params.Query, params.Owner, params.IsPublic, (*string)(params.Sort), *params.Page, *params.Limit)
+	playlists, total, err := h.Playlist.SearchPlaylists(c.Context(), params.Query, params.Owner, params.IsPublic, (*string)(params.Sort), *params.Page, *params.Limit)
 	if err != nil {
 		log.Infof("Error occured: %s", err.Error())
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
@@ -79,11 +105,14 @@ func (h *Handlers) GetSearchPlaylists(c *fiber.Ctx, params api.GetSearchPlaylist
 		})
 	}
 
+	h.logSearch(c, params.Query, models.SearchScopePlaylists, params.Page, total)
 	return c.JSON(playlists)
 }
 
 func (h *Handlers) GetSearchSongs(c *fiber.Ctx, params api.GetSearchSongsParams) error {
-	songs, err := h.Song.SearchSongs(c.Context(), params.Query, params.Artist, params.Genre, (*string)(params.Sort), (*string)(params.Order), params.Page, params.Limit)
+	// Songs that can't be played where the client is are left out
+	songs, total, err := h.Song.SearchSongs(c.Context(), params.Query, params.Artist, params.Genre, (*string)(params.Sort), (*string)(params.Order), params.Page, params.Limit,
+		h.clientLocation(c).CountryCode)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -91,5 +120,194 @@ func (h *Handlers) GetSearchSongs(c *fiber.Ctx, params api.GetSearchSongsParams)
 		})
 	}
 
+	h.logSearch(c, params.Query, models.SearchScopeSongs, params.Page, total)
 	return c.JSON(songs)
 }
+
+func (h *Handlers) GetSearchDidYouMean(c *fiber.Ctx, params api.GetSearchDidYouMeanParams) error {
+	suggestions, err := h.Search.DidYouMean(c.Context(), params.Query)
+	if err != nil {
+		log.Errorf("Failed to build search suggestions: %s", err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to build search suggestions",
+		})
+	}
+
+	return c.JSON(suggestions)
+}
+
+func (h *Handlers) GetSearchSuggest(c *fiber.Ctx, params api.GetSearchSuggestParams) error {
+	// Songs and albums that can't be played where the client is are left out
+	suggestions, err := h.Search.Suggest(c.Context(), params.Query, params.Types, params.Limit, h.clientLocation(c).CountryCode)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSuggestionType) {
+			return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+				Code:    fiber.StatusBadRequest,
+				Message: err.Error(),
+			})
+		}
+		log.Errorf("Failed to build autocomplete suggestions: %s", err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to fetch suggestions",
+		})
+	}
+
+	// Clients fire this on every keystroke, so let their cache absorb repeats.
+	// Suggestions depend on where the client is, so shared caches can't keep them.
+	c.Set(fiber.HeaderCacheControl, "private, max-age=30")
+	return c.JSON(suggestions)
+}
+
+// logSearch records the first page of a search for the search analytics and
+// passes its id to the client in X-Search-Id, so opened results can be reported
+func (h *Handlers) logSearch(c *fiber.Ctx, query *string, scope string, page *int, total int64) *types.UUID {
+	if query == nil || (page != nil && *page > 1) {
+		return nil
+	}
+
+	searchID := h.SearchStats.RecordSearch(c.Context(), services.SearchEvent{
+		Query:       *query,
+		Scope:       scope,
+		ResultCount: total,
+		UserID:      h.optionalUserID(c),
+		ClientIP:    h.clientIP(c),
+		UserAgent:   c.Get(fiber.HeaderUserAgent),
+	})
+	if searchID == uuid.Nil {
+		return nil
+	}
+
+	c.Set("X-Search-Id", searchID.String())
+	return &searchID
+}
+
+func searchAnalyticsError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrSearchLogNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidClickType),
+		errors.Is(err, services.ErrInvalidReportRange):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	default:
+		log.Errorf("%s: %s", fallback, err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: fallback,
+		})
+	}
+}
+
+func (h *Handlers) PostSearchClicks(c *fiber.Ctx) error {
+	var req api.SearchClickRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	click := services.SearchClickEvent{
+		SearchID:   req.SearchId,
+		ResultType: string(req.ResultType),
+		ResultID:   req.ResultId,
+	}
+	if req.Position != nil {
+		click.Position = *req.Position
+	}
+
+	if err := h.SearchStats.RecordClick(c.Context(), click); err != nil {
+		return searchAnalyticsError(c, err, "Failed to record click")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *Handlers) GetSearchTrending(c *fiber.Ctx, params api.GetSearchTrendingParams) error {
+	trending, err := h.SearchStats.Trending(c.Context(), params.Limit)
+	if err != nil {
+		return searchAnalyticsError(c, err, "Failed to fetch trending searches")
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(trending)
+}
+
+func (h *Handlers) GetSearchAnalyticsTopQueries(c *fiber.Ctx, params api.GetSearchAnalyticsTopQueriesParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	queries, err := h.SearchStats.TopQueries(c.Context(), params.From, params.To, params.Limit)
+	if err != nil {
+		return searchAnalyticsError(c, err, "Failed to fetch top queries")
+	}
+
+	return c.JSON(queries)
+}
+
+func (h *Handlers) GetSearchAnalyticsZeroResults(c *fiber.Ctx, params api.GetSearchAnalyticsZeroResultsParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	queries, err := h.SearchStats.ZeroResultQueries(c.Context(), params.From, params.To, params.Limit)
+	if err != nil {
+		return searchAnalyticsError(c, err, "Failed to fetch zero-result queries")
+	}
+
+	return c.JSON(queries)
+}
+
+func (h *Handlers) GetSearchAnalyticsClickThrough(c *fiber.Ctx, params api.GetSearchAnalyticsClickThroughParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	report, err := h.SearchStats.ClickThrough(c.Context(), params.From, params.To)
+	if err != nil {
+		return searchAnalyticsError(c, err, "Failed to fetch click-through report")
+	}
+
+	return c.JSON(report)
+}
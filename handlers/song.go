@@ -3,12 +3,16 @@ package handlers
 import (
 	"crawl/api"
 	"crawl/models"
+	"crawl/services"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/oapi-codegen/runtime/types"
 )
 
 func (h *Handlers) GetSongs(c *fiber.Ctx, params api.GetSongsParams) error {
-	songs, err := h.Song.GetAllSongs(c.Context(), params.Page, params.Limit, params.Genre, params.Artist, params.Album)
+	// Songs that can't be played where the client is are left out
+	songs, err := h.Song.GetAllSongs(c.Context(), params.Page, params.Limit, params.Genre, params.Artist, params.Album, params.Tags,
+		h.clientLocation(c).CountryCode)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -36,9 +40,8 @@ func (h *Handlers) PostSongs(c *fiber.Ctx) error {
 		})
 	}
 
-	// Verify the requesting user is the song's artist
-	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
-	if err != nil || artist.ID != songReq.ArtistId {
+	// Verify the requesting user is the song's artist or manages them through a label
+	if !h.canActForArtist(c, userID, songReq.ArtistId, models.LabelPermissionCatalog) {
 		return c.Status(fiber.StatusForbidden).JSON(api.Error{
 			Code:    fiber.StatusForbidden,
 			Message: "You can only create songs for yourself",
@@ -107,7 +110,7 @@ func (h *Handlers) PostSongs(c *fiber.Ctx) error {
 		song.ID = *songReq.Id
 	}
 
-	createdSong, err := h.Song.CreateSong(c.Context(), song)
+	createdSong, err := h.Song.CreateSong(actorContext(c, userID), song)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -127,6 +130,10 @@ func (h *Handlers) GetSongsSongId(c *fiber.Ctx, songId types.UUID) error {
 		})
 	}
 
+	if err := h.Territory.CheckSong(c.Context(), songId, h.clientLocation(c).CountryCode); err != nil {
+		return territoryError(c, err, "Failed to fetch song")
+	}
+
 	return c.JSON(song)
 }
 
@@ -147,7 +154,7 @@ func (h *Handlers) PutSongsSongId(c *fiber.Ctx, songId types.UUID) error {
 		})
 	}
 
-	// Verify the requesting user is the song's artist
+	// Verify the requesting user is the song's artist or manages them through a label
 	song, err := h.Song.GetSongByID(c.Context(), songId)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(api.Error{
@@ -156,8 +163,8 @@ func (h *Handlers) PutSongsSongId(c *fiber.Ctx, songId types.UUID) error {
 		})
 	}
 
-	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
-	if err != nil || artist.ID != song.ArtistID {
+	if !h.canActForArtist(c, userID, song.ArtistID, models.LabelPermissionCatalog) ||
+		!h.canActForArtist(c, userID, songReq.ArtistId, models.LabelPermissionCatalog) {
 		return c.Status(fiber.StatusForbidden).JSON(api.Error{
 			Code:    fiber.StatusForbidden,
 			Message: "You can only update your own songs",
@@ -204,7 +211,7 @@ func (h *Handlers) PutSongsSongId(c *fiber.Ctx, songId types.UUID) error {
 		song.ID = *songReq.Id
 	}
 
-	updatedSong, err := h.Song.UpdateSong(c.Context(), songId, song)
+	updatedSong, err := h.Song.UpdateSong(actorContext(c, userID), songId, song)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -224,7 +231,7 @@ func (h *Handlers) DeleteSongsSongId(c *fiber.Ctx, songId types.UUID) error {
 		})
 	}
 
-	// Verify the requesting user is the song's artist
+	// Verify the requesting user is the song's artist or manages them through a label
 	song, err := h.Song.GetSongByID(c.Context(), songId)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(api.Error{
@@ -233,15 +240,14 @@ func (h *Handlers) DeleteSongsSongId(c *fiber.Ctx, songId types.UUID) error {
 		})
 	}
 
-	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
-	if err != nil || artist.ID != song.ArtistID {
+	if !h.canActForArtist(c, userID, song.ArtistID, models.LabelPermissionCatalog) {
 		return c.Status(fiber.StatusForbidden).JSON(api.Error{
 			Code:    fiber.StatusForbidden,
 			Message: "You can only delete your own songs",
 		})
 	}
 
-	if err := h.Song.DeleteSong(c.Context(), songId); err != nil {
+	if err := h.Song.DeleteSong(actorContext(c, userID), songId); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
 			Message: "Failed to delete song",
@@ -280,7 +286,7 @@ func (h *Handlers) PostSongsSongIdContributors(c *fiber.Ctx, songId types.UUID)
 		})
 	}
 
-	// Verify the requesting user is the song's artist
+	// Verify the requesting user is the song's artist or manages them through a label
 	song, err := h.Song.GetSongByID(c.Context(), songId)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(api.Error{
@@ -289,8 +295,7 @@ func (h *Handlers) PostSongsSongIdContributors(c *fiber.Ctx, songId types.UUID)
 		})
 	}
 
-	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
-	if err != nil || artist.ID != song.ArtistID {
+	if !h.canActForArtist(c, userID, song.ArtistID, models.LabelPermissionCatalog) {
 		return c.Status(fiber.StatusForbidden).JSON(api.Error{
 			Code:    fiber.StatusForbidden,
 			Message: "You can only add contributors to your own songs",
@@ -309,7 +314,7 @@ func (h *Handlers) PostSongsSongIdContributors(c *fiber.Ctx, songId types.UUID)
 	contributor := &models.SongContributor{
 		SongID:           songId,
 		ArtistID:         contributorReq.ArtistId,
-		ContributionType: contributorReq.ContributionType,
+		ContributionType: string(contributorReq.ContributionType),
 	}
 
 	if contributorReq.RoyaltyPercentage != nil {
@@ -318,6 +323,12 @@ func (h *Handlers) PostSongsSongIdContributors(c *fiber.Ctx, songId types.UUID)
 
 	err = h.Song.AddSongContributor(c.Context(), songId, contributor)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidCreditRole) || errors.Is(err, services.ErrPrimaryArtistCredit) {
+			return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+				Code:    fiber.StatusBadRequest,
+				Message: err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
 			Message: "Failed to add contributor",
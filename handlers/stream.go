@@ -2,8 +2,19 @@ package handlers
 
 import (
 	"crawl/api"
+	"crawl/geoip"
 	"crawl/models"
+	"crawl/services"
+	"errors"
 	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime/types"
+	"unicode/utf8"
+)
+
+// The longest device type and id streams store
+const (
+	maxDeviceTypeLength = 50
+	maxDeviceIDLength   = 100
 )
 
 func (h *Handlers) PostStreams(c *fiber.Ctx) error {
@@ -23,41 +34,199 @@ func (h *Handlers) PostStreams(c *fiber.Ctx) error {
 		})
 	}
 
-	// Verify the song exists
-	_, err = h.Song.GetSongByID(c.Context(), streamReq.SongId)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
-			Code:    fiber.StatusBadRequest,
-			Message: "Song not found",
-		})
-	}
-
 	// Record the stream
 	stream := models.Stream{
+		UserID:    &userID,
 		SongID:    streamReq.SongId,
 		IsPreview: false, // default
+		ClientIP:  h.clientIP(c),
 	}
 
-	*stream.UserID = userID
-
+	// The country comes from the client address, and stays unresolved without a
+	// GeoIP database; the one sent is only kept as ClaimedCountry
+	location := h.clientLocation(c)
+	stream.CountryCode = location.CountryCode
+	stream.Region = location.Region
 	if streamReq.CountryCode != nil {
-		stream.CountryCode = *streamReq.CountryCode
+		stream.ClaimedCountry = geoip.ClaimedCountry(*streamReq.CountryCode)
 	}
 
 	if streamReq.DeviceType != nil {
 		stream.DeviceType = *streamReq.DeviceType
 	}
 
+	if streamReq.DeviceId != nil {
+		stream.DeviceID = *streamReq.DeviceId
+	}
+
+	// Checked here, as a value too long for its column would fail the whole
+	// batch the stream is written with
+	if utf8.RuneCountInString(stream.DeviceType) > maxDeviceTypeLength ||
+		utf8.RuneCountInString(stream.DeviceID) > maxDeviceIDLength {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "deviceType can be at most 50 characters and deviceId at most 100",
+		})
+	}
+
 	if streamReq.IsPreview != nil {
 		stream.IsPreview = *streamReq.IsPreview
 	}
 
+	if streamReq.ListenedSeconds != nil {
+		stream.ListenedSeconds = *streamReq.ListenedSeconds
+		stream.Tracked = true
+	}
+
+	if streamReq.PositionSeconds != nil {
+		stream.PositionSeconds = *streamReq.PositionSeconds
+	}
+
+	if streamReq.ContextType != nil {
+		stream.ContextType = string(*streamReq.ContextType)
+	}
+	stream.ContextID = streamReq.ContextId
+
+	// Players that report playback events have their play recorded when it ends
+	if streamReq.Event != nil {
+		event := services.PlaybackEvent{Event: string(*streamReq.Event), Stream: stream}
+		if streamReq.SessionId != nil {
+			event.SessionID = *streamReq.SessionId
+		}
+
+		sessionID, err := h.Stream.RecordPlayback(c.Context(), event)
+		if err != nil {
+			return streamError(c, err)
+		}
+		return c.Status(fiber.StatusAccepted).JSON(api.PlaybackSession{SessionId: &sessionID})
+	}
+
 	if err := h.Stream.RecordStream(c.Context(), stream); err != nil {
+		return streamError(c, err)
+	}
+
+	// Accepted for recording; it's written with the next batch
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+func streamError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrSongIDRequired),
+		errors.Is(err, services.ErrStreamSongNotFound),
+		errors.Is(err, services.ErrInvalidPlayContext),
+		errors.Is(err, services.ErrInvalidPlaybackEvent),
+		errors.Is(err, services.ErrPlaybackSessionRequired),
+		errors.Is(err, services.ErrInvalidPlaybackPosition):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrNotAvailableInRegion):
+		return territoryError(c, err, "Failed to record stream")
+	case errors.Is(err, services.ErrPlaybackSessionNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrTooManyPlaybackSessions):
+		return c.Status(fiber.StatusTooManyRequests).JSON(api.Error{
+			Code:    fiber.StatusTooManyRequests,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrStreamsBusy):
+		c.Set(fiber.HeaderRetryAfter, "1")
+		return c.Status(fiber.StatusServiceUnavailable).JSON(api.Error{
+			Code:    fiber.StatusServiceUnavailable,
+			Message: err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: "Failed to record stream",
+	})
+}
+
+func (h *Handlers) GetStreamsSuspicious(c *fiber.Ctx, params api.GetStreamsSuspiciousParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	streams, err := h.Stream.GetSuspicious(c.Context(), params.Page, params.Limit)
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
-			Message: "Failed to record stream",
+			Message: "Failed to fetch suspicious streams",
+		})
+	}
+
+	return c.JSON(streams)
+}
+
+func (h *Handlers) PostStreamsStreamIdReview(c *fiber.Ctx, streamId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	var reviewReq api.StreamReview
+	if err := c.BodyParser(&reviewReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	stream, err := h.Stream.Review(c.Context(), streamId, userID, string(reviewReq.Decision))
+	if err != nil {
+		return streamReviewError(c, err)
+	}
+
+	return c.JSON(stream)
+}
+
+func streamReviewError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrStreamNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrStreamReviewed):
+		return c.Status(fiber.StatusConflict).JSON(api.Error{
+			Code:    fiber.StatusConflict,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidStreamDecision):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
 		})
 	}
 
-	return c.SendStatus(fiber.StatusCreated)
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: "Failed to review stream",
+	})
 }
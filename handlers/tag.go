@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"crawl/api"
+	"crawl/models"
+	"crawl/services"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+func (h *Handlers) GetTags(c *fiber.Ctx, params api.GetTagsParams) error {
+	tags, err := h.Tag.GetTags(c.Context(), (*string)(params.Kind))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to fetch tags",
+		})
+	}
+
+	return c.JSON(tags)
+}
+
+func (h *Handlers) PostTags(c *fiber.Ctx) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	var tagReq api.Tag
+	if err := c.BodyParser(&tagReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	tag := &models.Tag{Name: tagReq.Name}
+	if tagReq.Kind != nil {
+		tag.Kind = string(*tagReq.Kind)
+	}
+
+	created, err := h.Tag.CreateTag(c.Context(), tag)
+	if err != nil {
+		return tagError(c, err, "Failed to create tag")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+func (h *Handlers) DeleteTagsTagId(c *fiber.Ctx, tagId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	if err := h.Tag.DeleteTag(c.Context(), tagId); err != nil {
+		return tagError(c, err, "Failed to delete tag")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *Handlers) GetSongsSongIdTags(c *fiber.Ctx, songId types.UUID) error {
+	tags, err := h.Tag.GetSongTags(c.Context(), songId)
+	if err != nil {
+		return tagError(c, err, "Failed to fetch song tags")
+	}
+
+	return c.JSON(tags)
+}
+
+func (h *Handlers) PutSongsSongIdTags(c *fiber.Ctx, songId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	var tagReq api.TagAssignment
+	if err := c.BodyParser(&tagReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Only the song's artist and admins can tag it
+	song, err := h.Song.GetSongByID(c.Context(), songId)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Song not found",
+		})
+	}
+
+	if !h.canActForArtist(c, userID, song.ArtistID, models.LabelPermissionCatalog) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only tag your own songs",
+		})
+	}
+
+	tags, err := h.Tag.SetSongTags(c.Context(), songId, stringList(tagReq.Tags), stringList(tagReq.Moods))
+	if err != nil {
+		return tagError(c, err, "Failed to update song tags")
+	}
+
+	return c.JSON(tags)
+}
+
+func (h *Handlers) GetAlbumsAlbumIdTags(c *fiber.Ctx, albumId types.UUID) error {
+	tags, err := h.Tag.GetAlbumTags(c.Context(), albumId)
+	if err != nil {
+		return tagError(c, err, "Failed to fetch album tags")
+	}
+
+	return c.JSON(tags)
+}
+
+func (h *Handlers) PutAlbumsAlbumIdTags(c *fiber.Ctx, albumId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	var tagReq api.TagAssignment
+	if err := c.BodyParser(&tagReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Only the album's artist and admins can tag it
+	album, err := h.Album.GetAlbumByID(c.Context(), albumId)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Album not found",
+		})
+	}
+
+	if !h.canActForArtist(c, userID, album.ArtistID, models.LabelPermissionCatalog) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only tag your own albums",
+		})
+	}
+
+	tags, err := h.Tag.SetAlbumTags(c.Context(), albumId, stringList(tagReq.Tags), stringList(tagReq.Moods))
+	if err != nil {
+		return tagError(c, err, "Failed to update album tags")
+	}
+
+	return c.JSON(tags)
+}
+
+func stringList(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
+
+func tagError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrTagNotFound), errors.Is(err, services.ErrTaggedItemNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrTagExists):
+		return c.Status(fiber.StatusConflict).JSON(api.Error{
+			Code:    fiber.StatusConflict,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidTag), errors.Is(err, services.ErrInvalidKind), errors.Is(err, services.ErrTooManyTags):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: message,
+	})
+}
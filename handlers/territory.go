@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"crawl/api"
+	"crawl/models"
+	"crawl/services"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+func territoryError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrNotAvailableInRegion):
+		return c.Status(fiber.StatusUnavailableForLegalReasons).JSON(api.Error{
+			Code:    fiber.StatusUnavailableForLegalReasons,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidTerritoryMode),
+		errors.Is(err, services.ErrInvalidTerritory),
+		errors.Is(err, services.ErrInvalidTerritoryRange):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrTerritoryRuleNotFound),
+		errors.Is(err, services.ErrTerritorySongNotFound),
+		errors.Is(err, services.ErrTerritoryAlbumNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: fallback,
+	})
+}
+
+// territoryArtist returns the artist whose song or album the territory rules
+// are for, or a zero ID when it's gone
+func (h *Handlers) territoryArtist(c *fiber.Ctx, songID, albumID *types.UUID) types.UUID {
+	if songID != nil {
+		if song, err := h.Song.GetSongByID(c.Context(), *songID); err == nil {
+			return song.ArtistID
+		}
+	}
+	if albumID != nil {
+		if album, err := h.Album.GetAlbumByID(c.Context(), *albumID); err == nil {
+			return album.ArtistID
+		}
+	}
+	return types.UUID{}
+}
+
+func (h *Handlers) GetSongsSongIdTerritories(c *fiber.Ctx, songId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	song, err := h.Song.GetSongByID(c.Context(), songId)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Song not found",
+		})
+	}
+	if !h.canActForArtist(c, userID, song.ArtistID, models.LabelPermissionCatalog) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view the territories of your own songs",
+		})
+	}
+
+	rules, err := h.Territory.GetSongRules(c.Context(), songId)
+	if err != nil {
+		return territoryError(c, err, "Failed to fetch territory rules")
+	}
+	return c.JSON(rules)
+}
+
+func (h *Handlers) PostSongsSongIdTerritories(c *fiber.Ctx, songId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	var req api.PostSongsSongIdTerritoriesJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	song, err := h.Song.GetSongByID(c.Context(), songId)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Song not found",
+		})
+	}
+	if !h.canActForArtist(c, userID, song.ArtistID, models.LabelPermissionCatalog) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only restrict your own songs",
+		})
+	}
+
+	rules, err := h.Territory.AddSongRules(c.Context(), songId, services.TerritoryRules{
+		Mode:      string(req.Mode),
+		Countries: req.CountryCodes,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+	}, userID)
+	if err != nil {
+		return territoryError(c, err, "Failed to add territory rules")
+	}
+	return c.Status(fiber.StatusCreated).JSON(rules)
+}
+
+func (h *Handlers) GetAlbumsAlbumIdTerritories(c *fiber.Ctx, albumId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	album, err := h.Album.GetAlbumByID(c.Context(), albumId)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Album not found",
+		})
+	}
+	if !h.canActForArtist(c, userID, album.ArtistID, models.LabelPermissionCatalog) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view the territories of your own albums",
+		})
+	}
+
+	rules, err := h.Territory.GetAlbumRules(c.Context(), albumId)
+	if err != nil {
+		return territoryError(c, err, "Failed to fetch territory rules")
+	}
+	return c.JSON(rules)
+}
+
+func (h *Handlers) PostAlbumsAlbumIdTerritories(c *fiber.Ctx, albumId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	var req api.PostAlbumsAlbumIdTerritoriesJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	album, err := h.Album.GetAlbumByID(c.Context(), albumId)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: "Album not found",
+		})
+	}
+	if !h.canActForArtist(c, userID, album.ArtistID, models.LabelPermissionCatalog) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only restrict your own albums",
+		})
+	}
+
+	rules, err := h.Territory.AddAlbumRules(c.Context(), albumId, services.TerritoryRules{
+		Mode:      string(req.Mode),
+		Countries: req.CountryCodes,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+	}, userID)
+	if err != nil {
+		return territoryError(c, err, "Failed to add territory rules")
+	}
+	return c.Status(fiber.StatusCreated).JSON(rules)
+}
+
+func (h *Handlers) DeleteTerritoriesRuleId(c *fiber.Ctx, ruleId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	rule, err := h.Territory.GetRule(c.Context(), ruleId)
+	if err != nil {
+		return territoryError(c, err, "Failed to fetch territory rule")
+	}
+	artistID := h.territoryArtist(c, rule.SongID, rule.AlbumID)
+	if !h.canActForArtist(c, userID, artistID, models.LabelPermissionCatalog) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only lift restrictions on your own catalog",
+		})
+	}
+
+	if err := h.Territory.DeleteRule(c.Context(), ruleId); err != nil {
+		return territoryError(c, err, "Failed to delete territory rule")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -31,13 +31,10 @@ func (h *Handlers) PostTips(c *fiber.Ctx) error {
 		})
 	}
 
-	// Process tip payment
+	// Start the tip's payment; the artist is credited when the provider confirms it
 	tip, err := h.Tip.SendTip(c.Context(), tipReq, userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
-			Code:    fiber.StatusInternalServerError,
-			Message: "Failed to process tip",
-		})
+		return paymentError(c, err, "Failed to process tip")
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(tip)
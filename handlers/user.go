@@ -259,7 +259,7 @@ func (h *Handlers) PostUsersUserIdPlaylists(c *fiber.Ctx, userId types.UUID) err
 		newPlaylist.ID = *playlistReq.Id
 	}
 
-	createdPlaylist, err := h.User.CreatePlaylist(c.Context(), userId, newPlaylist)
+	createdPlaylist, err := h.User.CreatePlaylist(actorContext(c, requestingUserID), userId, newPlaylist)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -287,7 +287,8 @@ func (h *Handlers) GetUsersUserIdLibraryAlbums(c *fiber.Ctx, userId types.UUID)
 		})
 	}
 
-	albums, err := h.User.GetUserPurchasedAlbums(c.Context(), userId)
+	// Albums that can't be played where the client is are left out
+	albums, err := h.User.GetUserPurchasedAlbums(c.Context(), userId, h.clientLocation(c).CountryCode)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -315,7 +316,8 @@ func (h *Handlers) GetUsersUserIdLibrarySongs(c *fiber.Ctx, userId types.UUID) e
 		})
 	}
 
-	songs, err := h.User.GetUserPurchasedSongs(c.Context(), userId)
+	// Songs that can't be played where the client is are left out
+	songs, err := h.User.GetUserPurchasedSongs(c.Context(), userId, h.clientLocation(c).CountryCode)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
 			Code:    fiber.StatusInternalServerError,
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"crawl/api"
+	"crawl/services"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime/types"
+	"mime/multipart"
+)
+
+func (h *Handlers) GetArtistsArtistIdVerification(c *fiber.Ctx, artistId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.ownsArtistOrAdmin(c, userID, artistId) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view your own verification requests",
+		})
+	}
+
+	requests, err := h.Verification.GetArtistRequests(c.Context(), artistId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to fetch verification requests",
+		})
+	}
+
+	return c.JSON(requests)
+}
+
+func (h *Handlers) PostArtistsArtistIdVerification(c *fiber.Ctx, artistId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Only the artist themselves can ask to be verified
+	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
+	if err != nil || artist.ID != artistId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only request verification for yourself",
+		})
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid multipart form",
+		})
+	}
+
+	var message string
+	if values := form.Value["message"]; len(values) > 0 {
+		message = values[0]
+	}
+
+	documents := make([]services.EvidenceUpload, 0, len(form.File["documents"]))
+	for _, header := range form.File["documents"] {
+		file, err := header.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+				Code:    fiber.StatusBadRequest,
+				Message: "Failed to read " + header.Filename,
+			})
+		}
+		defer func(file multipart.File) { _ = file.Close() }(file)
+
+		documents = append(documents, services.EvidenceUpload{
+			FileName:    header.Filename,
+			ContentType: header.Header.Get(fiber.HeaderContentType),
+			Size:        header.Size,
+			Content:     file,
+		})
+	}
+
+	request, err := h.Verification.SubmitRequest(actorContext(c, userID), artistId, userID, message, form.Value["links"], documents)
+	if err != nil {
+		return verificationError(c, err, "Failed to submit verification request")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(request)
+}
+
+func (h *Handlers) PostArtistsArtistIdVerificationRevoke(c *fiber.Ctx, artistId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	var revokeReq api.VerificationRevocation
+	if err := c.BodyParser(&revokeReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.Verification.Revoke(actorContext(c, userID), artistId, userID, revokeReq.Notes); err != nil {
+		return verificationError(c, err, "Failed to revoke verification")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *Handlers) GetVerificationRequests(c *fiber.Ctx, params api.GetVerificationRequestsParams) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	requests, err := h.Verification.GetQueue(c.Context(), (*string)(params.Status), params.Page, params.Limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Failed to fetch verification queue",
+		})
+	}
+
+	return c.JSON(requests)
+}
+
+func (h *Handlers) GetVerificationRequestsRequestId(c *fiber.Ctx, requestId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	request, err := h.Verification.GetRequest(c.Context(), requestId)
+	if err != nil {
+		return verificationError(c, err, "Failed to fetch verification request")
+	}
+
+	if !h.ownsArtistOrAdmin(c, userID, request.ArtistID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view your own verification requests",
+		})
+	}
+
+	return c.JSON(request)
+}
+
+func (h *Handlers) PostVerificationRequestsRequestIdReview(c *fiber.Ctx, requestId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "Admin access required",
+		})
+	}
+
+	var reviewReq api.VerificationReview
+	if err := c.BodyParser(&reviewReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "Invalid request body",
+		})
+	}
+
+	var notes string
+	if reviewReq.Notes != nil {
+		notes = *reviewReq.Notes
+	}
+
+	request, err := h.Verification.Review(actorContext(c, userID), requestId, userID, string(reviewReq.Decision), notes)
+	if err != nil {
+		return verificationError(c, err, "Failed to review verification request")
+	}
+
+	return c.JSON(request)
+}
+
+func (h *Handlers) GetVerificationRequestsRequestIdEvidenceEvidenceId(c *fiber.Ctx, requestId types.UUID, evidenceId types.UUID) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	request, err := h.Verification.GetRequest(c.Context(), requestId)
+	if err != nil {
+		return verificationError(c, err, "Failed to fetch verification request")
+	}
+
+	if !h.ownsArtistOrAdmin(c, userID, request.ArtistID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only view your own verification documents",
+		})
+	}
+
+	evidence, content, err := h.Verification.OpenDocument(c.Context(), requestId, evidenceId)
+	if err != nil {
+		return verificationError(c, err, "Failed to open document")
+	}
+
+	c.Set(fiber.HeaderContentType, evidence.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", evidence.FileName))
+	// Fiber closes the reader once the body has been written
+	return c.SendStream(content, int(evidence.Size))
+}
+
+// ownsArtistOrAdmin reports whether userID is the artist's own account or an admin
+func (h *Handlers) ownsArtistOrAdmin(c *fiber.Ctx, userID types.UUID, artistID types.UUID) bool {
+	artist, err := h.User.GetArtistByUserId(c.Context(), userID)
+	if err == nil && artist.ID == artistID {
+		return true
+	}
+	return h.isAdmin(c, userID)
+}
+
+func verificationError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrArtistNotFound),
+		errors.Is(err, services.ErrVerificationNotFound),
+		errors.Is(err, services.ErrEvidenceNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrAlreadyVerified),
+		errors.Is(err, services.ErrNotVerified),
+		errors.Is(err, services.ErrVerificationPending),
+		errors.Is(err, services.ErrVerificationClosed):
+		return c.Status(fiber.StatusConflict).JSON(api.Error{
+			Code:    fiber.StatusConflict,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrNoEvidence),
+		errors.Is(err, services.ErrInvalidEvidence),
+		errors.Is(err, services.ErrInvalidDecision),
+		errors.Is(err, services.ErrReviewNotesRequired):
+		return c.Status(fiber.StatusBadRequest).JSON(api.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: message,
+	})
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"crawl/api"
+	"crawl/models"
+	"crawl/services"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+func wrappedError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrWrappedNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(api.Error{
+			Code:    fiber.StatusNotFound,
+			Message: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(api.Error{
+		Code:    fiber.StatusInternalServerError,
+		Message: "Failed to fetch year in review",
+	})
+}
+
+func (h *Handlers) GetUsersUserIdWrappedYear(c *fiber.Ctx, userId types.UUID, year api.WrappedYear) error {
+	requestingUserID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	// Verify the requesting user is accessing their own year in review
+	if requestingUserID != userId {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You can only access your own year in review",
+		})
+	}
+
+	report, err := h.Wrapped.GetListenerReport(c.Context(), userId, year)
+	if err != nil {
+		return wrappedError(c, err)
+	}
+	return c.JSON(report)
+}
+
+func (h *Handlers) GetArtistsArtistIdWrappedYear(c *fiber.Ctx, artistId types.UUID, year api.WrappedYear) error {
+	userID, err := h.getUserIDFromToken(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(api.Error{
+			Code:    fiber.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	if !h.canActForArtist(c, userID, artistId, models.LabelPermissionAnalytics) && !h.isAdmin(c, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(api.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "You don't have access to this artist's year in review",
+		})
+	}
+
+	report, err := h.Wrapped.GetArtistReport(c.Context(), artistId, year)
+	if err != nil {
+		return wrappedError(c, err)
+	}
+	return c.JSON(report)
+}
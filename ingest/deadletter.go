@@ -0,0 +1,80 @@
+package ingest
+
+import (
+	"crawl/models"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+)
+
+// writeBatch writes streams to sink. When the sink rejects the batch it's split
+// in halves, and those halves again, until the rejected streams are singled out
+// and the rest written; the rejected ones are returned. Any other error stops
+// the write, to be retried whole: the sink ignores streams it already has.
+func writeBatch(sink Sink, streams []models.Stream) ([]models.Stream, error) {
+	err := sink.CreateBatch(streams)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, ErrRejected) {
+		return nil, err
+	}
+	if len(streams) == 1 {
+		log.Printf("stream pipeline: sink rejected stream %s: %v", streams[0].ID, err)
+		return streams, nil
+	}
+
+	half := len(streams) / 2
+	rejected, err := writeBatch(sink, streams[:half])
+	if err != nil {
+		return nil, err
+	}
+	more, err := writeBatch(sink, streams[half:])
+	if err != nil {
+		return nil, err
+	}
+	return append(rejected, more...), nil
+}
+
+// setAside appends streams the sink rejected to the dead-letter file, one JSON
+// document per line like the write-ahead log, to be fixed and replayed by hand.
+// Without the file, or if it can't be written, they're logged in full instead.
+func (p *Pipeline) setAside(streams []models.Stream) {
+	if len(streams) == 0 {
+		return
+	}
+	err := appendDeadLetter(p.cfg.DeadLetterPath, streams)
+	if err == nil {
+		log.Printf("stream pipeline: set aside %d rejected streams in %s", len(streams), p.cfg.DeadLetterPath)
+		return
+	}
+
+	log.Printf("stream pipeline: failed to set aside %d rejected streams, dropping them: %v", len(streams), err)
+	for _, stream := range streams {
+		line, _ := json.Marshal(walEntry{Stream: stream})
+		log.Printf("stream pipeline: dropped %s", line)
+	}
+}
+
+func appendDeadLetter(path string, streams []models.Stream) error {
+	if path == "" {
+		return errors.New("no dead-letter file configured")
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for _, stream := range streams {
+		line, err := json.Marshal(walEntry{Stream: stream})
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return file.Sync()
+}
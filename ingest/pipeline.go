@@ -0,0 +1,271 @@
+package ingest
+
+import (
+	"context"
+	"crawl/models"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrQueueFull      = errors.New("stream queue is full")
+	ErrPipelineClosed = errors.New("stream pipeline is shut down")
+	// ErrRejected marks a Sink error the streams themselves caused, such as a
+	// value too long for its column, which no retry can get past
+	ErrRejected = errors.New("streams rejected")
+)
+
+// Sink persists a batch of streams along with the play counts they add. Writing
+// a stream whose id is already stored must be a no-op, so batches can be
+// retried and replayed from the write-ahead log. Errors wrapping ErrRejected
+// have the batch split up and the streams that keep failing set aside.
+type Sink interface {
+	CreateBatch(streams []models.Stream) error
+}
+
+type Config struct {
+	// BufferSize bounds the streams held in memory, queued or being written;
+	// once reached, Enqueue waits for room
+	BufferSize int
+	// BatchSize is how many streams are written per transaction
+	BatchSize int
+	// FlushInterval is the longest a queued stream waits for its batch to fill
+	FlushInterval time.Duration
+	// EnqueueTimeout is how long Enqueue waits for room before giving up with ErrQueueFull
+	EnqueueTimeout time.Duration
+	// WALDir, when set, holds a write-ahead log: streams are appended to it before
+	// Enqueue returns and replayed on startup if the process died before writing them
+	WALDir string
+	// DeadLetterPath is the file streams the sink rejects are appended to; when
+	// empty they're only logged
+	DeadLetterPath string
+}
+
+var DefaultConfig = Config{
+	BufferSize:     10000,
+	BatchSize:      500,
+	FlushInterval:  time.Second,
+	EnqueueTimeout: 100 * time.Millisecond,
+}
+
+const maxRetryBackoff = 30 * time.Second
+
+type event struct {
+	stream  models.Stream
+	segment int // write-ahead log segment holding the stream, -1 without a log
+}
+
+// Pipeline queues streams in memory and writes them to a Sink in batches from a
+// single goroutine, so plays of the same song become one play count update per
+// batch instead of one per request
+type Pipeline struct {
+	cfg  Config
+	sink Sink
+	wal  *wal
+
+	// slots holds a token for every stream queued or being written, bounding memory
+	slots  chan struct{}
+	events chan event
+
+	// mu keeps Enqueue from sending on events once Close has closed it
+	mu      sync.RWMutex
+	closed  bool
+	done    chan struct{}
+	abandon chan struct{}
+}
+
+// NewPipeline replays any streams left in the write-ahead log, then starts the writer
+func NewPipeline(cfg Config, sink Sink) (*Pipeline, error) {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = DefaultConfig.BufferSize
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultConfig.BatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultConfig.FlushInterval
+	}
+	if cfg.EnqueueTimeout <= 0 {
+		cfg.EnqueueTimeout = DefaultConfig.EnqueueTimeout
+	}
+
+	p := &Pipeline{
+		cfg:     cfg,
+		sink:    sink,
+		slots:   make(chan struct{}, cfg.BufferSize),
+		events:  make(chan event, cfg.BufferSize),
+		done:    make(chan struct{}),
+		abandon: make(chan struct{}),
+	}
+
+	if cfg.WALDir != "" {
+		w, err := openWAL(cfg.WALDir, func(streams []models.Stream) error {
+			for start := 0; start < len(streams); start += cfg.BatchSize {
+				rejected, err := writeBatch(sink, streams[start:min(start+cfg.BatchSize, len(streams))])
+				if err != nil {
+					return err
+				}
+				p.setAside(rejected)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		p.wal = w
+	}
+
+	go p.run()
+	return p, nil
+}
+
+// Enqueue queues stream for writing. When the buffer is full it waits up to the
+// configured timeout for room, then fails with ErrQueueFull.
+func (p *Pipeline) Enqueue(ctx context.Context, stream models.Stream) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPipelineClosed
+	}
+
+	timer := time.NewTimer(p.cfg.EnqueueTimeout)
+	defer timer.Stop()
+	select {
+	case p.slots <- struct{}{}:
+	case <-timer.C:
+		return ErrQueueFull
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// Ids are assigned up front so retries and replays can't record a stream twice
+	if stream.ID == uuid.Nil {
+		stream.ID = uuid.New()
+	}
+	if stream.CreatedAt.IsZero() {
+		stream.CreatedAt = time.Now()
+	}
+
+	ev := event{stream: stream, segment: -1}
+	if p.wal != nil {
+		segment, err := p.wal.append(stream)
+		if err != nil {
+			<-p.slots
+			return err
+		}
+		ev.segment = segment
+	}
+
+	// Never blocks: events has room for every slot
+	p.events <- ev
+	return nil
+}
+
+// Close stops accepting streams and writes everything queued. If ctx ends first
+// the remaining streams are dropped from memory; with a write-ahead log they are
+// written on the next start.
+func (p *Pipeline) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.events)
+	p.mu.Unlock()
+
+	var err error
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		close(p.abandon)
+		<-p.done
+		err = ctx.Err()
+	}
+
+	if p.wal != nil {
+		if closeErr := p.wal.close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (p *Pipeline) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]event, 0, p.cfg.BatchSize)
+	for {
+		select {
+		case ev, ok := <-p.events:
+			if !ok {
+				p.flush(batch)
+				return
+			}
+			batch = append(batch, ev)
+			if len(batch) >= p.cfg.BatchSize {
+				p.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				p.flush(batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+// flush writes batch, retrying with backoff until it succeeds or Close gives up.
+// Streams the sink rejects are set aside rather than retried.
+func (p *Pipeline) flush(batch []event) {
+	if len(batch) == 0 {
+		return
+	}
+
+	streams := make([]models.Stream, len(batch))
+	for i, ev := range batch {
+		streams[i] = ev.stream
+	}
+
+	written := true
+	backoff := 100 * time.Millisecond
+	for {
+		rejected, err := writeBatch(p.sink, streams)
+		if err == nil {
+			p.setAside(rejected)
+			break
+		}
+		log.Printf("stream pipeline: failed to write %d streams, retrying in %s: %v", len(streams), backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-p.abandon:
+			log.Printf("stream pipeline: dropped %d unwritten streams on shutdown", len(streams))
+			written = false
+		}
+		if !written {
+			break
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+
+	// Unwritten streams stay in the log to be replayed
+	if p.wal != nil && written {
+		segments := make([]int, len(batch))
+		for i, ev := range batch {
+			segments[i] = ev.segment
+		}
+		p.wal.release(segments)
+	}
+	for range batch {
+		<-p.slots
+	}
+}
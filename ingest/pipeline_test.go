@@ -0,0 +1,241 @@
+package ingest
+
+import (
+	"context"
+	"crawl/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeSink records the batches written to it; fail, when set, decides whether
+// a batch fails instead
+type fakeSink struct {
+	mu      sync.Mutex
+	batches [][]models.Stream
+	fail    func(streams []models.Stream) error
+}
+
+func (s *fakeSink) CreateBatch(streams []models.Stream) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail != nil {
+		if err := s.fail(streams); err != nil {
+			return err
+		}
+	}
+	s.batches = append(s.batches, append([]models.Stream(nil), streams...))
+	return nil
+}
+
+// written returns the ids of every stream written, in order
+func (s *fakeSink) written() []uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []uuid.UUID
+	for _, batch := range s.batches {
+		for _, stream := range batch {
+			ids = append(ids, stream.ID)
+		}
+	}
+	return ids
+}
+
+func newStreams(n int) []models.Stream {
+	streams := make([]models.Stream, n)
+	for i := range streams {
+		streams[i] = models.Stream{SongID: uuid.New()}
+		streams[i].ID = uuid.New()
+	}
+	return streams
+}
+
+func sameIDs(got []uuid.UUID, streams []models.Stream) bool {
+	if len(got) != len(streams) {
+		return false
+	}
+	for i, stream := range streams {
+		if got[i] != stream.ID {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPipelineBatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		streams   int
+		batchSize int
+		// want is the size of each batch written
+		want []int
+	}{
+		{name: "full batches", streams: 6, batchSize: 3, want: []int{3, 3}},
+		{name: "partial batch on close", streams: 4, batchSize: 3, want: []int{3, 1}},
+		{name: "nothing queued", streams: 0, batchSize: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &fakeSink{}
+			p, err := NewPipeline(Config{BatchSize: tt.batchSize, FlushInterval: time.Hour}, sink)
+			if err != nil {
+				t.Fatalf("NewPipeline: %v", err)
+			}
+			streams := newStreams(tt.streams)
+			for _, stream := range streams {
+				if err := p.Enqueue(context.Background(), stream); err != nil {
+					t.Fatalf("Enqueue: %v", err)
+				}
+			}
+			if err := p.Close(context.Background()); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			if len(sink.batches) != len(tt.want) {
+				t.Fatalf("wrote %d batches, want %d", len(sink.batches), len(tt.want))
+			}
+			for i, batch := range sink.batches {
+				if len(batch) != tt.want[i] {
+					t.Errorf("batch %d has %d streams, want %d", i, len(batch), tt.want[i])
+				}
+			}
+			if !sameIDs(sink.written(), streams) {
+				t.Errorf("wrote %v, want the streams in the order queued", sink.written())
+			}
+			if err := p.Enqueue(context.Background(), newStreams(1)[0]); !errors.Is(err, ErrPipelineClosed) {
+				t.Errorf("Enqueue after Close: got %v, want %v", err, ErrPipelineClosed)
+			}
+		})
+	}
+}
+
+func TestPipelineQueueFull(t *testing.T) {
+	// The sink holds the first batch until released, so the buffer fills up
+	release := make(chan struct{})
+	sink := &fakeSink{fail: func([]models.Stream) error {
+		<-release
+		return nil
+	}}
+	p, err := NewPipeline(Config{BufferSize: 2, BatchSize: 1, EnqueueTimeout: 10 * time.Millisecond}, sink)
+	if err != nil {
+		t.Fatalf("NewPipeline: %v", err)
+	}
+
+	streams := newStreams(3)
+	for _, stream := range streams[:2] {
+		if err := p.Enqueue(context.Background(), stream); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	if err := p.Enqueue(context.Background(), streams[2]); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Enqueue past the buffer: got %v, want %v", err, ErrQueueFull)
+	}
+
+	close(release)
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !sameIDs(sink.written(), streams[:2]) {
+		t.Errorf("wrote %v, want the two streams queued", sink.written())
+	}
+}
+
+// readDeadLetter returns the ids of the streams set aside in path
+func readDeadLetter(t *testing.T, path string) []uuid.UUID {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []uuid.UUID
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		var entry walEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("dead-letter line %q: %v", line, err)
+		}
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
+// rejecting fails the batches holding any of poison with ErrRejected
+func rejecting(poison ...models.Stream) func([]models.Stream) error {
+	return func(streams []models.Stream) error {
+		for _, stream := range streams {
+			for _, bad := range poison {
+				if stream.ID == bad.ID {
+					return fmt.Errorf("%w: value too long for type character varying(50)", ErrRejected)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func TestPipelineSetsAsideRejectedStreams(t *testing.T) {
+	tests := []struct {
+		name    string
+		streams int
+		// poison are the indexes of the streams the sink rejects
+		poison []int
+	}{
+		{name: "one in a batch", streams: 5, poison: []int{2}},
+		{name: "several", streams: 8, poison: []int{0, 5, 7}},
+		{name: "the whole batch", streams: 3, poison: []int{0, 1, 2}},
+		{name: "a batch of one", streams: 1, poison: []int{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams := newStreams(tt.streams)
+			var poison, good []models.Stream
+			bad := map[int]bool{}
+			for _, i := range tt.poison {
+				bad[i] = true
+				poison = append(poison, streams[i])
+			}
+			for i, stream := range streams {
+				if !bad[i] {
+					good = append(good, stream)
+				}
+			}
+
+			deadLetter := filepath.Join(t.TempDir(), "rejected.jsonl")
+			sink := &fakeSink{fail: rejecting(poison...)}
+			p, err := NewPipeline(Config{BatchSize: tt.streams, FlushInterval: time.Hour, DeadLetterPath: deadLetter}, sink)
+			if err != nil {
+				t.Fatalf("NewPipeline: %v", err)
+			}
+			for _, stream := range streams {
+				if err := p.Enqueue(context.Background(), stream); err != nil {
+					t.Fatalf("Enqueue: %v", err)
+				}
+			}
+			// Close waits for the batch, so a retried poison batch would hang here
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := p.Close(ctx); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			if !sameIDs(sink.written(), good) {
+				t.Errorf("wrote %v, want the streams that weren't rejected", sink.written())
+			}
+			if !sameIDs(readDeadLetter(t, deadLetter), poison) {
+				t.Errorf("set aside %v, want the rejected streams", readDeadLetter(t, deadLetter))
+			}
+		})
+	}
+}
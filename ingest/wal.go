@@ -0,0 +1,211 @@
+package ingest
+
+import (
+	"bufio"
+	"crawl/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// walSegmentSize is the size at which the log moves on to a new segment file
+const walSegmentSize = 8 << 20
+
+const walSuffix = ".wal"
+
+// walEntry is a logged stream; the shadowing fields keep its empty associations out of the log
+type walEntry struct {
+	models.Stream
+	Song *struct{} `json:"song,omitempty"`
+	User *struct{} `json:"user,omitempty"`
+}
+
+// wal appends queued streams to numbered segment files, one JSON document per
+// line, synced before Enqueue returns. A segment is deleted once every stream in
+// it has been written to the database; segments found on startup are replayed.
+type wal struct {
+	dir string
+
+	mu      sync.Mutex
+	file    *os.File
+	segment int
+	size    int64
+	// pending counts the streams per segment not yet written to the database
+	pending map[int]int
+}
+
+func segmentPath(dir string, segment int) string {
+	return filepath.Join(dir, fmt.Sprintf("%010d%s", segment, walSuffix))
+}
+
+// openWAL replays the segments left in dir through replay, removing each once
+// it's written, then starts a fresh segment
+func openWAL(dir string, replay func([]models.Stream) error) (*wal, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var segments []int
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, walSuffix) {
+			continue
+		}
+		segment, err := strconv.Atoi(strings.TrimSuffix(name, walSuffix))
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	sort.Ints(segments)
+
+	next := 0
+	for _, segment := range segments {
+		path := segmentPath(dir, segment)
+		streams, err := readSegment(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(streams) > 0 {
+			if err := replay(streams); err != nil {
+				return nil, fmt.Errorf("replaying %s: %w", path, err)
+			}
+			log.Printf("stream pipeline: replayed %d streams from %s", len(streams), path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+		next = segment + 1
+	}
+
+	w := &wal{dir: dir, segment: next - 1, pending: map[int]int{}}
+	if err := w.rotate(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func readSegment(path string) ([]models.Stream, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var streams []models.Stream
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry walEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A crash mid-append leaves a torn last line; it was never acknowledged
+			log.Printf("stream pipeline: skipping unreadable entry in %s: %v", path, err)
+			continue
+		}
+		streams = append(streams, entry.Stream)
+	}
+	return streams, scanner.Err()
+}
+
+// rotate closes the current segment and opens the next; callers hold mu or own w exclusively
+func (w *wal) rotate() error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+	}
+
+	w.segment++
+	file, err := os.OpenFile(segmentPath(w.dir, w.segment), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	w.file = file
+	w.size = 0
+	w.removeWritten()
+	return nil
+}
+
+// append logs stream durably and returns the segment it went to
+func (w *wal) append(stream models.Stream) (int, error) {
+	line, err := json.Marshal(walEntry{Stream: stream})
+	if err != nil {
+		return 0, err
+	}
+	line = append(line, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n, err := w.file.Write(line)
+	if err != nil {
+		return 0, err
+	}
+	if err := w.file.Sync(); err != nil {
+		return 0, err
+	}
+
+	segment := w.segment
+	w.pending[segment]++
+	w.size += int64(n)
+	if w.size >= walSegmentSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	return segment, nil
+}
+
+// release marks one stream from each of segments as written to the database
+func (w *wal) release(segments []int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, segment := range segments {
+		w.pending[segment]--
+	}
+	w.removeWritten()
+}
+
+// removeWritten deletes closed segments whose streams have all been written
+func (w *wal) removeWritten() {
+	for segment, count := range w.pending {
+		if count > 0 || segment == w.segment {
+			continue
+		}
+		if err := os.Remove(segmentPath(w.dir, segment)); err != nil && !os.IsNotExist(err) {
+			log.Printf("stream pipeline: failed to remove written log segment %d: %v", segment, err)
+			continue
+		}
+		delete(w.pending, segment)
+	}
+}
+
+func (w *wal) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	if w.pending[w.segment] == 0 {
+		delete(w.pending, w.segment)
+		if err := os.Remove(segmentPath(w.dir, w.segment)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	// Drop closed segments that are fully written; the rest are replayed on the next start
+	w.segment = -1
+	w.removeWritten()
+	return nil
+}
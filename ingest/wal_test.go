@@ -0,0 +1,197 @@
+package ingest
+
+import (
+	"context"
+	"crawl/models"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// walFiles lists the log segments left in dir
+func walFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+walSuffix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestPipelineReplaysUnwrittenStreams(t *testing.T) {
+	dir := t.TempDir()
+	down := errors.New("database is down")
+
+	// The first run can't write anything and gives up on shutdown
+	first, err := NewPipeline(Config{WALDir: dir, BatchSize: 2}, &fakeSink{fail: func([]models.Stream) error { return down }})
+	if err != nil {
+		t.Fatalf("NewPipeline: %v", err)
+	}
+	streams := newStreams(5)
+	for _, stream := range streams {
+		if err := first.Enqueue(context.Background(), stream); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := first.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Close: got %v, want the shutdown deadline", err)
+	}
+	if len(walFiles(t, dir)) == 0 {
+		t.Fatal("no log segment left to replay")
+	}
+
+	// The next start writes them before taking new ones, in batches
+	sink := &fakeSink{}
+	second, err := NewPipeline(Config{WALDir: dir, BatchSize: 2}, sink)
+	if err != nil {
+		t.Fatalf("NewPipeline replaying: %v", err)
+	}
+	if !sameIDs(sink.written(), streams) {
+		t.Errorf("replayed %v, want the streams in the order queued", sink.written())
+	}
+	for i, batch := range sink.batches {
+		if len(batch) > 2 {
+			t.Errorf("replayed batch %d has %d streams, more than the batch size", i, len(batch))
+		}
+	}
+	if err := second.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if files := walFiles(t, dir); len(files) != 0 {
+		t.Errorf("segments left after everything was written: %v", files)
+	}
+}
+
+func TestOpenWAL(t *testing.T) {
+	streams := newStreams(3)
+	line := func(stream models.Stream) string {
+		encoded, err := json.Marshal(walEntry{Stream: stream})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(encoded) + "\n"
+	}
+
+	tests := []struct {
+		name     string
+		segments map[int]string
+		// want is the streams replayed, in order
+		want      []models.Stream
+		replayErr error
+		wantErr   bool
+	}{
+		{
+			name:     "segments in order",
+			segments: map[int]string{7: line(streams[2]), 3: line(streams[0]) + line(streams[1])},
+			want:     streams,
+		},
+		{
+			name:     "torn last line",
+			segments: map[int]string{1: line(streams[0]) + `{"id":"`},
+			want:     streams[:1],
+		},
+		{
+			name:     "empty segment",
+			segments: map[int]string{1: ""},
+		},
+		{
+			name:      "replay fails",
+			segments:  map[int]string{1: line(streams[0])},
+			replayErr: errors.New("database is down"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for segment, content := range tt.segments {
+				if err := os.WriteFile(segmentPath(dir, segment), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var replayed []models.Stream
+			w, err := openWAL(dir, func(streams []models.Stream) error {
+				if tt.replayErr != nil {
+					return tt.replayErr
+				}
+				replayed = append(replayed, streams...)
+				return nil
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("got no error, want the replay's")
+				}
+				if got := len(walFiles(t, dir)); got != len(tt.segments) {
+					t.Errorf("%d segments left, want all %d kept for the next start", got, len(tt.segments))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("openWAL: %v", err)
+			}
+			defer w.close()
+
+			if len(replayed) != len(tt.want) {
+				t.Fatalf("replayed %d streams, want %d", len(replayed), len(tt.want))
+			}
+			for i, stream := range tt.want {
+				if replayed[i].ID != stream.ID {
+					t.Errorf("stream %d is %s, want %s", i, replayed[i].ID, stream.ID)
+				}
+			}
+			// Only the fresh segment, numbered after the replayed ones, is left
+			files := walFiles(t, dir)
+			if len(files) != 1 || files[0] != segmentPath(dir, w.segment) {
+				t.Errorf("segments left %v, want just the new one", files)
+			}
+			for segment := range tt.segments {
+				if w.segment <= segment {
+					t.Errorf("new segment %d doesn't follow replayed segment %d", w.segment, segment)
+				}
+			}
+		})
+	}
+}
+
+func TestPipelineReplaySetsAsideRejectedStreams(t *testing.T) {
+	dir := t.TempDir()
+	streams := newStreams(4)
+	var segment []byte
+	for _, stream := range streams {
+		line, err := json.Marshal(walEntry{Stream: stream})
+		if err != nil {
+			t.Fatal(err)
+		}
+		segment = append(append(segment, line...), '\n')
+	}
+	if err := os.WriteFile(segmentPath(dir, 1), segment, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A stream the database refuses no longer keeps the pipeline from starting
+	deadLetter := filepath.Join(dir, "rejected.jsonl")
+	sink := &fakeSink{fail: rejecting(streams[1])}
+	p, err := NewPipeline(Config{WALDir: dir, BatchSize: 2, DeadLetterPath: deadLetter}, sink)
+	if err != nil {
+		t.Fatalf("NewPipeline: %v", err)
+	}
+	defer p.Close(context.Background())
+
+	want := []models.Stream{streams[0], streams[2], streams[3]}
+	if !sameIDs(sink.written(), want) {
+		t.Errorf("replayed %v, want every stream but the rejected one", sink.written())
+	}
+	if !sameIDs(readDeadLetter(t, deadLetter), streams[1:2]) {
+		t.Errorf("set aside %v, want the rejected stream", readDeadLetter(t, deadLetter))
+	}
+	if files := walFiles(t, dir); len(files) != 1 || files[0] == segmentPath(dir, 1) {
+		t.Errorf("segments left %v, want just the new one", files)
+	}
+}
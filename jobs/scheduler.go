@@ -0,0 +1,79 @@
+// Package jobs runs background work, such as the daily stream rollups, on fixed
+// intervals inside the server process.
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is one run of a background task; it should return soon after ctx is done
+type Job func(ctx context.Context) error
+
+type scheduled struct {
+	name     string
+	interval time.Duration
+	run      Job
+}
+
+// Scheduler runs each job once on Start and then every interval, never
+// overlapping a job with itself
+type Scheduler struct {
+	jobs   []scheduled
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every adds a job; it must be called before Start
+func (s *Scheduler) Every(name string, interval time.Duration, run Job) {
+	s.jobs = append(s.jobs, scheduled{name: name, interval: interval, run: run})
+}
+
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job scheduled) {
+			defer s.wg.Done()
+			ticker := time.NewTicker(job.interval)
+			defer ticker.Stop()
+
+			for {
+				runJob(ctx, job)
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(job)
+	}
+}
+
+func runJob(ctx context.Context, job scheduled) {
+	started := time.Now()
+	if err := job.run(ctx); err != nil {
+		if ctx.Err() == nil {
+			log.Printf("job %s failed after %s: %v", job.name, time.Since(started).Round(time.Millisecond), err)
+		}
+		return
+	}
+	log.Printf("job %s finished in %s", job.name, time.Since(started).Round(time.Millisecond))
+}
+
+// Stop cancels running jobs and waits for them to return
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
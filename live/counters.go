@@ -0,0 +1,45 @@
+package live
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Counters add up an artist's events since a subscriber connected
+type Counters struct {
+	Since          time.Time           `json:"since"`
+	Plays          int64               `json:"plays"`
+	SongPlays      map[uuid.UUID]int64 `json:"song_plays"` // by song ID
+	Tips           int64               `json:"tips"`
+	TipAmount      float64             `json:"tip_amount"`
+	Purchases      int64               `json:"purchases"`
+	PurchaseAmount float64             `json:"purchase_amount"`
+	// Dropped events arrived faster than the connection could take them and aren't counted
+	Dropped   int64     `json:"dropped"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewCounters(since time.Time) *Counters {
+	return &Counters{Since: since, SongPlays: map[uuid.UUID]int64{}, UpdatedAt: since}
+}
+
+// Add counts event
+func (c *Counters) Add(event Event) {
+	switch event.Kind {
+	case EventPlay:
+		c.Plays++
+		if event.SongID != nil {
+			c.SongPlays[*event.SongID]++
+		}
+	case EventTip:
+		c.Tips++
+		c.TipAmount += event.Amount
+	case EventPurchase:
+		c.Purchases++
+		c.PurchaseAmount += event.Amount
+	}
+	if event.At.After(c.UpdatedAt) {
+		c.UpdatedAt = event.At
+	}
+}
@@ -0,0 +1,204 @@
+// Package live fans out plays, tips and purchases to the artists watching them
+// as they happen. Events only live in memory: a subscriber gets the events
+// published while it is subscribed, on this server.
+package live
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of live event
+const (
+	EventPlay     = "play"
+	EventTip      = "tip"
+	EventPurchase = "purchase"
+)
+
+var (
+	ErrTooManySubscriptions = errors.New("too many live connections open")
+	ErrHubClosed            = errors.New("live feed is shut down")
+)
+
+// Event is something that just happened to one of an artist's songs or albums
+type Event struct {
+	Kind     string
+	ArtistID uuid.UUID
+	SongID   *uuid.UUID // for plays, and tips and purchases of a song
+	AlbumID  *uuid.UUID // for purchases of an album
+	Amount   float64    // paid, for tips and purchases
+	At       time.Time
+}
+
+type Config struct {
+	// FlushInterval is the most often a subscriber is sent counters; events in
+	// between are added up, which rate limits each connection
+	FlushInterval time.Duration
+	// KeepAlive is how often an idle connection is written to, so proxies don't close it
+	KeepAlive time.Duration
+	// BufferSize is how many events a subscriber can fall behind by; events
+	// past that are dropped for it rather than slowing down publishers
+	BufferSize int
+	// MaxPerUser bounds the live connections one user holds open at once
+	MaxPerUser int
+}
+
+var DefaultConfig = Config{
+	FlushInterval: time.Second,
+	KeepAlive:     15 * time.Second,
+	BufferSize:    256,
+	MaxPerUser:    5,
+}
+
+// Hub is an in-process publish-subscribe fan-out of events by artist. It is
+// safe for concurrent use, and publishing never blocks.
+type Hub struct {
+	cfg Config
+
+	mu      sync.RWMutex
+	artists map[uuid.UUID]map[*Subscription]struct{}
+	users   map[uuid.UUID]int
+	closed  bool
+	done    chan struct{}
+}
+
+func NewHub(cfg Config) *Hub {
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultConfig.FlushInterval
+	}
+	if cfg.KeepAlive <= 0 {
+		cfg.KeepAlive = DefaultConfig.KeepAlive
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = DefaultConfig.BufferSize
+	}
+	if cfg.MaxPerUser <= 0 {
+		cfg.MaxPerUser = DefaultConfig.MaxPerUser
+	}
+	return &Hub{
+		cfg:     cfg,
+		artists: map[uuid.UUID]map[*Subscription]struct{}{},
+		users:   map[uuid.UUID]int{},
+		done:    make(chan struct{}),
+	}
+}
+
+func (h *Hub) Config() Config {
+	return h.cfg
+}
+
+// Subscription receives the events of one artist until it is closed
+type Subscription struct {
+	// Events is closed when the subscription or the hub is
+	Events <-chan Event
+
+	hub      *Hub
+	artistID uuid.UUID
+	userID   uuid.UUID
+	events   chan Event
+	once     sync.Once
+
+	mu      sync.Mutex
+	dropped int64
+}
+
+// Subscribe starts receiving the artist's events for the user, who may hold
+// at most MaxPerUser subscriptions at a time
+func (h *Hub) Subscribe(artistID, userID uuid.UUID) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+	if h.users[userID] >= h.cfg.MaxPerUser {
+		return nil, ErrTooManySubscriptions
+	}
+
+	events := make(chan Event, h.cfg.BufferSize)
+	sub := &Subscription{
+		Events:   events,
+		hub:      h,
+		artistID: artistID,
+		userID:   userID,
+		events:   events,
+	}
+	if h.artists[artistID] == nil {
+		h.artists[artistID] = map[*Subscription]struct{}{}
+	}
+	h.artists[artistID][sub] = struct{}{}
+	h.users[userID]++
+	return sub, nil
+}
+
+// Publish hands event to the artist's subscribers that have room for it
+func (h *Hub) Publish(event Event) {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for sub := range h.artists[event.ArtistID] {
+		select {
+		case sub.events <- event:
+		default:
+			sub.mu.Lock()
+			sub.dropped++
+			sub.mu.Unlock()
+		}
+	}
+}
+
+// Dropped returns how many events the subscription missed for falling behind
+func (s *Subscription) Dropped() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Close stops the subscription; it may be called more than once
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.close()
+}
+
+// close ends the subscription with the hub locked
+func (s *Subscription) close() {
+	s.once.Do(func() {
+		subs := s.hub.artists[s.artistID]
+		delete(subs, s)
+		if len(subs) == 0 {
+			delete(s.hub.artists, s.artistID)
+		}
+		if s.hub.users[s.userID]--; s.hub.users[s.userID] <= 0 {
+			delete(s.hub.users, s.userID)
+		}
+		close(s.events)
+	})
+}
+
+// Done is closed when the hub shuts down
+func (h *Hub) Done() <-chan struct{} {
+	return h.done
+}
+
+// Close ends every subscription and turns new ones away, so open connections
+// finish before the server stops
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for _, subs := range h.artists {
+		for sub := range subs {
+			sub.close()
+		}
+	}
+	close(h.done)
+}
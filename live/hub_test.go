@@ -0,0 +1,170 @@
+package live
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// drain returns the events waiting on sub without blocking
+func drain(sub *Subscription) []Event {
+	var events []Event
+	for {
+		select {
+		case event, ok := <-sub.Events:
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestHubPublish(t *testing.T) {
+	artist, other := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		bufferSize  int
+		subscribers int
+		publish     []Event
+		// want is how many events each subscriber receives, and dropped how many it misses
+		want    int
+		dropped int64
+	}{
+		{
+			name:        "only the artist's events",
+			subscribers: 1,
+			publish: []Event{
+				{Kind: EventPlay, ArtistID: artist},
+				{Kind: EventTip, ArtistID: other, Amount: 500},
+				{Kind: EventPurchase, ArtistID: artist, Amount: 1000},
+			},
+			want: 2,
+		},
+		{
+			name:        "every subscriber of the artist",
+			subscribers: 3,
+			publish:     []Event{{Kind: EventPlay, ArtistID: artist}, {Kind: EventPlay, ArtistID: artist}},
+			want:        2,
+		},
+		{
+			name:        "events past the buffer are dropped",
+			bufferSize:  2,
+			subscribers: 1,
+			publish: []Event{
+				{Kind: EventPlay, ArtistID: artist},
+				{Kind: EventPlay, ArtistID: artist},
+				{Kind: EventPlay, ArtistID: artist},
+				{Kind: EventPlay, ArtistID: artist},
+			},
+			want:    2,
+			dropped: 2,
+		},
+		{
+			name:        "no subscribers",
+			subscribers: 0,
+			publish:     []Event{{Kind: EventPlay, ArtistID: artist}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewHub(Config{BufferSize: tt.bufferSize})
+			defer hub.Close()
+
+			subs := make([]*Subscription, tt.subscribers)
+			for i := range subs {
+				sub, err := hub.Subscribe(artist, uuid.New())
+				if err != nil {
+					t.Fatalf("Subscribe: %v", err)
+				}
+				subs[i] = sub
+			}
+			for _, event := range tt.publish {
+				hub.Publish(event)
+			}
+
+			for i, sub := range subs {
+				events := drain(sub)
+				if len(events) != tt.want {
+					t.Errorf("subscriber %d got %d events, want %d", i, len(events), tt.want)
+				}
+				for _, event := range events {
+					if event.ArtistID != artist {
+						t.Errorf("subscriber %d got an event of artist %s", i, event.ArtistID)
+					}
+					if event.At.IsZero() {
+						t.Errorf("subscriber %d got an event without a time", i)
+					}
+				}
+				if got := sub.Dropped(); got != tt.dropped {
+					t.Errorf("subscriber %d dropped %d events, want %d", i, got, tt.dropped)
+				}
+			}
+		})
+	}
+}
+
+func TestHubSubscriptionsPerUser(t *testing.T) {
+	hub := NewHub(Config{MaxPerUser: 2})
+	defer hub.Close()
+	user := uuid.New()
+
+	first, err := hub.Subscribe(uuid.New(), user)
+	if err != nil {
+		t.Fatalf("first Subscribe: %v", err)
+	}
+	if _, err := hub.Subscribe(uuid.New(), user); err != nil {
+		t.Fatalf("second Subscribe: %v", err)
+	}
+	if _, err := hub.Subscribe(uuid.New(), user); !errors.Is(err, ErrTooManySubscriptions) {
+		t.Fatalf("third Subscribe: got %v, want %v", err, ErrTooManySubscriptions)
+	}
+	if _, err := hub.Subscribe(uuid.New(), uuid.New()); err != nil {
+		t.Fatalf("another user's Subscribe: %v", err)
+	}
+
+	// Closing twice frees one slot, once
+	first.Close()
+	first.Close()
+	if _, err := hub.Subscribe(uuid.New(), user); err != nil {
+		t.Fatalf("Subscribe after closing one: %v", err)
+	}
+	if _, err := hub.Subscribe(uuid.New(), user); !errors.Is(err, ErrTooManySubscriptions) {
+		t.Fatalf("Subscribe past the limit again: got %v, want %v", err, ErrTooManySubscriptions)
+	}
+	if _, ok := <-first.Events; ok {
+		t.Error("a closed subscription still delivers events")
+	}
+}
+
+func TestHubClose(t *testing.T) {
+	hub := NewHub(Config{})
+	artist := uuid.New()
+	sub, err := hub.Subscribe(artist, uuid.New())
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	hub.Close()
+	hub.Close()
+	sub.Close()
+
+	select {
+	case <-hub.Done():
+	default:
+		t.Error("Done isn't closed after Close")
+	}
+	if _, ok := <-sub.Events; ok {
+		t.Error("subscription still open after the hub closed")
+	}
+	if _, err := hub.Subscribe(artist, uuid.New()); !errors.Is(err, ErrHubClosed) {
+		t.Errorf("Subscribe after Close: got %v, want %v", err, ErrHubClosed)
+	}
+	// Publishing to a closed hub is a no-op rather than a panic
+	hub.Publish(Event{Kind: EventPlay, ArtistID: artist})
+}
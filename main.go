@@ -1,26 +1,62 @@
 package main
 
 import (
+	"context"
 	"crawl/api"
 	"crawl/config"
 	"crawl/handlers"
+	"crawl/ingest"
+	"crawl/jobs"
+	"crawl/repositories"
+	"crawl/search"
+	"crawl/services"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/joho/godotenv"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
 	_ = godotenv.Load()
 	//var jwtSecret = []byte("your-secret-key")
 	config.ConnectDatabase()
+	// The search migration sets the fuzzy matching threshold
+	config.LoadSearchSettings()
 	config.MigrateDatabase()
+	config.ConnectStorage()
+	config.ConnectSearchIndex()
+	config.LoadStreamSettings()
+	config.ConnectGeoIP()
+	config.LoadLiveSettings()
+	config.LoadExportSettings()
+	config.LoadPaymentSettings()
 
 	db := config.DB
+	if err := repositories.RegisterAuditCallbacks(db); err != nil {
+		log.Fatal("Failed to register audit callbacks:", err)
+	}
+	if config.SearchStore != nil {
+		if err := search.RegisterIndexCallbacks(db, config.SearchStore); err != nil {
+			log.Fatal("Failed to register search index callbacks:", err)
+		}
+	}
 
-	server := handlers.NewHandlers(db)
-	app := fiber.New()
+	qualifier := services.NewStreamQualifier(repositories.NewStreamRepository(db), config.StreamRules)
+	streams, err := ingest.NewPipeline(config.StreamIngest, qualifier)
+	if err != nil {
+		log.Fatal("Failed to start stream ingestion:", err)
+	}
+
+	server := handlers.NewHandlers(db, config.Blobs, config.Fuzzy, config.SearchStore, streams, config.GeoIP, config.LiveFeed, config.Payments, config.Exports)
+	app := fiber.New(fiber.Config{
+		// Leave room for verification documents uploaded in a single request
+		BodyLimit: 64 * 1024 * 1024,
+	})
 	app.Use(logger.New())
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "https://crawl-app.vercel.app, https://crawl-admin.vercel.app/",
@@ -30,7 +66,47 @@ func main() {
 
 	api.RegisterHandlers(app, server)
 
+	// Background jobs run alongside the server
+	scheduler := jobs.NewScheduler()
+	rollups := services.NewRollupService(repositories.NewStreamRollupRepository(db))
+	scheduler.Every("stream rollups", time.Hour, rollups.RollupRecent)
+	listeners := services.NewMonthlyListenersService(repositories.NewMonthlyListenersRepository(db))
+	scheduler.Every("monthly listeners", time.Hour, listeners.ComputeRecent)
+	scheduler.Every("weekly charts", time.Hour, server.Chart.PublishRecent)
+	scheduler.Every("trending songs", 15*time.Minute, server.Chart.RefreshTrending)
+	scheduler.Every("year in review", 24*time.Hour, server.Wrapped.GeneratePending)
+	scheduler.Every("analytics exports", time.Minute, server.Export.RunPending)
+	scheduler.Every("GeoIP reload", config.GeoIPReloadInterval, config.GeoIP.Reload)
+	scheduler.Start()
+
+	// Stop taking requests on SIGINT or SIGTERM so queued streams can be flushed
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+		log.Println("🛑 Shutting down...")
+		// Live connections stay open until their feed ends
+		config.LiveFeed.Close()
+		if err := app.ShutdownWithTimeout(10 * time.Second); err != nil {
+			log.Println("Failed to drain HTTP connections:", err)
+		}
+	}()
+
 	// And we serve HTTP until the world ends.
-	log.Fatal(app.Listen("0.0.0.0:8082"))
+	if err := app.Listen("0.0.0.0:8082"); err != nil {
+		log.Fatal(err)
+	}
 
+	scheduler.Stop()
+	// Open playback sessions are recorded as they stand before the queue drains
+	server.Stream.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := streams.Close(ctx); err != nil {
+		log.Println("Failed to flush queued streams:", err)
+	}
+	if config.SearchStore != nil {
+		config.SearchStore.Close()
+	}
+	log.Println("👋 Stopped")
 }
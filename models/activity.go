@@ -1,29 +1,92 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type ArtistTip struct {
 	BaseModel
-	SenderID            uuid.UUID `gorm:"not null" json:"sender_id"`
-	ArtistID            uuid.UUID `gorm:"not null" json:"artist_id"`
-	Amount              int       `gorm:"not null;default:0" json:"amount"`
-	Message             string    `gorm:"type:text" json:"message"`
-	Currency            string    `gorm:"size:3;default:'NGN'" json:"currency"`
-	PaymentStatus       string    `gorm:"size:20;default:'completed'" json:"payment_status"`
-	StripeTransactionID string    `gorm:"size:255" json:"stripe_transaction_id"`
-	Sender              User      `gorm:"foreignKey:SenderID" json:"sender"`
-	Artist              Artist    `gorm:"foreignKey:ArtistID" json:"artist"`
+	SenderID            uuid.UUID  `gorm:"not null" json:"sender_id"`
+	ArtistID            uuid.UUID  `gorm:"not null" json:"artist_id"`
+	Amount              int        `gorm:"not null;default:0" json:"amount"`
+	Message             string     `gorm:"type:text" json:"message"`
+	Currency            string     `gorm:"size:3;default:'NGN'" json:"currency"`
+	PaymentStatus       string     `gorm:"size:20;default:'pending'" json:"payment_status"`
+	StripeTransactionID string     `gorm:"size:255" json:"stripe_transaction_id"`
+	PaymentID           *uuid.UUID `gorm:"type:uuid" json:"payment_id,omitempty"`
+	Payment             *Payment   `gorm:"foreignKey:PaymentID" json:"payment,omitempty"`
+	Sender              User       `gorm:"foreignKey:SenderID" json:"sender"`
+	Artist              Artist     `gorm:"foreignKey:ArtistID" json:"artist"`
 }
 
+// Stream states; only qualified streams count as plays, in charts and for royalties
+const (
+	StreamQualified  = "qualified"
+	StreamInvalid    = "invalid"    // broke a play rule, see InvalidReason
+	StreamSuspicious = "suspicious" // held back for review by its fraud score
+	StreamRejected   = "rejected"   // confirmed as fraud on review
+)
+
+// Contexts a song is played from; songs played on their own have none
+const (
+	PlayContextAlbum    = "album"
+	PlayContextPlaylist = "playlist"
+	PlayContextArtist   = "artist"
+)
+
+// Reasons a stream is invalid
+const (
+	StreamReasonPreview   = "preview"
+	StreamReasonTooShort  = "too_short"
+	StreamReasonDuplicate = "duplicate"
+)
+
+// Fraud signals that add to a stream's fraud score
+const (
+	FraudSignalLooping     = "looping"      // the listener keeps replaying the song
+	FraudSignalIPBurst     = "ip_burst"     // too many plays from one IP address
+	FraudSignalDeviceBurst = "device_burst" // more plays from one device than it could play
+	FraudSignalInactive    = "inactive"     // the account does nothing but stream one artist
+)
+
 type Stream struct {
 	BaseModel
-	UserID      *uuid.UUID `gorm:"index" json:"user_id,omitempty"`
-	SongID      uuid.UUID  `gorm:"not null;index" json:"song_id"`
-	IsPreview   bool       `gorm:"default:false" json:"is_preview"`
-	DeviceType  string     `gorm:"size:50" json:"device_type"`
-	CountryCode string     `gorm:"size:2" json:"country_code"`
-	User        *User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Song        Song       `gorm:"foreignKey:SongID" json:"song"`
+	UserID          *uuid.UUID `gorm:"index" json:"user_id,omitempty"`
+	SongID          uuid.UUID  `gorm:"not null;index" json:"song_id"`
+	IsPreview       bool       `gorm:"default:false" json:"is_preview"`
+	DeviceType      string     `gorm:"size:50" json:"device_type"`
+	DeviceID        string     `gorm:"size:100;index" json:"device_id,omitempty"`
+	CountryCode     string     `gorm:"size:2" json:"country_code"`
+	Region          string     `gorm:"size:6" json:"region,omitempty"`          // ISO 3166-2 subdivision, when the GeoIP database has it
+	ClaimedCountry  string     `gorm:"size:2" json:"claimed_country,omitempty"` // the country the client says it's in, unverified and never used as CountryCode
+	ClientIP        string     `gorm:"size:45;index" json:"client_ip,omitempty"`
+	ListenedSeconds int        `gorm:"not null;default:0" json:"listened_seconds"`
+	PositionSeconds int        `gorm:"not null;default:0" json:"position_seconds"`               // where playback stopped
+	Tracked         bool       `gorm:"not null;default:false" json:"tracked"`                    // the player reported how long it played
+	Completed       bool       `gorm:"not null;default:false" json:"completed"`                  // played through to the end of the song
+	Skipped         bool       `gorm:"not null;default:false" json:"skipped"`                    // stopped before it could count as a play
+	Status          string     `gorm:"size:20;not null;default:'qualified';index" json:"status"` // "qualified", "invalid", "suspicious" or "rejected"
+	InvalidReason   string     `gorm:"size:20" json:"invalid_reason,omitempty"`
+	FraudScore      float64    `gorm:"not null;default:0" json:"fraud_score"`
+	FraudSignals    string     `gorm:"size:100" json:"fraud_signals,omitempty"` // comma-separated FraudSignal* values
+	ReviewerID      *uuid.UUID `json:"reviewer_id,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	ContextType     string     `gorm:"size:20" json:"context_type,omitempty"` // what the song was played from, a PlayContext* value
+	ContextID       *uuid.UUID `gorm:"type:uuid" json:"context_id,omitempty"`
+	// HiddenFromHistory streams still count as plays but are left out of the
+	// listener's history and anything personalized from it
+	HiddenFromHistory bool  `gorm:"not null;default:false" json:"hidden_from_history"`
+	User              *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Song              Song  `gorm:"foreignKey:SongID" json:"song"`
+}
+
+// ListenerSongPlays sums a listener's recent plays of one song
+type ListenerSongPlays struct {
+	UserID        uuid.UUID
+	SongID        uuid.UUID
+	Plays         int64      // streams that met the play rules, whatever their fraud score
+	LastCountedAt *time.Time // latest stream counted as a play or held for review
 }
 
 type MonthlyRoyalty struct {
@@ -36,3 +99,89 @@ type MonthlyRoyalty struct {
 	PaidStatus bool      `gorm:"default:false" json:"paid_status"`
 	Artist     Artist    `gorm:"foreignKey:ArtistID" json:"artist"`
 }
+
+// SongListenStats is how far listeners got into a song over a period, counting
+// only tracked plays and skips
+type SongListenStats struct {
+	SongID          uuid.UUID `json:"song_id"`
+	Title           string    `json:"title"`
+	Listens         int64     `json:"listens"`
+	Completions     int64     `json:"completions"`
+	Skips           int64     `json:"skips"`
+	CompletionRate  float64   `json:"completion_rate"`
+	SkipRate        float64   `json:"skip_rate"`
+	AverageListened float64   `json:"average_listened_seconds"`
+}
+
+// SongStreamCount is the number of streams a song received over a period
+type SongStreamCount struct {
+	SongID  uuid.UUID `json:"song_id"`
+	Title   string    `json:"title"`
+	Streams int64     `json:"streams"`
+}
+
+// PeriodStreams is an artist's qualified streams and distinct listeners in one
+// period, a day, week or month starting on Period
+type PeriodStreams struct {
+	Period    time.Time `json:"period"`
+	Streams   int64     `json:"streams"`
+	Listeners int64     `json:"listeners"`
+}
+
+// StreamShare is the number of streams from one country or kind of device, and
+// their share of all streams over a period
+type StreamShare struct {
+	Value   string  `json:"value"`
+	Streams int64   `json:"streams"`
+	Share   float64 `json:"share"`
+}
+
+// PeriodSales is an artist's completed purchases and tips in one currency in
+// one period, a day, week or month starting on Period
+type PeriodSales struct {
+	Period    time.Time `json:"period"`
+	Currency  string    `json:"currency"`
+	Purchases int64     `json:"purchases"`
+	Tips      int64     `json:"tips"`
+	Revenue   float64   `json:"revenue"` // from purchases and tips
+}
+
+// SongSales is a song's completed purchases in one currency over a period
+type SongSales struct {
+	SongID    uuid.UUID `json:"song_id"`
+	Title     string    `json:"title"`
+	Currency  string    `json:"currency"`
+	Purchases int64     `json:"purchases"`
+	Revenue   float64   `json:"revenue"`
+}
+
+// ListeningHistoryItem is one play in a listener's history
+type ListeningHistoryItem struct {
+	StreamID        uuid.UUID  `json:"stream_id"`
+	PlayedAt        time.Time  `json:"played_at"`
+	SongID          uuid.UUID  `json:"song_id"`
+	ContextType     string     `json:"context_type,omitempty"`
+	ContextID       *uuid.UUID `json:"context_id,omitempty"`
+	DeviceType      string     `json:"device_type,omitempty"`
+	ListenedSeconds int        `json:"listened_seconds"`
+	Completed       bool       `json:"completed"`
+	Song            Song       `json:"song"`
+}
+
+// Kinds of recently played item; songs played from an album or playlist are
+// listed under it
+const (
+	RecentSong     = "song"
+	RecentAlbum    = PlayContextAlbum
+	RecentPlaylist = PlayContextPlaylist
+)
+
+// RecentPlay is the last time a listener played a song, album or playlist
+type RecentPlay struct {
+	Kind     string    `json:"kind"`
+	ItemID   uuid.UUID `json:"item_id"`
+	PlayedAt time.Time `json:"played_at"`
+	Song     *Song     `gorm:"-" json:"song,omitempty"`
+	Album    *Album    `gorm:"-" json:"album,omitempty"`
+	Playlist *Playlist `gorm:"-" json:"playlist,omitempty"`
+}
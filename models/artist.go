@@ -13,4 +13,5 @@ type Artist struct {
 	User             User      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
 	Songs            []Song    `gorm:"foreignKey:ArtistID" json:"songs,omitempty"`
 	Albums           []Album   `gorm:"foreignKey:ArtistID" json:"albums,omitempty"`
+	Relevance        float64   `gorm:"-" json:"relevance,omitempty"` // full-text rank, only set by search queries
 }
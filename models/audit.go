@@ -0,0 +1,68 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+)
+
+// Entity types recorded in the change history
+const (
+	EntityTypeSong     = "song"
+	EntityTypeAlbum    = "album"
+	EntityTypeArtist   = "artist"
+	EntityTypePlaylist = "playlist"
+)
+
+// Snapshot holds the column values of a record keyed by column name
+type Snapshot map[string]json.RawMessage
+
+func (s Snapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func (s *Snapshot) Scan(value interface{}) error {
+	return scanJSON(value, s)
+}
+
+type FieldChange struct {
+	Old json.RawMessage `json:"old"`
+	New json.RawMessage `json:"new"`
+}
+
+// FieldChanges is the field-level diff between two versions keyed by column name
+type FieldChanges map[string]FieldChange
+
+func (c FieldChanges) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+func (c *FieldChanges) Scan(value interface{}) error {
+	return scanJSON(value, c)
+}
+
+type EntityVersion struct {
+	BaseModel
+	EntityType string       `gorm:"size:20;not null;index:idx_entity_version,unique" json:"entity_type"` // "song", "album", "artist" or "playlist"
+	EntityID   uuid.UUID    `gorm:"not null;index:idx_entity_version,unique" json:"entity_id"`
+	Version    int          `gorm:"not null;index:idx_entity_version,unique" json:"version"`
+	Action     string       `gorm:"size:20;not null" json:"action"` // "create", "update", "delete" or "rollback"
+	ActorID    *uuid.UUID   `gorm:"index" json:"actor_id,omitempty"`
+	Changes    FieldChanges `gorm:"type:jsonb" json:"changes"`
+	Snapshot   Snapshot     `gorm:"type:jsonb" json:"snapshot"`
+	Actor      *User        `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return errors.New("unsupported type for jsonb column")
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Kinds of weekly chart; country and genre charts are scoped by a country code or genre ID
+const (
+	ChartGlobal  = "global"
+	ChartCountry = "country"
+	ChartGenre   = "genre"
+)
+
+// ChartWeek marks a week, starting on Monday, whose charts have been published
+type ChartWeek struct {
+	Week        time.Time `gorm:"type:date;primaryKey" json:"week"`
+	Entries     int64     `gorm:"not null;default:0" json:"entries"` // across every chart that week
+	PublishedAt time.Time `gorm:"not null" json:"published_at"`
+}
+
+// ChartEntry is a song's place on one weekly chart, ranked by its qualified
+// streams over the week starting on Week
+type ChartEntry struct {
+	Kind             string    `gorm:"size:10;primaryKey" json:"kind"`
+	Scope            string    `gorm:"size:36;primaryKey" json:"scope"` // country code or genre ID, empty on the global chart
+	Week             time.Time `gorm:"type:date;primaryKey" json:"week"`
+	Position         int       `gorm:"primaryKey" json:"position"`
+	SongID           uuid.UUID `gorm:"type:uuid;not null;index" json:"song_id"`
+	Streams          int64     `gorm:"not null;default:0" json:"streams"`
+	PreviousPosition *int      `json:"previous_position"` // on the week before, nil for new entries and re-entries
+	PeakPosition     int       `gorm:"not null" json:"peak_position"`
+	WeeksOnChart     int       `gorm:"not null" json:"weeks_on_chart"` // this week included
+	Song             *Song     `gorm:"foreignKey:SongID" json:"song,omitempty"`
+}
+
+// TrendingSong is a song gaining streams fast, as of the latest trending refresh
+type TrendingSong struct {
+	SongID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"song_id"`
+	Score       float64   `gorm:"not null;index" json:"score"`
+	Streams     int64     `gorm:"not null;default:0" json:"streams"`   // over the last day
+	Listeners   int64     `gorm:"not null;default:0" json:"listeners"` // over the last day
+	Baseline    float64   `gorm:"not null;default:0" json:"baseline"`  // average daily streams over the week before
+	RefreshedAt time.Time `gorm:"not null" json:"refreshed_at"`
+	Song        *Song     `gorm:"foreignKey:SongID" json:"song,omitempty"`
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Export job states
+const (
+	ExportPending   = "pending"
+	ExportRunning   = "running"
+	ExportCompleted = "completed"
+	ExportFailed    = "failed"
+)
+
+// Datasets an export can hold
+const (
+	ExportStreams   = "streams"
+	ExportPurchases = "purchases"
+	ExportTips      = "tips"
+	ExportRoyalties = "royalties"
+)
+
+// ExportJob writes analytics data for the UTC days from From through To to the
+// blob store, one file per dataset, format and day
+type ExportJob struct {
+	BaseModel
+	RequestedByID uuid.UUID   `gorm:"type:uuid;not null" json:"requested_by_id"`
+	From          time.Time   `gorm:"type:date;not null" json:"from"`
+	To            time.Time   `gorm:"type:date;not null" json:"to"`
+	Datasets      StringList  `gorm:"type:jsonb;not null" json:"datasets"`
+	Formats       StringList  `gorm:"type:jsonb;not null" json:"formats"`
+	Status        string      `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	Error         string      `gorm:"type:text" json:"error,omitempty"`
+	Files         ExportFiles `gorm:"type:jsonb" json:"files"`
+	StartedAt     *time.Time  `json:"started_at,omitempty"`
+	FinishedAt    *time.Time  `json:"finished_at,omitempty"`
+	// HeartbeatAt is when the server running the job last renewed its claim on it
+	HeartbeatAt *time.Time `json:"-"`
+	// DownloadURL is a signed link to every file of a completed job, valid until LinkExpiresAt
+	DownloadURL   string     `gorm:"type:text" json:"download_url,omitempty"`
+	LinkExpiresAt *time.Time `json:"link_expires_at,omitempty"`
+}
+
+// ExportFile is one file an export job wrote
+type ExportFile struct {
+	Dataset string `json:"dataset"`
+	Format  string `json:"format"`
+	Day     string `json:"day"`  // UTC day of the partition, YYYY-MM-DD
+	Path    string `json:"path"` // within the job, e.g. streams/day=2025-01-31/streams.csv
+	Rows    int64  `json:"rows"`
+	Bytes   int64  `json:"bytes"`
+}
+
+type ExportFiles []ExportFile
+
+func (f ExportFiles) Value() (driver.Value, error) {
+	return json.Marshal(f)
+}
+
+func (f *ExportFiles) Scan(value interface{}) error {
+	return scanJSON(value, f)
+}
+
+// StringList is a list of strings kept as a JSON array
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	return scanJSON(value, l)
+}
+
+// StreamExportRow is a stream as exported, without the listener's device and address
+type StreamExportRow struct {
+	ID              uuid.UUID
+	CreatedAt       time.Time
+	UserID          *uuid.UUID
+	SongID          uuid.UUID
+	ArtistID        uuid.UUID
+	AlbumID         *uuid.UUID
+	IsPreview       bool
+	DeviceType      string
+	CountryCode     string
+	Region          string
+	ListenedSeconds int64
+	PositionSeconds int64
+	Tracked         bool
+	Completed       bool
+	Skipped         bool
+	Status          string
+	InvalidReason   string
+	FraudScore      float64
+	ContextType     string
+	ContextID       *uuid.UUID
+}
+
+// PurchaseExportRow is a song or album purchase as exported
+type PurchaseExportRow struct {
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	Kind          string // "song" or "album"
+	UserID        uuid.UUID
+	SongID        *uuid.UUID
+	AlbumID       *uuid.UUID
+	ArtistID      uuid.UUID
+	Price         float64
+	Currency      string
+	CountryCode   string
+	PaymentStatus string
+}
+
+// TipExportRow is a tip as exported, without its message
+type TipExportRow struct {
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	SenderID      uuid.UUID
+	ArtistID      uuid.UUID
+	Amount        int64
+	Currency      string
+	PaymentStatus string
+}
+
+// RoyaltyExportRow is an artist's royalty for a month, as exported
+type RoyaltyExportRow struct {
+	ID        uuid.UUID
+	Month     time.Time // first day of the month
+	ArtistID  uuid.UUID
+	Amount    int64
+	Currency  string
+	Paid      bool
+	CreatedAt time.Time
+}
@@ -0,0 +1,65 @@
+package models
+
+import "github.com/google/uuid"
+
+// Label member roles. Owners and managers administer the label; every member
+// can act for linked artists within the permissions each artist granted.
+const (
+	LabelRoleOwner   = "owner"
+	LabelRoleManager = "manager"
+	LabelRoleMember  = "member"
+)
+
+// Label-to-artist link states
+const (
+	LabelArtistPending = "pending"
+	LabelArtistActive  = "active"
+)
+
+// Permissions an artist can grant to a label
+const (
+	LabelPermissionCatalog   = "catalog"
+	LabelPermissionAnalytics = "analytics"
+	LabelPermissionRoyalties = "royalties"
+)
+
+type Label struct {
+	BaseModel
+	Name        string        `gorm:"size:100;not null" json:"name"`
+	Description string        `gorm:"type:text" json:"description"`
+	LogoURL     string        `gorm:"size:255" json:"logo_url"`
+	Members     []LabelMember `gorm:"foreignKey:LabelID" json:"members,omitempty"`
+	Artists     []LabelArtist `gorm:"foreignKey:LabelID" json:"artists,omitempty"`
+}
+
+type LabelMember struct {
+	BaseModel
+	LabelID uuid.UUID `gorm:"not null;index:idx_label_member,unique" json:"label_id"`
+	UserID  uuid.UUID `gorm:"not null;index:idx_label_member,unique;index" json:"user_id"`
+	Role    string    `gorm:"size:20;not null;default:'member'" json:"role"` // "owner", "manager" or "member"
+	User    *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Label   *Label    `gorm:"foreignKey:LabelID" json:"label,omitempty"`
+}
+
+// LabelArtist links an artist to a label. The label proposes the link and the
+// artist accepts it, so a label can never act for an artist without consent.
+type LabelArtist struct {
+	BaseModel
+	LabelID          uuid.UUID `gorm:"not null;index:idx_label_artist,unique" json:"label_id"`
+	ArtistID         uuid.UUID `gorm:"not null;index:idx_label_artist,unique;index" json:"artist_id"`
+	Status           string    `gorm:"size:20;not null;default:'pending'" json:"status"` // "pending" or "active"
+	CanManageCatalog bool      `gorm:"default:false" json:"can_manage_catalog"`
+	CanViewAnalytics bool      `gorm:"default:false" json:"can_view_analytics"`
+	CanViewRoyalties bool      `gorm:"default:false" json:"can_view_royalties"`
+	Artist           *Artist   `gorm:"foreignKey:ArtistID" json:"artist,omitempty"`
+	Label            *Label    `gorm:"foreignKey:LabelID" json:"label,omitempty"`
+}
+
+// ArtistRoyaltyTotal sums an artist's monthly royalties in one currency
+type ArtistRoyaltyTotal struct {
+	ArtistID   uuid.UUID `json:"artist_id"`
+	ArtistName string    `json:"artist_name"`
+	Currency   string    `json:"currency"`
+	Amount     int64     `json:"amount"`
+	PaidAmount int64     `json:"paid_amount"`
+}
@@ -8,26 +8,32 @@ import (
 
 type SongPurchase struct {
 	BaseModel
-	UserID              uuid.UUID `gorm:"not null;index:idx_user_song,unique" json:"user_id"`
-	SongID              uuid.UUID `gorm:"not null;index:idx_user_song,unique" json:"song_id"`
-	PurchasePrice       float64   `gorm:"type:decimal(10,2);not null" json:"purchase_price"`
-	Currency            string    `gorm:"size:3;default:'USD'" json:"currency"`
-	PaymentStatus       string    `gorm:"size:20;default:'pending'" json:"payment_status"`
-	StripeTransactionID string    `gorm:"size:255" json:"stripe_transaction_id"`
-	User                User      `gorm:"foreignKey:UserID" json:"-"`
-	Song                Song      `gorm:"foreignKey:SongID" json:"song"`
+	UserID              uuid.UUID  `gorm:"not null;index:idx_user_song,unique" json:"user_id"`
+	SongID              uuid.UUID  `gorm:"not null;index:idx_user_song,unique" json:"song_id"`
+	PurchasePrice       float64    `gorm:"type:decimal(10,2);not null" json:"purchase_price"`
+	Currency            string     `gorm:"size:3;default:'USD'" json:"currency"`
+	CountryCode         string     `gorm:"size:2" json:"country_code"` // where the buyer was, from their address
+	PaymentStatus       string     `gorm:"size:20;default:'pending'" json:"payment_status"`
+	StripeTransactionID string     `gorm:"size:255" json:"stripe_transaction_id"`
+	PaymentID           *uuid.UUID `gorm:"type:uuid" json:"payment_id,omitempty"` // the latest payment for it
+	Payment             *Payment   `gorm:"foreignKey:PaymentID" json:"payment,omitempty"`
+	User                User       `gorm:"foreignKey:UserID" json:"-"`
+	Song                Song       `gorm:"foreignKey:SongID" json:"song"`
 }
 
 type AlbumPurchase struct {
 	BaseModel
-	UserID              uuid.UUID `gorm:"not null;index:idx_user_album,unique" json:"user_id"`
-	AlbumID             uuid.UUID `gorm:"not null;index:idx_user_album,unique" json:"album_id"`
-	PurchasePrice       float64   `gorm:"type:decimal(10,2);not null" json:"purchase_price"`
-	Currency            string    `gorm:"size:3;default:'USD'" json:"currency"`
-	PaymentStatus       string    `gorm:"size:20;default:'pending'" json:"payment_status"`
-	StripeTransactionID string    `gorm:"size:255" json:"stripe_transaction_id"`
-	User                User      `gorm:"foreignKey:UserID" json:"-"`
-	Album               Album     `gorm:"foreignKey:AlbumID" json:"album"`
+	UserID              uuid.UUID  `gorm:"not null;index:idx_user_album,unique" json:"user_id"`
+	AlbumID             uuid.UUID  `gorm:"not null;index:idx_user_album,unique" json:"album_id"`
+	PurchasePrice       float64    `gorm:"type:decimal(10,2);not null" json:"purchase_price"`
+	Currency            string     `gorm:"size:3;default:'USD'" json:"currency"`
+	CountryCode         string     `gorm:"size:2" json:"country_code"` // where the buyer was, from their address
+	PaymentStatus       string     `gorm:"size:20;default:'pending'" json:"payment_status"`
+	StripeTransactionID string     `gorm:"size:255" json:"stripe_transaction_id"`
+	PaymentID           *uuid.UUID `gorm:"type:uuid" json:"payment_id,omitempty"`
+	Payment             *Payment   `gorm:"foreignKey:PaymentID" json:"payment,omitempty"`
+	User                User       `gorm:"foreignKey:UserID" json:"-"`
+	Album               Album      `gorm:"foreignKey:AlbumID" json:"album"`
 }
 
 type UserFavorite struct {
@@ -49,6 +55,7 @@ type Playlist struct {
 	User          User      `gorm:"foreignKey:UserID" json:"user"`
 	Likes         *int      `gorm:"default:0" json:"likes"`
 	Songs         []Song    `gorm:"many2many:playlist_songs;" json:"songs,omitempty"`
+	Relevance     float64   `gorm:"-" json:"relevance,omitempty"` // full-text rank, only set by search queries
 }
 
 type PlaylistSong struct {
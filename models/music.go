@@ -4,16 +4,36 @@ import (
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
 type Genre struct {
 	BaseModel
-	Name        string  `gorm:"size:50;uniqueIndex" json:"name"`
-	Description string  `gorm:"type:text" json:"description"`
-	ImageURL    string  `gorm:"size:255" json:"image_url"`
-	Songs       []Song  `gorm:"foreignKey:GenreID" json:"songs,omitempty"`
-	Albums      []Album `gorm:"foreignKey:GenreID" json:"albums,omitempty"`
+	Name        string     `gorm:"size:50;uniqueIndex:idx_genres_live_name,where:deleted_at IS NULL" json:"name"` // unique among live genres, so deleted names can be reused
+	Description string     `gorm:"type:text" json:"description"`
+	ImageURL    string     `gorm:"size:255" json:"image_url"`
+	ParentID    *uuid.UUID `gorm:"index" json:"parent_id,omitempty"`
+	Popularity  int64      `gorm:"->;-:migration" json:"popularity"` // qualified streams over the last 30 days, only set by popularity queries
+	Relevance   float64    `gorm:"-" json:"relevance,omitempty"`     // full-text rank, only set by search queries
+	Parent      *Genre     `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
+	Subgenres   []Genre    `gorm:"foreignKey:ParentID" json:"subgenres,omitempty"`
+	Songs       []Song     `gorm:"foreignKey:GenreID" json:"songs,omitempty"`
+	Albums      []Album    `gorm:"foreignKey:GenreID" json:"albums,omitempty"`
+}
+
+// Tag kinds
+const (
+	TagKindTag  = "tag"
+	TagKindMood = "mood"
+)
+
+// Tag is a free-form label or mood attached to songs and albums
+type Tag struct {
+	BaseModel
+	Name string `gorm:"size:50;not null" json:"name"`
+	Slug string `gorm:"size:50;not null;index:idx_tag_kind_slug,unique" json:"slug"`
+	Kind string `gorm:"size:10;not null;default:'tag';index:idx_tag_kind_slug,unique" json:"kind"` // "tag" or "mood"
 }
 
 type Song struct {
@@ -35,6 +55,10 @@ type Song struct {
 	Album         *Album             `gorm:"foreignKey:AlbumID" json:"album,omitempty"`
 	Genre         *Genre             `gorm:"foreignKey:GenreID" json:"genre,omitempty"`
 	Contributors  []Artist           `gorm:"many2many:song_contributors;" json:"contributors,omitempty"`
+	Credits       []SongContributor  `gorm:"foreignKey:SongID" json:"credits,omitempty"`
+	Tags          []Tag              `gorm:"many2many:song_tags;" json:"tags,omitempty"`
+	DisplayArtist string             `gorm:"-" json:"display_artist,omitempty"` // e.g. "A feat. B", set when Artist and Credits are loaded
+	Relevance     float64            `gorm:"-" json:"relevance,omitempty"`      // full-text rank, only set by search queries
 }
 
 type Album struct {
@@ -51,6 +75,31 @@ type Album struct {
 	Genre         *Genre             `gorm:"foreignKey:GenreID" json:"genre,omitempty"`
 	Songs         []Song             `gorm:"foreignKey:AlbumID" json:"songs,omitempty"`
 	Contributors  []Artist           `gorm:"many2many:album_contributors;" json:"contributors,omitempty"`
+	Tags          []Tag              `gorm:"many2many:album_tags;" json:"tags,omitempty"`
+	Relevance     float64            `gorm:"-" json:"relevance,omitempty"` // full-text rank, only set by search queries
+}
+
+// Credit roles used as ContributionType on song and album contributors
+const (
+	CreditPrimary  = "primary"
+	CreditFeatured = "featured"
+	CreditProducer = "producer"
+	CreditWriter   = "writer"
+	CreditComposer = "composer"
+	CreditMixer    = "mixer"
+)
+
+// CreditRoles lists every accepted credit role
+var CreditRoles = []string{CreditPrimary, CreditFeatured, CreditProducer, CreditWriter, CreditComposer, CreditMixer}
+
+// IsCreditRole reports whether role is part of the credit vocabulary
+func IsCreditRole(role string) bool {
+	for _, r := range CreditRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
 }
 
 type SongContributor struct {
@@ -60,6 +109,7 @@ type SongContributor struct {
 	RoyaltyPercentage int            `gorm:"not null;default:0" json:"royalty_percentage"`
 	CreatedAt         time.Time      `json:"created_at"`
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	Artist            *Artist        `gorm:"foreignKey:ArtistID" json:"artist,omitempty"`
 }
 
 type AlbumContributor struct {
@@ -70,3 +120,47 @@ type AlbumContributor struct {
 	CreatedAt         time.Time      `json:"created_at"`
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
+
+// AfterFind fills DisplayArtist from the preloaded primary artist and credits
+func (s *Song) AfterFind(tx *gorm.DB) error {
+	if s.Artist.ArtistName == "" {
+		return nil
+	}
+
+	primary := []string{s.Artist.ArtistName}
+	var featured []string
+	for _, credit := range s.Credits {
+		if credit.Artist == nil || credit.ArtistID == s.ArtistID {
+			continue
+		}
+		switch credit.ContributionType {
+		case CreditPrimary:
+			primary = append(primary, credit.Artist.ArtistName)
+		case CreditFeatured:
+			featured = append(featured, credit.Artist.ArtistName)
+		}
+	}
+
+	s.DisplayArtist = FormatArtistCredit(primary, featured)
+	return nil
+}
+
+// FormatArtistCredit joins artist names the way they're shown on a track,
+// e.g. "A & B feat. C, D & E"
+func FormatArtistCredit(primary, featured []string) string {
+	name := joinArtistNames(primary)
+	if len(featured) > 0 {
+		name += " feat. " + joinArtistNames(featured)
+	}
+	return name
+}
+
+func joinArtistNames(names []string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " & " + names[len(names)-1]
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Payment states; purchases and tips take the state of their payment as
+// their payment_status
+const (
+	PaymentPending   = "pending"
+	PaymentCompleted = "completed"
+	PaymentFailed    = "failed"
+)
+
+// What a payment pays for
+const (
+	PaymentForSong  = "song_purchase"
+	PaymentForAlbum = "album_purchase"
+	PaymentForTip   = "tip"
+)
+
+// Payment is one attempt to charge a user through a payment provider for a
+// song, an album or a tip. What it pays for is only granted once the provider
+// reports by webhook that the payment succeeded.
+type Payment struct {
+	BaseModel
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Provider    string    `gorm:"size:20;not null" json:"provider"`
+	ProviderRef string    `gorm:"size:255;index" json:"provider_ref"`
+	Purpose     string    `gorm:"size:20;not null" json:"purpose"`
+	// TargetID is the song purchase, album purchase or tip paid for
+	TargetID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"target_id"`
+	Amount        int64      `gorm:"not null" json:"amount"` // in minor units of Currency
+	Currency      string     `gorm:"size:3;not null" json:"currency"`
+	Status        string     `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	FailureReason string     `gorm:"size:255" json:"failure_reason,omitempty"`
+	CheckoutURL   string     `gorm:"type:text" json:"checkout_url,omitempty"`
+	SettledAt     *time.Time `json:"settled_at,omitempty"`
+	// RefundDue marks a completed payment for what another payment had
+	// already paid for; the payer is owed it back
+	RefundDue bool `gorm:"not null;default:false;index" json:"refund_due,omitempty"`
+	// ClientSecret lets the payer's app confirm the payment; it's only sent
+	// back when the payment is made, never kept
+	ClientSecret string `gorm:"-" json:"client_secret,omitempty"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// StreamRollup marks a UTC day whose streams have been rolled up into the daily tables
+type StreamRollup struct {
+	Day        time.Time `gorm:"type:date;primaryKey" json:"day"`
+	Streams    int64     `gorm:"not null;default:0" json:"streams"` // qualified streams that day
+	RolledUpAt time.Time `gorm:"not null" json:"rolled_up_at"`
+}
+
+// SongDailyStat is a song's qualified streams and listening on one UTC day
+type SongDailyStat struct {
+	Day             time.Time `gorm:"type:date;primaryKey" json:"day"`
+	SongID          uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"song_id"`
+	Streams         int64     `gorm:"not null;default:0" json:"streams"`
+	Listeners       int64     `gorm:"not null;default:0" json:"listeners"`
+	Listens         int64     `gorm:"not null;default:0" json:"listens"` // tracked plays and skips
+	Completions     int64     `gorm:"not null;default:0" json:"completions"`
+	Skips           int64     `gorm:"not null;default:0" json:"skips"`
+	ListenedSeconds int64     `gorm:"not null;default:0" json:"listened_seconds"` // over listens
+}
+
+// CountryDailyStat is a song's qualified streams from one country on one UTC day
+type CountryDailyStat struct {
+	Day         time.Time `gorm:"type:date;primaryKey" json:"day"`
+	SongID      uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"song_id"`
+	CountryCode string    `gorm:"size:2;primaryKey" json:"country_code"` // empty when unknown
+	Streams     int64     `gorm:"not null;default:0" json:"streams"`
+	Listeners   int64     `gorm:"not null;default:0" json:"listeners"`
+}
+
+// DeviceDailyStat is a song's qualified streams from one kind of device on one UTC day
+type DeviceDailyStat struct {
+	Day        time.Time `gorm:"type:date;primaryKey" json:"day"`
+	SongID     uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"song_id"`
+	DeviceType string    `gorm:"size:50;primaryKey" json:"device_type"` // empty when unknown
+	Streams    int64     `gorm:"not null;default:0" json:"streams"`
+	Listeners  int64     `gorm:"not null;default:0" json:"listeners"`
+}
+
+// ArtistDailyListener is one listener's qualified streams of an artist's songs
+// on one UTC day. Listener counts don't add up across days, so distinct listeners
+// over a period are counted from these rows.
+type ArtistDailyListener struct {
+	Day      time.Time `gorm:"type:date;primaryKey" json:"day"`
+	ArtistID uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"artist_id"`
+	UserID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Streams  int64     `gorm:"not null;default:0" json:"streams"`
+}
+
+// DailyStreamCount is the number of qualified streams on one UTC day
+type DailyStreamCount struct {
+	Day     time.Time `json:"day"`
+	Streams int64     `json:"streams"`
+}
+
+// ArtistMonthlyListeners is an artist's distinct listeners over the 28 UTC days
+// ending on Day, counting the songs they're credited on as well as their own
+type ArtistMonthlyListeners struct {
+	Day       time.Time `gorm:"type:date;primaryKey" json:"day"`
+	ArtistID  uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"artist_id"`
+	Listeners int64     `gorm:"not null;default:0" json:"listeners"`
+}
+
+// DailyListenerCount is an artist's monthly listeners as of one UTC day
+type DailyListenerCount struct {
+	Day       time.Time `json:"day"`
+	Listeners int64     `json:"listeners"`
+}
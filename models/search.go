@@ -0,0 +1,103 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Suggestion types
+const (
+	SuggestionArtist   = "artist"
+	SuggestionSong     = "song"
+	SuggestionAlbum    = "album"
+	SuggestionPlaylist = "playlist"
+)
+
+// SearchSuggestion is a catalog name offered while typing or as a correction for a search query
+type SearchSuggestion struct {
+	Type     string    `json:"type"`
+	ID       uuid.UUID `json:"id"`
+	Text     string    `json:"text"`
+	Subtitle string    `json:"subtitle,omitempty"` // artist for songs and albums, owner for playlists
+	ImageURL string    `json:"image_url,omitempty"`
+	Score    float64   `json:"score"`
+}
+
+// FacetCount is how many search matches share one facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// SearchFacets breaks search matches down for filtering
+type SearchFacets struct {
+	Genres       []FacetCount `json:"genres"`
+	ReleaseYears []FacetCount `json:"release_years"`
+	PriceRanges  []FacetCount `json:"price_ranges"`
+}
+
+// Search scopes recorded in the search log: a federated search, or one content type
+const (
+	SearchScopeAll       = "all"
+	SearchScopeSongs     = "songs"
+	SearchScopeAlbums    = "albums"
+	SearchScopeArtists   = "artists"
+	SearchScopePlaylists = "playlists"
+)
+
+// SearchLog is one search someone ran. Queries are stored normalized. Logged-out
+// searches carry no user, only a visitor hash keyed with a salt that is rotated
+// daily and never stored, so it can't be traced back to a client.
+type SearchLog struct {
+	BaseModel
+	Query       string     `gorm:"size:200;not null;index" json:"query"`
+	Scope       string     `gorm:"size:20;not null" json:"scope"`
+	ResultCount int64      `gorm:"not null" json:"result_count"`
+	UserID      *uuid.UUID `gorm:"type:uuid;index" json:"user_id,omitempty"`
+	VisitorHash string     `gorm:"size:64" json:"-"`
+}
+
+// SearchClick is a result opened from a logged search
+type SearchClick struct {
+	BaseModel
+	SearchLogID uuid.UUID `gorm:"type:uuid;not null;index" json:"search_id"`
+	ResultType  string    `gorm:"size:20;not null" json:"result_type"`
+	ResultID    uuid.UUID `gorm:"type:uuid;not null" json:"result_id"`
+	Position    int       `json:"position"` // 1-based rank of the result in the list the user saw
+}
+
+// SearchQueryStat aggregates the searches for one normalized query
+type SearchQueryStat struct {
+	Query            string  `json:"query"`
+	Searches         int64   `json:"searches"`
+	Searchers        int64   `json:"searchers"`
+	AverageResults   float64 `json:"average_results"`
+	Clicks           int64   `json:"clicks"`
+	ClickThroughRate float64 `json:"click_through_rate"`
+}
+
+// ZeroResultQuery is a query that found nothing
+type ZeroResultQuery struct {
+	Query          string    `json:"query"`
+	Searches       int64     `json:"searches"`
+	Searchers      int64     `json:"searchers"`
+	LastSearchedAt time.Time `json:"last_searched_at"`
+}
+
+// SearchClickThrough is the share of searches that led to a click over a period
+type SearchClickThrough struct {
+	From              time.Time `json:"from"`
+	To                time.Time `json:"to"`
+	Searches          int64     `json:"searches"`
+	ClickedSearches   int64     `json:"clicked_searches"`
+	Clicks            int64     `json:"clicks"`
+	ClickThroughRate  float64   `json:"click_through_rate"`
+	MeanClickPosition float64   `json:"mean_click_position"`
+}
+
+// TrendingSearch is a query searched unusually often lately
+type TrendingSearch struct {
+	Query    string `json:"query"`
+	Searches int64  `json:"searches"`
+}
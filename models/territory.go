@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Territory rule modes. A song or album with allow rules in force is only
+// available in the countries they list; deny rules keep it out of theirs.
+const (
+	TerritoryAllow = "allow"
+	TerritoryDeny  = "deny"
+)
+
+// TerritoryRule licenses a song or album in one country, or keeps it out of
+// it, from StartsAt until EndsAt. An album's rules apply to its songs as well
+// as the songs' own.
+type TerritoryRule struct {
+	BaseModel
+	SongID      *uuid.UUID `gorm:"type:uuid;index" json:"song_id,omitempty"`
+	AlbumID     *uuid.UUID `gorm:"type:uuid;index" json:"album_id,omitempty"`
+	Mode        string     `gorm:"size:5;not null" json:"mode"`         // "allow" or "deny"
+	CountryCode string     `gorm:"size:2;not null" json:"country_code"` // ISO 3166-1 alpha-2
+	StartsAt    *time.Time `json:"starts_at,omitempty"`                 // in force from the start when nil
+	EndsAt      *time.Time `json:"ends_at,omitempty"`                   // in force for good when nil
+	CreatedByID *uuid.UUID `gorm:"type:uuid" json:"created_by_id,omitempty"`
+}
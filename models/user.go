@@ -11,6 +11,7 @@ type User struct {
 	ProfileImage   string  `gorm:"size:255" json:"profile_image_url"`
 	Bio            string  `gorm:"type:text" json:"bio"`
 	IsArtist       bool    `gorm:"default:false" json:"is_artist"`
+	HistoryPaused  bool    `gorm:"not null;default:false" json:"-"` // plays are still counted but kept out of the listening history
 	Roles          []Role  `gorm:"many2many:user_roles;" json:"roles,omitempty"`
 	ArtistProfile  *Artist `gorm:"foreignKey:UserID" json:"artist_profile,omitempty"`
 }
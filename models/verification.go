@@ -0,0 +1,55 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Verification request states
+const (
+	VerificationPending   = "pending"
+	VerificationNeedsInfo = "needs_info"
+	VerificationApproved  = "approved"
+	VerificationRejected  = "rejected"
+	VerificationRevoked   = "revoked"
+)
+
+// Evidence kinds
+const (
+	EvidenceLink     = "link"
+	EvidenceDocument = "document"
+)
+
+type VerificationRequest struct {
+	BaseModel
+	ArtistID    uuid.UUID              `gorm:"not null;index" json:"artist_id"`
+	Status      string                 `gorm:"size:20;not null;default:'pending';index" json:"status"` // "pending", "needs_info", "approved", "rejected" or "revoked"
+	Message     string                 `gorm:"type:text" json:"message"`
+	ReviewerID  *uuid.UUID             `json:"reviewer_id,omitempty"`
+	ReviewNotes string                 `gorm:"type:text" json:"review_notes"`
+	ReviewedAt  *time.Time             `json:"reviewed_at,omitempty"`
+	Artist      *Artist                `gorm:"foreignKey:ArtistID" json:"artist,omitempty"`
+	Evidence    []VerificationEvidence `gorm:"foreignKey:RequestID" json:"evidence,omitempty"`
+	Events      []VerificationEvent    `gorm:"foreignKey:RequestID" json:"events,omitempty"`
+}
+
+type VerificationEvidence struct {
+	BaseModel
+	RequestID   uuid.UUID `gorm:"not null;index" json:"request_id"`
+	Kind        string    `gorm:"size:20;not null" json:"kind"` // "link" or "document"
+	URL         string    `gorm:"size:500" json:"url,omitempty"`
+	FileName    string    `gorm:"size:255" json:"file_name,omitempty"`
+	ContentType string    `gorm:"size:100" json:"content_type,omitempty"`
+	Size        int64     `json:"size,omitempty"`
+	StorageKey  string    `gorm:"size:255" json:"-"`
+}
+
+// VerificationEvent is the audit trail of every decision taken on an artist's verification
+type VerificationEvent struct {
+	BaseModel
+	ArtistID  uuid.UUID  `gorm:"not null;index" json:"artist_id"`
+	RequestID *uuid.UUID `gorm:"index" json:"request_id,omitempty"`
+	ActorID   uuid.UUID  `gorm:"not null" json:"actor_id"`
+	Action    string     `gorm:"size:20;not null" json:"action"` // "submitted", "resubmitted", "approved", "rejected", "needs_info" or "revoked"
+	Notes     string     `gorm:"type:text" json:"notes"`
+}
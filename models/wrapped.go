@@ -0,0 +1,163 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Kinds of year-in-review report
+const (
+	WrappedListener = "listener"
+	WrappedArtist   = "artist"
+)
+
+// WrappedVersion is the version of the report document written by the current
+// code. Reports of older versions are kept, so clients reading them keep working.
+const WrappedVersion = 1
+
+// WrappedReport is one listener's or artist's year in review
+type WrappedReport struct {
+	Kind        string          `gorm:"size:10;primaryKey" json:"kind"`
+	SubjectID   uuid.UUID       `gorm:"type:uuid;primaryKey" json:"subject_id"` // user or artist ID
+	Year        int             `gorm:"primaryKey" json:"year"`
+	Version     int             `gorm:"primaryKey" json:"version"`
+	Document    WrappedDocument `gorm:"type:jsonb;not null" json:"document"`
+	GeneratedAt time.Time       `gorm:"not null" json:"generated_at"`
+}
+
+// WrappedYear marks a year whose reports of a version were all made after the
+// year ended, so the scheduled job doesn't make them again
+type WrappedYear struct {
+	Year        int       `gorm:"primaryKey" json:"year"`
+	Version     int       `gorm:"primaryKey" json:"version"`
+	Listeners   int       `gorm:"not null" json:"listeners"`
+	Artists     int       `gorm:"not null" json:"artists"`
+	CompletedAt time.Time `gorm:"not null" json:"completed_at"`
+}
+
+// WrappedFan is where a listener ranks among everyone who streamed an artist
+// over a year. The report job ranks a year's fans once before making listener
+// reports and clears them when it's done.
+type WrappedFan struct {
+	Year          int       `gorm:"primaryKey" json:"year"`
+	ArtistID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"artist_id"`
+	UserID        uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	Streams       int64     `gorm:"not null" json:"streams"`
+	Minutes       int64     `gorm:"not null" json:"minutes"`
+	TopFanPercent float64   `gorm:"not null" json:"top_fan_percent"`
+}
+
+// WrappedDocument holds the report of one kind
+type WrappedDocument struct {
+	From     string           `json:"from"`    // first day counted
+	Through  string           `json:"through"` // last day counted, before the year ends for reports made early
+	Listener *ListenerWrapped `json:"listener,omitempty"`
+	Artist   *ArtistWrapped   `json:"artist,omitempty"`
+}
+
+func (d WrappedDocument) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+func (d *WrappedDocument) Scan(value interface{}) error {
+	return scanJSON(value, d)
+}
+
+// ListenerWrapped is what a listener played over the year, from their listening history
+type ListenerWrapped struct {
+	Streams         int64              `json:"streams"`
+	MinutesListened int64              `json:"minutes_listened"`
+	DaysListened    int                `json:"days_listened"`
+	LongestStreak   WrappedStreak      `json:"longest_streak"`
+	TopSongs        []WrappedSong      `json:"top_songs"`
+	TopArtists      []WrappedTopArtist `json:"top_artists"`
+	TopGenres       []WrappedGenre     `json:"top_genres"`
+}
+
+// WrappedStreak is a run of consecutive UTC days with plays
+type WrappedStreak struct {
+	Days    int    `json:"days"`
+	From    string `json:"from,omitempty"`
+	Through string `json:"through,omitempty"`
+}
+
+type WrappedSong struct {
+	SongID    uuid.UUID `json:"song_id"`
+	Title     string    `json:"title"`
+	Streams   int64     `json:"streams"`
+	Listeners int64     `json:"listeners,omitempty"` // on artist reports
+	Minutes   int64     `json:"minutes"`
+}
+
+type WrappedTopArtist struct {
+	ArtistID uuid.UUID `json:"artist_id"`
+	Name     string    `json:"name"`
+	Streams  int64     `json:"streams"`
+	Minutes  int64     `json:"minutes"`
+	// TopFanPercent places the listener among everyone who streamed the artist
+	// that year: 1 means in their top 1% of listeners by streams
+	TopFanPercent float64 `json:"top_fan_percent"`
+}
+
+type WrappedGenre struct {
+	GenreID uuid.UUID `json:"genre_id"`
+	Name    string    `json:"name"`
+	Streams int64     `json:"streams"`
+}
+
+// ArtistWrapped is how an artist was streamed over the year, counting qualified streams
+type ArtistWrapped struct {
+	Streams         int64            `json:"streams"`
+	Listeners       int64            `json:"listeners"`
+	MinutesListened int64            `json:"minutes_listened"`
+	Countries       int64            `json:"countries"`
+	TopSongs        []WrappedSong    `json:"top_songs"`
+	TopCountries    []WrappedCountry `json:"top_countries"`
+}
+
+type WrappedCountry struct {
+	CountryCode string `json:"country_code"`
+	Streams     int64  `json:"streams"`
+	Listeners   int64  `json:"listeners"`
+}
+
+// WrappedTotals are one subject's yearly totals, as read by the report job
+type WrappedTotals struct {
+	SubjectID uuid.UUID
+	Streams   int64
+	Listeners int64
+	Minutes   int64
+	Countries int64
+}
+
+// WrappedSongRow is one of a subject's top songs, as read by the report job
+type WrappedSongRow struct {
+	SubjectID uuid.UUID
+	WrappedSong
+}
+
+// WrappedArtistRow is one of a listener's top artists, as read by the report job
+type WrappedArtistRow struct {
+	SubjectID uuid.UUID
+	WrappedTopArtist
+}
+
+// WrappedGenreRow is one of a listener's top genres, as read by the report job
+type WrappedGenreRow struct {
+	SubjectID uuid.UUID
+	WrappedGenre
+}
+
+// WrappedCountryRow is one of an artist's top countries, as read by the report job
+type WrappedCountryRow struct {
+	SubjectID uuid.UUID
+	WrappedCountry
+}
+
+// WrappedDay is a UTC day a listener played something, as read by the report job
+type WrappedDay struct {
+	SubjectID uuid.UUID
+	Day       time.Time
+}
@@ -0,0 +1,110 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// FakeSignatureHeader carries a fake webhook's signature, a hex HMAC-SHA256
+// of the body
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// Fake is a PaymentProvider that keeps payments in memory and charges no one,
+// for development and tests. Payments stay pending until a webhook made by
+// Webhook is posted for them.
+type Fake struct {
+	secret []byte
+
+	mu      sync.Mutex
+	charges map[string]Charge
+}
+
+func NewFake(webhookSecret string) *Fake {
+	return &Fake{
+		secret:  []byte(webhookSecret),
+		charges: map[string]Charge{},
+	}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) CreatePayment(ctx context.Context, charge Charge) (*Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.charges[charge.Reference] = charge
+	return &Payment{ProviderRef: "fake_" + charge.Reference, Status: StatusPending}, nil
+}
+
+// Charged returns the charge started with reference
+func (f *Fake) Charged(reference string) (Charge, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	charge, ok := f.charges[reference]
+	return charge, ok
+}
+
+type fakeWebhook struct {
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Webhook makes the signed webhook settling the charge started with reference
+// as status, StatusSucceeded or StatusFailed
+func (f *Fake) Webhook(reference, status string) (body []byte, signature string, err error) {
+	charge, ok := f.Charged(reference)
+	if !ok {
+		return nil, "", fmt.Errorf("fake: no charge %q", reference)
+	}
+	webhook := fakeWebhook{Reference: reference, Status: status, Amount: charge.Amount, Currency: charge.Currency}
+	if status == StatusFailed {
+		webhook.Reason = "declined by the fake provider"
+	}
+	body, err = json.Marshal(webhook)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, f.sign(body), nil
+}
+
+func (f *Fake) sign(body []byte) string {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (f *Fake) ParseWebhook(ctx context.Context, header func(string) string, body []byte) (*Event, error) {
+	signature, err := hex.DecodeString(header(FakeSignatureHeader))
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	expected, _ := hex.DecodeString(f.sign(body))
+	if !hmac.Equal(signature, expected) {
+		return nil, ErrInvalidSignature
+	}
+
+	var webhook fakeWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("fake: unreadable webhook: %w", err)
+	}
+	if webhook.Status != StatusSucceeded && webhook.Status != StatusFailed {
+		return nil, nil
+	}
+	return &Event{
+		Reference:   webhook.Reference,
+		ProviderRef: "fake_" + webhook.Reference,
+		Status:      webhook.Status,
+		Amount:      webhook.Amount,
+		Currency:    webhook.Currency,
+		Reason:      webhook.Reason,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package payments
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFakeParseWebhook(t *testing.T) {
+	fake := NewFake("fake-secret")
+	charge := Charge{Reference: "ref-1", Amount: 1200, Currency: "NGN"}
+	if _, err := fake.CreatePayment(context.Background(), charge); err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+	webhook := func(status string) (string, string) {
+		body, signature, err := fake.Webhook(charge.Reference, status)
+		if err != nil {
+			t.Fatalf("Webhook: %v", err)
+		}
+		return string(body), signature
+	}
+	succeeded, succeededSignature := webhook(StatusSucceeded)
+	failed, failedSignature := webhook(StatusFailed)
+	pending, pendingSignature := webhook(StatusPending)
+	forged := NewFake("other-secret").sign([]byte(succeeded))
+
+	tests := []struct {
+		name      string
+		body      string
+		signature string
+		want      *Event
+		wantErr   error
+	}{
+		{
+			name:      "succeeded",
+			body:      succeeded,
+			signature: succeededSignature,
+			want:      &Event{Reference: "ref-1", ProviderRef: "fake_ref-1", Status: StatusSucceeded, Amount: 1200, Currency: "NGN"},
+		},
+		{
+			name:      "failed",
+			body:      failed,
+			signature: failedSignature,
+			want: &Event{Reference: "ref-1", ProviderRef: "fake_ref-1", Status: StatusFailed, Amount: 1200, Currency: "NGN",
+				Reason: "declined by the fake provider"},
+		},
+		{
+			name:      "other statuses settle nothing",
+			body:      pending,
+			signature: pendingSignature,
+		},
+		{
+			name:      "signed with another secret",
+			body:      succeeded,
+			signature: forged,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "another webhook's signature",
+			body:      succeeded,
+			signature: failedSignature,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:    "no signature",
+			body:    succeeded,
+			wantErr: ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(FakeSignatureHeader, tt.signature)
+			event, err := fake.ParseWebhook(context.Background(), header.Get, []byte(tt.body))
+			checkEvent(t, event, err, tt.want, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package payments
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFlutterwaveParseWebhook(t *testing.T) {
+	const (
+		secretKey = "FLWSECK_TEST"
+		hash      = "verif-hash-test"
+	)
+	webhook := func(event string) string {
+		// The webhook's own copy of the transaction is never believed
+		return `{"event":"` + event + `","data":{"id":42,"tx_ref":"forged","status":"successful","amount":1,"currency":"NGN"}}`
+	}
+
+	tests := []struct {
+		name string
+		body string
+		hash string
+		// verified is the transaction the verify call answers with, or
+		// the response status when it's not 200
+		verified string
+		status   int
+		want     *Event
+		wantErr  error
+		otherErr bool
+	}{
+		{
+			name:     "verified successful",
+			body:     webhook("charge.completed"),
+			hash:     hash,
+			verified: `{"status":"success","data":{"id":42,"tx_ref":"ref-1","status":"successful","amount":2500.5,"currency":"ngn"}}`,
+			want:     &Event{Reference: "ref-1", ProviderRef: "42", Status: StatusSucceeded, Amount: 250050, Currency: "NGN"},
+		},
+		{
+			name:     "verified failed",
+			body:     webhook("charge.completed"),
+			hash:     hash,
+			verified: `{"status":"success","data":{"id":42,"tx_ref":"ref-1","status":"failed","amount":2500,"currency":"NGN","processor_response":"Insufficient funds"}}`,
+			want:     &Event{Reference: "ref-1", ProviderRef: "42", Status: StatusFailed, Amount: 250000, Currency: "NGN", Reason: "Insufficient funds"},
+		},
+		{
+			name:     "still pending once verified",
+			body:     webhook("charge.completed"),
+			hash:     hash,
+			verified: `{"status":"success","data":{"id":42,"tx_ref":"ref-1","status":"pending","amount":2500,"currency":"NGN"}}`,
+		},
+		{
+			name:     "verify call fails",
+			body:     webhook("charge.completed"),
+			hash:     hash,
+			verified: `{"status":"error","message":"No transaction was found for this id"}`,
+			status:   http.StatusNotFound,
+			otherErr: true,
+		},
+		{
+			name: "other events settle nothing",
+			body: webhook("transfer.completed"),
+			hash: hash,
+		},
+		{
+			name:    "wrong hash",
+			body:    webhook("charge.completed"),
+			hash:    "guessed",
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "no hash",
+			body:    webhook("charge.completed"),
+			wantErr: ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifies := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				verifies++
+				if r.Method != http.MethodGet || r.URL.Path != "/v3/transactions/42/verify" {
+					t.Errorf("unexpected call %s %s", r.Method, r.URL.Path)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer "+secretKey {
+					t.Errorf("verify call authorized with %q", got)
+				}
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				w.Write([]byte(tt.verified))
+			}))
+			defer server.Close()
+
+			provider := NewFlutterwave(secretKey, hash, "")
+			provider.BaseURL = server.URL
+			header := http.Header{}
+			header.Set("verif-hash", tt.hash)
+
+			event, err := provider.ParseWebhook(context.Background(), header.Get, []byte(tt.body))
+			if tt.verified == "" && verifies > 0 {
+				t.Errorf("verified the transaction %d times, want none", verifies)
+			}
+			if tt.otherErr {
+				if err == nil {
+					t.Fatal("got no error, want the verify call's")
+				}
+				return
+			}
+			checkEvent(t, event, err, tt.want, tt.wantErr)
+		})
+	}
+}
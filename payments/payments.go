@@ -0,0 +1,182 @@
+// Package payments charges payers through payment providers. A payment is
+// started with CreatePayment and only settled later, when the provider reports
+// its outcome by a signed webhook.
+package payments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Statuses a provider reports for a payment
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+var ErrInvalidSignature = errors.New("webhook signature is invalid")
+
+// Charge is a payment to start
+type Charge struct {
+	// Reference is ours and unique to the payment; providers send it back in webhooks
+	Reference string
+	Amount    int64  // in minor units of Currency
+	Currency  string // ISO 4217, upper case
+	Email     string // of the payer
+	// PaymentMethodID is a card or authorization the payer saved with the
+	// provider earlier. Without one the payer finishes paying on CheckoutURL,
+	// or in their app with ClientSecret.
+	PaymentMethodID string
+	Description     string
+}
+
+// Payment is a provider's payment started for a Charge
+type Payment struct {
+	ProviderRef  string
+	Status       string
+	CheckoutURL  string // page the payer finishes paying on, for redirect flows
+	ClientSecret string // lets the payer's app confirm the payment, for Stripe
+}
+
+// Event is what a webhook says about how a payment turned out
+type Event struct {
+	Reference   string // ours, from the Charge
+	ProviderRef string
+	Status      string // StatusSucceeded or StatusFailed
+	Amount      int64  // in minor units, as charged
+	Currency    string
+	Reason      string // why it failed, when the provider says
+}
+
+// PaymentProvider charges payers through one payment provider
+type PaymentProvider interface {
+	// Name is the provider's name in webhook URLs and on payments
+	Name() string
+	CreatePayment(ctx context.Context, charge Charge) (*Payment, error)
+	// ParseWebhook verifies a webhook's signature and reads it. Webhooks that
+	// don't settle a payment give a nil event.
+	ParseWebhook(ctx context.Context, header func(string) string, body []byte) (*Event, error)
+}
+
+// Registry holds the configured providers by name. The first one is the
+// default, used when a payer doesn't choose.
+type Registry struct {
+	providers map[string]PaymentProvider
+	names     []string
+}
+
+func NewRegistry(providers ...PaymentProvider) *Registry {
+	r := &Registry{providers: map[string]PaymentProvider{}}
+	for _, provider := range providers {
+		if _, ok := r.providers[provider.Name()]; ok {
+			continue
+		}
+		r.providers[provider.Name()] = provider
+		r.names = append(r.names, provider.Name())
+	}
+	return r
+}
+
+// Get finds the provider named name, or the default one for ""
+func (r *Registry) Get(name string) (PaymentProvider, bool) {
+	if name == "" {
+		if len(r.names) == 0 {
+			return nil, false
+		}
+		name = r.names[0]
+	}
+	provider, ok := r.providers[name]
+	return provider, ok
+}
+
+// Names lists the providers, the default first
+func (r *Registry) Names() []string {
+	return append([]string(nil), r.names...)
+}
+
+// zeroDecimal are the currencies without minor units
+var zeroDecimal = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true, "KMF": true, "KRW": true,
+	"MGA": true, "PYG": true, "RWF": true, "UGX": true, "VND": true, "VUV": true, "XAF": true,
+	"XOF": true, "XPF": true,
+}
+
+// MinorUnits converts amount in currency to its minor units, e.g. naira to kobo
+func MinorUnits(amount float64, currency string) int64 {
+	if zeroDecimal[strings.ToUpper(currency)] {
+		return int64(math.Round(amount))
+	}
+	return int64(math.Round(amount * 100))
+}
+
+// MajorUnits converts amount in minor units of currency back, e.g. kobo to naira
+func MajorUnits(amount int64, currency string) float64 {
+	if zeroDecimal[strings.ToUpper(currency)] {
+		return float64(amount)
+	}
+	return float64(amount) / 100
+}
+
+var defaultClient = &http.Client{Timeout: 30 * time.Second}
+
+// doJSON sends a request with a JSON body, when body isn't nil, and decodes
+// the response into out. Responses that aren't 2xx are returned as errors,
+// with errorMessage reading the provider's reason from the body.
+func doJSON(ctx context.Context, client *http.Client, method, url string, header http.Header, body, out any, errorMessage func([]byte) string) error {
+	var content io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		content = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, content)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return do(client, req, out, errorMessage)
+}
+
+func do(client *http.Client, req *http.Request, out any, errorMessage func([]byte) string) error {
+	if client == nil {
+		client = defaultClient
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message := errorMessage(content)
+		if message == "" {
+			message = resp.Status
+		}
+		return errors.New(message)
+	}
+	if err := json.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("unreadable response: %w", err)
+	}
+	return nil
+}
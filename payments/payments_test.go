@@ -0,0 +1,29 @@
+package payments
+
+import (
+	"errors"
+	"testing"
+)
+
+// checkEvent compares what ParseWebhook returned with what's wanted; a nil
+// wantErr wants no error
+func checkEvent(t *testing.T, event *Event, err error, want *Event, wantErr error) {
+	t.Helper()
+	if wantErr != nil {
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("got error %v, want %v", err, wantErr)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("ParseWebhook: %v", err)
+	}
+	switch {
+	case want == nil && event != nil:
+		t.Errorf("got %+v, want no event", *event)
+	case want != nil && event == nil:
+		t.Errorf("got no event, want %+v", *want)
+	case want != nil && *event != *want:
+		t.Errorf("got %+v, want %+v", *event, *want)
+	}
+}
@@ -0,0 +1,137 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Paystack charges through Paystack transactions. Its webhooks are signed
+// with the secret key, as a hex HMAC-SHA512 of the body in the
+// X-Paystack-Signature header. Paystack only sends webhooks for successful
+// charges; payments it declines stay pending.
+type Paystack struct {
+	SecretKey string
+	// CallbackURL is where Paystack sends payers after checkout
+	CallbackURL string
+	BaseURL     string
+	Client      *http.Client
+}
+
+func NewPaystack(secretKey, callbackURL string) *Paystack {
+	return &Paystack{
+		SecretKey:   secretKey,
+		CallbackURL: callbackURL,
+		BaseURL:     "https://api.paystack.co",
+	}
+}
+
+func (p *Paystack) Name() string {
+	return "paystack"
+}
+
+type paystackTransaction struct {
+	ID               int64  `json:"id"`
+	Reference        string `json:"reference"`
+	Status           string `json:"status"`
+	Amount           int64  `json:"amount"`
+	Currency         string `json:"currency"`
+	GatewayResponse  string `json:"gateway_response"`
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+func (p *Paystack) CreatePayment(ctx context.Context, charge Charge) (*Payment, error) {
+	body := map[string]any{
+		"email":     charge.Email,
+		"amount":    charge.Amount,
+		"currency":  charge.Currency,
+		"reference": charge.Reference,
+	}
+	endpoint := "/transaction/initialize"
+	if charge.PaymentMethodID != "" {
+		// A saved authorization is charged without the payer
+		endpoint = "/transaction/charge_authorization"
+		body["authorization_code"] = charge.PaymentMethodID
+	} else if p.CallbackURL != "" {
+		body["callback_url"] = p.CallbackURL
+	}
+
+	var response struct {
+		Status  bool                `json:"status"`
+		Message string              `json:"message"`
+		Data    paystackTransaction `json:"data"`
+	}
+	header := http.Header{"Authorization": {"Bearer " + p.SecretKey}}
+	if err := doJSON(ctx, p.Client, http.MethodPost, p.BaseURL+endpoint, header, body, &response, paystackError); err != nil {
+		return nil, fmt.Errorf("paystack: %w", err)
+	}
+	if !response.Status {
+		return nil, fmt.Errorf("paystack: %s", response.Message)
+	}
+
+	status := StatusPending
+	switch response.Data.Status {
+	case "success":
+		status = StatusSucceeded
+	case "failed", "abandoned", "reversed":
+		status = StatusFailed
+	}
+	return &Payment{
+		ProviderRef: charge.Reference,
+		Status:      status,
+		CheckoutURL: response.Data.AuthorizationURL,
+	}, nil
+}
+
+func paystackError(body []byte) string {
+	var response struct {
+		Message string `json:"message"`
+	}
+	json.Unmarshal(body, &response)
+	return response.Message
+}
+
+func (p *Paystack) ParseWebhook(ctx context.Context, header func(string) string, body []byte) (*Event, error) {
+	signature, err := hex.DecodeString(header("X-Paystack-Signature"))
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	mac := hmac.New(sha512.New, []byte(p.SecretKey))
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event struct {
+		Event string              `json:"event"`
+		Data  paystackTransaction `json:"data"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("paystack: unreadable webhook: %w", err)
+	}
+	if event.Event != "charge.success" {
+		return nil, nil
+	}
+	if event.Data.Reference == "" {
+		return nil, errors.New("paystack: webhook has no reference")
+	}
+
+	result := &Event{
+		Reference:   event.Data.Reference,
+		ProviderRef: event.Data.Reference,
+		Amount:      event.Data.Amount,
+		Currency:    strings.ToUpper(event.Data.Currency),
+		Status:      StatusSucceeded,
+	}
+	if event.Data.Status != "success" {
+		result.Status = StatusFailed
+		result.Reason = event.Data.GatewayResponse
+	}
+	return result, nil
+}
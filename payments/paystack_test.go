@@ -0,0 +1,91 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPaystackParseWebhook(t *testing.T) {
+	const secret = "sk_test_paystack"
+	sign := func(key, body string) string {
+		mac := hmac.New(sha512.New, []byte(key))
+		mac.Write([]byte(body))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	charge := func(event, reference, status string) string {
+		return `{"event":"` + event + `","data":{"id":1,"reference":"` + reference + `","status":"` + status + `",
+			"amount":250000,"currency":"ngn","gateway_response":"Declined"}}`
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		signature string // the body signed with the secret when empty
+		want      *Event
+		wantErr   error
+		// otherErr wants an error other than a bad signature
+		otherErr bool
+	}{
+		{
+			name: "charge succeeded",
+			body: charge("charge.success", "ref-1", "success"),
+			want: &Event{Reference: "ref-1", ProviderRef: "ref-1", Status: StatusSucceeded, Amount: 250000, Currency: "NGN"},
+		},
+		{
+			name: "charge event that didn't succeed",
+			body: charge("charge.success", "ref-1", "failed"),
+			want: &Event{Reference: "ref-1", ProviderRef: "ref-1", Status: StatusFailed, Amount: 250000, Currency: "NGN", Reason: "Declined"},
+		},
+		{
+			name: "other events settle nothing",
+			body: charge("transfer.success", "ref-1", "success"),
+		},
+		{
+			name:     "no reference",
+			body:     charge("charge.success", "", "success"),
+			otherErr: true,
+		},
+		{
+			name:      "signed with another key",
+			body:      charge("charge.success", "ref-1", "success"),
+			signature: sign("sk_other", charge("charge.success", "ref-1", "success")),
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "signature isn't hex",
+			body:      charge("charge.success", "ref-1", "success"),
+			signature: "not-hex",
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:     "unreadable body",
+			body:     `{"event":`,
+			otherErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature := tt.signature
+			if signature == "" {
+				signature = sign(secret, tt.body)
+			}
+			header := http.Header{}
+			header.Set("X-Paystack-Signature", signature)
+
+			event, err := NewPaystack(secret, "").ParseWebhook(context.Background(), header.Get, []byte(tt.body))
+			if tt.otherErr {
+				if err == nil || errors.Is(err, ErrInvalidSignature) {
+					t.Fatalf("got error %v, want one reading the webhook", err)
+				}
+				return
+			}
+			checkEvent(t, event, err, tt.want, tt.wantErr)
+		})
+	}
+}
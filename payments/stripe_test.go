@@ -0,0 +1,100 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// stripeSignature signs body as Stripe does at t
+func stripeSignature(secret string, t time.Time, body string) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + body))
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestStripeParseWebhook(t *testing.T) {
+	const secret = "whsec_test"
+	intent := func(eventType string) string {
+		return `{"type":"` + eventType + `","data":{"object":{"id":"pi_1","amount":5000,"currency":"ngn",
+			"metadata":{"reference":"ref-1"},"last_payment_error":{"message":"card declined"}}}}`
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		signature func(body string) string
+		want      *Event
+		wantErr   error
+	}{
+		{
+			name: "succeeded",
+			body: intent("payment_intent.succeeded"),
+			want: &Event{Reference: "ref-1", ProviderRef: "pi_1", Status: StatusSucceeded, Amount: 5000, Currency: "NGN"},
+		},
+		{
+			name: "canceled is final",
+			body: intent("payment_intent.canceled"),
+			want: &Event{Reference: "ref-1", ProviderRef: "pi_1", Status: StatusFailed, Amount: 5000, Currency: "NGN", Reason: "card declined"},
+		},
+		{
+			name: "a failed attempt settles nothing",
+			body: intent("payment_intent.payment_failed"),
+		},
+		{
+			name: "other events settle nothing",
+			body: intent("payment_intent.created"),
+		},
+		{
+			name: "signed with another secret",
+			body: intent("payment_intent.succeeded"),
+			signature: func(body string) string {
+				return stripeSignature("whsec_other", time.Now(), body)
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "signed too long ago",
+			body: intent("payment_intent.succeeded"),
+			signature: func(body string) string {
+				return stripeSignature(secret, time.Now().Add(-time.Hour), body)
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:      "no signature",
+			body:      intent("payment_intent.succeeded"),
+			signature: func(string) string { return "" },
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name: "tampered body",
+			body: intent("payment_intent.succeeded"),
+			signature: func(body string) string {
+				return stripeSignature(secret, time.Now(), intent("payment_intent.canceled"))
+			},
+			wantErr: ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature := stripeSignature(secret, time.Now(), tt.body)
+			if tt.signature != nil {
+				signature = tt.signature(tt.body)
+			}
+			header := http.Header{}
+			header.Set("Stripe-Signature", signature)
+
+			event, err := NewStripe("sk_test", secret).ParseWebhook(context.Background(), header.Get, []byte(tt.body))
+			checkEvent(t, event, err, tt.want, tt.wantErr)
+		})
+	}
+}
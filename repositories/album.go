@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"crawl/models"
 	"errors"
 	"github.com/google/uuid"
@@ -10,17 +11,45 @@ import (
 
 type AlbumRepository struct {
 	BaseRepository[models.Album]
+	fuzzy   FuzzySettings
+	hits    []uuid.UUID
+	country *string
 }
 
-func NewAlbumRepository(db *gorm.DB) IAlbumRepository {
+func NewAlbumRepository(db *gorm.DB, fuzzy FuzzySettings) IAlbumRepository {
 	return &AlbumRepository{
 		BaseRepository: BaseRepository[models.Album]{DB: db},
+		fuzzy:          fuzzy,
 	}
 }
 
+// WithIndexHits returns a copy of the repository whose searches match only ids,
+// the hits of an external search index, ranked in the given order
+func (r *AlbumRepository) WithIndexHits(ids []uuid.UUID) IAlbumRepository {
+	indexed := *r
+	indexed.hits = ids
+	return &indexed
+}
+
+// AvailableIn returns a copy of the repository whose listings leave out the
+// albums that can't be played in country now; "" is an unknown country
+func (r *AlbumRepository) AvailableIn(country string) IAlbumRepository {
+	available := *r
+	available.country = &country
+	return &available
+}
+
+// available applies the territory filter set by AvailableIn, if any
+func (r *AlbumRepository) available(db *gorm.DB) *gorm.DB {
+	if r.country == nil {
+		return db
+	}
+	return availableIn(db, albumAvailable, *r.country)
+}
+
 func (r *AlbumRepository) GetWithSongs(id uuid.UUID) (*models.Album, error) {
 	var album models.Album
-	err := r.DB.Preload("Songs").First(&album, id).Error
+	err := r.DB.Preload("Songs").Preload("Tags").First(&album, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, ErrRecordNotFound
 	}
@@ -33,35 +62,80 @@ func (r *AlbumRepository) GetByArtist(artistID uuid.UUID) ([]models.Album, error
 	return albums, err
 }
 
-func (r *AlbumRepository) SearchAlbums(query *string, artist *string, genre *string, sort *string, page *int, limit *int) ([]models.Album, error) {
-	// Implementation would depend on your specific search requirements
-	// This is a basic example that would need to be expanded
-	dbQuery := r.DB.Model(&models.Album{}).Preload("Artist").Preload("Genre")
+func (r *AlbumRepository) GetFiltered(artistID, genreID *uuid.UUID, tags []string, offset, limit int) ([]models.Album, error) {
+	var albums []models.Album
+	db := r.DB.Model(&models.Album{})
+
+	if artistID != nil {
+		db = db.Where("albums.artist_id = ?", *artistID)
+	}
+	if genreID != nil {
+		db = db.Where("albums.genre_id IN ("+genreSubtreeQuery("id = ?")+")", *genreID)
+	}
+	if len(tags) > 0 {
+		db = taggedWith(db, "albums", "album_tags", "album_id", tags)
+	}
+	db = r.available(db)
 
-	if query != nil && *query != "" {
-		dbQuery = dbQuery.Where("title LIKE ?", "%"+*query+"%")
+	if limit > 0 {
+		db = db.Offset(offset).Limit(limit)
+	}
+
+	err := db.Find(&albums).Error
+	return albums, err
+}
+
+// albumSearchVector weights the album's own title and description above its artist and genre
+const albumSearchVector = `(albums.search_vector
+	|| setweight(coalesce(artists.search_vector, ''), 'B')
+	|| setweight(coalesce(genres.search_vector, ''), 'D'))`
+
+// searchScope applies the query and filters shared by SearchAlbums and SearchFacets
+func (r *AlbumRepository) searchScope(ctx context.Context, terms searchTerms, artist, genre *string) *gorm.DB {
+	db := r.DB.WithContext(ctx).Model(&models.Album{}).
+		Joins("LEFT JOIN artists ON artists.id = albums.artist_id AND artists.deleted_at IS NULL").
+		Joins("LEFT JOIN genres ON genres.id = albums.genre_id AND genres.deleted_at IS NULL")
+
+	if !terms.empty() {
+		db = terms.where(db, "albums", albumSearchVector, "albums.title", "artists.artist_name")
 	}
 
 	if artist != nil && *artist != "" {
-		dbQuery = dbQuery.Joins("JOIN artists ON artists.id = albums.artist_id").
-			Where("artists.name LIKE ?", "%"+*artist+"%")
+		db = db.Where("artists.artist_name ILIKE ?", "%"+*artist+"%")
 	}
 
 	if genre != nil && *genre != "" {
-		dbQuery = dbQuery.Joins("JOIN genres ON genres.id = albums.genre_id").
-			Where("genres.name LIKE ?", "%"+*genre+"%")
+		db = db.Where("albums.genre_id IN ("+genreSubtreeQuery("name ILIKE ?")+")", "%"+*genre+"%")
 	}
 
+	return r.available(db)
+}
+
+func (r *AlbumRepository) SearchAlbums(ctx context.Context, query *string, artist *string, genre *string, sort *string, page *int, limit *int) ([]models.Album, int64, error) {
+	var total int64
+
+	terms := newSearchTerms(query, r.fuzzy, r.hits)
+	dbQuery := r.searchScope(ctx, terms, artist, genre)
+
+	if err := dbQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var sortKey string
 	if sort != nil {
-		switch *sort {
-		case "newest":
-			dbQuery = dbQuery.Order("release_date DESC")
-		case "oldest":
-			dbQuery = dbQuery.Order("release_date ASC")
-		case "popular":
-			// Would need a plays_count or similar field
-			dbQuery = dbQuery.Order("plays_count DESC")
-		}
+		sortKey = *sort
+	}
+	switch {
+	case sortKey == "release_date":
+		dbQuery = dbQuery.Order("albums.release_date DESC")
+	case sortKey == "price":
+		dbQuery = dbQuery.Order("albums.price ASC")
+	case sortKey == "title":
+		dbQuery = dbQuery.Order("albums.title ASC")
+	case sortKey == "popularity":
+		dbQuery = dbQuery.Order("(SELECT COALESCE(SUM(songs.plays_count), 0) FROM songs WHERE songs.album_id = albums.id AND songs.deleted_at IS NULL) DESC")
+	case !terms.empty():
+		dbQuery = dbQuery.Order("relevance DESC")
 	}
 
 	if page != nil && limit != nil {
@@ -69,7 +143,22 @@ func (r *AlbumRepository) SearchAlbums(query *string, artist *string, genre *str
 		dbQuery = dbQuery.Offset(offset).Limit(*limit)
 	}
 
+	if !terms.empty() {
+		rank, args := terms.rank(albumSearchVector, "albums.title", "artists.artist_name")
+		albums, err := findRanked(dbQuery, r.DB.WithContext(ctx).Preload("Artist").Preload("Genre"), "albums", rank, args,
+			func(album *models.Album) (uuid.UUID, *float64) { return album.ID, &album.Relevance })
+		return albums, total, err
+	}
+
 	var albums []models.Album
-	err := dbQuery.Find(&albums).Error
-	return albums, err
+	err := dbQuery.Preload("Artist").Preload("Genre").Find(&albums).Error
+	return albums, total, err
+}
+
+// SearchFacets counts the albums matching query by genre, release year and price range
+func (r *AlbumRepository) SearchFacets(ctx context.Context, query *string) (*models.SearchFacets, error) {
+	terms := newSearchTerms(query, r.fuzzy, r.hits)
+	return searchFacets("albums", func() *gorm.DB {
+		return r.searchScope(ctx, terms, nil, nil)
+	})
 }
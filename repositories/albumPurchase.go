@@ -40,10 +40,23 @@ func (r *AlbumPurchaseRepository) GetUserAlbumPurchases(userID uuid.UUID) ([]mod
 	return purchases, err
 }
 
+// GetLibraryAlbums returns the albums the user paid for, leaving out those that
+// can't be played in country now
+func (r *AlbumPurchaseRepository) GetLibraryAlbums(userID uuid.UUID, country string) ([]models.Album, error) {
+	var albums []models.Album
+	db := r.DB.
+		Preload("Artist").
+		Where("albums.id IN (?)", r.DB.Model(&models.AlbumPurchase{}).
+			Select("album_id").
+			Where("user_id = ? AND payment_status = ?", userID, models.PaymentCompleted))
+	err := availableIn(db, albumAvailable, country).Find(&albums).Error
+	return albums, err
+}
+
 func (r *AlbumPurchaseRepository) HasPurchasedAlbum(userID, albumID uuid.UUID) (bool, error) {
 	var count int64
 	err := r.DB.Model(&models.AlbumPurchase{}).
-		Where("user_id = ? AND album_id = ? payment_status = completed", userID, albumID).
+		Where("user_id = ? AND album_id = ? AND payment_status = ?", userID, albumID, models.PaymentCompleted).
 		Count(&count).
 		Error
 	return count > 0, err
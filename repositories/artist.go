@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"crawl/models"
 	"errors"
 	"github.com/google/uuid"
@@ -8,16 +9,31 @@ import (
 	"gorm.io/gorm"
 )
 
+// verifiedBoost scales the relevance of verified artists, so they rank above
+// unverified names that match about as well without burying better matches
+const verifiedBoost = 1.5
+
 type ArtistRepository struct {
 	BaseRepository[models.Artist]
+	fuzzy FuzzySettings
+	hits  []uuid.UUID
 }
 
-func NewArtistRepository(db *gorm.DB) IArtistRepository {
+func NewArtistRepository(db *gorm.DB, fuzzy FuzzySettings) IArtistRepository {
 	return &ArtistRepository{
 		BaseRepository: BaseRepository[models.Artist]{DB: db},
+		fuzzy:          fuzzy,
 	}
 }
 
+// WithIndexHits returns a copy of the repository whose searches match only ids,
+// the hits of an external search index, ranked in the given order
+func (r *ArtistRepository) WithIndexHits(ids []uuid.UUID) IArtistRepository {
+	indexed := *r
+	indexed.hits = ids
+	return &indexed
+}
+
 func (r *ArtistRepository) GetWithSongs(id uuid.UUID) (*models.Artist, error) {
 	var artist models.Artist
 	err := r.DB.Preload("Songs").First(&artist, id).Error
@@ -45,13 +61,52 @@ func (r *ArtistRepository) GetWithUserId(userID uuid.UUID) (*models.Artist, erro
 	return &artist, err
 }
 
-func (r *ArtistRepository) SearchByName(query string, limit int, offset int) ([]models.Artist, error) {
+func (r *ArtistRepository) SearchByName(ctx context.Context, query string, limit int, offset int) ([]models.Artist, int64, error) {
 	var artists []models.Artist
-	err := r.DB.
-		Where("artist_name ILIKE ?", "%"+query+"%").
+	var total int64
+	db := r.DB.WithContext(ctx).Model(&models.Artist{})
+
+	terms := newSearchTerms(&query, r.fuzzy, r.hits)
+	if !terms.empty() {
+		db = terms.where(db, "artists", "artists.search_vector", "artists.artist_name")
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if !terms.empty() {
+		db = db.Order("relevance DESC")
+	}
+	db = db.
+		Order("verified DESC").
+		Order("monthly_listeners DESC").
 		Limit(limit).
-		Offset(offset).
-		Find(&artists).
-		Error
-	return artists, err
+		Offset(offset)
+
+	if !terms.empty() {
+		rank, args := terms.rank("artists.search_vector", "artists.artist_name")
+		rank = "(" + rank + ") * CASE WHEN artists.verified THEN ? ELSE 1 END"
+		args = append(args, verifiedBoost)
+		artists, err := findRanked(db, r.DB.WithContext(ctx), "artists", rank, args,
+			func(artist *models.Artist) (uuid.UUID, *float64) { return artist.ID, &artist.Relevance })
+		return artists, total, err
+	}
+
+	err := db.Find(&artists).Error
+	return artists, total, err
+}
+
+func (r *ArtistRepository) SetVerified(ctx context.Context, id uuid.UUID, verified bool) error {
+	// Goes through the artist model so the change history records who granted or revoked it
+	result := r.DB.WithContext(ctx).
+		Model(&models.Artist{BaseModel: models.BaseModel{ID: id}}).
+		Update("verified", verified)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
 }
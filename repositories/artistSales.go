@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"crawl/models"
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// artistSales are the artist's completed song and album purchases and tips
+// made from @from up to @until, one row each
+const artistSales = `
+	SELECT song_purchases.created_at AS sold_at, song_purchases.currency, 1 AS purchases, 0 AS tips, song_purchases.purchase_price AS amount
+	FROM song_purchases
+	JOIN songs ON songs.id = song_purchases.song_id
+	WHERE songs.artist_id = @artist AND song_purchases.payment_status = @completed AND song_purchases.deleted_at IS NULL
+		AND song_purchases.created_at >= @from AND song_purchases.created_at < @until
+	UNION ALL
+	SELECT album_purchases.created_at, album_purchases.currency, 1, 0, album_purchases.purchase_price
+	FROM album_purchases
+	JOIN albums ON albums.id = album_purchases.album_id
+	WHERE albums.artist_id = @artist AND album_purchases.payment_status = @completed AND album_purchases.deleted_at IS NULL
+		AND album_purchases.created_at >= @from AND album_purchases.created_at < @until
+	UNION ALL
+	SELECT artist_tips.created_at, artist_tips.currency, 0, 1, artist_tips.amount
+	FROM artist_tips
+	WHERE artist_tips.artist_id = @artist AND artist_tips.payment_status = @completed AND artist_tips.deleted_at IS NULL
+		AND artist_tips.created_at >= @from AND artist_tips.created_at < @until`
+
+type ArtistSalesRepository struct {
+	DB *gorm.DB
+}
+
+func NewArtistSalesRepository(db *gorm.DB) IArtistSalesRepository {
+	return &ArtistSalesRepository{DB: db}
+}
+
+func salesArgs(artistID uuid.UUID, from, until time.Time, extra ...interface{}) []interface{} {
+	return append([]interface{}{
+		sql.Named("artist", artistID),
+		sql.Named("from", from),
+		sql.Named("until", until),
+		sql.Named("completed", "completed"),
+	}, extra...)
+}
+
+// GetSalesSeries sums the artist's sales per currency in each unit ('day',
+// 'week' or 'month') from from up to until, by UTC day. Periods without sales
+// are left out.
+func (r *ArtistSalesRepository) GetSalesSeries(artistID uuid.UUID, from, until time.Time, unit string) ([]models.PeriodSales, error) {
+	series := []models.PeriodSales{}
+	err := r.DB.Raw(`SELECT `+periodOf("timezone('UTC', sales.sold_at)")+` AS period, sales.currency,
+			SUM(sales.purchases) AS purchases, SUM(sales.tips) AS tips, SUM(sales.amount) AS revenue
+		FROM (`+artistSales+`) sales
+		GROUP BY 1, 2
+		ORDER BY 1, 2`,
+		salesArgs(artistID, from, until, sql.Named("unit", unit))...).
+		Scan(&series).
+		Error
+	return series, err
+}
+
+// GetSongSales sums the completed purchases of each of the artist's songs per
+// currency from from up to until. Album purchases aren't credited to songs.
+func (r *ArtistSalesRepository) GetSongSales(artistID uuid.UUID, from, until time.Time) ([]models.SongSales, error) {
+	sales := []models.SongSales{}
+	err := r.DB.Model(&models.SongPurchase{}).
+		Select(`songs.id AS song_id, songs.title, song_purchases.currency,
+			COUNT(*) AS purchases, SUM(song_purchases.purchase_price) AS revenue`).
+		Joins("JOIN songs ON songs.id = song_purchases.song_id").
+		Where("songs.artist_id = ? AND song_purchases.payment_status = ?", artistID, "completed").
+		Where("song_purchases.created_at >= ? AND song_purchases.created_at < ?", from, until).
+		Group("songs.id, songs.title, song_purchases.currency").
+		Scan(&sales).
+		Error
+	return sales, err
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// dryRunDB opens a database that builds statements without running them and
+// returns it with the SQL of every query made through it. Reading rows isn't
+// supported in a dry run, so those queries fail with
+// gorm.ErrDryRunModeUnsupported once their SQL is captured.
+func dryRunDB(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	var statements []string
+	capture := func(db *gorm.DB) {
+		statements = append(statements, db.Dialector.Explain(db.Statement.SQL.String(), db.Statement.Vars...))
+		// A dry run keeps the SQL it built, which the next query on the same
+		// statement would reuse; a real run resets it
+		db.Statement.SQL.Reset()
+		db.Statement.Vars = nil
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture", capture); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := db.Callback().Row().After("gorm:row").Register("test:capture", capture); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	return db, &statements
+}
+
+func TestArtistSearchByNameBoostsVerified(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		indexed bool
+		// want are fragments the ranking query must contain
+		want []string
+	}{
+		{
+			name:  "text match",
+			query: "burna",
+			want: []string{
+				"(ts_rank(artists.search_vector, to_tsquery('simple', search_unaccent('burna:*')))",
+				") * CASE WHEN artists.verified THEN 1.5 ELSE 1 END AS relevance",
+				"ORDER BY relevance DESC,verified DESC,monthly_listeners DESC",
+			},
+		},
+		{
+			name:    "index hits",
+			indexed: true,
+			want: []string{
+				"(1.0 / index_hits.position) * CASE WHEN artists.verified THEN 1.5 ELSE 1 END AS relevance",
+				"ORDER BY relevance DESC,verified DESC,monthly_listeners DESC",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := dryRunDB(t)
+			repo := NewArtistRepository(db, DefaultFuzzySettings)
+			if tt.indexed {
+				repo = repo.(*ArtistRepository).WithIndexHits([]uuid.UUID{uuid.New()})
+			}
+			if _, _, err := repo.SearchByName(context.Background(), tt.query, 10, 0); err != nil && !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+				t.Fatalf("SearchByName: %v", err)
+			}
+
+			var ranking string
+			for _, statement := range *statements {
+				if strings.Contains(statement, "AS relevance") {
+					ranking = statement
+				}
+			}
+			if ranking == "" {
+				t.Fatalf("no ranking query among %q", *statements)
+			}
+			for _, fragment := range tt.want {
+				if !strings.Contains(ranking, fragment) {
+					t.Errorf("ranking query %q doesn't contain %q", ranking, fragment)
+				}
+			}
+		})
+	}
+}
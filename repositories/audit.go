@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"crawl/models"
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"reflect"
+)
+
+type actorContextKey struct{}
+
+const auditActionKey = "audit:action"
+
+// auditedTables maps the tables whose changes are versioned to their entity type
+var auditedTables = map[string]string{
+	"songs":     models.EntityTypeSong,
+	"albums":    models.EntityTypeAlbum,
+	"artists":   models.EntityTypeArtist,
+	"playlists": models.EntityTypePlaylist,
+}
+
+// auditedModels builds an empty model for each audited entity type
+var auditedModels = map[string]func() interface{}{
+	models.EntityTypeSong:     func() interface{} { return &models.Song{} },
+	models.EntityTypeAlbum:    func() interface{} { return &models.Album{} },
+	models.EntityTypeArtist:   func() interface{} { return &models.Artist{} },
+	models.EntityTypePlaylist: func() interface{} { return &models.Playlist{} },
+}
+
+// auditedColumns are the editable catalog fields of each entity type, the only
+// ones versioned and rolled back. Counters, balances, payout accounts,
+// verification and moderation flags change through their own flows, and a
+// rollback must never rewrite them. deleted_at is kept so deletes are
+// versioned and can be rolled back.
+var auditedColumns = map[string]map[string]bool{
+	models.EntityTypeSong: {
+		"title": true, "artist_id": true, "album_id": true, "duration": true, "price": true,
+		"audio_url": true, "preview_url": true, "release_date": true, "cover_image_url": true,
+		"genre_id": true, "deleted_at": true,
+	},
+	models.EntityTypeAlbum: {
+		"title": true, "artist_id": true, "description": true, "price": true, "cover_image_url": true,
+		"release_date": true, "genre_id": true, "deleted_at": true,
+	},
+	models.EntityTypeArtist: {
+		"artist_name": true, "deleted_at": true,
+	},
+	models.EntityTypePlaylist: {
+		"title": true, "description": true, "cover_image_url": true, "is_public": true, "deleted_at": true,
+	},
+}
+
+// ContextWithActor tags ctx with the user performing a change so the audit trail can attribute it
+func ContextWithActor(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, userID)
+}
+
+// ActorFromContext returns the user attached by ContextWithActor, if any
+func ActorFromContext(ctx context.Context) *uuid.UUID {
+	if ctx == nil {
+		return nil
+	}
+	if userID, ok := ctx.Value(actorContextKey{}).(uuid.UUID); ok && userID != uuid.Nil {
+		return &userID
+	}
+	return nil
+}
+
+// RegisterAuditCallbacks hooks the change history into every create, update and delete
+// issued through db against an audited table
+func RegisterAuditCallbacks(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:create").Register("audit:create", auditCallback("create")); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("audit:update", auditCallback("update")); err != nil {
+		return err
+	}
+	return db.Callback().Delete().After("gorm:delete").Register("audit:delete", auditCallback("delete"))
+}
+
+func auditCallback(action string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.Statement.Schema == nil || db.RowsAffected == 0 {
+			return
+		}
+
+		entityType, ok := auditedTables[db.Statement.Schema.Table]
+		if !ok {
+			return
+		}
+
+		recordedAction := action
+		if override, ok := db.Get(auditActionKey); ok {
+			recordedAction = override.(string)
+		}
+
+		// Bulk statements without a primary key on the model (e.g. play count
+		// increments) are not versioned
+		for _, id := range StatementIDs(db) {
+			if err := recordVersion(db, entityType, recordedAction, id); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	}
+}
+
+// StatementIDs lists the primary keys of the records a create, update or delete
+// statement was issued for; it's empty for bulk statements on a bare model
+func StatementIDs(db *gorm.DB) []uuid.UUID {
+	pk := db.Statement.Schema.PrioritizedPrimaryField
+	if pk == nil {
+		return nil
+	}
+
+	var ids []uuid.UUID
+	collect := func(rv reflect.Value) {
+		value, zero := pk.ValueOf(db.Statement.Context, reflect.Indirect(rv))
+		if zero {
+			return
+		}
+		if id, ok := value.(uuid.UUID); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	switch rv := db.Statement.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			collect(rv.Index(i))
+		}
+	case reflect.Struct:
+		collect(rv)
+	}
+	return ids
+}
+
+func recordVersion(db *gorm.DB, entityType string, action string, id uuid.UUID) error {
+	tx := db.Session(&gorm.Session{NewDB: true})
+
+	// Reload the stored row so the snapshot reflects database defaults and partial updates
+	record := auditedModels[entityType]()
+	if err := tx.Unscoped().First(record, "id = ?", id).Error; err != nil {
+		return err
+	}
+
+	snapshot, err := takeSnapshot(db.Statement.Context, db.Statement.Schema, entityType, record)
+	if err != nil {
+		return err
+	}
+
+	var previous models.EntityVersion
+	err = tx.
+		Where("entity_type = ? AND entity_id = ?", entityType, id).
+		Order("version DESC").
+		Limit(1).
+		Find(&previous).
+		Error
+	if err != nil {
+		return err
+	}
+
+	changes := diffSnapshots(previous.Snapshot, snapshot)
+	if previous.Version > 0 && len(changes) == 0 {
+		return nil
+	}
+
+	return tx.Create(&models.EntityVersion{
+		EntityType: entityType,
+		EntityID:   id,
+		Version:    previous.Version + 1,
+		Action:     action,
+		ActorID:    ActorFromContext(db.Statement.Context),
+		Changes:    changes,
+		Snapshot:   snapshot,
+	}).Error
+}
+
+// takeSnapshot captures the audited columns of record
+func takeSnapshot(ctx context.Context, sch *schema.Schema, entityType string, record interface{}) (models.Snapshot, error) {
+	rv := reflect.Indirect(reflect.ValueOf(record))
+	snapshot := models.Snapshot{}
+	for _, field := range sch.Fields {
+		if field.DBName == "" || !auditedColumns[entityType][field.DBName] {
+			continue
+		}
+		value, _ := field.ValueOf(ctx, rv)
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		snapshot[field.DBName] = raw
+	}
+	return snapshot, nil
+}
+
+func diffSnapshots(previous, current models.Snapshot) models.FieldChanges {
+	changes := models.FieldChanges{}
+	for column, value := range current {
+		if old, ok := previous[column]; !ok || !bytes.Equal(old, value) {
+			changes[column] = models.FieldChange{Old: previous[column], New: value}
+		}
+	}
+	return changes
+}
@@ -1,10 +1,13 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 	"reflect"
+	"strings"
 )
 
 var (
@@ -12,6 +15,18 @@ var (
 	ErrEditConflict   = errors.New("edit conflict")
 )
 
+// IsInvalidData reports whether err is the database refusing the values
+// written, such as a string too long for its column or a reference to a
+// missing row, rather than failing to run the statement at all
+func IsInvalidData(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	// Class 22 is data exceptions, class 23 integrity constraint violations
+	return strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")
+}
+
 type BaseRepository[T any] struct {
 	DB *gorm.DB
 }
@@ -99,7 +114,17 @@ func getID[T any](model *T) uuid.UUID {
 }
 
 func (r *BaseRepository[T]) Delete(id uuid.UUID) error {
-	result := r.DB.Delete(new(T), id)
+	// Load the record first so delete callbacks see its primary key
+	var model T
+	err := r.DB.First(&model, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrRecordNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	result := r.DB.Delete(&model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -109,6 +134,12 @@ func (r *BaseRepository[T]) Delete(id uuid.UUID) error {
 	return nil
 }
 
+// WithContext returns a copy of the repository whose queries carry ctx,
+// which lets callbacks read request-scoped values such as the acting user
+func (r *BaseRepository[T]) WithContext(ctx context.Context) IBaseRepository[T] {
+	return &BaseRepository[T]{DB: r.DB.WithContext(ctx)}
+}
+
 func (r *BaseRepository[T]) Exists(id uuid.UUID) (bool, error) {
 	var count int64
 	err := r.DB.Model(new(T)).Where("id = ?", id).Count(&count).Error
@@ -0,0 +1,213 @@
+package repositories
+
+import (
+	"crawl/models"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// chartStatements publish every weekly chart for the week from @week up to
+// @next from the daily rollups. Songs are ranked by streams, then listeners, in
+// the whole world, in each country and in each genre, counting a song in its
+// genre's parent genres too. Previous positions, peaks and weeks on chart come
+// from the charts of earlier weeks.
+var chartStatements = []string{
+	`DELETE FROM chart_entries WHERE week = CAST(@week AS date)`,
+	`WITH RECURSIVE song_genres AS (
+		SELECT id AS song_id, genre_id FROM songs
+		WHERE genre_id IS NOT NULL
+			AND id IN (SELECT song_id FROM song_daily_stats WHERE day >= CAST(@week AS date) AND day < CAST(@next AS date))
+		UNION
+		SELECT song_genres.song_id, genres.parent_id FROM song_genres
+		JOIN genres ON genres.id = song_genres.genre_id
+		WHERE genres.parent_id IS NOT NULL AND genres.deleted_at IS NULL
+	), weekly AS (
+		SELECT CAST(@global AS text) AS kind, '' AS scope, song_id, SUM(streams) AS streams, SUM(listeners) AS listeners
+		FROM song_daily_stats
+		WHERE day >= CAST(@week AS date) AND day < CAST(@next AS date)
+		GROUP BY song_id
+		UNION ALL
+		SELECT CAST(@country AS text), country_code, song_id, SUM(streams), SUM(listeners)
+		FROM country_daily_stats
+		WHERE day >= CAST(@week AS date) AND day < CAST(@next AS date) AND country_code <> ''
+		GROUP BY country_code, song_id
+		UNION ALL
+		SELECT CAST(@genre AS text), CAST(song_genres.genre_id AS text), song_daily_stats.song_id, SUM(streams), SUM(listeners)
+		FROM song_daily_stats
+		JOIN song_genres ON song_genres.song_id = song_daily_stats.song_id
+		WHERE day >= CAST(@week AS date) AND day < CAST(@next AS date)
+		GROUP BY song_genres.genre_id, song_daily_stats.song_id
+	), ranked AS (
+		SELECT weekly.*, ROW_NUMBER() OVER (
+			PARTITION BY kind, scope ORDER BY streams DESC, listeners DESC, song_id
+		) AS position
+		FROM weekly
+		WHERE streams > 0
+			AND song_id IN (SELECT id FROM songs WHERE deleted_at IS NULL AND NOT is_flagged)
+	)
+	INSERT INTO chart_entries (kind, scope, week, position, song_id, streams, previous_position, peak_position, weeks_on_chart)
+	SELECT ranked.kind, ranked.scope, CAST(@week AS date), ranked.position, ranked.song_id, ranked.streams,
+		previous.position,
+		LEAST(ranked.position, COALESCE(history.peak, ranked.position)),
+		history.weeks + 1
+	FROM ranked
+	LEFT JOIN chart_entries previous
+		ON previous.kind = ranked.kind AND previous.scope = ranked.scope
+		AND previous.week = CAST(@previous AS date) AND previous.song_id = ranked.song_id
+	CROSS JOIN LATERAL (
+		SELECT MIN(position) AS peak, COUNT(*) AS weeks FROM chart_entries
+		WHERE chart_entries.kind = ranked.kind AND chart_entries.scope = ranked.scope
+			AND chart_entries.song_id = ranked.song_id AND chart_entries.week < CAST(@week AS date)
+	) history
+	WHERE ranked.position <= @size`,
+}
+
+type ChartRepository struct {
+	DB *gorm.DB
+}
+
+func NewChartRepository(db *gorm.DB) IChartRepository {
+	return &ChartRepository{DB: db}
+}
+
+// PublishWeek rebuilds every chart of the week starting on week, each holding
+// at most size songs, and returns how many entries they hold. Charts of later
+// weeks keep the positions they were published with.
+func (r *ChartRepository) PublishWeek(week time.Time, size int) (int64, error) {
+	start := UTCDay(week)
+	published := models.ChartWeek{Week: start, PublishedAt: time.Now()}
+
+	err := Transaction(r.DB, func(tx *gorm.DB) error {
+		args := []interface{}{
+			sql.Named("week", start.Format("2006-01-02")),
+			sql.Named("next", start.AddDate(0, 0, 7).Format("2006-01-02")),
+			sql.Named("previous", start.AddDate(0, 0, -7).Format("2006-01-02")),
+			sql.Named("global", models.ChartGlobal),
+			sql.Named("country", models.ChartCountry),
+			sql.Named("genre", models.ChartGenre),
+			sql.Named("size", size),
+		}
+		for _, statement := range chartStatements {
+			result := tx.Exec(statement, args...)
+			if result.Error != nil {
+				return result.Error
+			}
+			published.Entries = result.RowsAffected
+		}
+		return tx.Save(&published).Error
+	})
+	return published.Entries, err
+}
+
+// LatestWeek returns the start of the latest week published, or the zero time
+// when none was
+func (r *ChartRepository) LatestWeek() (time.Time, error) {
+	var published struct {
+		Latest *time.Time
+	}
+	err := r.DB.Model(&models.ChartWeek{}).Select("MAX(week) AS latest").Scan(&published).Error
+	if err != nil || published.Latest == nil {
+		return time.Time{}, err
+	}
+	return UTCDay(*published.Latest), nil
+}
+
+// IsPublished reports whether the charts of the week starting on week were published
+func (r *ChartRepository) IsPublished(week time.Time) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.ChartWeek{}).
+		Where("week = CAST(? AS date)", UTCDay(week).Format("2006-01-02")).
+		Count(&count).
+		Error
+	return count > 0, err
+}
+
+// GetEntries returns one chart of the week starting on week, top position
+// first, leaving out the songs that can't be played in country now
+func (r *ChartRepository) GetEntries(kind, scope string, week time.Time, country string) ([]models.ChartEntry, error) {
+	entries := []models.ChartEntry{}
+	db := r.DB.
+		Preload("Song", withCredits).
+		Where("kind = ? AND scope = ? AND week = CAST(? AS date)", kind, scope, UTCDay(week).Format("2006-01-02"))
+	err := withAvailableSong(db, "chart_entries.song_id", country).
+		Order("position").
+		Find(&entries).
+		Error
+	return entries, err
+}
+
+// GetSongEntries returns every chart place the song has had, latest week first
+func (r *ChartRepository) GetSongEntries(songID uuid.UUID) ([]models.ChartEntry, error) {
+	entries := []models.ChartEntry{}
+	err := r.DB.
+		Where("song_id = ?", songID).
+		Order("week DESC, kind, scope").
+		Find(&entries).
+		Error
+	return entries, err
+}
+
+// RefreshTrending replaces the trending songs with the limit songs whose
+// qualified streams since recent most outpace their daily average over the
+// baseline period before it. The score measures how far the last day's streams
+// are above that average in standard deviations, taking streams as a Poisson
+// count, so a big song's usual audience doesn't trend but a small song's sudden
+// one does. Songs fewer than minListeners people streamed are left out.
+func (r *ChartRepository) RefreshTrending(recent, baseline time.Time, minListeners, limit int) (int64, error) {
+	baselineDays := recent.Sub(baseline).Hours() / 24
+	if baselineDays < 1 {
+		return 0, errors.New("trending baseline must span at least a day")
+	}
+
+	var refreshed int64
+	err := Transaction(r.DB, func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM trending_songs").Error; err != nil {
+			return err
+		}
+		result := tx.Exec(`INSERT INTO trending_songs (song_id, score, streams, listeners, baseline, refreshed_at)
+			SELECT song_id, (streams - baseline) / sqrt(baseline + 1), streams, listeners, baseline, CAST(@now AS timestamptz)
+			FROM (
+				SELECT streams.song_id,
+					COUNT(*) FILTER (WHERE streams.created_at >= @recent) AS streams,
+					COUNT(DISTINCT streams.user_id) FILTER (WHERE streams.created_at >= @recent) AS listeners,
+					CAST(COUNT(*) FILTER (WHERE streams.created_at < @recent) AS float8) / CAST(@days AS float8) AS baseline
+				FROM streams
+				JOIN songs ON songs.id = streams.song_id AND songs.deleted_at IS NULL AND NOT songs.is_flagged
+				WHERE streams.created_at >= @baseline AND streams.deleted_at IS NULL AND streams.status = @qualified
+				GROUP BY streams.song_id
+			) counts
+			WHERE listeners >= @min AND streams > baseline
+			ORDER BY 2 DESC
+			LIMIT @limit`,
+			sql.Named("now", time.Now()),
+			sql.Named("recent", recent),
+			sql.Named("baseline", baseline),
+			sql.Named("days", baselineDays),
+			sql.Named("qualified", models.StreamQualified),
+			sql.Named("min", minListeners),
+			sql.Named("limit", limit))
+		refreshed = result.RowsAffected
+		return result.Error
+	})
+	return refreshed, err
+}
+
+// GetTrending returns the trending songs, hottest first, optionally only those
+// in a genre or any of its subgenres
+func (r *ChartRepository) GetTrending(genreID *uuid.UUID, limit int, country string) ([]models.TrendingSong, error) {
+	trending := []models.TrendingSong{}
+	db := r.DB.Preload("Song", withCredits)
+	if genreID != nil {
+		db = db.Where("song_id IN (SELECT id FROM songs WHERE genre_id IN ("+genreSubtreeQuery("id = ?")+"))", *genreID)
+	}
+	err := withAvailableSong(db, "trending_songs.song_id", country).
+		Order("score DESC").
+		Limit(limit).
+		Find(&trending).
+		Error
+	return trending, err
+}
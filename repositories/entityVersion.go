@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"crawl/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"reflect"
+)
+
+type EntityVersionRepository struct {
+	DB *gorm.DB
+}
+
+func NewEntityVersionRepository(db *gorm.DB) IEntityVersionRepository {
+	return &EntityVersionRepository{DB: db}
+}
+
+func (r *EntityVersionRepository) GetHistory(entityType string, entityID uuid.UUID, offset, limit int) ([]models.EntityVersion, error) {
+	var versions []models.EntityVersion
+	db := r.DB.
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Preload("Actor").
+		Order("version DESC")
+
+	if limit > 0 {
+		db = db.Offset(offset).Limit(limit)
+	}
+
+	err := db.Find(&versions).Error
+	return versions, err
+}
+
+func (r *EntityVersionRepository) FindVersion(entityType string, entityID uuid.UUID, version int) (*models.EntityVersion, error) {
+	var entityVersion models.EntityVersion
+	err := r.DB.
+		Where("entity_type = ? AND entity_id = ? AND version = ?", entityType, entityID, version).
+		First(&entityVersion).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &entityVersion, err
+}
+
+func (r *EntityVersionRepository) Restore(ctx context.Context, entityType string, entityID uuid.UUID, snapshot models.Snapshot) error {
+	newModel, ok := auditedModels[entityType]
+	if !ok {
+		return fmt.Errorf("unsupported entity type %q", entityType)
+	}
+
+	record := newModel()
+	stmt := &gorm.Statement{DB: r.DB}
+	if err := stmt.Parse(record); err != nil {
+		return err
+	}
+
+	// Decode each stored column back into its Go type so the driver receives
+	// native values. Snapshots taken before a column stopped being audited may
+	// still hold it, so only the audited columns are restored.
+	values := map[string]interface{}{}
+	for column, raw := range snapshot {
+		if !auditedColumns[entityType][column] {
+			continue
+		}
+		field := stmt.Schema.LookUpField(column)
+		if field == nil || field.DBName == "" {
+			continue
+		}
+		value := reflect.New(field.FieldType)
+		if err := json.Unmarshal(raw, value.Interface()); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", column, err)
+		}
+		values[column] = value.Elem().Interface()
+	}
+
+	if err := stmt.Schema.PrioritizedPrimaryField.Set(ctx, reflect.ValueOf(record).Elem(), entityID); err != nil {
+		return err
+	}
+
+	result := r.DB.WithContext(ctx).
+		Set(auditActionKey, "rollback").
+		Unscoped().
+		Model(record).
+		Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
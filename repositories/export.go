@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"crawl/models"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ExportRepository struct {
+	DB *gorm.DB
+}
+
+func NewExportRepository(db *gorm.DB) IExportRepository {
+	return &ExportRepository{DB: db}
+}
+
+func (r *ExportRepository) CreateJob(job *models.ExportJob) error {
+	return r.DB.Create(job).Error
+}
+
+func (r *ExportRepository) SaveJob(job *models.ExportJob) error {
+	return r.DB.Save(job).Error
+}
+
+func (r *ExportRepository) GetJob(id uuid.UUID) (*models.ExportJob, error) {
+	var job models.ExportJob
+	err := r.DB.First(&job, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &job, err
+}
+
+// ListJobs returns export jobs, latest first
+func (r *ExportRepository) ListJobs(offset, limit int) ([]models.ExportJob, error) {
+	jobs := []models.ExportJob{}
+	err := r.DB.
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&jobs).
+		Error
+	return jobs, err
+}
+
+// ClaimJob marks the oldest pending job running and returns it, or nil when
+// there is none. Running jobs whose heartbeat stopped before stale, as the
+// server running them did, are claimed again. Servers running exports side by
+// side never claim the same job. The job's StartedAt identifies the claim.
+func (r *ExportRepository) ClaimJob(stale time.Time) (*models.ExportJob, error) {
+	var job models.ExportJob
+	now := time.Now()
+	err := r.DB.Raw(`UPDATE export_jobs SET status = @running, started_at = @now, heartbeat_at = @now, updated_at = @now, error = ''
+		WHERE id = (
+			SELECT id FROM export_jobs
+			WHERE deleted_at IS NULL AND (status = @pending
+				OR (status = @running AND COALESCE(heartbeat_at, started_at) < @stale))
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		sql.Named("running", models.ExportRunning),
+		sql.Named("pending", models.ExportPending),
+		sql.Named("now", now),
+		sql.Named("stale", stale)).
+		Scan(&job).
+		Error
+	if err != nil || job.ID == uuid.Nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// RenewJob renews the claim made on the job at claimed, reporting false when
+// the job has since been claimed again or finished
+func (r *ExportRepository) RenewJob(id uuid.UUID, claimed time.Time) (bool, error) {
+	result := r.DB.Model(&models.ExportJob{}).
+		Where("id = ? AND status = ? AND started_at = ?", id, models.ExportRunning, claimed).
+		Update("heartbeat_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// FinishJob saves the outcome of the job claimed at claimed, reporting false
+// and saving nothing when the job has since been claimed again
+func (r *ExportRepository) FinishJob(job *models.ExportJob, claimed time.Time) (bool, error) {
+	result := r.DB.Model(job).
+		Where("status = ? AND started_at = ?", models.ExportRunning, claimed).
+		Select("status", "error", "files", "finished_at", "download_url", "link_expires_at").
+		Updates(job)
+	return result.RowsAffected > 0, result.Error
+}
+
+// eachRow scans the rows of query into a T at a time, without loading them all
+func eachRow[T any](db *gorm.DB, fn func(T) error, query string, args ...interface{}) error {
+	rows, err := db.Raw(query, args...).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row T
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func dayArgs(day time.Time) []interface{} {
+	start := UTCDay(day)
+	return []interface{}{
+		sql.Named("day", start),
+		sql.Named("next", start.AddDate(0, 0, 1)),
+	}
+}
+
+// EachStream calls fn with every stream made on the UTC day, oldest first
+func (r *ExportRepository) EachStream(day time.Time, fn func(models.StreamExportRow) error) error {
+	return eachRow(r.DB, fn, `SELECT streams.id, streams.created_at, streams.user_id, streams.song_id,
+			songs.artist_id, songs.album_id, streams.is_preview, streams.device_type, streams.country_code,
+			streams.region, streams.listened_seconds, streams.position_seconds, streams.tracked,
+			streams.completed, streams.skipped, streams.status, streams.invalid_reason, streams.fraud_score,
+			streams.context_type, streams.context_id
+		FROM streams
+		JOIN songs ON songs.id = streams.song_id
+		WHERE streams.deleted_at IS NULL AND streams.created_at >= @day AND streams.created_at < @next
+		ORDER BY streams.created_at, streams.id`,
+		dayArgs(day)...)
+}
+
+// EachPurchase calls fn with every song and album purchase made on the UTC day, oldest first
+func (r *ExportRepository) EachPurchase(day time.Time, fn func(models.PurchaseExportRow) error) error {
+	return eachRow(r.DB, fn, `SELECT * FROM (
+			SELECT song_purchases.id, song_purchases.created_at, 'song' AS kind, song_purchases.user_id,
+				song_purchases.song_id, CAST(NULL AS uuid) AS album_id, songs.artist_id,
+				CAST(song_purchases.purchase_price AS float8) AS price, song_purchases.currency,
+				song_purchases.country_code, song_purchases.payment_status
+			FROM song_purchases
+			JOIN songs ON songs.id = song_purchases.song_id
+			WHERE song_purchases.deleted_at IS NULL
+				AND song_purchases.created_at >= @day AND song_purchases.created_at < @next
+			UNION ALL
+			SELECT album_purchases.id, album_purchases.created_at, 'album', album_purchases.user_id,
+				NULL, album_purchases.album_id, albums.artist_id,
+				CAST(album_purchases.purchase_price AS float8), album_purchases.currency,
+				album_purchases.country_code, album_purchases.payment_status
+			FROM album_purchases
+			JOIN albums ON albums.id = album_purchases.album_id
+			WHERE album_purchases.deleted_at IS NULL
+				AND album_purchases.created_at >= @day AND album_purchases.created_at < @next
+		) purchases
+		ORDER BY created_at, id`,
+		dayArgs(day)...)
+}
+
+// EachTip calls fn with every tip sent on the UTC day, oldest first
+func (r *ExportRepository) EachTip(day time.Time, fn func(models.TipExportRow) error) error {
+	return eachRow(r.DB, fn, `SELECT id, created_at, sender_id, artist_id, amount, currency, payment_status
+		FROM artist_tips
+		WHERE deleted_at IS NULL AND created_at >= @day AND created_at < @next
+		ORDER BY created_at, id`,
+		dayArgs(day)...)
+}
+
+// EachRoyalty calls fn with every royalty of the month the UTC day falls in
+func (r *ExportRepository) EachRoyalty(day time.Time, fn func(models.RoyaltyExportRow) error) error {
+	day = UTCDay(day)
+	return eachRow(r.DB, fn, `SELECT id, make_date(year, month, 1) AS month, artist_id, amount, currency,
+			paid_status AS paid, created_at
+		FROM monthly_royalties
+		WHERE deleted_at IS NULL AND year = @year AND month = @month
+		ORDER BY artist_id`,
+		sql.Named("year", day.Year()), sql.Named("month", int(day.Month())))
+}
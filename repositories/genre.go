@@ -1,12 +1,18 @@
 package repositories
 
 import (
+	"context"
 	"crawl/models"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
-	"strings"
+	"time"
 )
 
+// Genre popularity counts the streams of its songs over this window
+const genrePopularityWindow = 30 * 24 * time.Hour
+
 type GenreRepository struct {
 	BaseRepository[models.Genre]
 }
@@ -17,41 +23,109 @@ func NewGenreRepository(db *gorm.DB) IGenreRepository {
 	}
 }
 
+// genreSubtreeQuery selects the IDs of the genres matching cond together with
+// all of their descendants. UNION rather than UNION ALL stops on cycles.
+func genreSubtreeQuery(cond string) string {
+	return `WITH RECURSIVE genre_tree AS (
+		SELECT id FROM genres WHERE deleted_at IS NULL AND ` + cond + `
+		UNION
+		SELECT g.id FROM genres g JOIN genre_tree t ON g.parent_id = t.id WHERE g.deleted_at IS NULL
+	) SELECT id FROM genre_tree`
+}
+
+func (r *GenreRepository) withPopularity(db *gorm.DB) *gorm.DB {
+	since := time.Now().Add(-genrePopularityWindow)
+	return db.
+		Select("genres.*, COUNT(streams.id) AS popularity").
+		Joins("LEFT JOIN songs ON songs.genre_id = genres.id AND songs.deleted_at IS NULL").
+		Joins("LEFT JOIN streams ON streams.song_id = songs.id AND streams.status = ? AND streams.deleted_at IS NULL AND streams.created_at >= ?",
+			models.StreamQualified, since).
+		Group("genres.id")
+}
+
 func (r *GenreRepository) GetPopular(limit int) ([]models.Genre, error) {
 	var genres []models.Genre
-	err := r.DB.
-		Model(&models.Genre{}).
-		Select("genres.*, COUNT(songs.id) as song_count").
-		Joins("LEFT JOIN songs ON songs.genre_id = genres.id").
-		Group("genres.id").
-		Order("song_count DESC").
+	err := r.withPopularity(r.DB.Model(&models.Genre{})).
+		Order("popularity DESC").
 		Limit(limit).
 		Find(&genres).Error
 	return genres, err
 }
 
-func (r *GenreRepository) SearchGenres(query *string, sort *string) ([]models.Genre, error) {
+func (r *GenreRepository) FindByName(name string) (*models.Genre, error) {
+	var genre models.Genre
+	err := r.DB.Where("LOWER(name) = LOWER(?)", name).First(&genre).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &genre, err
+}
+
+func (r *GenreRepository) GetChildren(parentID uuid.UUID) ([]models.Genre, error) {
+	var genres []models.Genre
+	err := r.DB.
+		Where("parent_id = ?", parentID).
+		Order("name ASC").
+		Find(&genres).
+		Error
+	return genres, err
+}
+
+func (r *GenreRepository) IsInSubtree(rootID, genreID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.Raw(
+		"SELECT COUNT(*) FROM ("+genreSubtreeQuery("id = ?")+") AS subtree WHERE id = ?",
+		rootID, genreID,
+	).Scan(&count).Error
+	return count > 0, err
+}
+
+func (r *GenreRepository) SetParent(genreID uuid.UUID, parentID *uuid.UUID) error {
+	return r.DB.Model(&models.Genre{}).
+		Where("id = ?", genreID).
+		Update("parent_id", parentID).
+		Error
+}
+
+func (r *GenreRepository) ReparentChildren(parentID uuid.UUID, newParentID *uuid.UUID) error {
+	return r.DB.Model(&models.Genre{}).
+		Where("parent_id = ?", parentID).
+		Update("parent_id", newParentID).
+		Error
+}
+
+func (r *GenreRepository) SearchGenres(ctx context.Context, query *string, sort *string) ([]models.Genre, error) {
 	var genres []models.Genre
 
 	// Base query
-	q := r.DB.Model(&models.Genre{})
+	q := r.DB.WithContext(ctx).Model(&models.Genre{})
 
 	// Apply search query if provided
-	if query != nil && *query != "" {
-		searchTerm := fmt.Sprintf("%%%s%%", strings.ToLower(*query))
-		q = q.Where("LOWER(name) LIKE ?", searchTerm)
+	var tsQuery string
+	if query != nil {
+		tsQuery = prefixTSQuery(*query)
+	}
+	if tsQuery != "" {
+		q = q.Where("genres.search_vector @@ "+textQuery, tsQuery)
 	}
 
-	// Apply sorting if provided
-	if sort != nil {
+	// Apply sorting, by relevance when searching without an explicit order
+	if (sort == nil || *sort == "relevance") && tsQuery != "" {
+		q = q.Order("relevance DESC").Order("genres.name ASC")
+		genres, err := findRanked(q, r.DB.WithContext(ctx), "genres", "ts_rank(genres.search_vector, "+textQuery+")", []interface{}{tsQuery},
+			func(genre *models.Genre) (uuid.UUID, *float64) { return genre.ID, &genre.Relevance })
+		if err != nil {
+			return nil, fmt.Errorf("failed to search genres: %w", err)
+		}
+		return genres, nil
+	} else if sort != nil {
 		switch *sort {
 		case "name":
-			q = q.Order("name ASC")
+			q = q.Order("genres.name ASC")
 		case "popularity":
-			// Assuming you have a popularity field or need to join with related data
-			q = q.Order("popularity DESC")
+			q = r.withPopularity(q).Order("popularity DESC").Order("genres.name ASC")
 		default:
-			q = q.Order("name ASC") // Default sort
+			q = q.Order("genres.name ASC") // Default sort
 		}
 	}
 
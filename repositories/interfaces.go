@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"crawl/models"
 	"github.com/google/uuid"
 	"time"
@@ -14,6 +15,7 @@ type IBaseRepository[T any] interface {
 	Update(model *T) (*T, error)
 	Delete(id uuid.UUID) error
 	Exists(id uuid.UUID) (bool, error)
+	WithContext(ctx context.Context) IBaseRepository[T]
 }
 
 // IUserRepository User operations
@@ -32,24 +34,47 @@ type IArtistRepository interface {
 	GetWithSongs(id uuid.UUID) (*models.Artist, error)
 	GetWithAlbums(id uuid.UUID) (*models.Artist, error)
 	GetWithUserId(userID uuid.UUID) (*models.Artist, error)
-	SearchByName(query string, limit int, offset int) ([]models.Artist, error)
+	SearchByName(ctx context.Context, query string, limit int, offset int) ([]models.Artist, int64, error)
+	WithIndexHits(ids []uuid.UUID) IArtistRepository
+	SetVerified(ctx context.Context, id uuid.UUID, verified bool) error
+}
+
+// IVerificationRepository Artist verification requests
+type IVerificationRepository interface {
+	IBaseRepository[models.VerificationRequest]
+	GetWithDetails(id uuid.UUID) (*models.VerificationRequest, error)
+	GetByArtist(artistID uuid.UUID) ([]models.VerificationRequest, error)
+	GetLatestByStatus(artistID uuid.UUID, statuses ...string) (*models.VerificationRequest, error)
+	GetQueue(statuses []string, offset, limit int) ([]models.VerificationRequest, error)
+	UpdateStatus(id uuid.UUID, status string, reviewerID *uuid.UUID, notes string) error
+	AddEvidence(evidence []models.VerificationEvidence) error
+	GetEvidence(requestID, evidenceID uuid.UUID) (*models.VerificationEvidence, error)
+	AddEvent(event *models.VerificationEvent) error
 }
 
 // ISongRepository song operations
 type ISongRepository interface {
 	IBaseRepository[models.Song]
 	GetWithArtist(id uuid.UUID) (*models.Song, error)
+	GetCreditedTo(artistID uuid.UUID, roles []string, offset, limit int) ([]models.Song, error)
 	GetByArtist(artistID uuid.UUID) ([]models.Song, error)
-	GetTrending(limit int, since time.Time) ([]models.Song, error)
 	AddPlayCount(id uuid.UUID, count int) error
-	Search(query, artist, genre *string, sort, order *string, offset, limit int) ([]models.Song, error)
+	GetFiltered(genreID, artistID, albumID *uuid.UUID, tags []string, offset, limit int) ([]models.Song, error)
+	Search(ctx context.Context, query, artist, genre *string, sort, order *string, offset, limit int) ([]models.Song, int64, error)
+	SearchFacets(ctx context.Context, query *string) (*models.SearchFacets, error)
+	WithIndexHits(ids []uuid.UUID) ISongRepository
+	AvailableIn(country string) ISongRepository
 }
 
 type IAlbumRepository interface {
 	IBaseRepository[models.Album]
 	GetWithSongs(id uuid.UUID) (*models.Album, error)
 	GetByArtist(artistID uuid.UUID) ([]models.Album, error)
-	SearchAlbums(query *string, artist *string, genre *string, sort *string, page *int, limit *int) ([]models.Album, error)
+	GetFiltered(artistID, genreID *uuid.UUID, tags []string, offset, limit int) ([]models.Album, error)
+	SearchAlbums(ctx context.Context, query *string, artist *string, genre *string, sort *string, page *int, limit *int) ([]models.Album, int64, error)
+	SearchFacets(ctx context.Context, query *string) (*models.SearchFacets, error)
+	WithIndexHits(ids []uuid.UUID) IAlbumRepository
+	AvailableIn(country string) IAlbumRepository
 }
 
 type IPlaylistRepository interface {
@@ -59,6 +84,7 @@ type IPlaylistRepository interface {
 	AddSongToPlaylist(playlistID, songID uuid.UUID) error
 	GetUserPublicPlaylists(userID uuid.UUID) ([]models.Playlist, error)
 	SearchPlaylists(
+		ctx context.Context,
 		query *string,
 		owner *string,
 		isPublic *bool,
@@ -66,12 +92,122 @@ type IPlaylistRepository interface {
 		page int,
 		limit int,
 	) ([]models.Playlist, int64, error)
+	WithIndexHits(ids []uuid.UUID) IPlaylistRepository
 }
 type IStreamRepository interface {
 	IBaseRepository[models.Stream]
+	CreateBatch(streams []models.Stream) error
 	GetStreamCount(songID uuid.UUID, since time.Time) (int64, error)
-	GetArtistStreams(artistID uuid.UUID, start, end time.Time) ([]models.Stream, error)
+	GetArtistDailyStreams(artistID uuid.UUID, start, end time.Time) ([]models.DailyStreamCount, error)
 	GetStreamBySong(songID uuid.UUID) (*models.Stream, error)
+	GetArtistStreamStats(artistID uuid.UUID, from, until time.Time) (streams int64, listeners int64, err error)
+	GetArtistStreamSeries(artistID uuid.UUID, from, until time.Time, unit string) ([]models.PeriodStreams, error)
+	GetArtistStreamShares(artistID uuid.UUID, from, until time.Time, by string) ([]models.StreamShare, error)
+	GetArtistSongStreams(artistID uuid.UUID, from, until time.Time) ([]models.SongStreamCount, error)
+	GetArtistListenStats(artistID uuid.UUID, from, until time.Time) ([]models.SongListenStats, error)
+	ListenerSongPlays(userIDs, songIDs []uuid.UUID, since time.Time) ([]models.ListenerSongPlays, error)
+	CountPlaysBy(column string, values []string, since time.Time) (map[string]int64, error)
+	LastPlays(userIDs []uuid.UUID, since time.Time) (map[uuid.UUID]time.Time, error)
+	InactiveListeners(userIDs []uuid.UUID, since time.Time) ([]uuid.UUID, error)
+	HistoryPausedListeners(userIDs []uuid.UUID) ([]uuid.UUID, error)
+	GetSuspicious(offset, limit int) ([]models.Stream, error)
+	Review(id uuid.UUID, status string, reviewerID uuid.UUID) (bool, error)
+}
+
+// IStreamRollupRepository Stream Rollup
+type IStreamRollupRepository interface {
+	RollupDay(day time.Time) (int64, error)
+	RolledUpThrough() (time.Time, error)
+	MissingDays(from, until time.Time) ([]time.Time, error)
+}
+
+// IMonthlyListenersRepository Monthly Listeners
+type IMonthlyListenersRepository interface {
+	ComputeDay(day time.Time) (int64, error)
+	LatestDay() (time.Time, error)
+	UpdateArtists(day time.Time) error
+	GetArtistHistory(artistID uuid.UUID, from, until time.Time) ([]models.DailyListenerCount, error)
+}
+
+// IListeningHistoryRepository Listening history
+type IListeningHistoryRepository interface {
+	GetHistory(userID uuid.UUID, offset, limit int) ([]models.Stream, error)
+	HideStream(userID, streamID uuid.UUID) (bool, error)
+	HideAll(userID uuid.UUID, before time.Time) (int64, error)
+	RecentlyPlayed(userID uuid.UUID, since time.Time, limit int, country string) ([]models.RecentPlay, error)
+	SetHistoryPaused(userID uuid.UUID, paused bool) (bool, error)
+}
+
+// IExportRepository Analytics export jobs and the rows they export
+type IExportRepository interface {
+	CreateJob(job *models.ExportJob) error
+	SaveJob(job *models.ExportJob) error
+	GetJob(id uuid.UUID) (*models.ExportJob, error)
+	ListJobs(offset, limit int) ([]models.ExportJob, error)
+	ClaimJob(stale time.Time) (*models.ExportJob, error)
+	RenewJob(id uuid.UUID, claimed time.Time) (bool, error)
+	FinishJob(job *models.ExportJob, claimed time.Time) (bool, error)
+	EachStream(day time.Time, fn func(models.StreamExportRow) error) error
+	EachPurchase(day time.Time, fn func(models.PurchaseExportRow) error) error
+	EachTip(day time.Time, fn func(models.TipExportRow) error) error
+	EachRoyalty(day time.Time, fn func(models.RoyaltyExportRow) error) error
+}
+
+// IPaymentRepository Payments for purchases and tips
+type IPaymentRepository interface {
+	Create(payment *models.Payment) error
+	SetProviderRef(payment *models.Payment) error
+	GetByID(id uuid.UUID) (*models.Payment, error)
+	FindPending(purpose string, targetID uuid.UUID, since time.Time) (*models.Payment, error)
+	FindByProviderRef(provider, ref string) (*models.Payment, error)
+	Settle(payment *models.Payment, status, reason string) (bool, error)
+}
+
+// IWrappedRepository Year-in-review reports
+type IWrappedRepository interface {
+	ListenerIDs(from, until time.Time, after uuid.UUID, limit int) ([]uuid.UUID, error)
+	ListenerTotals(userIDs []uuid.UUID, from, until time.Time) ([]models.WrappedTotals, error)
+	ListenerTopSongs(userIDs []uuid.UUID, from, until time.Time, limit int) ([]models.WrappedSongRow, error)
+	RankFans(from, until time.Time) error
+	ClearFans(year int) error
+	ListenerTopArtists(userIDs []uuid.UUID, year, limit int) ([]models.WrappedArtistRow, error)
+	ListenerTopGenres(userIDs []uuid.UUID, from, until time.Time, limit int) ([]models.WrappedGenreRow, error)
+	ListenerDays(userIDs []uuid.UUID, from, until time.Time) ([]models.WrappedDay, error)
+	ArtistIDs(from, until time.Time, after uuid.UUID, limit int) ([]uuid.UUID, error)
+	ArtistTotals(artistIDs []uuid.UUID, from, until time.Time) ([]models.WrappedTotals, error)
+	ArtistTopSongs(artistIDs []uuid.UUID, from, until time.Time, limit int) ([]models.WrappedSongRow, error)
+	ArtistTopCountries(artistIDs []uuid.UUID, from, until time.Time, limit int) ([]models.WrappedCountryRow, error)
+	SaveReports(reports []models.WrappedReport) error
+	GetReport(kind string, subjectID uuid.UUID, year int) (*models.WrappedReport, error)
+	CompleteYear(year models.WrappedYear) error
+	YearCompleted(year int) (bool, error)
+}
+
+// ITerritoryRepository Territory availability
+type ITerritoryRepository interface {
+	UnavailableSongs(songIDs []uuid.UUID, country string, at time.Time) ([]uuid.UUID, error)
+	UnavailableAlbums(albumIDs []uuid.UUID, country string, at time.Time) ([]uuid.UUID, error)
+	CreateRules(rules []models.TerritoryRule) error
+	GetRules(songID, albumID *uuid.UUID) ([]models.TerritoryRule, error)
+	GetRule(id uuid.UUID) (*models.TerritoryRule, error)
+	DeleteRule(id uuid.UUID) error
+}
+
+// IChartRepository Weekly charts and trending songs
+type IChartRepository interface {
+	PublishWeek(week time.Time, size int) (int64, error)
+	LatestWeek() (time.Time, error)
+	IsPublished(week time.Time) (bool, error)
+	GetEntries(kind, scope string, week time.Time, country string) ([]models.ChartEntry, error)
+	GetSongEntries(songID uuid.UUID) ([]models.ChartEntry, error)
+	RefreshTrending(recent, baseline time.Time, minListeners, limit int) (int64, error)
+	GetTrending(genreID *uuid.UUID, limit int, country string) ([]models.TrendingSong, error)
+}
+
+// IArtistSalesRepository Artist Sales
+type IArtistSalesRepository interface {
+	GetSalesSeries(artistID uuid.UUID, from, until time.Time, unit string) ([]models.PeriodSales, error)
+	GetSongSales(artistID uuid.UUID, from, until time.Time) ([]models.SongSales, error)
 }
 
 type ITipRepository interface {
@@ -80,6 +216,7 @@ type ITipRepository interface {
 	GetUserTips(userID uuid.UUID) ([]models.ArtistTip, error)
 	GetTotalTipsReceived(artistID uuid.UUID) (int64, error)
 	GetTotalTipsSent(userID uuid.UUID) (int64, error)
+	FindPending(senderID, artistID uuid.UUID, amount int, message string, since time.Time) (*models.ArtistTip, error)
 }
 
 type IModerationRepository interface {
@@ -91,7 +228,24 @@ type IModerationRepository interface {
 type IGenreRepository interface {
 	IBaseRepository[models.Genre]
 	GetPopular(limit int) ([]models.Genre, error)
-	SearchGenres(query *string, sort *string) ([]models.Genre, error)
+	FindByName(name string) (*models.Genre, error)
+	GetChildren(parentID uuid.UUID) ([]models.Genre, error)
+	IsInSubtree(rootID, genreID uuid.UUID) (bool, error)
+	SetParent(genreID uuid.UUID, parentID *uuid.UUID) error
+	ReparentChildren(parentID uuid.UUID, newParentID *uuid.UUID) error
+	SearchGenres(ctx context.Context, query *string, sort *string) ([]models.Genre, error)
+}
+
+// ITagRepository Tags and moods
+type ITagRepository interface {
+	IBaseRepository[models.Tag]
+	FindOrCreate(tag *models.Tag) (*models.Tag, error)
+	GetBySlug(kind, slug string) (*models.Tag, error)
+	GetByKind(kind *string) ([]models.Tag, error)
+	GetSongTags(songID uuid.UUID) ([]models.Tag, error)
+	GetAlbumTags(albumID uuid.UUID) ([]models.Tag, error)
+	ReplaceSongTags(songID uuid.UUID, tagIDs []uuid.UUID) error
+	ReplaceAlbumTags(albumID uuid.UUID, tagIDs []uuid.UUID) error
 }
 
 // ISongContributorRepository Song Contributor
@@ -131,7 +285,7 @@ type IPlaylistSongRepository interface {
 	AddSongToPlaylist(playlistID, songID uuid.UUID, position int) error
 	RemoveSongFromPlaylist(playlistID, songID uuid.UUID) error
 	ReorderSongs(playlistID uuid.UUID, songOrder map[uuid.UUID]int) error
-	GetPlaylistSongs(playlistID uuid.UUID) ([]models.Song, error)
+	GetPlaylistSongs(playlistID uuid.UUID, country string) ([]models.Song, error)
 	SongInPlaylistExists(playlistID uuid.UUID, songID uuid.UUID) (bool, error)
 }
 
@@ -141,6 +295,7 @@ type ISongPurchaseRepository interface {
 	FindByUserAndSong(userID, songID uuid.UUID) (*models.SongPurchase, error)
 	GetUserPurchases(userID uuid.UUID) ([]models.SongPurchase, error)
 	GetUserSongPurchases(userID uuid.UUID) ([]models.SongPurchase, error)
+	GetLibrarySongs(userID uuid.UUID, country string) ([]models.Song, error)
 	HasPurchasedSong(userID, songID uuid.UUID) (bool, error)
 }
 
@@ -149,6 +304,7 @@ type IAlbumPurchaseRepository interface {
 	IBaseRepository[models.AlbumPurchase]
 	FindByUserAndAlbum(userID, albumID uuid.UUID) (*models.AlbumPurchase, error)
 	GetUserAlbumPurchases(userID uuid.UUID) ([]models.AlbumPurchase, error)
+	GetLibraryAlbums(userID uuid.UUID, country string) ([]models.Album, error)
 	HasPurchasedAlbum(userID, albumID uuid.UUID) (bool, error)
 }
 
@@ -159,4 +315,46 @@ type IMonthlyRoyaltyRepository interface {
 	MarkAsPaid(artistID uuid.UUID, year int, month int) error
 	GetArtistRoyalties(artistID uuid.UUID) ([]models.MonthlyRoyalty, error)
 	CalculatePendingRoyalties() (float64, error)
+	SumForArtists(artistIDs []uuid.UUID, year, month *int) ([]models.ArtistRoyaltyTotal, error)
+}
+
+// ILabelRepository Record labels, their members and linked artists
+type ILabelRepository interface {
+	IBaseRepository[models.Label]
+	GetWithArtists(id uuid.UUID) (*models.Label, error)
+	GetUserLabels(userID uuid.UUID) ([]models.Label, error)
+	GetMember(labelID, userID uuid.UUID) (*models.LabelMember, error)
+	GetMembers(labelID uuid.UUID) ([]models.LabelMember, error)
+	SaveMember(member *models.LabelMember) error
+	RemoveMember(labelID, userID uuid.UUID) error
+	GetArtistLinks(labelID uuid.UUID) ([]models.LabelArtist, error)
+	GetArtistLink(labelID, artistID uuid.UUID) (*models.LabelArtist, error)
+	GetArtistLabels(artistID uuid.UUID) ([]models.LabelArtist, error)
+	CreateArtistLink(link *models.LabelArtist) error
+	UpdateArtistLink(link *models.LabelArtist) error
+	RemoveArtistLink(labelID, artistID uuid.UUID) error
+	HasArtistPermission(userID, artistID uuid.UUID, permission string) (bool, error)
+	GetPermittedArtistIDs(labelID uuid.UUID, permission string) ([]uuid.UUID, error)
+}
+
+// IEntityVersionRepository Entity Version
+type IEntityVersionRepository interface {
+	GetHistory(entityType string, entityID uuid.UUID, offset, limit int) ([]models.EntityVersion, error)
+	FindVersion(entityType string, entityID uuid.UUID, version int) (*models.EntityVersion, error)
+	Restore(ctx context.Context, entityType string, entityID uuid.UUID, snapshot models.Snapshot) error
+}
+
+type ISearchLogRepository interface {
+	IBaseRepository[models.SearchLog]
+	CreateClick(click *models.SearchClick) error
+	TopQueries(from, to time.Time, limit int) ([]models.SearchQueryStat, error)
+	ZeroResultQueries(from, to time.Time, limit int) ([]models.ZeroResultQuery, error)
+	ClickThrough(from, to time.Time) (*models.SearchClickThrough, error)
+	Trending(recent, baseline time.Time, minSearchers int, limit int) ([]models.TrendingSearch, error)
+}
+
+// ISearchRepository cross-catalog search helpers
+type ISearchRepository interface {
+	ClosestNames(ctx context.Context, query string, limit int) ([]models.SearchSuggestion, error)
+	Suggest(ctx context.Context, prefix string, types []string, perType int, country string) ([]models.SearchSuggestion, error)
 }
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"crawl/models"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Columns backing each permission an artist can grant a label
+var labelPermissionColumns = map[string]string{
+	models.LabelPermissionCatalog:   "can_manage_catalog",
+	models.LabelPermissionAnalytics: "can_view_analytics",
+	models.LabelPermissionRoyalties: "can_view_royalties",
+}
+
+type LabelRepository struct {
+	BaseRepository[models.Label]
+}
+
+func NewLabelRepository(db *gorm.DB) ILabelRepository {
+	return &LabelRepository{
+		BaseRepository: BaseRepository[models.Label]{DB: db},
+	}
+}
+
+func (r *LabelRepository) GetWithArtists(id uuid.UUID) (*models.Label, error) {
+	var label models.Label
+	err := r.DB.
+		Preload("Artists", "status = ?", models.LabelArtistActive).
+		Preload("Artists.Artist").
+		First(&label, id).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &label, err
+}
+
+func (r *LabelRepository) GetUserLabels(userID uuid.UUID) ([]models.Label, error) {
+	var labels []models.Label
+	err := r.DB.
+		Joins("JOIN label_members ON label_members.label_id = labels.id AND label_members.deleted_at IS NULL").
+		Where("label_members.user_id = ?", userID).
+		Order("labels.name ASC").
+		Find(&labels).
+		Error
+	return labels, err
+}
+
+func (r *LabelRepository) GetMember(labelID, userID uuid.UUID) (*models.LabelMember, error) {
+	var member models.LabelMember
+	err := r.DB.
+		Where("label_id = ? AND user_id = ?", labelID, userID).
+		First(&member).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &member, err
+}
+
+func (r *LabelRepository) GetMembers(labelID uuid.UUID) ([]models.LabelMember, error) {
+	var members []models.LabelMember
+	err := r.DB.
+		Preload("User").
+		Where("label_id = ?", labelID).
+		Order("created_at ASC").
+		Find(&members).
+		Error
+	return members, err
+}
+
+func (r *LabelRepository) SaveMember(member *models.LabelMember) error {
+	// Adding an existing member changes their role
+	return r.DB.
+		Where(models.LabelMember{LabelID: member.LabelID, UserID: member.UserID}).
+		Assign(models.LabelMember{Role: member.Role}).
+		FirstOrCreate(member).
+		Error
+}
+
+func (r *LabelRepository) RemoveMember(labelID, userID uuid.UUID) error {
+	result := r.DB.Unscoped().
+		Where("label_id = ? AND user_id = ?", labelID, userID).
+		Delete(&models.LabelMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *LabelRepository) GetArtistLinks(labelID uuid.UUID) ([]models.LabelArtist, error) {
+	var links []models.LabelArtist
+	err := r.DB.
+		Preload("Artist").
+		Where("label_id = ?", labelID).
+		Order("created_at ASC").
+		Find(&links).
+		Error
+	return links, err
+}
+
+func (r *LabelRepository) GetArtistLink(labelID, artistID uuid.UUID) (*models.LabelArtist, error) {
+	var link models.LabelArtist
+	err := r.DB.
+		Where("label_id = ? AND artist_id = ?", labelID, artistID).
+		First(&link).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &link, err
+}
+
+func (r *LabelRepository) GetArtistLabels(artistID uuid.UUID) ([]models.LabelArtist, error) {
+	var links []models.LabelArtist
+	err := r.DB.
+		Preload("Label").
+		Joins("JOIN labels ON labels.id = label_artists.label_id AND labels.deleted_at IS NULL").
+		Where("label_artists.artist_id = ?", artistID).
+		Order("label_artists.created_at ASC").
+		Find(&links).
+		Error
+	return links, err
+}
+
+func (r *LabelRepository) CreateArtistLink(link *models.LabelArtist) error {
+	return r.DB.Create(link).Error
+}
+
+func (r *LabelRepository) UpdateArtistLink(link *models.LabelArtist) error {
+	// Explicit map so revoked permissions (false) are written too
+	return r.DB.Model(&models.LabelArtist{}).
+		Where("id = ?", link.ID).
+		Updates(map[string]interface{}{
+			"status":             link.Status,
+			"can_manage_catalog": link.CanManageCatalog,
+			"can_view_analytics": link.CanViewAnalytics,
+			"can_view_royalties": link.CanViewRoyalties,
+		}).
+		Error
+}
+
+func (r *LabelRepository) RemoveArtistLink(labelID, artistID uuid.UUID) error {
+	result := r.DB.Unscoped().
+		Where("label_id = ? AND artist_id = ?", labelID, artistID).
+		Delete(&models.LabelArtist{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *LabelRepository) HasArtistPermission(userID, artistID uuid.UUID, permission string) (bool, error) {
+	column, ok := labelPermissionColumns[permission]
+	if !ok {
+		return false, fmt.Errorf("unknown label permission %q", permission)
+	}
+
+	var count int64
+	err := r.DB.Model(&models.LabelArtist{}).
+		Joins("JOIN labels ON labels.id = label_artists.label_id AND labels.deleted_at IS NULL").
+		Joins("JOIN label_members ON label_members.label_id = label_artists.label_id AND label_members.deleted_at IS NULL").
+		Where("label_artists.artist_id = ? AND label_members.user_id = ?", artistID, userID).
+		Where("label_artists.status = ? AND label_artists."+column+" = ?", models.LabelArtistActive, true).
+		Count(&count).
+		Error
+	return count > 0, err
+}
+
+func (r *LabelRepository) GetPermittedArtistIDs(labelID uuid.UUID, permission string) ([]uuid.UUID, error) {
+	column, ok := labelPermissionColumns[permission]
+	if !ok {
+		return nil, fmt.Errorf("unknown label permission %q", permission)
+	}
+
+	var ids []uuid.UUID
+	err := r.DB.Model(&models.LabelArtist{}).
+		Where("label_id = ? AND status = ? AND "+column+" = ?", labelID, models.LabelArtistActive, true).
+		Pluck("artist_id", &ids).
+		Error
+	return ids, err
+}
@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"crawl/models"
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ListeningHistory scopes streams to the plays that belong in a listener's
+// history: full plays they haven't hidden. Anything personalized from what a
+// listener played must read streams through it, so deleted items and plays
+// made while history was paused are left out.
+func ListeningHistory(userID uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("streams.user_id = ? AND NOT streams.is_preview AND NOT streams.hidden_from_history", userID)
+	}
+}
+
+type ListeningHistoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewListeningHistoryRepository(db *gorm.DB) IListeningHistoryRepository {
+	return &ListeningHistoryRepository{DB: db}
+}
+
+// GetHistory lists the listener's plays, latest first
+func (r *ListeningHistoryRepository) GetHistory(userID uuid.UUID, offset, limit int) ([]models.Stream, error) {
+	streams := []models.Stream{}
+	err := r.DB.
+		Scopes(ListeningHistory(userID)).
+		Preload("Song", withCredits).
+		Order("streams.created_at DESC, streams.id").
+		Offset(offset).
+		Limit(limit).
+		Find(&streams).
+		Error
+	return streams, err
+}
+
+// HideStream removes one play from the listener's history and reports whether
+// it was there
+func (r *ListeningHistoryRepository) HideStream(userID, streamID uuid.UUID) (bool, error) {
+	result := r.DB.Model(&models.Stream{}).
+		Scopes(ListeningHistory(userID)).
+		Where("streams.id = ?", streamID).
+		Update("hidden_from_history", true)
+	return result.RowsAffected > 0, result.Error
+}
+
+// HideAll removes every play before before from the listener's history and
+// returns how many it removed
+func (r *ListeningHistoryRepository) HideAll(userID uuid.UUID, before time.Time) (int64, error) {
+	result := r.DB.Model(&models.Stream{}).
+		Scopes(ListeningHistory(userID)).
+		Where("streams.created_at < ?", before).
+		Update("hidden_from_history", true)
+	return result.RowsAffected, result.Error
+}
+
+// RecentlyPlayed returns the limit songs, albums and playlists the listener
+// played last since since, each once at the time it was last played. Songs
+// played from an album or playlist are listed under it; the listener's view of
+// playlists is limited to public ones and their own.
+func (r *ListeningHistoryRepository) RecentlyPlayed(userID uuid.UUID, since time.Time, limit int, country string) ([]models.RecentPlay, error) {
+	plays := []models.RecentPlay{}
+	err := r.DB.Raw(`SELECT kind, item_id, MAX(created_at) AS played_at
+		FROM (
+			SELECT CASE WHEN context_type IN (@album, @playlist) THEN context_type ELSE CAST(@song AS text) END AS kind,
+				CASE WHEN context_type IN (@album, @playlist) THEN context_id ELSE song_id END AS item_id,
+				created_at
+			FROM streams
+			WHERE user_id = @user AND NOT is_preview AND NOT hidden_from_history
+				AND created_at >= @since AND deleted_at IS NULL
+		) played
+		WHERE (kind = @song AND item_id IN (SELECT id FROM songs WHERE deleted_at IS NULL AND `+songAvailable+`))
+			OR (kind = @album AND item_id IN (SELECT id FROM albums WHERE deleted_at IS NULL AND `+albumAvailable+`))
+			OR (kind = @playlist AND item_id IN (
+				SELECT id FROM playlists WHERE deleted_at IS NULL AND (is_public OR user_id = @user)
+			))
+		GROUP BY kind, item_id
+		ORDER BY played_at DESC
+		LIMIT @limit`,
+		territoryArgs(nil, country, time.Now(),
+			sql.Named("user", userID),
+			sql.Named("since", since),
+			sql.Named("song", models.RecentSong),
+			sql.Named("album", models.RecentAlbum),
+			sql.Named("playlist", models.RecentPlaylist),
+			sql.Named("limit", limit))...).
+		Scan(&plays).
+		Error
+	if err != nil || len(plays) == 0 {
+		return plays, err
+	}
+	return plays, r.loadItems(plays)
+}
+
+// loadItems sets the song, album or playlist on each recent play
+func (r *ListeningHistoryRepository) loadItems(plays []models.RecentPlay) error {
+	ids := map[string][]uuid.UUID{}
+	for _, play := range plays {
+		ids[play.Kind] = append(ids[play.Kind], play.ItemID)
+	}
+
+	songs := []models.Song{}
+	albums := []models.Album{}
+	playlists := []models.Playlist{}
+	if len(ids[models.RecentSong]) > 0 {
+		if err := withCredits(r.DB).Find(&songs, ids[models.RecentSong]).Error; err != nil {
+			return err
+		}
+	}
+	if len(ids[models.RecentAlbum]) > 0 {
+		if err := r.DB.Preload("Artist").Find(&albums, ids[models.RecentAlbum]).Error; err != nil {
+			return err
+		}
+	}
+	if len(ids[models.RecentPlaylist]) > 0 {
+		if err := r.DB.Preload("User").Find(&playlists, ids[models.RecentPlaylist]).Error; err != nil {
+			return err
+		}
+	}
+
+	for i := range plays {
+		switch plays[i].Kind {
+		case models.RecentSong:
+			for j := range songs {
+				if songs[j].ID == plays[i].ItemID {
+					plays[i].Song = &songs[j]
+				}
+			}
+		case models.RecentAlbum:
+			for j := range albums {
+				if albums[j].ID == plays[i].ItemID {
+					plays[i].Album = &albums[j]
+				}
+			}
+		case models.RecentPlaylist:
+			for j := range playlists {
+				if playlists[j].ID == plays[i].ItemID {
+					plays[i].Playlist = &playlists[j]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SetHistoryPaused pauses or resumes the user's listening history and reports
+// whether the user exists
+func (r *ListeningHistoryRepository) SetHistoryPaused(userID uuid.UUID, paused bool) (bool, error) {
+	result := r.DB.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("history_paused", paused)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"crawl/models"
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MonthlyListenerDays is how many UTC days, ending on the day counted, monthly listeners span
+const MonthlyListenerDays = 28
+
+// monthlyListenerStatements rebuild the monthly listeners of every artist as of
+// the UTC day @day, from the qualified streams created from @start to @next.
+// An artist is credited with a stream of their own song or of a song they're a
+// contributor on.
+var monthlyListenerStatements = []string{
+	`DELETE FROM artist_monthly_listeners WHERE day = CAST(@day AS date)`,
+	`INSERT INTO artist_monthly_listeners (day, artist_id, listeners)
+	SELECT CAST(@day AS date), credits.artist_id, COUNT(DISTINCT streams.user_id)
+	FROM streams
+	JOIN (
+		SELECT id AS song_id, artist_id FROM songs
+		UNION
+		SELECT song_id, artist_id FROM song_contributors WHERE deleted_at IS NULL
+	) credits ON credits.song_id = streams.song_id
+	WHERE streams.created_at >= @start AND streams.created_at < @next AND streams.deleted_at IS NULL
+		AND streams.status = @qualified AND streams.user_id IS NOT NULL
+	GROUP BY credits.artist_id`,
+}
+
+type MonthlyListenersRepository struct {
+	DB *gorm.DB
+}
+
+func NewMonthlyListenersRepository(db *gorm.DB) IMonthlyListenersRepository {
+	return &MonthlyListenersRepository{DB: db}
+}
+
+// ComputeDay rebuilds every artist's monthly listeners as of the UTC day holding
+// day and returns how many artists had any
+func (r *MonthlyListenersRepository) ComputeDay(day time.Time) (int64, error) {
+	start := UTCDay(day)
+	args := []interface{}{
+		sql.Named("day", start.Format("2006-01-02")),
+		sql.Named("start", start.AddDate(0, 0, 1-MonthlyListenerDays)),
+		sql.Named("next", start.AddDate(0, 0, 1)),
+		sql.Named("qualified", models.StreamQualified),
+	}
+
+	var artists int64
+	err := Transaction(r.DB, func(tx *gorm.DB) error {
+		for _, statement := range monthlyListenerStatements {
+			result := tx.Exec(statement, args...)
+			if result.Error != nil {
+				return result.Error
+			}
+			artists = result.RowsAffected
+		}
+		return nil
+	})
+	return artists, err
+}
+
+// LatestDay returns the last UTC day monthly listeners were computed for, or
+// the zero time when they never were
+func (r *MonthlyListenersRepository) LatestDay() (time.Time, error) {
+	var computed struct {
+		Latest *time.Time
+	}
+	err := r.DB.Model(&models.ArtistMonthlyListeners{}).Select("MAX(day) AS latest").Scan(&computed).Error
+	if err != nil || computed.Latest == nil {
+		return time.Time{}, err
+	}
+	return UTCDay(*computed.Latest), nil
+}
+
+// UpdateArtists sets every artist's monthly listeners to their count as of the
+// UTC day holding day, zero for artists nobody listened to
+func (r *MonthlyListenersRepository) UpdateArtists(day time.Time) error {
+	return r.DB.Exec(`UPDATE artists SET monthly_listeners = counts.listeners
+		FROM (
+			SELECT artists.id, COALESCE(artist_monthly_listeners.listeners, 0) AS listeners
+			FROM artists
+			LEFT JOIN artist_monthly_listeners
+				ON artist_monthly_listeners.artist_id = artists.id AND artist_monthly_listeners.day = CAST(@day AS date)
+		) counts
+		WHERE counts.id = artists.id AND artists.monthly_listeners <> counts.listeners`,
+		sql.Named("day", UTCDay(day).Format("2006-01-02"))).
+		Error
+}
+
+// GetArtistHistory returns the artist's monthly listeners as of each UTC day
+// from from up to until that they were computed for
+func (r *MonthlyListenersRepository) GetArtistHistory(artistID uuid.UUID, from, until time.Time) ([]models.DailyListenerCount, error) {
+	history := []models.DailyListenerCount{}
+	err := r.DB.Model(&models.ArtistMonthlyListeners{}).
+		Select("day, listeners").
+		Where("artist_id = ? AND day >= CAST(? AS date) AND day < CAST(? AS date)",
+			artistID, UTCDay(from).Format("2006-01-02"), UTCDay(until).Format("2006-01-02")).
+		Order("day").
+		Scan(&history).
+		Error
+	return history, err
+}
@@ -55,3 +55,31 @@ func (r *MonthlyRoyaltyRepository) CalculatePendingRoyalties() (float64, error)
 		Error
 	return total, err
 }
+
+func (r *MonthlyRoyaltyRepository) SumForArtists(artistIDs []uuid.UUID, year, month *int) ([]models.ArtistRoyaltyTotal, error) {
+	var totals []models.ArtistRoyaltyTotal
+	if len(artistIDs) == 0 {
+		return totals, nil
+	}
+
+	db := r.DB.Model(&models.MonthlyRoyalty{}).
+		Select(`monthly_royalties.artist_id, artists.artist_name, monthly_royalties.currency,
+			COALESCE(SUM(monthly_royalties.amount), 0) AS amount,
+			COALESCE(SUM(CASE WHEN monthly_royalties.paid_status THEN monthly_royalties.amount ELSE 0 END), 0) AS paid_amount`).
+		Joins("JOIN artists ON artists.id = monthly_royalties.artist_id").
+		Where("monthly_royalties.artist_id IN ?", artistIDs)
+
+	if year != nil {
+		db = db.Where("monthly_royalties.year = ?", *year)
+	}
+	if month != nil {
+		db = db.Where("monthly_royalties.month = ?", *month)
+	}
+
+	err := db.
+		Group("monthly_royalties.artist_id, artists.artist_name, monthly_royalties.currency").
+		Order("amount DESC").
+		Scan(&totals).
+		Error
+	return totals, err
+}
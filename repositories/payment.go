@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"crawl/models"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PaymentRepository struct {
+	DB *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) IPaymentRepository {
+	return &PaymentRepository{DB: db}
+}
+
+// paymentTarget is the model of what payments for purpose pay for
+func paymentTarget(purpose string) (interface{}, error) {
+	switch purpose {
+	case models.PaymentForSong:
+		return &models.SongPurchase{}, nil
+	case models.PaymentForAlbum:
+		return &models.AlbumPurchase{}, nil
+	case models.PaymentForTip:
+		return &models.ArtistTip{}, nil
+	}
+	return nil, fmt.Errorf("unknown payment purpose %q", purpose)
+}
+
+// Create adds a pending payment and makes it the payment of what it pays for,
+// which goes back to pending with it
+func (r *PaymentRepository) Create(payment *models.Payment) error {
+	target, err := paymentTarget(payment.Purpose)
+	if err != nil {
+		return err
+	}
+	payment.Status = models.PaymentPending
+	return Transaction(r.DB, func(tx *gorm.DB) error {
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+		return tx.Model(target).
+			Where("id = ?", payment.TargetID).
+			Updates(map[string]interface{}{"payment_id": payment.ID, "payment_status": models.PaymentPending}).
+			Error
+	})
+}
+
+// SetProviderRef records the provider's reference and checkout page for a payment
+func (r *PaymentRepository) SetProviderRef(payment *models.Payment) error {
+	return r.DB.Model(&models.Payment{}).
+		Where("id = ?", payment.ID).
+		Updates(map[string]interface{}{"provider_ref": payment.ProviderRef, "checkout_url": payment.CheckoutURL}).
+		Error
+}
+
+func (r *PaymentRepository) GetByID(id uuid.UUID) (*models.Payment, error) {
+	var payment models.Payment
+	err := r.DB.First(&payment, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &payment, err
+}
+
+// FindPending finds the latest payment for purpose and target still pending
+// and started since since, or nil when there is none
+func (r *PaymentRepository) FindPending(purpose string, targetID uuid.UUID, since time.Time) (*models.Payment, error) {
+	var payment models.Payment
+	err := r.DB.
+		Where("purpose = ? AND target_id = ? AND status = ? AND created_at >= ?", purpose, targetID, models.PaymentPending, since).
+		Order("created_at DESC").
+		First(&payment).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &payment, err
+}
+
+func (r *PaymentRepository) FindByProviderRef(provider, ref string) (*models.Payment, error) {
+	var payment models.Payment
+	err := r.DB.First(&payment, "provider = ? AND provider_ref = ?", provider, ref).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &payment, err
+}
+
+// Settle moves a pending payment to status, completed or failed, and what it
+// pays for along with it. It reports false, changing nothing, when the payment
+// was already settled, so webhooks delivered twice are only applied once.
+//
+// A completed payment completes what it pays for even when a newer payment
+// has since been started for it, as the payer has paid. When another payment
+// had already completed it, the payment is marked RefundDue instead. A failed
+// one only fails what it pays for while it's still its latest payment.
+func (r *PaymentRepository) Settle(payment *models.Payment, status, reason string) (bool, error) {
+	target, err := paymentTarget(payment.Purpose)
+	if err != nil {
+		return false, err
+	}
+
+	settled := false
+	now := time.Now()
+	err = Transaction(r.DB, func(tx *gorm.DB) error {
+		result := tx.Model(&models.Payment{}).
+			Where("id = ? AND status = ?", payment.ID, models.PaymentPending).
+			Updates(map[string]interface{}{"status": status, "failure_reason": reason, "settled_at": now})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		settled = true
+
+		if status == models.PaymentCompleted {
+			result := tx.Model(target).
+				Where("id = ? AND payment_status <> ?", payment.TargetID, models.PaymentCompleted).
+				Updates(map[string]interface{}{"payment_id": payment.ID, "payment_status": status})
+			if result.Error != nil || result.RowsAffected > 0 {
+				return result.Error
+			}
+			payment.RefundDue = true
+			return tx.Model(&models.Payment{}).
+				Where("id = ?", payment.ID).
+				Update("refund_due", true).
+				Error
+		}
+		return tx.Model(target).
+			Where("id = ? AND payment_id = ?", payment.TargetID, payment.ID).
+			Update("payment_status", status).
+			Error
+	})
+	if err != nil || !settled {
+		return false, err
+	}
+	payment.Status = status
+	payment.FailureReason = reason
+	payment.SettledAt = &now
+	return true, nil
+}
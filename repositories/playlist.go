@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"crawl/models"
 	"errors"
 	"fmt"
@@ -13,14 +14,25 @@ import (
 
 type PlaylistRepository struct {
 	BaseRepository[models.Playlist]
+	fuzzy FuzzySettings
+	hits  []uuid.UUID
 }
 
-func NewPlaylistRepository(db *gorm.DB) IPlaylistRepository {
+func NewPlaylistRepository(db *gorm.DB, fuzzy FuzzySettings) IPlaylistRepository {
 	return &PlaylistRepository{
 		BaseRepository: BaseRepository[models.Playlist]{DB: db},
+		fuzzy:          fuzzy,
 	}
 }
 
+// WithIndexHits returns a copy of the repository whose searches match only ids,
+// the hits of an external search index, ranked in the given order
+func (r *PlaylistRepository) WithIndexHits(ids []uuid.UUID) IPlaylistRepository {
+	indexed := *r
+	indexed.hits = ids
+	return &indexed
+}
+
 func (r *PlaylistRepository) GetWithSongs(id uuid.UUID) (*models.Playlist, error) {
 	var playlist models.Playlist
 	err := r.DB.
@@ -73,6 +85,7 @@ func (r *PlaylistRepository) AddSongToPlaylist(playlistID, songID uuid.UUID) err
 }
 
 func (r *PlaylistRepository) SearchPlaylists(
+	ctx context.Context,
 	query *string,
 	owner *string,
 	isPublic *bool,
@@ -83,13 +96,13 @@ func (r *PlaylistRepository) SearchPlaylists(
 	var playlists []models.Playlist
 	var total int64
 
-	// Base query with user preload
-	q := r.DB.Model(&models.Playlist{}).Preload("User")
+	// Base query; users are preloaded once the page is known
+	q := r.DB.WithContext(ctx).Model(&models.Playlist{})
 
 	// Apply search query if provided
-	if query != nil && *query != "" {
-		searchTerm := fmt.Sprintf("%%%s%%", strings.ToLower(*query))
-		q = q.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ?", searchTerm, searchTerm)
+	terms := newSearchTerms(query, r.fuzzy, r.hits)
+	if !terms.empty() {
+		q = terms.where(q, "playlists", "playlists.search_vector", "playlists.title")
 	}
 
 	// Filter by owner (username or ID)
@@ -101,23 +114,29 @@ func (r *PlaylistRepository) SearchPlaylists(
 
 	// Filter by public/private status
 	if isPublic != nil {
-		q = q.Where("is_public = ?", *isPublic)
+		q = q.Where("playlists.is_public = ?", *isPublic)
 	}
 
 	// Apply sorting
-	if sort != nil {
+	if sort == nil && !terms.empty() {
+		q = q.Order("relevance DESC")
+	} else if sort != nil {
 		switch *sort {
+		case "relevance":
+			if !terms.empty() {
+				q = q.Order("relevance DESC")
+			}
 		case "title":
-			q = q.Order("title ASC")
+			q = q.Order("playlists.title ASC")
 		case "created_at":
-			q = q.Order("created_at DESC")
+			q = q.Order("playlists.created_at DESC")
 		case "updated_at":
-			q = q.Order("updated_at DESC")
+			q = q.Order("playlists.updated_at DESC")
 		case "popularity":
 			// Assuming you track playlist popularity (e.g., through likes or plays)
 			//q = q.Order("(SELECT COUNT(*) FROM playlist_likes WHERE playlist_likes.playlist_id = playlists.id) DESC")
 		default:
-			q = q.Order("created_at DESC") // Default sort
+			q = q.Order("playlists.created_at DESC") // Default sort
 		}
 	}
 
@@ -132,8 +151,19 @@ func (r *PlaylistRepository) SearchPlaylists(
 		q = q.Offset(offset).Limit(limit)
 	}
 
+	// Rank only after counting, Count can't wrap a multi-column select
+	if !terms.empty() {
+		rank, args := terms.rank("playlists.search_vector", "playlists.title")
+		playlists, err := findRanked(q, r.DB.WithContext(ctx).Preload("User"), "playlists", rank, args,
+			func(playlist *models.Playlist) (uuid.UUID, *float64) { return playlist.ID, &playlist.Relevance })
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to search playlists: %w", err)
+		}
+		return playlists, total, nil
+	}
+
 	// Execute query
-	if err := q.Find(&playlists).Error; err != nil {
+	if err := q.Preload("User").Find(&playlists).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to search playlists: %w", err)
 	}
 
@@ -32,35 +32,29 @@ func (r *PlaylistSongRepository) RemoveSongFromPlaylist(playlistID, songID uuid.
 }
 
 func (r *PlaylistSongRepository) ReorderSongs(playlistID uuid.UUID, songOrder map[uuid.UUID]int) error {
-	tx := r.DB.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	return Transaction(r.DB, func(tx *gorm.DB) error {
+		for songID, position := range songOrder {
+			if err := tx.Model(&models.PlaylistSong{}).
+				Where("playlist_id = ? AND song_id = ?", playlistID, songID).
+				Update("position", position).
+				Error; err != nil {
+				return err
+			}
 		}
-	}()
-
-	for songID, position := range songOrder {
-		if err := tx.Model(&models.PlaylistSong{}).
-			Where("playlist_id = ? AND song_id = ?", playlistID, songID).
-			Update("position", position).
-			Error; err != nil {
-			tx.Rollback()
-			return err
-		}
-	}
-
-	return tx.Commit().Error
+		return nil
+	})
 }
 
-func (r *PlaylistSongRepository) GetPlaylistSongs(playlistID uuid.UUID) ([]models.Song, error) {
+// GetPlaylistSongs returns the playlist's songs in order, leaving out those
+// that can't be played in country now
+func (r *PlaylistSongRepository) GetPlaylistSongs(playlistID uuid.UUID, country string) ([]models.Song, error) {
 	var songs []models.Song
-	err := r.DB.
+	db := r.DB.
 		Joins("JOIN playlist_songs ON songs.id = playlist_songs.song_id").
 		Where("playlist_songs.playlist_id = ?", playlistID).
 		Order("playlist_songs.position").
-		Preload("Artist").
-		Find(&songs).
-		Error
+		Preload("Artist")
+	err := availableIn(db, songAvailable, country).Find(&songs).Error
 	return songs, err
 }
 
@@ -10,10 +10,20 @@ type Repositories struct {
 	Album                     IAlbumRepository
 	Song                      ISongRepository
 	Genre                     IGenreRepository
+	Tag                       ITagRepository
 	Playlist                  IPlaylistRepository
 	SongPurchase              ISongPurchaseRepository
 	Stream                    IStreamRepository
+	StreamRollup              IStreamRollupRepository
+	MonthlyListeners          IMonthlyListenersRepository
+	Chart                     IChartRepository
+	ListeningHistory          IListeningHistoryRepository
+	Wrapped                   IWrappedRepository
+	Territory                 ITerritoryRepository
+	Export                    IExportRepository
+	Payment                   IPaymentRepository
 	Tip                       ITipRepository
+	ArtistSales               IArtistSalesRepository
 	Moderation                IModerationRepository
 	AlbumPurchase             IAlbumPurchaseRepository
 	Role                      IRoleRepository
@@ -22,19 +32,34 @@ type Repositories struct {
 	MonthlyRoyalty            IMonthlyRoyaltyRepository
 	UserFavorite              IUserFavoriteRepository
 	PlaylistSong              IPlaylistSongRepository
+	EntityVersion             IEntityVersionRepository
+	Verification              IVerificationRepository
+	Label                     ILabelRepository
+	Search                    ISearchRepository
+	SearchLog                 ISearchLogRepository
 }
 
-func NewRepositories(db *gorm.DB) *Repositories {
+func NewRepositories(db *gorm.DB, fuzzy FuzzySettings) *Repositories {
 	return &Repositories{
 		User:                      NewUserRepository(db),
-		Artist:                    NewArtistRepository(db),
-		Album:                     NewAlbumRepository(db),
-		Song:                      NewSongRepository(db),
+		Artist:                    NewArtistRepository(db, fuzzy),
+		Album:                     NewAlbumRepository(db, fuzzy),
+		Song:                      NewSongRepository(db, fuzzy),
 		Genre:                     NewGenreRepository(db),
-		Playlist:                  NewPlaylistRepository(db),
+		Tag:                       NewTagRepository(db),
+		Playlist:                  NewPlaylistRepository(db, fuzzy),
 		SongPurchase:              NewSongPurchaseRepository(db),
 		Stream:                    NewStreamRepository(db),
+		StreamRollup:              NewStreamRollupRepository(db),
+		MonthlyListeners:          NewMonthlyListenersRepository(db),
+		Chart:                     NewChartRepository(db),
+		ListeningHistory:          NewListeningHistoryRepository(db),
+		Wrapped:                   NewWrappedRepository(db),
+		Territory:                 NewTerritoryRepository(db),
+		Export:                    NewExportRepository(db),
+		Payment:                   NewPaymentRepository(db),
 		Tip:                       NewTipRepository(db),
+		ArtistSales:               NewArtistSalesRepository(db),
 		Moderation:                NewModerationRepository(db),
 		AlbumPurchase:             NewAlbumPurchaseRepository(db),
 		Role:                      NewRoleRepository(db),
@@ -43,5 +68,10 @@ func NewRepositories(db *gorm.DB) *Repositories {
 		MonthlyRoyalty:            NewMonthlyRoyaltyRepository(db),
 		UserFavorite:              NewUserFavoriteRepository(db),
 		PlaylistSong:              NewPlaylistSongRepository(db),
+		EntityVersion:             NewEntityVersionRepository(db),
+		Verification:              NewVerificationRepository(db),
+		Label:                     NewLabelRepository(db),
+		Search:                    NewSearchRepository(db, fuzzy),
+		SearchLog:                 NewSearchLogRepository(db),
 	}
 }
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"crawl/models"
+	"database/sql"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// rollupStatements rebuild the daily tables for the UTC day @day, whose streams
+// were created from @start to @next. Each clears the day first, so rolling a day
+// up again replaces it.
+var rollupStatements = []string{
+	`DELETE FROM song_daily_stats WHERE day = CAST(@day AS date)`,
+	`INSERT INTO song_daily_stats (day, song_id, streams, listeners, listens, completions, skips, listened_seconds)
+	SELECT CAST(@day AS date), song_id,
+		COUNT(*) FILTER (WHERE status = @qualified),
+		COUNT(DISTINCT user_id) FILTER (WHERE status = @qualified),
+		COUNT(*) FILTER (WHERE tracked),
+		COUNT(*) FILTER (WHERE tracked AND completed),
+		COUNT(*) FILTER (WHERE skipped),
+		COALESCE(SUM(listened_seconds) FILTER (WHERE tracked), 0)
+	FROM streams
+	WHERE created_at >= @start AND created_at < @next AND deleted_at IS NULL
+		AND (status = @qualified OR skipped)
+	GROUP BY song_id`,
+
+	`DELETE FROM country_daily_stats WHERE day = CAST(@day AS date)`,
+	`INSERT INTO country_daily_stats (day, song_id, country_code, streams, listeners)
+	SELECT CAST(@day AS date), song_id, COALESCE(upper(country_code), ''), COUNT(*), COUNT(DISTINCT user_id)
+	FROM streams
+	WHERE created_at >= @start AND created_at < @next AND deleted_at IS NULL AND status = @qualified
+	GROUP BY song_id, COALESCE(upper(country_code), '')`,
+
+	`DELETE FROM device_daily_stats WHERE day = CAST(@day AS date)`,
+	`INSERT INTO device_daily_stats (day, song_id, device_type, streams, listeners)
+	SELECT CAST(@day AS date), song_id, COALESCE(device_type, ''), COUNT(*), COUNT(DISTINCT user_id)
+	FROM streams
+	WHERE created_at >= @start AND created_at < @next AND deleted_at IS NULL AND status = @qualified
+	GROUP BY song_id, COALESCE(device_type, '')`,
+
+	`DELETE FROM artist_daily_listeners WHERE day = CAST(@day AS date)`,
+	`INSERT INTO artist_daily_listeners (day, artist_id, user_id, streams)
+	SELECT CAST(@day AS date), songs.artist_id, streams.user_id, COUNT(*)
+	FROM streams
+	JOIN songs ON songs.id = streams.song_id
+	WHERE streams.created_at >= @start AND streams.created_at < @next AND streams.deleted_at IS NULL
+		AND streams.status = @qualified AND streams.user_id IS NOT NULL
+	GROUP BY songs.artist_id, streams.user_id`,
+}
+
+// rollupLock holds the transaction's advisory lock on a day until it ends
+const rollupLock = `SELECT pg_advisory_xact_lock(hashtext('stream_rollup'), CAST(? AS date) - DATE '1970-01-01')`
+
+type StreamRollupRepository struct {
+	DB *gorm.DB
+}
+
+func NewStreamRollupRepository(db *gorm.DB) IStreamRollupRepository {
+	return &StreamRollupRepository{DB: db}
+}
+
+// RollupDay rebuilds the daily tables for the UTC day holding day in one
+// transaction and returns the day's qualified streams
+func (r *StreamRollupRepository) RollupDay(day time.Time) (int64, error) {
+	start := UTCDay(day)
+	rollup := models.StreamRollup{Day: start, RolledUpAt: time.Now()}
+
+	// The day goes as text so the session time zone can't shift it
+	dayText := start.Format("2006-01-02")
+	err := Transaction(r.DB, func(tx *gorm.DB) error {
+		// Instances rolling up the same day take turns, each replacing the day whole
+		if err := tx.Exec(rollupLock, dayText).Error; err != nil {
+			return err
+		}
+
+		args := []interface{}{
+			sql.Named("day", dayText),
+			sql.Named("start", start),
+			sql.Named("next", start.AddDate(0, 0, 1)),
+			sql.Named("qualified", models.StreamQualified),
+		}
+		for _, statement := range rollupStatements {
+			if err := tx.Exec(statement, args...).Error; err != nil {
+				return err
+			}
+		}
+
+		err := tx.Model(&models.SongDailyStat{}).
+			Where("day = CAST(? AS date)", dayText).
+			Select("COALESCE(SUM(streams), 0)").
+			Scan(&rollup.Streams).
+			Error
+		if err != nil {
+			return err
+		}
+		return tx.Save(&rollup).Error
+	})
+	return rollup.Streams, err
+}
+
+// RolledUpThrough returns the start of the first day after the latest one rolled
+// up, or the zero time when nothing has been rolled up. Earlier days aren't
+// necessarily all rolled up; MissingDays tells which aren't.
+func (r *StreamRollupRepository) RolledUpThrough() (time.Time, error) {
+	var latest struct {
+		Last *time.Time
+	}
+	err := r.DB.Model(&models.StreamRollup{}).Select("MAX(day) AS last").Scan(&latest).Error
+	if err != nil || latest.Last == nil {
+		return time.Time{}, err
+	}
+	return UTCDay(*latest.Last).AddDate(0, 0, 1), nil
+}
+
+// MissingDays returns the UTC days from the one holding from up to until that
+// haven't been rolled up, in order
+func (r *StreamRollupRepository) MissingDays(from, until time.Time) ([]time.Time, error) {
+	rolled, err := rolledUpDaysBetween(r.DB, from, until)
+	if err != nil {
+		return nil, err
+	}
+	return missingDays(from, until, rolled), nil
+}
+
+// rolledUpDaysBetween lists the days rolled up from the UTC day holding from up
+// to until, in order
+func rolledUpDaysBetween(db *gorm.DB, from, until time.Time) ([]time.Time, error) {
+	var days []time.Time
+	err := db.Model(&models.StreamRollup{}).
+		Where("day >= CAST(? AS date) AND day < CAST(? AS date)", UTCDay(from).Format("2006-01-02"), until.UTC().Format("2006-01-02")).
+		Order("day").
+		Pluck("day", &days).
+		Error
+	for i := range days {
+		days[i] = UTCDay(days[i])
+	}
+	return days, err
+}
+
+// missingDays returns the UTC days from the one holding from up to until that
+// aren't in rolled, which must be in order
+func missingDays(from, until time.Time, rolled []time.Time) []time.Time {
+	missing := []time.Time{}
+	next := 0
+	for day := UTCDay(from); day.Before(until); day = day.AddDate(0, 0, 1) {
+		for next < len(rolled) && rolled[next].Before(day) {
+			next++
+		}
+		if next < len(rolled) && rolled[next].Equal(day) {
+			continue
+		}
+		missing = append(missing, day)
+	}
+	return missing
+}
+
+// timeRange is the time from start up to end
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+// rawRanges splits since up to until into the ranges whose streams aren't in
+// the rollups: all of it but the whole days in rolled, which must be in order.
+// A rolled up day counts whole even when since falls inside it.
+func rawRanges(since, until time.Time, rolled []time.Time) []timeRange {
+	var ranges []timeRange
+	cursor := since
+	for _, day := range rolled {
+		next := day.AddDate(0, 0, 1)
+		if !next.After(cursor) || !day.Before(until) {
+			continue
+		}
+		if day.After(cursor) {
+			ranges = append(ranges, timeRange{cursor, day})
+		}
+		cursor = next
+	}
+	if cursor.Before(until) {
+		ranges = append(ranges, timeRange{cursor, until})
+	}
+	return ranges
+}
+
+// UTCDay returns the start of the UTC day holding t
+func UTCDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func days(s ...string) []time.Time {
+	out := make([]time.Time, len(s))
+	for i := range s {
+		out[i] = day(s[i])
+	}
+	return out
+}
+
+func TestRawRanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		since  time.Time
+		until  time.Time
+		rolled []time.Time
+		want   []timeRange
+	}{
+		{
+			name:  "nothing rolled up",
+			since: day("2026-03-01"),
+			until: day("2026-03-04"),
+			want:  []timeRange{{day("2026-03-01"), day("2026-03-04")}},
+		},
+		{
+			name:   "all but today rolled up",
+			since:  day("2026-03-01"),
+			until:  day("2026-03-04"),
+			rolled: days("2026-03-01", "2026-03-02"),
+			want:   []timeRange{{day("2026-03-03"), day("2026-03-04")}},
+		},
+		{
+			name:   "every day rolled up",
+			since:  day("2026-03-01"),
+			until:  day("2026-03-03"),
+			rolled: days("2026-03-01", "2026-03-02"),
+			want:   nil,
+		},
+		{
+			name:   "gap between backfills",
+			since:  day("2026-03-01"),
+			until:  day("2026-03-06"),
+			rolled: days("2026-03-01", "2026-03-03", "2026-03-04"),
+			want: []timeRange{
+				{day("2026-03-02"), day("2026-03-03")},
+				{day("2026-03-05"), day("2026-03-06")},
+			},
+		},
+		{
+			name:   "rollups start after since",
+			since:  day("2026-03-01"),
+			until:  day("2026-03-04"),
+			rolled: days("2026-03-02"),
+			want: []timeRange{
+				{day("2026-03-01"), day("2026-03-02")},
+				{day("2026-03-03"), day("2026-03-04")},
+			},
+		},
+		{
+			name:   "since inside a rolled up day counts the day whole",
+			since:  at("2026-03-01 15:00"),
+			until:  day("2026-03-03"),
+			rolled: days("2026-03-01"),
+			want:   []timeRange{{day("2026-03-02"), day("2026-03-03")}},
+		},
+		{
+			name:   "since inside a day not rolled up",
+			since:  at("2026-03-01 15:00"),
+			until:  day("2026-03-03"),
+			rolled: days("2026-03-02"),
+			want:   []timeRange{{at("2026-03-01 15:00"), day("2026-03-02")}},
+		},
+		{
+			name:   "rolled up days outside the window are ignored",
+			since:  day("2026-03-02"),
+			until:  day("2026-03-03"),
+			rolled: days("2026-02-28", "2026-03-03"),
+			want:   []timeRange{{day("2026-03-02"), day("2026-03-03")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rawRanges(tt.since, tt.until, tt.rolled)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rawRanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingDays(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   time.Time
+		until  time.Time
+		rolled []time.Time
+		want   []time.Time
+	}{
+		{
+			name:  "nothing rolled up",
+			from:  day("2026-03-01"),
+			until: day("2026-03-03"),
+			want:  days("2026-03-01", "2026-03-02"),
+		},
+		{
+			name:   "week fully rolled up",
+			from:   day("2026-03-02"),
+			until:  day("2026-03-09"),
+			rolled: days("2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05", "2026-03-06", "2026-03-07", "2026-03-08"),
+			want:   days(),
+		},
+		{
+			name:   "gap in the middle",
+			from:   day("2026-03-01"),
+			until:  day("2026-03-04"),
+			rolled: days("2026-03-01", "2026-03-03"),
+			want:   days("2026-03-02"),
+		},
+		{
+			name:   "from inside a day",
+			from:   at("2026-03-01 08:30"),
+			until:  day("2026-03-02"),
+			rolled: days("2026-02-28"),
+			want:   days("2026-03-01"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := missingDays(tt.from, tt.until, tt.rolled)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingDays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package repositories
+
+import (
+	"context"
+	"crawl/models"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// textQuery is a prefix-matching, accent-insensitive tsquery; bind it to the output of prefixTSQuery
+const textQuery = "to_tsquery('simple', search_unaccent(?))"
+
+// FuzzySettings tunes trigram matching for misspelled queries
+type FuzzySettings struct {
+	// MinSimilarity is the pg_trgm word similarity a name needs to match a query
+	// the full-text index missed
+	MinSimilarity float64
+	// SuggestBelow is the similarity of the closest name under which "did you mean"
+	// suggestions are offered
+	SuggestBelow float64
+}
+
+var DefaultFuzzySettings = FuzzySettings{MinSimilarity: 0.3, SuggestBelow: 0.6}
+
+// prefixTSQuery turns free text into a tsquery string where every word must match
+// as a prefix, e.g. "burna bo" becomes "burna:* & bo:*". It returns "" when the
+// input holds no searchable words.
+func prefixTSQuery(input string) string {
+	return tsQueryTerms(input, ":*")
+}
+
+// namePrefixTSQuery is prefixTSQuery restricted to weight A lexemes, which the
+// search_vector columns reserve for names and titles
+func namePrefixTSQuery(input string) string {
+	return tsQueryTerms(input, ":*A")
+}
+
+func tsQueryTerms(input, suffix string) string {
+	words := strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, word+suffix)
+	}
+	return strings.Join(terms, " & ")
+}
+
+// searchTerms is a user query prepared for full-text and trigram matching. When
+// an external index already matched the query, hits replaces the text match:
+// only those ids match and they rank in the index's order.
+type searchTerms struct {
+	tsQuery       string
+	text          string
+	minSimilarity float64
+	hits          []uuid.UUID
+}
+
+func newSearchTerms(query *string, fuzzy FuzzySettings, hits []uuid.UUID) searchTerms {
+	if hits != nil {
+		return searchTerms{hits: hits}
+	}
+	if query == nil {
+		return searchTerms{}
+	}
+	return searchTerms{
+		tsQuery:       prefixTSQuery(*query),
+		text:          strings.TrimSpace(*query),
+		minSimilarity: fuzzy.MinSimilarity,
+	}
+}
+
+func (t searchTerms) empty() bool {
+	return t.tsQuery == "" && !t.indexed()
+}
+
+// indexed reports whether the match comes from external index hits
+func (t searchTerms) indexed() bool {
+	return t.hits != nil
+}
+
+// hitArray formats the hits as a uuid[] literal; uuids need no escaping
+func (t searchTerms) hitArray() string {
+	ids := make([]string, len(t.hits))
+	for i, id := range t.hits {
+		ids[i] = id.String()
+	}
+	return "{" + strings.Join(ids, ",") + "}"
+}
+
+// similarity scores the closest of names against the raw query, ignoring case and accents
+func (t searchTerms) similarity(names ...string) (string, []interface{}) {
+	exprs := make([]string, len(names))
+	args := make([]interface{}, len(names))
+	for i, name := range names {
+		exprs[i] = "COALESCE(word_similarity(search_unaccent(lower(?)), search_unaccent(lower(" + name + "))), 0)"
+		args[i] = t.text
+	}
+	if len(exprs) == 1 {
+		return exprs[0], args
+	}
+	return "GREATEST(" + strings.Join(exprs, ", ") + ")", args
+}
+
+// where restricts db to the rows of table that match. Index hits are joined
+// in with their position, which rank reads, so a query can take every hit of
+// the index and still filter, sort and count them in one pass.
+func (t searchTerms) where(db *gorm.DB, table, vector string, names ...string) *gorm.DB {
+	if t.indexed() {
+		return db.Joins("JOIN unnest(CAST(? AS uuid[])) WITH ORDINALITY AS index_hits(id, position) ON index_hits.id = "+table+".id", t.hitArray())
+	}
+	match, args := t.match(vector, names...)
+	return db.Where(match, args...)
+}
+
+// match is true when vector matches the full-text query, or when a misspelled
+// query is still close enough to one of names. The <% operators let the trigram
+// indexes find candidates; the similarity check keeps the configured minimum
+// exact whatever the database's threshold.
+func (t searchTerms) match(vector string, names ...string) (string, []interface{}) {
+	near := make([]string, len(names))
+	args := []interface{}{t.tsQuery}
+	for i, name := range names {
+		near[i] = "search_unaccent(lower(?)) <% search_unaccent(lower(" + name + "))"
+		args = append(args, t.text)
+	}
+	similarity, similarityArgs := t.similarity(names...)
+	args = append(append(args, similarityArgs...), t.minSimilarity)
+	return "(" + vector + " @@ " + textQuery + " OR ((" + strings.Join(near, " OR ") + ") AND " + similarity + " >= ?))", args
+}
+
+// rank scores vector against the full-text query, boosted by how closely names match
+func (t searchTerms) rank(vector string, names ...string) (string, []interface{}) {
+	if t.indexed() {
+		return "1.0 / index_hits.position", nil
+	}
+	similarity, args := t.similarity(names...)
+	return "ts_rank(" + vector + ", " + textQuery + ") + " + similarity, append([]interface{}{t.tsQuery}, args...)
+}
+
+// rankedID is a search hit's id and rank. Models keep their relevance out of
+// their columns, so ranks are read apart from the records they belong to.
+type rankedID struct {
+	ID        uuid.UUID
+	Relevance float64
+}
+
+// findRanked reads the ids of the rows of table that query returns, in its
+// order and page, with rank as their relevance, then loads those rows through
+// load. The records come back in query's order with their relevance set;
+// fields returns a record's id and its Relevance field. query may order by
+// "relevance DESC".
+func findRanked[T any](query, load *gorm.DB, table, rank string, rankArgs []interface{}, fields func(*T) (uuid.UUID, *float64)) ([]T, error) {
+	var ranked []rankedID
+	if err := query.Select(table+".id AS id, "+rank+" AS relevance", rankArgs...).Scan(&ranked).Error; err != nil {
+		return nil, err
+	}
+
+	records := make([]T, 0, len(ranked))
+	if len(ranked) == 0 {
+		return records, nil
+	}
+	ids := make([]uuid.UUID, len(ranked))
+	for i, hit := range ranked {
+		ids[i] = hit.ID
+	}
+	var found []T
+	if err := load.Where(table+".id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]T, len(found))
+	for _, record := range found {
+		id, _ := fields(&record)
+		byID[id] = record
+	}
+	for _, hit := range ranked {
+		record, ok := byID[hit.ID]
+		if !ok {
+			continue
+		}
+		_, relevance := fields(&record)
+		*relevance = hit.Relevance
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+type SearchRepository struct {
+	DB    *gorm.DB
+	fuzzy FuzzySettings
+}
+
+func NewSearchRepository(db *gorm.DB, fuzzy FuzzySettings) ISearchRepository {
+	return &SearchRepository{DB: db, fuzzy: fuzzy}
+}
+
+// closestNamesQuery lists every public name a query can be corrected to
+const closestNamesQuery = `
+SELECT 'artist' AS type, id, artist_name AS text FROM artists WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'song', id, title FROM songs WHERE deleted_at IS NULL AND is_flagged = false
+UNION ALL
+SELECT 'album', id, title FROM albums WHERE deleted_at IS NULL AND is_flagged = false
+UNION ALL
+SELECT 'playlist', id, title FROM playlists WHERE deleted_at IS NULL AND is_public = true`
+
+// ClosestNames returns the names most similar to query, best first
+func (r *SearchRepository) ClosestNames(ctx context.Context, query string, limit int) ([]models.SearchSuggestion, error) {
+	var suggestions []models.SearchSuggestion
+	err := r.DB.WithContext(ctx).Raw(`SELECT type, id, text, score FROM (
+			SELECT candidates.*, similarity(search_unaccent(lower(?)), search_unaccent(lower(candidates.text))) AS score
+			FROM (`+closestNamesQuery+`) candidates
+		) scored
+		WHERE score >= ?
+		ORDER BY score DESC, text ASC
+		LIMIT ?`, query, r.fuzzy.MinSimilarity, limit).
+		Scan(&suggestions).
+		Error
+	return suggestions, err
+}
+
+// suggestSources holds one autocomplete branch per suggestion type. Each scores its
+// rows by popularity on a log scale, doubled when the name starts with the typed text.
+var suggestSources = map[string]string{
+	models.SuggestionArtist: `SELECT 'artist' AS type, artists.id, artists.artist_name AS text, '' AS subtitle, '' AS image_url,
+		ln(2 + artists.monthly_listeners) * CASE WHEN search_unaccent(lower(artists.artist_name)) LIKE search_unaccent(@starts) THEN 2 ELSE 1 END AS score
+		FROM artists
+		WHERE artists.deleted_at IS NULL AND artists.search_vector @@ to_tsquery('simple', search_unaccent(@tsq))`,
+	models.SuggestionSong: `SELECT 'song' AS type, songs.id, songs.title AS text, artists.artist_name AS subtitle, songs.cover_image_url AS image_url,
+		ln(2 + songs.plays_count) * CASE WHEN search_unaccent(lower(songs.title)) LIKE search_unaccent(@starts) THEN 2 ELSE 1 END AS score
+		FROM songs JOIN artists ON artists.id = songs.artist_id
+		WHERE songs.deleted_at IS NULL AND songs.is_flagged = false AND songs.search_vector @@ to_tsquery('simple', search_unaccent(@tsq))
+			AND ` + songAvailable,
+	models.SuggestionAlbum: `SELECT 'album' AS type, albums.id, albums.title AS text, artists.artist_name AS subtitle, albums.cover_image_url AS image_url,
+		ln(2 + (SELECT COALESCE(SUM(songs.plays_count), 0) FROM songs WHERE songs.album_id = albums.id AND songs.deleted_at IS NULL))
+			* CASE WHEN search_unaccent(lower(albums.title)) LIKE search_unaccent(@starts) THEN 2 ELSE 1 END AS score
+		FROM albums JOIN artists ON artists.id = albums.artist_id
+		WHERE albums.deleted_at IS NULL AND albums.is_flagged = false AND albums.search_vector @@ to_tsquery('simple', search_unaccent(@tsq))
+			AND ` + albumAvailable,
+	models.SuggestionPlaylist: `SELECT 'playlist' AS type, playlists.id, playlists.title AS text, users.username AS subtitle, playlists.cover_image_url AS image_url,
+		ln(2 + COALESCE(playlists.likes, 0)) * CASE WHEN search_unaccent(lower(playlists.title)) LIKE search_unaccent(@starts) THEN 2 ELSE 1 END AS score
+		FROM playlists JOIN users ON users.id = playlists.user_id
+		WHERE playlists.deleted_at IS NULL AND playlists.is_public = true AND playlists.search_vector @@ to_tsquery('simple', search_unaccent(@tsq))`,
+}
+
+// Suggest returns autocomplete suggestions for a partially typed query, at most
+// perType of each type, ranked together by score. Songs and albums that can't
+// be played in country now are left out.
+func (r *SearchRepository) Suggest(ctx context.Context, prefix string, types []string, perType int, country string) ([]models.SearchSuggestion, error) {
+	suggestions := []models.SearchSuggestion{}
+
+	tsQuery := namePrefixTSQuery(prefix)
+	if tsQuery == "" {
+		return suggestions, nil
+	}
+
+	branches := make([]string, 0, len(types))
+	for _, suggestionType := range types {
+		source, ok := suggestSources[suggestionType]
+		if !ok {
+			continue
+		}
+		branches = append(branches, "("+source+" ORDER BY score DESC LIMIT @per_type)")
+	}
+	if len(branches) == 0 {
+		return suggestions, nil
+	}
+
+	err := r.DB.WithContext(ctx).
+		Raw(strings.Join(branches, " UNION ALL ")+" ORDER BY score DESC, text ASC", territoryArgs(nil, country, time.Now(),
+			sql.Named("tsq", tsQuery),
+			sql.Named("starts", escapeLike(strings.ToLower(strings.TrimSpace(prefix)))+"%"),
+			sql.Named("per_type", perType))...).
+		Scan(&suggestions).
+		Error
+	return suggestions, err
+}
+
+// escapeLike makes s match literally inside a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// priceRanges buckets prices for facet counts. Prices are whole naira, as
+// songs and albums store them, not kobo.
+var priceRanges = []struct {
+	Value    string
+	Min, Max int // Max < 0 means unbounded
+}{
+	{"0", 0, 0},
+	{"1-499", 1, 499},
+	{"500-999", 500, 999},
+	{"1000-4999", 1000, 4999},
+	{"5000+", 5000, -1},
+}
+
+// maxGenreFacets keeps the genre facet to the most common genres
+const maxGenreFacets = 20
+
+// searchFacets counts the rows of table matched by scope by genre, release year and
+// price range. scope must return a fresh query each time it's called.
+func searchFacets(table string, scope func() *gorm.DB) (*models.SearchFacets, error) {
+	facets := &models.SearchFacets{}
+
+	err := scope().
+		Select("facet_genres.id::text AS value, facet_genres.name AS label, COUNT(*) AS count").
+		Joins("JOIN genres facet_genres ON facet_genres.id = " + table + ".genre_id").
+		Group("facet_genres.id, facet_genres.name").
+		Order("count DESC").
+		Limit(maxGenreFacets).
+		Scan(&facets.Genres).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	year := "EXTRACT(YEAR FROM " + table + ".release_date)::int::text"
+	err = scope().
+		Select(year + " AS value, " + year + " AS label, COUNT(*) AS count").
+		Where(table + ".release_date IS NOT NULL").
+		Group(year).
+		Order("value DESC").
+		Scan(&facets.ReleaseYears).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var bucket strings.Builder
+	bucket.WriteString("CASE")
+	for _, priceRange := range priceRanges {
+		if priceRange.Max < 0 {
+			fmt.Fprintf(&bucket, " WHEN %s.price >= %d THEN '%s'", table, priceRange.Min, priceRange.Value)
+		} else {
+			fmt.Fprintf(&bucket, " WHEN %s.price BETWEEN %d AND %d THEN '%s'", table, priceRange.Min, priceRange.Max, priceRange.Value)
+		}
+	}
+	bucket.WriteString(" END")
+
+	var priceCounts []models.FacetCount
+	err = scope().
+		Select(bucket.String() + " AS value, COUNT(*) AS count").
+		Group("value").
+		Scan(&priceCounts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Report every range in a fixed order, including the empty ones
+	counts := make(map[string]int64, len(priceCounts))
+	for _, priceCount := range priceCounts {
+		counts[priceCount.Value] = priceCount.Count
+	}
+	facets.PriceRanges = make([]models.FacetCount, 0, len(priceRanges))
+	for _, priceRange := range priceRanges {
+		facets.PriceRanges = append(facets.PriceRanges, models.FacetCount{
+			Value: priceRange.Value,
+			Label: priceRange.Value,
+			Count: counts[priceRange.Value],
+		})
+	}
+
+	if facets.Genres == nil {
+		facets.Genres = []models.FacetCount{}
+	}
+	if facets.ReleaseYears == nil {
+		facets.ReleaseYears = []models.FacetCount{}
+	}
+	return facets, nil
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"crawl/models"
+	"database/sql"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// searcherKey identifies who ran a search: the user when logged in, otherwise the visitor hash
+const searcherKey = "COALESCE(search_logs.user_id::text, search_logs.visitor_hash)"
+
+// searchClicksSince sums the clicks per logged search made since @from
+const searchClicksSince = `(
+	SELECT search_log_id, COUNT(*) AS clicks, MIN(position) AS first_position
+	FROM search_clicks
+	WHERE created_at >= @from AND deleted_at IS NULL
+	GROUP BY search_log_id
+) clicks ON clicks.search_log_id = search_logs.id`
+
+type SearchLogRepository struct {
+	BaseRepository[models.SearchLog]
+}
+
+func NewSearchLogRepository(db *gorm.DB) ISearchLogRepository {
+	return &SearchLogRepository{
+		BaseRepository: BaseRepository[models.SearchLog]{DB: db},
+	}
+}
+
+func (r *SearchLogRepository) CreateClick(click *models.SearchClick) error {
+	return r.DB.Create(click).Error
+}
+
+func (r *SearchLogRepository) TopQueries(from, to time.Time, limit int) ([]models.SearchQueryStat, error) {
+	stats := []models.SearchQueryStat{}
+	err := r.DB.Model(&models.SearchLog{}).
+		Select(`search_logs.query,
+			COUNT(*) AS searches,
+			COUNT(DISTINCT `+searcherKey+`) AS searchers,
+			AVG(search_logs.result_count) AS average_results,
+			COALESCE(SUM(clicks.clicks), 0) AS clicks,
+			COUNT(clicks.search_log_id)::float / COUNT(*) AS click_through_rate`).
+		Joins("LEFT JOIN "+searchClicksSince, sql.Named("from", from)).
+		Where("search_logs.created_at >= ? AND search_logs.created_at < ?", from, to).
+		Group("search_logs.query").
+		Order("searches DESC, search_logs.query").
+		Limit(limit).
+		Scan(&stats).
+		Error
+	return stats, err
+}
+
+func (r *SearchLogRepository) ZeroResultQueries(from, to time.Time, limit int) ([]models.ZeroResultQuery, error) {
+	queries := []models.ZeroResultQuery{}
+	err := r.DB.Model(&models.SearchLog{}).
+		Select(`search_logs.query,
+			COUNT(*) AS searches,
+			COUNT(DISTINCT `+searcherKey+`) AS searchers,
+			MAX(search_logs.created_at) AS last_searched_at`).
+		Where("search_logs.created_at >= ? AND search_logs.created_at < ?", from, to).
+		Where("search_logs.result_count = 0").
+		Group("search_logs.query").
+		Order("searches DESC, last_searched_at DESC").
+		Limit(limit).
+		Scan(&queries).
+		Error
+	return queries, err
+}
+
+func (r *SearchLogRepository) ClickThrough(from, to time.Time) (*models.SearchClickThrough, error) {
+	report := models.SearchClickThrough{From: from, To: to}
+	err := r.DB.Model(&models.SearchLog{}).
+		Select(`COUNT(*) AS searches,
+			COUNT(clicks.search_log_id) AS clicked_searches,
+			COALESCE(SUM(clicks.clicks), 0) AS clicks,
+			COALESCE(AVG(clicks.first_position), 0) AS mean_click_position`).
+		Joins("LEFT JOIN "+searchClicksSince, sql.Named("from", from)).
+		Where("search_logs.created_at >= ? AND search_logs.created_at < ?", from, to).
+		Scan(&report).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if report.Searches > 0 {
+		report.ClickThroughRate = float64(report.ClickedSearches) / float64(report.Searches)
+	}
+	return &report, nil
+}
+
+// Trending ranks the queries searched since recent by how far they outpace their
+// daily average over the baseline period before it. Only queries that found
+// something and that at least minSearchers different logged-in users ran are
+// listed, so one person's searches never surface publicly. Logged-out searches
+// still count towards the ranking, but not the threshold: a visitor hash
+// changes with the user agent, so one person could pass for several.
+func (r *SearchLogRepository) Trending(recent, baseline time.Time, minSearchers int, limit int) ([]models.TrendingSearch, error) {
+	baselineDays := recent.Sub(baseline).Hours() / 24
+	if baselineDays < 1 {
+		baselineDays = 1
+	}
+
+	trending := []models.TrendingSearch{}
+	err := r.DB.Model(&models.SearchLog{}).
+		Select("search_logs.query, COUNT(*) FILTER (WHERE search_logs.created_at >= @recent) AS searches", sql.Named("recent", recent)).
+		Where("search_logs.created_at >= @baseline AND search_logs.result_count > 0", sql.Named("baseline", baseline)).
+		Group("search_logs.query").
+		Having("COUNT(DISTINCT search_logs.user_id) FILTER (WHERE search_logs.created_at >= @recent) >= @min",
+			sql.Named("recent", recent), sql.Named("min", minSearchers)).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL: `COUNT(*) FILTER (WHERE search_logs.created_at >= ?)::float
+				/ (COUNT(*) FILTER (WHERE search_logs.created_at < ?) / ? + 1) DESC`,
+			Vars:               []interface{}{recent, recent, baselineDays},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Scan(&trending).
+		Error
+	return trending, err
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestSearchLogTrendingCountsLoggedInSearchers(t *testing.T) {
+	db, statements := dryRunDB(t)
+	recent := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	_, err := NewSearchLogRepository(db).Trending(recent, recent.AddDate(0, 0, -7), 3, 10)
+	if err != nil && !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+		t.Fatalf("Trending: %v", err)
+	}
+	if len(*statements) != 1 {
+		t.Fatalf("got %d queries, want 1", len(*statements))
+	}
+	query := (*statements)[0]
+	if want := "HAVING COUNT(DISTINCT search_logs.user_id) FILTER (WHERE search_logs.created_at >= '2026-03-14 00:00:00') >= 3"; !strings.Contains(query, want) {
+		t.Errorf("query %q doesn't contain %q", query, want)
+	}
+	if strings.Contains(query, "visitor_hash") {
+		t.Errorf("query %q counts visitor hashes", query)
+	}
+}
@@ -1,27 +1,71 @@
 package repositories
 
 import (
+	"context"
 	"crawl/models"
+	"database/sql"
 	"errors"
 	"github.com/google/uuid"
-	"time"
 
 	"gorm.io/gorm"
 )
 
 type SongRepository struct {
 	BaseRepository[models.Song]
+	fuzzy   FuzzySettings
+	hits    []uuid.UUID
+	country *string
 }
 
-func NewSongRepository(db *gorm.DB) ISongRepository {
+func NewSongRepository(db *gorm.DB, fuzzy FuzzySettings) ISongRepository {
 	return &SongRepository{
 		BaseRepository: BaseRepository[models.Song]{DB: db},
+		fuzzy:          fuzzy,
 	}
 }
 
+// WithIndexHits returns a copy of the repository whose searches match only ids,
+// the hits of an external search index, ranked in the given order
+func (r *SongRepository) WithIndexHits(ids []uuid.UUID) ISongRepository {
+	indexed := *r
+	indexed.hits = ids
+	return &indexed
+}
+
+// AvailableIn returns a copy of the repository whose listings leave out the
+// songs that can't be played in country now; "" is an unknown country
+func (r *SongRepository) AvailableIn(country string) ISongRepository {
+	available := *r
+	available.country = &country
+	return &available
+}
+
+// available applies the territory filter set by AvailableIn, if any
+func (r *SongRepository) available(db *gorm.DB) *gorm.DB {
+	if r.country == nil {
+		return db
+	}
+	return availableIn(db, songAvailable, *r.country)
+}
+
+// withCredits preloads everything needed to build a song's display artist
+func withCredits(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Artist").
+		Preload("Credits", func(db *gorm.DB) *gorm.DB {
+			return db.Order("song_contributors.created_at")
+		}).
+		Preload("Credits.Artist")
+}
+
+// creditedArtistMatch matches songs whose primary artist or any credited artist has a matching name
+const creditedArtistMatch = `(songs.artist_id IN (SELECT id FROM artists WHERE artist_name ILIKE @name AND deleted_at IS NULL)
+	OR songs.id IN (SELECT sc.song_id FROM song_contributors sc JOIN artists a ON a.id = sc.artist_id
+		WHERE a.artist_name ILIKE @name AND sc.deleted_at IS NULL))`
+
 func (r *SongRepository) GetWithArtist(id uuid.UUID) (*models.Song, error) {
 	var song models.Song
-	err := r.DB.Preload("Artist").First(&song, id).Error
+	err := withCredits(r.DB).Preload("Tags").First(&song, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, ErrRecordNotFound
 	}
@@ -34,14 +78,23 @@ func (r *SongRepository) GetByArtist(artistID uuid.UUID) ([]models.Song, error)
 	return songs, err
 }
 
-func (r *SongRepository) GetTrending(limit int, since time.Time) ([]models.Song, error) {
+// GetCreditedTo returns songs the artist owns or is credited on in one of the given roles
+func (r *SongRepository) GetCreditedTo(artistID uuid.UUID, roles []string, offset, limit int) ([]models.Song, error) {
 	var songs []models.Song
-	err := r.DB.
-		Where("created_at >= ?", since).
-		Order("plays_count DESC").
-		Limit(limit).
-		Find(&songs).
-		Error
+	db := withCredits(r.DB).
+		Where("songs.artist_id = ? OR songs.id IN (?)", artistID,
+			r.DB.Model(&models.SongContributor{}).
+				Select("song_id").
+				Where("artist_id = ? AND contribution_type IN ?", artistID, roles)).
+		Order("songs.release_date DESC").
+		Order("songs.created_at DESC")
+	db = r.available(db)
+
+	if limit > 0 {
+		db = db.Offset(offset).Limit(limit)
+	}
+
+	err := db.Find(&songs).Error
 	return songs, err
 }
 
@@ -58,36 +111,123 @@ func (r *SongRepository) GetByAlbum(albumID uuid.UUID) ([]models.Song, error) {
 	return songs, err
 }
 
-func (r *SongRepository) Search(query, artist, genre *string, sort, order *string, offset, limit int) ([]models.Song, error) {
+func (r *SongRepository) GetFiltered(genreID, artistID, albumID *uuid.UUID, tags []string, offset, limit int) ([]models.Song, error) {
 	var songs []models.Song
-	db := r.DB.Model(&models.Song{}).Preload("Artist")
+	db := withCredits(r.DB.Model(&models.Song{}))
+
+	if genreID != nil {
+		db = db.Where("songs.genre_id IN ("+genreSubtreeQuery("id = ?")+")", *genreID)
+	}
+	if artistID != nil {
+		db = db.Where("songs.artist_id = ?", *artistID)
+	}
+	if albumID != nil {
+		db = db.Where("songs.album_id = ?", *albumID)
+	}
+	if len(tags) > 0 {
+		db = taggedWith(db, "songs", "song_tags", "song_id", tags)
+	}
+	db = r.available(db)
+
+	if limit > 0 {
+		db = db.Offset(offset).Limit(limit)
+	}
+
+	err := db.Find(&songs).Error
+	return songs, err
+}
+
+// songSearchVector weights a song's own title above its artist, album and genre names
+const songSearchVector = `(songs.search_vector
+	|| setweight(coalesce(artists.search_vector, ''), 'B')
+	|| setweight(coalesce(albums.search_vector, ''), 'C')
+	|| setweight(coalesce(genres.search_vector, ''), 'D'))`
+
+// songSortColumns whitelists the sort keys accepted by Search
+var songSortColumns = map[string]string{
+	"popularity":   "songs.plays_count",
+	"release_date": "songs.release_date",
+	"duration":     "songs.duration",
+	"title":        "songs.title",
+	"relevance":    "relevance",
+}
 
-	if query != nil && *query != "" {
-		db = db.Where("title ILIKE ?", "%"+*query+"%")
+// searchScope applies the query and filters shared by Search and SearchFacets
+func (r *SongRepository) searchScope(ctx context.Context, terms searchTerms, artist, genre *string) *gorm.DB {
+	db := r.DB.WithContext(ctx).Model(&models.Song{})
+
+	if terms.indexed() {
+		db = terms.where(db.
+			Joins("LEFT JOIN artists ON artists.id = songs.artist_id AND artists.deleted_at IS NULL").
+			Joins("LEFT JOIN albums ON albums.id = songs.album_id AND albums.deleted_at IS NULL").
+			Joins("LEFT JOIN genres ON genres.id = songs.genre_id AND genres.deleted_at IS NULL"),
+			"songs", songSearchVector)
+	} else if !terms.empty() {
+		// Featured and other credited artists match too, they just don't add to the rank
+		match, args := terms.match(songSearchVector, "songs.title", "artists.artist_name")
+		db = db.
+			Joins("LEFT JOIN artists ON artists.id = songs.artist_id AND artists.deleted_at IS NULL").
+			Joins("LEFT JOIN albums ON albums.id = songs.album_id AND albums.deleted_at IS NULL").
+			Joins("LEFT JOIN genres ON genres.id = songs.genre_id AND genres.deleted_at IS NULL").
+			Where("("+match+` OR songs.id IN (
+				SELECT sc.song_id FROM song_contributors sc JOIN artists a ON a.id = sc.artist_id
+				WHERE a.search_vector @@ `+textQuery+` AND sc.deleted_at IS NULL))`, append(args, terms.tsQuery)...)
 	}
 
 	if artist != nil && *artist != "" {
-		db = db.Joins("JOIN artists ON songs.artist_id = artists.id").
-			Where("artists.name ILIKE ?", "%"+*artist+"%")
+		db = db.Where(creditedArtistMatch, sql.Named("name", "%"+*artist+"%"))
 	}
 
 	if genre != nil && *genre != "" {
-		db = db.Joins("JOIN genres ON songs.genre_id = genres.id").
-			Where("genres.name ILIKE ?", "%"+*genre+"%")
+		db = db.Where("songs.genre_id IN ("+genreSubtreeQuery("name ILIKE ?")+")", "%"+*genre+"%")
 	}
 
+	return r.available(db)
+}
+
+func (r *SongRepository) Search(ctx context.Context, query, artist, genre *string, sort, order *string, offset, limit int) ([]models.Song, int64, error) {
+	var songs []models.Song
+	var total int64
+
+	terms := newSearchTerms(query, r.fuzzy, r.hits)
+	db := r.searchScope(ctx, terms, artist, genre)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var column string
 	if sort != nil {
-		sortField := *sort
+		column = songSortColumns[*sort]
+	}
+	if column != "" && (column != "relevance" || !terms.empty()) {
 		if order != nil && *order == "desc" {
-			sortField += " DESC"
+			column += " DESC"
 		}
-		db = db.Order(sortField)
+		db = db.Order(column)
+	} else if !terms.empty() {
+		db = db.Order("relevance DESC").Order("songs.plays_count DESC")
 	}
 
 	if limit > 0 {
 		db = db.Offset(offset).Limit(limit)
 	}
 
-	err := db.Find(&songs).Error
-	return songs, err
+	if !terms.empty() {
+		rank, args := terms.rank(songSearchVector, "songs.title", "artists.artist_name")
+		songs, err := findRanked(db, withCredits(r.DB.WithContext(ctx)), "songs", rank, args,
+			func(song *models.Song) (uuid.UUID, *float64) { return song.ID, &song.Relevance })
+		return songs, total, err
+	}
+
+	err := withCredits(db).Find(&songs).Error
+	return songs, total, err
+}
+
+// SearchFacets counts the songs matching query by genre, release year and price range
+func (r *SongRepository) SearchFacets(ctx context.Context, query *string) (*models.SearchFacets, error) {
+	terms := newSearchTerms(query, r.fuzzy, r.hits)
+	return searchFacets("songs", func() *gorm.DB {
+		return r.searchScope(ctx, terms, nil, nil)
+	})
 }
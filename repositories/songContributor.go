@@ -17,6 +17,7 @@ func NewSongContributorRepository(db *gorm.DB) ISongContributorRepository {
 func (r *SongContributorRepository) FindBySongID(songID uuid.UUID) ([]models.SongContributor, error) {
 	var contributors []models.SongContributor
 	err := r.DB.
+		Preload("Artist").
 		Where("song_id = ?", songID).
 		Find(&contributors).
 		Error
@@ -40,10 +40,23 @@ func (r *SongPurchaseRepository) GetUserSongPurchases(userID uuid.UUID) ([]model
 	return purchases, err
 }
 
+// GetLibrarySongs returns the songs the user paid for, leaving out those that
+// can't be played in country now
+func (r *SongPurchaseRepository) GetLibrarySongs(userID uuid.UUID, country string) ([]models.Song, error) {
+	var songs []models.Song
+	db := r.DB.
+		Preload("Artist").
+		Where("songs.id IN (?)", r.DB.Model(&models.SongPurchase{}).
+			Select("song_id").
+			Where("user_id = ? AND payment_status = ?", userID, models.PaymentCompleted))
+	err := availableIn(db, songAvailable, country).Find(&songs).Error
+	return songs, err
+}
+
 func (r *SongPurchaseRepository) HasPurchasedSong(userID, songID uuid.UUID) (bool, error) {
 	var count int64
 	err := r.DB.Model(&models.SongPurchase{}).
-		Where("user_id = ? AND song_id = ? payment_status = completed", userID, songID).
+		Where("user_id = ? AND song_id = ? AND payment_status = ?", userID, songID, models.PaymentCompleted).
 		Count(&count).
 		Error
 	return count > 0, err
@@ -2,10 +2,16 @@ package repositories
 
 import (
 	"crawl/models"
+	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
+	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type StreamRepository struct {
@@ -18,30 +24,530 @@ func NewStreamRepository(db *gorm.DB) IStreamRepository {
 	}
 }
 
+// countedStreams keeps to the streams that count as plays
+func countedStreams(db *gorm.DB) *gorm.DB {
+	return db.Where("streams.status = ?", models.StreamQualified)
+}
+
+// rollupWindow splits a read of the streams from since up to until between the
+// daily rollup tables, for the whole days rolled up from the one holding since,
+// and raw streams for the rest: normally just today, plus any day before or
+// between the rolled up ones.
+type rollupWindow struct {
+	since  time.Time
+	until  time.Time
+	rolled []time.Time
+	raw    []timeRange
+}
+
+// windowSince covers since up to the end of today
+func (r *StreamRepository) windowSince(since time.Time) (rollupWindow, error) {
+	return r.windowBetween(since, UTCDay(time.Now()).AddDate(0, 0, 1))
+}
+
+// windowBetween covers since up to until, which must start a UTC day
+func (r *StreamRepository) windowBetween(since, until time.Time) (rollupWindow, error) {
+	rolled, err := rolledUpDaysBetween(r.DB, since, until)
+	if err != nil {
+		return rollupWindow{}, err
+	}
+	return rollupWindow{
+		since:  since,
+		until:  until,
+		rolled: rolled,
+		raw:    rawRanges(since, until, rolled),
+	}, nil
+}
+
+// args names the window's bounds for queries: days go as text, cast to date in
+// the query, so the session time zone can't shift them
+func (w rollupWindow) args(extra ...interface{}) []interface{} {
+	days := make([]string, len(w.rolled))
+	for i, day := range w.rolled {
+		days[i] = day.Format("2006-01-02")
+	}
+	args := []interface{}{
+		sql.Named("rolled_days", "{"+strings.Join(days, ",")+"}"),
+		sql.Named("qualified", models.StreamQualified),
+	}
+	for i, raw := range w.raw {
+		args = append(args, sql.Named(fmt.Sprintf("raw_start_%d", i), raw.start), sql.Named(fmt.Sprintf("raw_end_%d", i), raw.end))
+	}
+	return append(args, extra...)
+}
+
+// rawWindow restricts streams to the parts of the window that aren't rolled up
+func (w rollupWindow) rawWindow() string {
+	if len(w.raw) == 0 {
+		return "FALSE"
+	}
+	ranges := make([]string, len(w.raw))
+	for i := range w.raw {
+		ranges[i] = fmt.Sprintf("(streams.created_at >= @raw_start_%d AND streams.created_at < @raw_end_%d)", i, i)
+	}
+	return "(" + strings.Join(ranges, " OR ") + ") AND streams.deleted_at IS NULL"
+}
+
+// rawStreams is rawWindow kept to the streams that count as plays
+func (w rollupWindow) rawStreams() string {
+	return w.rawWindow() + " AND streams.status = @qualified"
+}
+
+// rolledUpDays restricts the daily tables to the window's rolled up days
+const (
+	rolledUpDays = "day = ANY(CAST(@rolled_days AS date[]))"
+	artistSongs  = "song_id IN (SELECT id FROM songs WHERE artist_id = @artist)"
+	rawDay       = "CAST(timezone('UTC', streams.created_at) AS date)"
+)
+
+// periodOf is the start of the @unit ('day', 'week' or 'month') holding a date
+func periodOf(day string) string {
+	return "CAST(date_trunc(@unit, CAST(" + day + " AS timestamp)) AS date)"
+}
+
+// GetStreamCount counts a song's qualified streams since the start of the UTC day holding since
 func (r *StreamRepository) GetStreamCount(songID uuid.UUID, since time.Time) (int64, error) {
+	window, err := r.windowSince(since)
+	if err != nil {
+		return 0, err
+	}
+
 	var count int64
-	err := r.DB.Model(&models.Stream{}).
-		Where("song_id = ? AND created_at >= ?", songID, since).
-		Count(&count).
+	err = r.DB.Raw(`SELECT
+		(SELECT COALESCE(SUM(streams), 0) FROM song_daily_stats WHERE song_id = @song AND `+rolledUpDays+`)
+		+ (SELECT COUNT(*) FROM streams WHERE streams.song_id = @song AND `+window.rawStreams()+`)`,
+		window.args(sql.Named("song", songID))...).
+		Scan(&count).
 		Error
 	return count, err
 }
 
-func (r *StreamRepository) GetArtistStreams(artistID uuid.UUID, start, end time.Time) ([]models.Stream, error) {
-	var streams []models.Stream
-	err := r.DB.
-		Joins("JOIN songs ON streams.song_id = songs.id").
-		Where("songs.artist_id = ? AND streams.created_at BETWEEN ? AND ?", artistID, start, end).
-		Find(&streams).
+// GetArtistDailyStreams counts the artist's qualified streams per UTC day from start to end
+func (r *StreamRepository) GetArtistDailyStreams(artistID uuid.UUID, start, end time.Time) ([]models.DailyStreamCount, error) {
+	window, err := r.windowSince(start)
+	if err != nil {
+		return nil, err
+	}
+
+	days := []models.DailyStreamCount{}
+	err = r.DB.Raw(`SELECT day, SUM(streams) AS streams FROM (
+			SELECT day, streams FROM song_daily_stats
+			WHERE `+artistSongs+` AND `+rolledUpDays+` AND day <= CAST(@last_day AS date)
+			UNION ALL
+			SELECT `+rawDay+` AS day, COUNT(*) AS streams FROM streams
+			WHERE streams.`+artistSongs+` AND `+window.rawStreams()+` AND streams.created_at <= @end
+			GROUP BY 1
+		) daily
+		GROUP BY day
+		ORDER BY day`,
+		window.args(
+			sql.Named("artist", artistID),
+			sql.Named("last_day", end.UTC().Format("2006-01-02")),
+			sql.Named("end", end),
+		)...).
+		Scan(&days).
 		Error
-	return streams, err
+	return days, err
 }
 
 func (r *StreamRepository) GetStreamBySong(songID uuid.UUID) (*models.Stream, error) {
 	var stream *models.Stream
 	err := r.DB.Model(&models.Stream{}).
+		Scopes(countedStreams).
 		Where("song_id = ?", songID).
 		Find(&stream).
 		Error
 	return stream, err
 }
+
+// GetArtistStreamStats counts the artist's qualified streams and distinct
+// listeners from the start of the UTC day holding from up to until
+func (r *StreamRepository) GetArtistStreamStats(artistID uuid.UUID, from, until time.Time) (streams int64, listeners int64, err error) {
+	window, err := r.windowBetween(from, until)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var stats struct {
+		Streams   int64
+		Listeners int64
+	}
+	err = r.DB.Raw(`SELECT
+			(SELECT COALESCE(SUM(streams), 0) FROM song_daily_stats WHERE `+artistSongs+` AND `+rolledUpDays+`)
+			+ (SELECT COUNT(*) FROM streams WHERE streams.`+artistSongs+` AND `+window.rawStreams()+`) AS streams,
+			(SELECT COUNT(DISTINCT user_id) FROM (
+				SELECT user_id FROM artist_daily_listeners WHERE artist_id = @artist AND `+rolledUpDays+`
+				UNION ALL
+				SELECT streams.user_id FROM streams WHERE streams.`+artistSongs+` AND `+window.rawStreams()+`
+			) listeners) AS listeners`,
+		window.args(sql.Named("artist", artistID))...).
+		Scan(&stats).
+		Error
+	return stats.Streams, stats.Listeners, err
+}
+
+// GetArtistStreamSeries counts the artist's qualified streams and distinct
+// listeners in each unit ('day', 'week' or 'month') from from up to until.
+// Periods without streams are left out.
+func (r *StreamRepository) GetArtistStreamSeries(artistID uuid.UUID, from, until time.Time, unit string) ([]models.PeriodStreams, error) {
+	window, err := r.windowBetween(from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	series := []models.PeriodStreams{}
+	err = r.DB.Raw(`SELECT counts.period, counts.streams, COALESCE(listeners.listeners, 0) AS listeners FROM (
+			SELECT `+periodOf("day")+` AS period, SUM(streams) AS streams FROM (
+				SELECT day, streams FROM song_daily_stats WHERE `+artistSongs+` AND `+rolledUpDays+`
+				UNION ALL
+				SELECT `+rawDay+`, COUNT(*) FROM streams WHERE streams.`+artistSongs+` AND `+window.rawStreams()+` GROUP BY 1
+			) daily
+			GROUP BY 1
+		) counts
+		LEFT JOIN (
+			SELECT `+periodOf("day")+` AS period, COUNT(DISTINCT user_id) AS listeners FROM (
+				SELECT day, user_id FROM artist_daily_listeners WHERE artist_id = @artist AND `+rolledUpDays+`
+				UNION ALL
+				SELECT `+rawDay+`, streams.user_id FROM streams WHERE streams.`+artistSongs+` AND `+window.rawStreams()+`
+			) daily
+			GROUP BY 1
+		) listeners ON listeners.period = counts.period
+		WHERE counts.streams > 0
+		ORDER BY counts.period`,
+		window.args(sql.Named("artist", artistID), sql.Named("unit", unit))...).
+		Scan(&series).
+		Error
+	return series, err
+}
+
+// streamShareSources are the daily tables and raw stream columns the artist's
+// streams can be broken down by
+var streamShareSources = map[string]struct {
+	table  string
+	column string
+	raw    string
+}{
+	"country": {"country_daily_stats", "country_code", "COALESCE(upper(streams.country_code), '')"},
+	"device":  {"device_daily_stats", "device_type", "COALESCE(streams.device_type, '')"},
+}
+
+// GetArtistStreamShares breaks the artist's qualified streams from from up to
+// until down by "country" or "device", most streamed first. Streams from an
+// unknown country or device are counted under the empty value.
+func (r *StreamRepository) GetArtistStreamShares(artistID uuid.UUID, from, until time.Time, by string) ([]models.StreamShare, error) {
+	source, ok := streamShareSources[by]
+	if !ok {
+		return nil, errors.New("streams can only be broken down by country or device")
+	}
+	window, err := r.windowBetween(from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := []models.StreamShare{}
+	err = r.DB.Raw(`SELECT value, SUM(streams) AS streams FROM (
+			SELECT `+source.column+` AS value, streams FROM `+source.table+` WHERE `+artistSongs+` AND `+rolledUpDays+`
+			UNION ALL
+			SELECT `+source.raw+`, COUNT(*) FROM streams WHERE streams.`+artistSongs+` AND `+window.rawStreams()+` GROUP BY 1
+		) counts
+		GROUP BY value
+		HAVING SUM(streams) > 0
+		ORDER BY streams DESC, value`,
+		window.args(sql.Named("artist", artistID))...).
+		Scan(&shares).
+		Error
+	return shares, err
+}
+
+// GetArtistSongStreams counts the qualified streams of each of the artist's
+// songs from from up to until, most streamed first, leaving out unstreamed songs
+func (r *StreamRepository) GetArtistSongStreams(artistID uuid.UUID, from, until time.Time) ([]models.SongStreamCount, error) {
+	window, err := r.windowBetween(from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	songs := []models.SongStreamCount{}
+	err = r.DB.Raw(`SELECT songs.id AS song_id, songs.title, SUM(counts.streams) AS streams FROM (
+			SELECT song_id, streams FROM song_daily_stats WHERE `+artistSongs+` AND `+rolledUpDays+`
+			UNION ALL
+			SELECT song_id, COUNT(*) FROM streams WHERE streams.`+artistSongs+` AND `+window.rawStreams()+` GROUP BY song_id
+		) counts
+		JOIN songs ON songs.id = counts.song_id
+		GROUP BY songs.id, songs.title
+		HAVING SUM(counts.streams) > 0
+		ORDER BY streams DESC`,
+		window.args(sql.Named("artist", artistID))...).
+		Scan(&songs).
+		Error
+	return songs, err
+}
+
+// GetArtistListenStats reports how far listeners got into each of the artist's
+// songs from from up to until, over tracked plays that counted and the skips
+// that didn't
+func (r *StreamRepository) GetArtistListenStats(artistID uuid.UUID, from, until time.Time) ([]models.SongListenStats, error) {
+	window, err := r.windowBetween(from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := []models.SongListenStats{}
+	err = r.DB.Raw(`SELECT songs.id AS song_id, songs.title,
+			SUM(listens.listens) AS listens,
+			SUM(listens.completions) AS completions,
+			SUM(listens.skips) AS skips,
+			SUM(listens.listened_seconds)::float / SUM(listens.listens) AS average_listened
+		FROM (
+			SELECT song_id, listens, completions, skips, listened_seconds FROM song_daily_stats
+			WHERE `+artistSongs+` AND `+rolledUpDays+` AND listens > 0
+			UNION ALL
+			SELECT song_id, COUNT(*), COUNT(*) FILTER (WHERE completed), COUNT(*) FILTER (WHERE skipped), SUM(listened_seconds)
+			FROM streams
+			WHERE streams.`+artistSongs+` AND `+window.rawWindow()+`
+				AND streams.tracked AND (streams.status = @qualified OR streams.skipped)
+			GROUP BY song_id
+		) listens
+		JOIN songs ON songs.id = listens.song_id
+		GROUP BY songs.id, songs.title
+		ORDER BY listens DESC`,
+		window.args(sql.Named("artist", artistID))...).
+		Scan(&stats).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range stats {
+		stats[i].CompletionRate = float64(stats[i].Completions) / float64(stats[i].Listens)
+		stats[i].SkipRate = float64(stats[i].Skips) / float64(stats[i].Listens)
+	}
+	return stats, nil
+}
+
+// CreateBatch stores the streams not stored yet and adds the qualified ones to
+// their songs' play counts, with one update per song, in a single transaction. Streams are
+// matched by id, so a retried or replayed batch is never counted twice.
+func (r *StreamRepository) CreateBatch(streams []models.Stream) error {
+	if len(streams) == 0 {
+		return nil
+	}
+
+	return Transaction(r.DB, func(tx *gorm.DB) error {
+		ids := make([]uuid.UUID, len(streams))
+		for i, stream := range streams {
+			ids[i] = stream.ID
+		}
+		var stored []uuid.UUID
+		if err := tx.Unscoped().Model(&models.Stream{}).Where("id IN ?", ids).Pluck("id", &stored).Error; err != nil {
+			return err
+		}
+
+		seen := make(map[uuid.UUID]bool, len(streams))
+		for _, id := range stored {
+			seen[id] = true
+		}
+		fresh := make([]models.Stream, 0, len(streams))
+		plays := map[uuid.UUID]int{}
+		for _, stream := range streams {
+			if seen[stream.ID] {
+				continue
+			}
+			seen[stream.ID] = true
+			fresh = append(fresh, stream)
+			if stream.Status == models.StreamQualified {
+				plays[stream.SongID]++
+			}
+		}
+		if len(fresh) == 0 {
+			return nil
+		}
+
+		if err := tx.Omit(clause.Associations).CreateInBatches(&fresh, 500).Error; err != nil {
+			return err
+		}
+
+		// Lock song rows in a fixed order so concurrent writers can't deadlock
+		songIDs := make([]uuid.UUID, 0, len(plays))
+		for songID := range plays {
+			songIDs = append(songIDs, songID)
+		}
+		sort.Slice(songIDs, func(i, j int) bool {
+			return songIDs[i].String() < songIDs[j].String()
+		})
+		for _, songID := range songIDs {
+			err := tx.Model(&models.Song{}).
+				Where("id = ?", songID).
+				UpdateColumn("plays_count", gorm.Expr("plays_count + ?", plays[songID])).
+				Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListenerSongPlays sums the plays since since of every listener in userIDs
+// streaming any of songIDs. Invalid streams aren't plays.
+func (r *StreamRepository) ListenerSongPlays(userIDs, songIDs []uuid.UUID, since time.Time) ([]models.ListenerSongPlays, error) {
+	plays := []models.ListenerSongPlays{}
+	if len(userIDs) == 0 || len(songIDs) == 0 {
+		return plays, nil
+	}
+	err := r.DB.Model(&models.Stream{}).
+		Select(`user_id, song_id, COUNT(*) AS plays,
+			MAX(created_at) FILTER (WHERE status IN ?) AS last_counted_at`,
+			[]string{models.StreamQualified, models.StreamSuspicious}).
+		Where("user_id IN ? AND song_id IN ? AND created_at >= ? AND status <> ?", userIDs, songIDs, since, models.StreamInvalid).
+		Group("user_id, song_id").
+		Scan(&plays).
+		Error
+	return plays, err
+}
+
+// CountPlaysBy counts the plays since since for each value of column, which is
+// "client_ip" or "device_id"
+func (r *StreamRepository) CountPlaysBy(column string, values []string, since time.Time) (map[string]int64, error) {
+	counts := make(map[string]int64, len(values))
+	if len(values) == 0 {
+		return counts, nil
+	}
+	if column != "client_ip" && column != "device_id" {
+		return nil, errors.New("plays can only be counted by client_ip or device_id")
+	}
+
+	var rows []struct {
+		Value string
+		Plays int64
+	}
+	err := r.DB.Model(&models.Stream{}).
+		Select(column+" AS value, COUNT(*) AS plays").
+		Where(column+" IN ? AND created_at >= ? AND status <> ?", values, since, models.StreamInvalid).
+		Group(column).
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.Value] = row.Plays
+	}
+	return counts, nil
+}
+
+// LastPlays returns when each listener in userIDs last reported a stream since
+// since, previews aside, whether or not it counted as a play
+func (r *StreamRepository) LastPlays(userIDs []uuid.UUID, since time.Time) (map[uuid.UUID]time.Time, error) {
+	last := make(map[uuid.UUID]time.Time, len(userIDs))
+	if len(userIDs) == 0 {
+		return last, nil
+	}
+
+	var rows []struct {
+		UserID   uuid.UUID
+		PlayedAt time.Time
+	}
+	err := r.DB.Model(&models.Stream{}).
+		Select("user_id, MAX(created_at) AS played_at").
+		Where("user_id IN ? AND created_at >= ? AND NOT is_preview", userIDs, since).
+		Group("user_id").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		last[row.UserID] = row.PlayedAt
+	}
+	return last, nil
+}
+
+// InactiveListeners returns the users in userIDs who have never paid for a purchase or tip,
+// favorited or made a playlist and who have streamed at most one artist since since
+func (r *StreamRepository) InactiveListeners(userIDs []uuid.UUID, since time.Time) ([]uuid.UUID, error) {
+	inactive := []uuid.UUID{}
+	if len(userIDs) == 0 {
+		return inactive, nil
+	}
+	err := r.DB.Model(&models.User{}).
+		Where("users.id IN ?", userIDs).
+		Where("NOT EXISTS (SELECT 1 FROM song_purchases WHERE song_purchases.user_id = users.id AND song_purchases.payment_status = ? AND song_purchases.deleted_at IS NULL)", models.PaymentCompleted).
+		Where("NOT EXISTS (SELECT 1 FROM album_purchases WHERE album_purchases.user_id = users.id AND album_purchases.payment_status = ? AND album_purchases.deleted_at IS NULL)", models.PaymentCompleted).
+		Where("NOT EXISTS (SELECT 1 FROM artist_tips WHERE artist_tips.sender_id = users.id AND artist_tips.payment_status = ? AND artist_tips.deleted_at IS NULL)", models.PaymentCompleted).
+		Where("NOT EXISTS (SELECT 1 FROM user_favorites WHERE user_favorites.user_id = users.id AND user_favorites.deleted_at IS NULL)").
+		Where("NOT EXISTS (SELECT 1 FROM playlists WHERE playlists.user_id = users.id AND playlists.deleted_at IS NULL)").
+		Where(`(SELECT COUNT(DISTINCT songs.artist_id) FROM streams JOIN songs ON songs.id = streams.song_id
+			WHERE streams.user_id = users.id AND streams.created_at >= ? AND streams.deleted_at IS NULL) <= 1`, since).
+		Pluck("users.id", &inactive).
+		Error
+	return inactive, err
+}
+
+// HistoryPausedListeners returns the users in userIDs who paused their listening history
+func (r *StreamRepository) HistoryPausedListeners(userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	paused := []uuid.UUID{}
+	if len(userIDs) == 0 {
+		return paused, nil
+	}
+	err := r.DB.Model(&models.User{}).
+		Where("id IN ? AND history_paused", userIDs).
+		Pluck("id", &paused).
+		Error
+	return paused, err
+}
+
+// GetSuspicious lists the streams held back for review, highest fraud score first
+func (r *StreamRepository) GetSuspicious(offset, limit int) ([]models.Stream, error) {
+	var streams []models.Stream
+	db := r.DB.
+		Preload("Song").
+		Preload("User").
+		Where("status = ?", models.StreamSuspicious).
+		Order("fraud_score DESC, created_at ASC")
+
+	if limit > 0 {
+		db = db.Offset(offset).Limit(limit)
+	}
+
+	err := db.Find(&streams).Error
+	return streams, err
+}
+
+// Review settles a suspicious stream as qualified or rejected; a qualified
+// stream is added to its song's play count. It reports false when the stream
+// was no longer awaiting review.
+func (r *StreamRepository) Review(id uuid.UUID, status string, reviewerID uuid.UUID) (bool, error) {
+	reviewed := false
+	err := Transaction(r.DB, func(tx *gorm.DB) error {
+		var stream models.Stream
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ?", id, models.StreamSuspicious).
+			First(&stream).
+			Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&stream).Updates(map[string]interface{}{
+			"status":      status,
+			"reviewer_id": reviewerID,
+			"reviewed_at": time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		reviewed = true
+
+		if status != models.StreamQualified {
+			return nil
+		}
+		return tx.Model(&models.Song{}).
+			Where("id = ?", stream.SongID).
+			UpdateColumn("plays_count", gorm.Expr("plays_count + 1")).
+			Error
+	})
+	return reviewed, err
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"crawl/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TagRepository struct {
+	BaseRepository[models.Tag]
+}
+
+func NewTagRepository(db *gorm.DB) ITagRepository {
+	return &TagRepository{
+		BaseRepository: BaseRepository[models.Tag]{DB: db},
+	}
+}
+
+// taggedWith keeps the rows of table carrying every one of the tag slugs
+func taggedWith(db *gorm.DB, table, joinTable, joinColumn string, slugs []string) *gorm.DB {
+	return db.Where(
+		table+".id IN (SELECT "+joinTable+"."+joinColumn+" FROM "+joinTable+
+			" JOIN tags ON tags.id = "+joinTable+".tag_id WHERE tags.slug IN ?"+
+			" GROUP BY "+joinTable+"."+joinColumn+" HAVING COUNT(DISTINCT tags.slug) = ?)",
+		slugs, len(slugs),
+	)
+}
+
+func (r *TagRepository) FindOrCreate(tag *models.Tag) (*models.Tag, error) {
+	var existing models.Tag
+	err := r.DB.
+		Where(models.Tag{Kind: tag.Kind, Slug: tag.Slug}).
+		Attrs(models.Tag{Name: tag.Name}).
+		FirstOrCreate(&existing).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+func (r *TagRepository) GetByKind(kind *string) ([]models.Tag, error) {
+	var tags []models.Tag
+	db := r.DB.Order("kind ASC, slug ASC")
+	if kind != nil && *kind != "" {
+		db = db.Where("kind = ?", *kind)
+	}
+	err := db.Find(&tags).Error
+	return tags, err
+}
+
+func (r *TagRepository) GetSongTags(songID uuid.UUID) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.DB.
+		Joins("JOIN song_tags ON song_tags.tag_id = tags.id").
+		Where("song_tags.song_id = ?", songID).
+		Order("tags.kind ASC, tags.slug ASC").
+		Find(&tags).
+		Error
+	return tags, err
+}
+
+func (r *TagRepository) GetAlbumTags(albumID uuid.UUID) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.DB.
+		Joins("JOIN album_tags ON album_tags.tag_id = tags.id").
+		Where("album_tags.album_id = ?", albumID).
+		Order("tags.kind ASC, tags.slug ASC").
+		Find(&tags).
+		Error
+	return tags, err
+}
+
+func (r *TagRepository) ReplaceSongTags(songID uuid.UUID, tagIDs []uuid.UUID) error {
+	return r.replaceTags("song_tags", "song_id", songID, tagIDs)
+}
+
+func (r *TagRepository) ReplaceAlbumTags(albumID uuid.UUID, tagIDs []uuid.UUID) error {
+	return r.replaceTags("album_tags", "album_id", albumID, tagIDs)
+}
+
+func (r *TagRepository) replaceTags(joinTable, ownerColumn string, ownerID uuid.UUID, tagIDs []uuid.UUID) error {
+	return Transaction(r.DB, func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM "+joinTable+" WHERE "+ownerColumn+" = ?", ownerID).Error; err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		rows := make([]map[string]interface{}, 0, len(tagIDs))
+		for _, tagID := range tagIDs {
+			rows = append(rows, map[string]interface{}{ownerColumn: ownerID, "tag_id": tagID})
+		}
+		return tx.Table(joinTable).Create(rows).Error
+	})
+}
+
+// Delete removes the tag permanently along with its assignments so the
+// slug can be reused
+func (r *TagRepository) Delete(id uuid.UUID) error {
+	return Transaction(r.DB, func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM song_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM album_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Delete(&models.Tag{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *TagRepository) GetBySlug(kind, slug string) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.DB.Where("kind = ? AND slug = ?", kind, slug).First(&tag).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &tag, err
+}
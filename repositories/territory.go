@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"crawl/models"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// territoryAvailable is the condition under which the rules of one level, song
+// or album, matched by owner let an item be played in @country at @at: none of
+// the rules in force denies the country, and if any allow a country, one of
+// them allows this one. An unknown country, "", is only allowed when no rule is
+// in force: it could be any of the denied ones.
+func territoryAvailable(owner string) string {
+	inForce := `territory_rules.deleted_at IS NULL AND ` + owner + `
+		AND (territory_rules.starts_at IS NULL OR territory_rules.starts_at <= @at)
+		AND (territory_rules.ends_at IS NULL OR territory_rules.ends_at > @at)`
+	return `NOT EXISTS (
+			SELECT 1 FROM territory_rules WHERE ` + inForce + `
+				AND territory_rules.mode = @deny AND (territory_rules.country_code = @country OR @country = '')
+		) AND (
+			NOT EXISTS (SELECT 1 FROM territory_rules WHERE ` + inForce + ` AND territory_rules.mode = @allow)
+			OR EXISTS (
+				SELECT 1 FROM territory_rules WHERE ` + inForce + `
+					AND territory_rules.mode = @allow AND territory_rules.country_code = @country
+			)
+		)`
+}
+
+var (
+	songAvailable = territoryAvailable("territory_rules.song_id = songs.id") + ` AND (songs.album_id IS NULL OR ` +
+		territoryAvailable("territory_rules.album_id = songs.album_id") + `)`
+	albumAvailable = territoryAvailable("territory_rules.album_id = albums.id")
+)
+
+type TerritoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewTerritoryRepository(db *gorm.DB) ITerritoryRepository {
+	return &TerritoryRepository{DB: db}
+}
+
+func territoryArgs(ids []uuid.UUID, country string, at time.Time, extra ...interface{}) []interface{} {
+	return append([]interface{}{
+		sql.Named("ids", ids),
+		sql.Named("country", country),
+		sql.Named("at", at),
+		sql.Named("allow", models.TerritoryAllow),
+		sql.Named("deny", models.TerritoryDeny),
+	}, extra...)
+}
+
+// availableIn leaves out of a query the rows that can't be played in country
+// now, by the condition available, songAvailable or albumAvailable
+func availableIn(db *gorm.DB, available, country string) *gorm.DB {
+	return db.Where(available, territoryArgs(nil, country, time.Now())...)
+}
+
+// withAvailableSong leaves out of a query the rows whose column holds a song
+// that can't be played in country now
+func withAvailableSong(db *gorm.DB, column, country string) *gorm.DB {
+	return availableIn(db, column+" IN (SELECT songs.id FROM songs WHERE "+songAvailable+")", country)
+}
+
+// UnavailableSongs returns the songs in songIDs that can't be played in country
+// at at, by their own rules or their album's
+func (r *TerritoryRepository) UnavailableSongs(songIDs []uuid.UUID, country string, at time.Time) ([]uuid.UUID, error) {
+	unavailable := []uuid.UUID{}
+	if len(songIDs) == 0 {
+		return unavailable, nil
+	}
+	err := r.DB.Raw(`SELECT songs.id FROM songs WHERE songs.id IN @ids AND NOT (`+songAvailable+`)`,
+		territoryArgs(songIDs, country, at)...).
+		Scan(&unavailable).
+		Error
+	return unavailable, err
+}
+
+// UnavailableAlbums returns the albums in albumIDs that can't be played in country at at
+func (r *TerritoryRepository) UnavailableAlbums(albumIDs []uuid.UUID, country string, at time.Time) ([]uuid.UUID, error) {
+	unavailable := []uuid.UUID{}
+	if len(albumIDs) == 0 {
+		return unavailable, nil
+	}
+	err := r.DB.Raw(`SELECT albums.id FROM albums WHERE albums.id IN @ids AND NOT (`+albumAvailable+`)`,
+		territoryArgs(albumIDs, country, at)...).
+		Scan(&unavailable).
+		Error
+	return unavailable, err
+}
+
+// CreateRules stores rules together
+func (r *TerritoryRepository) CreateRules(rules []models.TerritoryRule) error {
+	return r.DB.Create(&rules).Error
+}
+
+// GetRules lists the rules of a song or an album, by country
+func (r *TerritoryRepository) GetRules(songID, albumID *uuid.UUID) ([]models.TerritoryRule, error) {
+	rules := []models.TerritoryRule{}
+	db := r.DB
+	if songID != nil {
+		db = db.Where("song_id = ?", *songID)
+	}
+	if albumID != nil {
+		db = db.Where("album_id = ?", *albumID)
+	}
+	err := db.Order("country_code, mode, starts_at").Find(&rules).Error
+	return rules, err
+}
+
+func (r *TerritoryRepository) GetRule(id uuid.UUID) (*models.TerritoryRule, error) {
+	var rule models.TerritoryRule
+	err := r.DB.First(&rule, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &rule, err
+}
+
+func (r *TerritoryRepository) DeleteRule(id uuid.UUID) error {
+	return r.DB.Delete(&models.TerritoryRule{}, "id = ?", id).Error
+}
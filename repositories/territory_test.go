@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestUnavailableSongsDeniesUnknownCountries(t *testing.T) {
+	tests := []struct {
+		name    string
+		country string
+		want    string
+	}{
+		{name: "resolved country", country: "NG", want: "territory_rules.country_code = 'NG' OR 'NG' = ''"},
+		{name: "unknown country", country: "", want: "territory_rules.country_code = '' OR '' = ''"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := dryRunDB(t)
+			_, err := NewTerritoryRepository(db).UnavailableSongs([]uuid.UUID{uuid.New()}, tt.country, time.Now())
+			if err != nil && !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+				t.Fatalf("UnavailableSongs: %v", err)
+			}
+			if len(*statements) != 1 {
+				t.Fatalf("got %d queries, want 1", len(*statements))
+			}
+			// The song's and its album's deny rules both match
+			if got := strings.Count((*statements)[0], "territory_rules.mode = 'deny' AND ("+tt.want+")"); got != 2 {
+				t.Errorf("deny rules match %q %d times, want 2, in %q", tt.want, got, (*statements)[0])
+			}
+		})
+	}
+}
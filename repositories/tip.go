@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"crawl/models"
+	"errors"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"time"
 )
 
 type TipRepository struct {
@@ -16,10 +18,11 @@ func NewTipRepository(db *gorm.DB) ITipRepository {
 	}
 }
 
+// GetArtistTips returns the tips the artist has been paid, latest first
 func (r *TipRepository) GetArtistTips(artistID uuid.UUID) ([]models.ArtistTip, error) {
 	var tips []models.ArtistTip
 	err := r.DB.
-		Where("artist_id = ?", artistID).
+		Where("artist_id = ? AND payment_status = ?", artistID, models.PaymentCompleted).
 		Order("created_at DESC").
 		Find(&tips).Error
 	return tips, err
@@ -37,16 +40,32 @@ func (r *TipRepository) GetUserTips(userID uuid.UUID) ([]models.ArtistTip, error
 func (r *TipRepository) GetTotalTipsReceived(artistID uuid.UUID) (int64, error) {
 	var total int64
 	err := r.DB.Model(&models.ArtistTip{}).
-		Where("artist_id = ?", artistID).
+		Where("artist_id = ? AND payment_status = ?", artistID, models.PaymentCompleted).
 		Select("COALESCE(SUM(amount), 0)").
 		Scan(&total).Error
 	return total, err
 }
 
+// FindPending finds the sender's latest pending tip to the artist of amount
+// with message, sent since since, or nil when there is none
+func (r *TipRepository) FindPending(senderID, artistID uuid.UUID, amount int, message string, since time.Time) (*models.ArtistTip, error) {
+	var tip models.ArtistTip
+	err := r.DB.
+		Where("sender_id = ? AND artist_id = ? AND amount = ? AND message = ? AND payment_status = ? AND created_at >= ?",
+			senderID, artistID, amount, message, models.PaymentPending, since).
+		Order("created_at DESC").
+		First(&tip).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &tip, err
+}
+
 func (r *TipRepository) GetTotalTipsSent(userID uuid.UUID) (int64, error) {
 	var total int64
 	err := r.DB.Model(&models.ArtistTip{}).
-		Where("sender_id = ?", userID).
+		Where("sender_id = ? AND payment_status = ?", userID, models.PaymentCompleted).
 		Select("COALESCE(SUM(amount), 0)").
 		Scan(&total).Error
 	return total, err
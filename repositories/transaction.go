@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// openTransactions holds the commit hooks of the transactions Transaction has
+// open, by the connection their statements run on
+var openTransactions sync.Map
+
+type commitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+// Transaction runs fn in a transaction on db like db.Transaction does, then,
+// once it has committed, the hooks its statements registered with AfterCommit.
+// Called inside another Transaction, it joins the outer one, whose commit runs
+// the hooks.
+func Transaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	pending := &commitHooks{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if _, nested := openTransactions.Load(tx.Statement.ConnPool); nested {
+			return fn(tx)
+		}
+		openTransactions.Store(tx.Statement.ConnPool, pending)
+		defer openTransactions.Delete(tx.Statement.ConnPool)
+		return fn(tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range pending.hooks {
+		hook()
+	}
+	return nil
+}
+
+// AfterCommit runs hook once the changes made through db are committed: at once
+// outside a transaction, or when the Transaction db is in commits. Inside a
+// transaction opened some other way it can't tell when that is, and reports
+// false without running hook.
+func AfterCommit(db *gorm.DB, hook func()) bool {
+	if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); !inTransaction {
+		hook()
+		return true
+	}
+
+	value, ok := openTransactions.Load(db.Statement.ConnPool)
+	if !ok {
+		return false
+	}
+	pending := value.(*commitHooks)
+	pending.mu.Lock()
+	pending.hooks = append(pending.hooks, hook)
+	pending.mu.Unlock()
+	return true
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakePool is a connection that runs nothing and opens transactions that only
+// note when they commit or roll back
+type fakePool struct {
+	events *[]string
+}
+
+func (fakePool) PrepareContext(context.Context, string) (*sql.Stmt, error) { return nil, nil }
+
+func (fakePool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func (fakePool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, nil
+}
+
+func (fakePool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row { return nil }
+
+func (p fakePool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return &fakeTx{p}, nil
+}
+
+type fakeTx struct{ fakePool }
+
+func (tx *fakeTx) Commit() error {
+	*tx.events = append(*tx.events, "commit")
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	*tx.events = append(*tx.events, "rollback")
+	return nil
+}
+
+func TestTransactionAfterCommit(t *testing.T) {
+	failed := errors.New("failed")
+
+	tests := []struct {
+		name string
+		// run makes its changes, naming them with change
+		run  func(db *gorm.DB, change func(tx *gorm.DB, name string)) error
+		want []string
+	}{
+		{
+			name: "hooks run after commit, in order",
+			run: func(db *gorm.DB, change func(*gorm.DB, string)) error {
+				return Transaction(db, func(tx *gorm.DB) error {
+					change(tx, "first")
+					change(tx, "second")
+					return nil
+				})
+			},
+			want: []string{"commit", "first", "second"},
+		},
+		{
+			name: "rollback drops hooks",
+			run: func(db *gorm.DB, change func(*gorm.DB, string)) error {
+				return Transaction(db, func(tx *gorm.DB) error {
+					change(tx, "first")
+					return failed
+				})
+			},
+			want: []string{"rollback"},
+		},
+		{
+			name: "nested transaction waits for the outer commit",
+			run: func(db *gorm.DB, change func(*gorm.DB, string)) error {
+				return Transaction(db, func(tx *gorm.DB) error {
+					err := Transaction(tx, func(tx *gorm.DB) error {
+						change(tx, "inner")
+						return nil
+					})
+					change(tx, "outer")
+					return err
+				})
+			},
+			want: []string{"commit", "inner", "outer"},
+		},
+		{
+			name: "outside a transaction",
+			run: func(db *gorm.DB, change func(*gorm.DB, string)) error {
+				change(db, "now")
+				return nil
+			},
+			want: []string{"now"},
+		},
+		{
+			name: "transaction opened some other way",
+			run: func(db *gorm.DB, change func(*gorm.DB, string)) error {
+				return db.Transaction(func(tx *gorm.DB) error {
+					change(tx, "unseen")
+					return nil
+				})
+			},
+			want: []string{"unseen not queued", "commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+				DisableAutomaticPing: true,
+				Logger:               logger.Discard,
+			})
+			if err != nil {
+				t.Fatalf("open: %v", err)
+			}
+			var events []string
+			db.ConnPool = fakePool{&events}
+			db.Statement.ConnPool = db.ConnPool
+
+			change := func(tx *gorm.DB, name string) {
+				queued := AfterCommit(tx, func() { events = append(events, name) })
+				if !queued {
+					events = append(events, name+" not queued")
+				}
+			}
+			if err := tt.run(db, change); err != nil && !errors.Is(err, failed) {
+				t.Fatalf("run: %v", err)
+			}
+			if !reflect.DeepEqual(events, tt.want) {
+				t.Errorf("got %q, want %q", events, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"crawl/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type VerificationRepository struct {
+	BaseRepository[models.VerificationRequest]
+}
+
+func NewVerificationRepository(db *gorm.DB) IVerificationRepository {
+	return &VerificationRepository{
+		BaseRepository: BaseRepository[models.VerificationRequest]{DB: db},
+	}
+}
+
+func (r *VerificationRepository) withDetails(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Evidence", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") })
+}
+
+func (r *VerificationRepository) GetWithDetails(id uuid.UUID) (*models.VerificationRequest, error) {
+	var request models.VerificationRequest
+	err := r.withDetails(r.DB).Preload("Artist").First(&request, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &request, err
+}
+
+func (r *VerificationRepository) GetByArtist(artistID uuid.UUID) ([]models.VerificationRequest, error) {
+	var requests []models.VerificationRequest
+	err := r.withDetails(r.DB).
+		Where("artist_id = ?", artistID).
+		Order("created_at DESC").
+		Find(&requests).
+		Error
+	return requests, err
+}
+
+func (r *VerificationRepository) GetLatestByStatus(artistID uuid.UUID, statuses ...string) (*models.VerificationRequest, error) {
+	var request models.VerificationRequest
+	err := r.DB.
+		Where("artist_id = ? AND status IN ?", artistID, statuses).
+		Order("created_at DESC").
+		First(&request).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &request, err
+}
+
+func (r *VerificationRepository) GetQueue(statuses []string, offset, limit int) ([]models.VerificationRequest, error) {
+	var requests []models.VerificationRequest
+	db := r.DB.
+		Preload("Artist").
+		Preload("Evidence").
+		Where("status IN ?", statuses).
+		Order("updated_at ASC")
+
+	if limit > 0 {
+		db = db.Offset(offset).Limit(limit)
+	}
+
+	err := db.Find(&requests).Error
+	return requests, err
+}
+
+func (r *VerificationRepository) UpdateStatus(id uuid.UUID, status string, reviewerID *uuid.UUID, notes string) error {
+	updates := map[string]interface{}{"status": status}
+	if reviewerID != nil {
+		updates["reviewer_id"] = *reviewerID
+		updates["review_notes"] = notes
+		updates["reviewed_at"] = time.Now()
+	}
+
+	result := r.DB.Model(&models.VerificationRequest{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *VerificationRepository) AddEvidence(evidence []models.VerificationEvidence) error {
+	if len(evidence) == 0 {
+		return nil
+	}
+	return r.DB.Create(&evidence).Error
+}
+
+func (r *VerificationRepository) GetEvidence(requestID, evidenceID uuid.UUID) (*models.VerificationEvidence, error) {
+	var evidence models.VerificationEvidence
+	err := r.DB.
+		Where("id = ? AND request_id = ?", evidenceID, requestID).
+		First(&evidence).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &evidence, err
+}
+
+func (r *VerificationRepository) AddEvent(event *models.VerificationEvent) error {
+	return r.DB.Create(event).Error
+}
@@ -0,0 +1,297 @@
+package repositories
+
+import (
+	"crawl/models"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// wrappedStreams are the qualified streams of the year, from @from to @until
+	wrappedStreams = `streams.created_at >= @from AND streams.created_at < @until
+		AND streams.deleted_at IS NULL AND streams.status = @qualified`
+	// wrappedPlays are the listener plays of the year that are in their listening history
+	wrappedPlays = wrappedStreams + ` AND streams.user_id IS NOT NULL AND NOT streams.hidden_from_history`
+	// wrappedSeconds is how long a play lasted, taking untracked plays as the whole song
+	wrappedSeconds = `CASE WHEN streams.tracked THEN streams.listened_seconds ELSE songs.duration END`
+	// wrappedCredits credits an artist with their own songs and those they contributed to
+	wrappedCredits = `(
+		SELECT id AS song_id, artist_id FROM songs
+		UNION
+		SELECT song_id, artist_id FROM song_contributors WHERE deleted_at IS NULL
+	) credits ON credits.song_id = streams.song_id`
+)
+
+type WrappedRepository struct {
+	DB *gorm.DB
+}
+
+func NewWrappedRepository(db *gorm.DB) IWrappedRepository {
+	return &WrappedRepository{DB: db}
+}
+
+func wrappedArgs(from, until time.Time, extra ...interface{}) []interface{} {
+	return append([]interface{}{
+		sql.Named("from", from),
+		sql.Named("until", until),
+		sql.Named("qualified", models.StreamQualified),
+	}, extra...)
+}
+
+// ListenerIDs returns up to limit listeners, in ID order after after, who have
+// plays in their history from from to until
+func (r *WrappedRepository) ListenerIDs(from, until time.Time, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	err := r.DB.Raw(`SELECT streams.user_id FROM streams
+		WHERE `+wrappedPlays+` AND streams.user_id > @after
+		GROUP BY streams.user_id
+		ORDER BY streams.user_id
+		LIMIT @limit`,
+		wrappedArgs(from, until, sql.Named("after", after), sql.Named("limit", limit))...).
+		Scan(&ids).
+		Error
+	return ids, err
+}
+
+// ListenerTotals sums each listener's plays and minutes
+func (r *WrappedRepository) ListenerTotals(userIDs []uuid.UUID, from, until time.Time) ([]models.WrappedTotals, error) {
+	totals := []models.WrappedTotals{}
+	err := r.DB.Raw(`SELECT streams.user_id AS subject_id, COUNT(*) AS streams, SUM(`+wrappedSeconds+`) / 60 AS minutes
+		FROM streams
+		JOIN songs ON songs.id = streams.song_id
+		WHERE `+wrappedPlays+` AND streams.user_id IN @users
+		GROUP BY streams.user_id`,
+		wrappedArgs(from, until, sql.Named("users", userIDs))...).
+		Scan(&totals).
+		Error
+	return totals, err
+}
+
+// ListenerTopSongs returns each listener's limit most played songs, top first
+func (r *WrappedRepository) ListenerTopSongs(userIDs []uuid.UUID, from, until time.Time, limit int) ([]models.WrappedSongRow, error) {
+	songs := []models.WrappedSongRow{}
+	err := r.DB.Raw(`SELECT subject_id, song_id, title, streams, minutes FROM (
+			SELECT streams.user_id AS subject_id, songs.id AS song_id, songs.title,
+				COUNT(*) AS streams, SUM(`+wrappedSeconds+`) / 60 AS minutes,
+				ROW_NUMBER() OVER (
+					PARTITION BY streams.user_id ORDER BY COUNT(*) DESC, SUM(`+wrappedSeconds+`) DESC, songs.id
+				) AS position
+			FROM streams
+			JOIN songs ON songs.id = streams.song_id
+			WHERE `+wrappedPlays+` AND streams.user_id IN @users
+			GROUP BY streams.user_id, songs.id, songs.title
+		) ranked
+		WHERE position <= @limit
+		ORDER BY subject_id, position`,
+		wrappedArgs(from, until, sql.Named("users", userIDs), sql.Named("limit", limit))...).
+		Scan(&songs).
+		Error
+	return songs, err
+}
+
+// RankFans ranks every listener among everyone who played each artist they
+// played from from to until, replacing the ranks of the year of from
+func (r *WrappedRepository) RankFans(from, until time.Time) error {
+	return Transaction(r.DB, func(tx *gorm.DB) error {
+		if err := tx.Where("year = ?", from.Year()).Delete(&models.WrappedFan{}).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`INSERT INTO wrapped_fans (year, artist_id, user_id, streams, minutes, top_fan_percent)
+			SELECT @year, artist_id, user_id, streams, minutes,
+				CAST(RANK() OVER (PARTITION BY artist_id ORDER BY streams DESC) AS float8) * 100
+					/ COUNT(*) OVER (PARTITION BY artist_id)
+			FROM (
+				SELECT credits.artist_id, streams.user_id, COUNT(*) AS streams, SUM(`+wrappedSeconds+`) / 60 AS minutes
+				FROM streams
+				JOIN songs ON songs.id = streams.song_id
+				JOIN `+wrappedCredits+`
+				WHERE `+wrappedPlays+`
+				GROUP BY credits.artist_id, streams.user_id
+			) fans`,
+			wrappedArgs(from, until, sql.Named("year", from.Year()))...).
+			Error
+	})
+}
+
+// ClearFans drops the fan ranks of year
+func (r *WrappedRepository) ClearFans(year int) error {
+	return r.DB.Where("year = ?", year).Delete(&models.WrappedFan{}).Error
+}
+
+// ListenerTopArtists returns each listener's limit most played artists of
+// year, top first, with where the listener ranks among everyone who played
+// the artist. It reads the ranks made by RankFans.
+func (r *WrappedRepository) ListenerTopArtists(userIDs []uuid.UUID, year, limit int) ([]models.WrappedArtistRow, error) {
+	artists := []models.WrappedArtistRow{}
+	err := r.DB.Raw(`SELECT top.user_id AS subject_id, top.artist_id, artists.artist_name AS name,
+			top.streams, top.minutes, top.top_fan_percent
+		FROM (
+			SELECT wrapped_fans.*, ROW_NUMBER() OVER (
+				PARTITION BY user_id ORDER BY streams DESC, minutes DESC, artist_id
+			) AS position
+			FROM wrapped_fans
+			WHERE year = @year AND user_id IN @users
+		) top
+		JOIN artists ON artists.id = top.artist_id
+		WHERE top.position <= @limit
+		ORDER BY subject_id, top.position`,
+		sql.Named("year", year), sql.Named("users", userIDs), sql.Named("limit", limit)).
+		Scan(&artists).
+		Error
+	return artists, err
+}
+
+// ListenerTopGenres returns each listener's limit most played genres, top first
+func (r *WrappedRepository) ListenerTopGenres(userIDs []uuid.UUID, from, until time.Time, limit int) ([]models.WrappedGenreRow, error) {
+	genres := []models.WrappedGenreRow{}
+	err := r.DB.Raw(`SELECT subject_id, genre_id, name, streams FROM (
+			SELECT streams.user_id AS subject_id, genres.id AS genre_id, genres.name, COUNT(*) AS streams,
+				ROW_NUMBER() OVER (PARTITION BY streams.user_id ORDER BY COUNT(*) DESC, genres.id) AS position
+			FROM streams
+			JOIN songs ON songs.id = streams.song_id
+			JOIN genres ON genres.id = songs.genre_id AND genres.deleted_at IS NULL
+			WHERE `+wrappedPlays+` AND streams.user_id IN @users
+			GROUP BY streams.user_id, genres.id, genres.name
+		) ranked
+		WHERE position <= @limit
+		ORDER BY subject_id, position`,
+		wrappedArgs(from, until, sql.Named("users", userIDs), sql.Named("limit", limit))...).
+		Scan(&genres).
+		Error
+	return genres, err
+}
+
+// ListenerDays returns the UTC days each listener played something, in order
+func (r *WrappedRepository) ListenerDays(userIDs []uuid.UUID, from, until time.Time) ([]models.WrappedDay, error) {
+	days := []models.WrappedDay{}
+	err := r.DB.Raw(`SELECT DISTINCT streams.user_id AS subject_id, `+rawDay+` AS day
+		FROM streams
+		WHERE `+wrappedPlays+` AND streams.user_id IN @users
+		ORDER BY subject_id, day`,
+		wrappedArgs(from, until, sql.Named("users", userIDs))...).
+		Scan(&days).
+		Error
+	return days, err
+}
+
+// ArtistIDs returns up to limit artists, in ID order after after, credited
+// with qualified streams from from to until
+func (r *WrappedRepository) ArtistIDs(from, until time.Time, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	err := r.DB.Raw(`SELECT credits.artist_id FROM streams
+		JOIN `+wrappedCredits+`
+		WHERE `+wrappedStreams+` AND credits.artist_id > @after
+		GROUP BY credits.artist_id
+		ORDER BY credits.artist_id
+		LIMIT @limit`,
+		wrappedArgs(from, until, sql.Named("after", after), sql.Named("limit", limit))...).
+		Scan(&ids).
+		Error
+	return ids, err
+}
+
+// ArtistTotals sums each artist's streams, listeners, minutes and countries
+func (r *WrappedRepository) ArtistTotals(artistIDs []uuid.UUID, from, until time.Time) ([]models.WrappedTotals, error) {
+	totals := []models.WrappedTotals{}
+	err := r.DB.Raw(`SELECT credits.artist_id AS subject_id, COUNT(*) AS streams,
+			COUNT(DISTINCT streams.user_id) AS listeners,
+			SUM(`+wrappedSeconds+`) / 60 AS minutes,
+			COUNT(DISTINCT NULLIF(upper(streams.country_code), '')) AS countries
+		FROM streams
+		JOIN songs ON songs.id = streams.song_id
+		JOIN `+wrappedCredits+`
+		WHERE `+wrappedStreams+` AND credits.artist_id IN @artists
+		GROUP BY credits.artist_id`,
+		wrappedArgs(from, until, sql.Named("artists", artistIDs))...).
+		Scan(&totals).
+		Error
+	return totals, err
+}
+
+// ArtistTopSongs returns each artist's limit most streamed songs, top first
+func (r *WrappedRepository) ArtistTopSongs(artistIDs []uuid.UUID, from, until time.Time, limit int) ([]models.WrappedSongRow, error) {
+	songs := []models.WrappedSongRow{}
+	err := r.DB.Raw(`SELECT subject_id, song_id, title, streams, listeners, minutes FROM (
+			SELECT credits.artist_id AS subject_id, songs.id AS song_id, songs.title,
+				COUNT(*) AS streams, COUNT(DISTINCT streams.user_id) AS listeners,
+				SUM(`+wrappedSeconds+`) / 60 AS minutes,
+				ROW_NUMBER() OVER (
+					PARTITION BY credits.artist_id ORDER BY COUNT(*) DESC, COUNT(DISTINCT streams.user_id) DESC, songs.id
+				) AS position
+			FROM streams
+			JOIN songs ON songs.id = streams.song_id
+			JOIN `+wrappedCredits+`
+			WHERE `+wrappedStreams+` AND credits.artist_id IN @artists
+			GROUP BY credits.artist_id, songs.id, songs.title
+		) ranked
+		WHERE position <= @limit
+		ORDER BY subject_id, position`,
+		wrappedArgs(from, until, sql.Named("artists", artistIDs), sql.Named("limit", limit))...).
+		Scan(&songs).
+		Error
+	return songs, err
+}
+
+// ArtistTopCountries returns each artist's limit most streaming countries, top first
+func (r *WrappedRepository) ArtistTopCountries(artistIDs []uuid.UUID, from, until time.Time, limit int) ([]models.WrappedCountryRow, error) {
+	countries := []models.WrappedCountryRow{}
+	err := r.DB.Raw(`SELECT subject_id, country_code, streams, listeners FROM (
+			SELECT credits.artist_id AS subject_id, upper(streams.country_code) AS country_code,
+				COUNT(*) AS streams, COUNT(DISTINCT streams.user_id) AS listeners,
+				ROW_NUMBER() OVER (
+					PARTITION BY credits.artist_id ORDER BY COUNT(*) DESC, upper(streams.country_code)
+				) AS position
+			FROM streams
+			JOIN `+wrappedCredits+`
+			WHERE `+wrappedStreams+` AND credits.artist_id IN @artists AND COALESCE(streams.country_code, '') <> ''
+			GROUP BY credits.artist_id, upper(streams.country_code)
+		) ranked
+		WHERE position <= @limit
+		ORDER BY subject_id, position`,
+		wrappedArgs(from, until, sql.Named("artists", artistIDs), sql.Named("limit", limit))...).
+		Scan(&countries).
+		Error
+	return countries, err
+}
+
+// SaveReports stores reports, replacing those of the same subject, year and version
+func (r *WrappedRepository) SaveReports(reports []models.WrappedReport) error {
+	if len(reports) == 0 {
+		return nil
+	}
+	return r.DB.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(&reports, 500).Error
+}
+
+// GetReport returns the latest version of a subject's report for year
+func (r *WrappedRepository) GetReport(kind string, subjectID uuid.UUID, year int) (*models.WrappedReport, error) {
+	var report models.WrappedReport
+	err := r.DB.
+		Where("kind = ? AND subject_id = ? AND year = ?", kind, subjectID, year).
+		Order("version DESC").
+		First(&report).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	return &report, err
+}
+
+// CompleteYear marks year's reports of its version as all made
+func (r *WrappedRepository) CompleteYear(year models.WrappedYear) error {
+	return r.DB.Clauses(clause.OnConflict{UpdateAll: true}).Create(&year).Error
+}
+
+// YearCompleted reports whether every report of the current version was made for year
+func (r *WrappedRepository) YearCompleted(year int) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.WrappedYear{}).
+		Where("year = ? AND version = ?", year, models.WrappedVersion).
+		Count(&count).
+		Error
+	return count > 0, err
+}
@@ -0,0 +1,371 @@
+package search
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Field weights, matching ts_rank's defaults for the A to D weights the
+// PostgreSQL search vectors use
+const (
+	WeightA = 1.0
+	WeightB = 0.4
+	WeightC = 0.2
+	WeightD = 0.1
+)
+
+const (
+	// prefixMatchFactor scores a word that only starts a term below an exact match
+	prefixMatchFactor = 0.8
+	// fuzzyMatchFactor scores a misspelled word that is a small edit away from a term
+	fuzzyMatchFactor = 0.5
+	// minFuzzyLength is the shortest word typo tolerance applies to
+	minFuzzyLength = 4
+	// maxPostingsPerWord bounds the prefix scan for very short words such as "a"
+	maxPostingsPerWord = 100000
+)
+
+// Field is a piece of a document's text and how much a match in it counts
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+// Document is an entity as the disk index sees it
+type Document struct {
+	Kind   string
+	ID     uuid.UUID
+	Fields []Field
+}
+
+// Hit is a document matching a query, scored by how well it matches
+type Hit struct {
+	ID    uuid.UUID
+	Score float64
+}
+
+// DiskStore is an inverted index kept in an embedded bbolt file. For every kind
+// it holds three buckets:
+//
+//	postings: term + 0x00 + document id -> weight of the term in the document
+//	docs:     document id -> the document's terms, so they can be removed on change
+//	terms:    term -> number of documents holding it, scanned for typo tolerance
+type DiskStore struct {
+	db *bolt.DB
+}
+
+// OpenDiskStore opens or creates the index file at path. Only one process can
+// hold it open at a time.
+func OpenDiskStore(path string) (*DiskStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	return &DiskStore{db: db}, nil
+}
+
+func (s *DiskStore) Close() error {
+	return s.db.Close()
+}
+
+type kindBuckets struct {
+	postings *bolt.Bucket
+	docs     *bolt.Bucket
+	terms    *bolt.Bucket
+}
+
+func bucketNames(kind string) (postings, docs, terms []byte) {
+	return []byte(kind + "/postings"), []byte(kind + "/docs"), []byte(kind + "/terms")
+}
+
+// openBuckets returns kind's buckets, or nil when nothing of that kind was ever indexed
+func openBuckets(tx *bolt.Tx, kind string) *kindBuckets {
+	postings, docs, terms := bucketNames(kind)
+	b := &kindBuckets{postings: tx.Bucket(postings), docs: tx.Bucket(docs), terms: tx.Bucket(terms)}
+	if b.postings == nil || b.docs == nil || b.terms == nil {
+		return nil
+	}
+	return b
+}
+
+func createBuckets(tx *bolt.Tx, kind string) (*kindBuckets, error) {
+	postings, docs, terms := bucketNames(kind)
+	for _, name := range [][]byte{postings, docs, terms} {
+		if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+			return nil, err
+		}
+	}
+	return openBuckets(tx, kind), nil
+}
+
+func postingKey(term string, id uuid.UUID) []byte {
+	key := make([]byte, 0, len(term)+1+len(id))
+	key = append(key, term...)
+	key = append(key, 0)
+	return append(key, id[:]...)
+}
+
+// splitPostingKey undoes postingKey; terms never hold a zero byte, ids may
+func splitPostingKey(key []byte) ([]byte, uuid.UUID, bool) {
+	sep := bytes.IndexByte(key, 0)
+	if sep < 0 || len(key)-sep-1 != len(uuid.UUID{}) {
+		return nil, uuid.Nil, false
+	}
+	id, err := uuid.FromBytes(key[sep+1:])
+	return key[:sep], id, err == nil
+}
+
+func encodeFloat(value float64) []byte {
+	return binary.BigEndian.AppendUint64(nil, math.Float64bits(value))
+}
+
+func decodeFloat(raw []byte) float64 {
+	if len(raw) != 8 {
+		return 0
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(raw))
+}
+
+func adjustTermCount(terms *bolt.Bucket, term string, delta int64) error {
+	var count int64
+	if raw := terms.Get([]byte(term)); len(raw) == 8 {
+		count = int64(binary.BigEndian.Uint64(raw))
+	}
+	count += delta
+	if count <= 0 {
+		return terms.Delete([]byte(term))
+	}
+	return terms.Put([]byte(term), binary.BigEndian.AppendUint64(nil, uint64(count)))
+}
+
+// Put adds docs to the index, replacing any earlier version of them
+func (s *DiskStore) Put(docs ...Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, doc := range docs {
+			b, err := createBuckets(tx, doc.Kind)
+			if err != nil {
+				return err
+			}
+			if err := removeDocument(b, doc.ID); err != nil {
+				return err
+			}
+			if err := putDocument(b, doc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func putDocument(b *kindBuckets, doc Document) error {
+	// A term appearing in several fields counts with its heaviest one
+	weights := map[string]float64{}
+	for _, field := range doc.Fields {
+		for _, term := range tokenize(field.Text) {
+			if field.Weight > weights[term] {
+				weights[term] = field.Weight
+			}
+		}
+	}
+
+	terms := make([]string, 0, len(weights))
+	for term, weight := range weights {
+		if err := b.postings.Put(postingKey(term, doc.ID), encodeFloat(weight)); err != nil {
+			return err
+		}
+		if err := adjustTermCount(b.terms, term, 1); err != nil {
+			return err
+		}
+		terms = append(terms, term)
+	}
+
+	raw, err := json.Marshal(terms)
+	if err != nil {
+		return err
+	}
+	return b.docs.Put(doc.ID[:], raw)
+}
+
+func removeDocument(b *kindBuckets, id uuid.UUID) error {
+	raw := b.docs.Get(id[:])
+	if raw == nil {
+		return nil
+	}
+
+	var terms []string
+	if err := json.Unmarshal(raw, &terms); err != nil {
+		return err
+	}
+	for _, term := range terms {
+		if err := b.postings.Delete(postingKey(term, id)); err != nil {
+			return err
+		}
+		if err := adjustTermCount(b.terms, term, -1); err != nil {
+			return err
+		}
+	}
+	return b.docs.Delete(id[:])
+}
+
+// Delete drops the documents of kind with the given ids; unknown ids are ignored
+func (s *DiskStore) Delete(kind string, ids ...uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := openBuckets(tx, kind)
+		if b == nil {
+			return nil
+		}
+		for _, id := range ids {
+			if err := removeDocument(b, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Reset drops every document of kind
+func (s *DiskStore) Reset(kind string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		postings, docs, terms := bucketNames(kind)
+		for _, name := range [][]byte{postings, docs, terms} {
+			if err := tx.DeleteBucket(name); err != nil && err != bolt.ErrBucketNotFound {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Match returns the documents of kind containing every word, either whole, as
+// the start of a longer term, or misspelled by an edit or two. Hits come best
+// first, at most limit of them.
+func (s *DiskStore) Match(kind string, words []string, limit int) ([]Hit, error) {
+	hits := []Hit{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := openBuckets(tx, kind)
+		if b == nil || len(words) == 0 {
+			return nil
+		}
+
+		var scores map[uuid.UUID]float64
+		for _, word := range words {
+			found := matchWord(b, word)
+			if scores == nil {
+				scores = found
+			} else {
+				for id := range scores {
+					if score, ok := found[id]; ok {
+						scores[id] += score
+					} else {
+						delete(scores, id)
+					}
+				}
+			}
+			if len(scores) == 0 {
+				return nil
+			}
+		}
+
+		for id, score := range scores {
+			hits = append(hits, Hit{ID: id, Score: score})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return bytes.Compare(hits[i].ID[:], hits[j].ID[:]) < 0
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// matchWord scores every document holding a term that word starts, falling back
+// to terms a small edit away when nothing does
+func matchWord(b *kindBuckets, word string) map[uuid.UUID]float64 {
+	found := map[uuid.UUID]float64{}
+	scanPostings(b, []byte(word), func(term []byte, id uuid.UUID, weight float64) {
+		if len(term) != len(word) {
+			weight *= prefixMatchFactor
+		}
+		found[id] = max(found[id], weight)
+	})
+	if len(found) > 0 || utf8.RuneCountInString(word) < minFuzzyLength {
+		return found
+	}
+
+	for _, term := range similarTerms(b.terms, word) {
+		exact := append([]byte(term), 0)
+		scanPostings(b, exact, func(_ []byte, id uuid.UUID, weight float64) {
+			found[id] = max(found[id], weight*fuzzyMatchFactor)
+		})
+	}
+	return found
+}
+
+func scanPostings(b *kindBuckets, prefix []byte, visit func(term []byte, id uuid.UUID, weight float64)) {
+	c := b.postings.Cursor()
+	scanned := 0
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix) && scanned < maxPostingsPerWord; k, v = c.Next() {
+		scanned++
+		term, id, ok := splitPostingKey(k)
+		if !ok {
+			continue
+		}
+		visit(term, id, decodeFloat(v))
+	}
+}
+
+// similarTerms lists the indexed terms sharing word's first letter that are at
+// most one edit away, or two for words longer than five letters
+func similarTerms(terms *bolt.Bucket, word string) []string {
+	first, size := utf8.DecodeRuneInString(word)
+	if first == utf8.RuneError {
+		return nil
+	}
+	allowed := 1
+	length := utf8.RuneCountInString(word)
+	if length > 5 {
+		allowed = 2
+	}
+
+	var similar []string
+	prefix := []byte(word[:size])
+	c := terms.Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		term := string(k)
+		if diff := utf8.RuneCountInString(term) - length; diff > allowed || diff < -allowed {
+			continue
+		}
+		if editDistance(word, term) <= allowed {
+			similar = append(similar, term)
+		}
+	}
+	return similar
+}
@@ -0,0 +1,143 @@
+package search
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+
+	"github.com/google/uuid"
+)
+
+// DiskIndex is a SearchIndex that matches text against a DiskStore, then loads
+// just the matched rows from the database. Every hit goes to the database, so
+// filters, sorting and totals apply to all of them before the page is cut.
+// Searches without text are plain listings and go straight to the database.
+type DiskIndex struct {
+	store *DiskStore
+	sql   *SQLIndex
+}
+
+func NewDiskIndex(
+	store *DiskStore,
+	songs repositories.ISongRepository,
+	albums repositories.IAlbumRepository,
+	artists repositories.IArtistRepository,
+	playlists repositories.IPlaylistRepository,
+) *DiskIndex {
+	return &DiskIndex{
+		store: store,
+		sql:   NewSQLIndex(songs, albums, artists, playlists),
+	}
+}
+
+// match looks text up in the store; ok is false when text holds no searchable words
+func (i *DiskIndex) match(kind string, text *string) (hits []Hit, ok bool, err error) {
+	if text == nil {
+		return nil, false, nil
+	}
+	words := tokenize(*text)
+	if len(words) == 0 {
+		return nil, false, nil
+	}
+	hits, err = i.store.Match(kind, words, 0)
+	return hits, true, err
+}
+
+func hitIDs(hits []Hit) []uuid.UUID {
+	ids := make([]uuid.UUID, len(hits))
+	for k, hit := range hits {
+		ids[k] = hit.ID
+	}
+	return ids
+}
+
+func hitScores(hits []Hit) map[uuid.UUID]float64 {
+	scores := make(map[uuid.UUID]float64, len(hits))
+	for _, hit := range hits {
+		scores[hit.ID] = hit.Score
+	}
+	return scores
+}
+
+func (i *DiskIndex) SearchSongs(ctx context.Context, q SongQuery) ([]models.Song, int64, error) {
+	hits, ok, err := i.match(KindSong, q.Text)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok {
+		return i.sql.SearchSongs(ctx, q)
+	}
+	if len(hits) == 0 {
+		return []models.Song{}, 0, nil
+	}
+
+	songs, total, err := i.sql.songs.WithIndexHits(hitIDs(hits)).AvailableIn(q.Country).
+		Search(ctx, q.Text, q.Artist, q.Genre, q.Sort, q.Order, q.Offset, q.Limit)
+	scores := hitScores(hits)
+	for k := range songs {
+		songs[k].Relevance = scores[songs[k].ID]
+	}
+	return songs, total, err
+}
+
+func (i *DiskIndex) SearchAlbums(ctx context.Context, q AlbumQuery) ([]models.Album, int64, error) {
+	hits, ok, err := i.match(KindAlbum, q.Text)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok {
+		return i.sql.SearchAlbums(ctx, q)
+	}
+	if len(hits) == 0 {
+		return []models.Album{}, 0, nil
+	}
+
+	albums, total, err := i.sql.albums.WithIndexHits(hitIDs(hits)).AvailableIn(q.Country).
+		SearchAlbums(ctx, q.Text, q.Artist, q.Genre, q.Sort, q.Page, q.Limit)
+	scores := hitScores(hits)
+	for k := range albums {
+		albums[k].Relevance = scores[albums[k].ID]
+	}
+	return albums, total, err
+}
+
+func (i *DiskIndex) SearchArtists(ctx context.Context, q ArtistQuery) ([]models.Artist, int64, error) {
+	hits, ok, err := i.match(KindArtist, &q.Text)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok {
+		return i.sql.SearchArtists(ctx, q)
+	}
+	if len(hits) == 0 {
+		return []models.Artist{}, 0, nil
+	}
+
+	artists, total, err := i.sql.artists.WithIndexHits(hitIDs(hits)).SearchByName(ctx, q.Text, q.Limit, q.Offset)
+	scores := hitScores(hits)
+	for k := range artists {
+		artists[k].Relevance = scores[artists[k].ID]
+	}
+	return artists, total, err
+}
+
+func (i *DiskIndex) SearchPlaylists(ctx context.Context, q PlaylistQuery) ([]models.Playlist, int64, error) {
+	hits, ok, err := i.match(KindPlaylist, q.Text)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok {
+		return i.sql.SearchPlaylists(ctx, q)
+	}
+	if len(hits) == 0 {
+		return []models.Playlist{}, 0, nil
+	}
+
+	playlists, total, err := i.sql.playlists.WithIndexHits(hitIDs(hits)).
+		SearchPlaylists(ctx, q.Text, q.Owner, q.IsPublic, q.Sort, q.Page, q.Limit)
+	scores := hitScores(hits)
+	for k := range playlists {
+		playlists[k].Relevance = scores[playlists[k].ID]
+	}
+	return playlists, total, err
+}
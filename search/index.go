@@ -0,0 +1,101 @@
+package search
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+)
+
+// Kinds of documents kept in a search index
+const (
+	KindSong     = models.EntityTypeSong
+	KindAlbum    = models.EntityTypeAlbum
+	KindArtist   = models.EntityTypeArtist
+	KindPlaylist = models.EntityTypePlaylist
+)
+
+var Kinds = []string{KindSong, KindAlbum, KindArtist, KindPlaylist}
+
+type SongQuery struct {
+	Text    *string
+	Artist  *string
+	Genre   *string
+	Sort    *string
+	Order   *string
+	Country string // songs that can't be played there are left out
+	Offset  int
+	Limit   int
+}
+
+type AlbumQuery struct {
+	Text    *string
+	Artist  *string
+	Genre   *string
+	Sort    *string
+	Country string // albums that can't be played there are left out
+	Page    *int
+	Limit   *int
+}
+
+type ArtistQuery struct {
+	Text   string
+	Offset int
+	Limit  int
+}
+
+type PlaylistQuery struct {
+	Text     *string
+	Owner    *string
+	IsPublic *bool
+	Sort     *string
+	Page     int
+	Limit    int
+}
+
+// SearchIndex answers the catalog text searches, returning a page of matches
+// along with the total number of matches
+type SearchIndex interface {
+	SearchSongs(ctx context.Context, q SongQuery) ([]models.Song, int64, error)
+	SearchAlbums(ctx context.Context, q AlbumQuery) ([]models.Album, int64, error)
+	SearchArtists(ctx context.Context, q ArtistQuery) ([]models.Artist, int64, error)
+	SearchPlaylists(ctx context.Context, q PlaylistQuery) ([]models.Playlist, int64, error)
+}
+
+// SQLIndex is a SearchIndex that runs every search as a PostgreSQL full-text query.
+// The search_vector columns are generated, so it never needs reindexing.
+type SQLIndex struct {
+	songs     repositories.ISongRepository
+	albums    repositories.IAlbumRepository
+	artists   repositories.IArtistRepository
+	playlists repositories.IPlaylistRepository
+}
+
+func NewSQLIndex(
+	songs repositories.ISongRepository,
+	albums repositories.IAlbumRepository,
+	artists repositories.IArtistRepository,
+	playlists repositories.IPlaylistRepository,
+) *SQLIndex {
+	return &SQLIndex{
+		songs:     songs,
+		albums:    albums,
+		artists:   artists,
+		playlists: playlists,
+	}
+}
+
+func (i *SQLIndex) SearchSongs(ctx context.Context, q SongQuery) ([]models.Song, int64, error) {
+	return i.songs.AvailableIn(q.Country).Search(ctx, q.Text, q.Artist, q.Genre, q.Sort, q.Order, q.Offset, q.Limit)
+}
+
+func (i *SQLIndex) SearchAlbums(ctx context.Context, q AlbumQuery) ([]models.Album, int64, error) {
+	return i.albums.AvailableIn(q.Country).SearchAlbums(ctx, q.Text, q.Artist, q.Genre, q.Sort, q.Page, q.Limit)
+}
+
+func (i *SQLIndex) SearchArtists(ctx context.Context, q ArtistQuery) ([]models.Artist, int64, error) {
+	return i.artists.SearchByName(ctx, q.Text, q.Limit, q.Offset)
+}
+
+func (i *SQLIndex) SearchPlaylists(ctx context.Context, q PlaylistQuery) ([]models.Playlist, int64, error) {
+	return i.playlists.SearchPlaylists(ctx, q.Text, q.Owner, q.IsPublic, q.Sort, q.Page, q.Limit)
+}
@@ -0,0 +1,267 @@
+package search
+
+import (
+	"crawl/models"
+	"crawl/repositories"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// reindexBatchSize is how many rows Reindex loads and writes at a time
+const reindexBatchSize = 500
+
+// indexedTables maps the tables mirrored in the disk index to their document kind
+var indexedTables = map[string]string{
+	"songs":     KindSong,
+	"albums":    KindAlbum,
+	"artists":   KindArtist,
+	"playlists": KindPlaylist,
+}
+
+// kindModels builds an empty model for each document kind
+var kindModels = map[string]func() interface{}{
+	KindSong:     func() interface{} { return &models.Song{} },
+	KindAlbum:    func() interface{} { return &models.Album{} },
+	KindArtist:   func() interface{} { return &models.Artist{} },
+	KindPlaylist: func() interface{} { return &models.Playlist{} },
+}
+
+// RegisterIndexCallbacks keeps store in step with every create, update and delete
+// issued through db, once the change is committed. A failed index write is
+// logged rather than failing the database write; Reindex repairs any drift.
+func RegisterIndexCallbacks(db *gorm.DB, store *DiskStore) error {
+	committed := "gorm:commit_or_rollback_transaction"
+	if err := db.Callback().Create().After(committed).Register("search:create", indexCallback(db, store)); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After(committed).Register("search:update", indexCallback(db, store)); err != nil {
+		return err
+	}
+	return db.Callback().Delete().After(committed).Register("search:delete", indexCallback(db, store))
+}
+
+// indexCallback refreshes the documents a statement changed once the change
+// commits. Statements in their own transaction have committed by the time it
+// runs; those inside a repositories.Transaction wait for it to commit, and are
+// dropped if it rolls back. Changes inside any other transaction are left for
+// Reindex, as there's no telling whether they commit.
+func indexCallback(root *gorm.DB, store *DiskStore) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.Statement.Schema == nil || db.RowsAffected == 0 {
+			return
+		}
+
+		table := db.Statement.Schema.Table
+		ids := repositories.StatementIDs(db)
+		if len(ids) == 0 {
+			return
+		}
+
+		ctx := db.Statement.Context
+		refreshed := repositories.AfterCommit(db, func() {
+			refreshChanged(root.Session(&gorm.Session{NewDB: true, Context: ctx}), store, table, ids)
+		})
+		if !refreshed {
+			log.Printf("search index: %s changed in a transaction not opened with repositories.Transaction, leaving it for Reindex", table)
+		}
+	}
+}
+
+// refreshChanged reloads the documents of table's rows with ids and of the
+// documents that embed them
+func refreshChanged(tx *gorm.DB, store *DiskStore, table string, ids []uuid.UUID) {
+	changed, err := dependents(tx, table, ids)
+	if err != nil {
+		log.Printf("search index: failed to find documents affected by %s change: %v", table, err)
+		return
+	}
+	if kind, ok := indexedTables[table]; ok {
+		changed[kind] = append(changed[kind], ids...)
+	}
+
+	for kind, kindIDs := range changed {
+		if err := refresh(tx, store, kind, kindIDs); err != nil {
+			log.Printf("search index: failed to update %s documents: %v", kind, err)
+		}
+	}
+}
+
+// dependents lists the documents that embed the names in table's changed rows:
+// songs carry their artists, album and credited artists, albums their artist
+func dependents(db *gorm.DB, table string, ids []uuid.UUID) (map[string][]uuid.UUID, error) {
+	changed := map[string][]uuid.UUID{}
+	var songIDs, albumIDs []uuid.UUID
+
+	switch table {
+	case "artists":
+		err := db.Model(&models.Song{}).
+			Where("artist_id IN ? OR id IN (SELECT song_id FROM song_contributors WHERE artist_id IN ? AND deleted_at IS NULL)", ids, ids).
+			Pluck("id", &songIDs).
+			Error
+		if err != nil {
+			return nil, err
+		}
+		if err := db.Model(&models.Album{}).Where("artist_id IN ?", ids).Pluck("id", &albumIDs).Error; err != nil {
+			return nil, err
+		}
+	case "albums":
+		if err := db.Model(&models.Song{}).Where("album_id IN ?", ids).Pluck("id", &songIDs).Error; err != nil {
+			return nil, err
+		}
+	case "song_contributors":
+		// Removed credits are soft-deleted and still name their song
+		err := db.Unscoped().Model(&models.SongContributor{}).Where("id IN ?", ids).Distinct().Pluck("song_id", &songIDs).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(songIDs) > 0 {
+		changed[KindSong] = songIDs
+	}
+	if len(albumIDs) > 0 {
+		changed[KindAlbum] = albumIDs
+	}
+	return changed, nil
+}
+
+// refresh reloads the documents of kind with ids, dropping the ones that no longer exist
+func refresh(db *gorm.DB, store *DiskStore, kind string, ids []uuid.UUID) error {
+	docs, err := loadDocuments(db, kind, ids)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[uuid.UUID]bool, len(docs))
+	for _, doc := range docs {
+		found[doc.ID] = true
+	}
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	if err := store.Delete(kind, missing...); err != nil {
+		return err
+	}
+	return store.Put(docs...)
+}
+
+// Reindex rebuilds every document of kind from the database and returns how many it indexed
+func Reindex(db *gorm.DB, store *DiskStore, kind string) (int, error) {
+	model, ok := kindModels[kind]
+	if !ok {
+		return 0, fmt.Errorf("unknown search index kind %q", kind)
+	}
+	if err := store.Reset(kind); err != nil {
+		return 0, err
+	}
+
+	var ids []uuid.UUID
+	if err := db.Model(model()).Order("id").Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+
+	indexed := 0
+	for start := 0; start < len(ids); start += reindexBatchSize {
+		batch := ids[start:min(start+reindexBatchSize, len(ids))]
+		docs, err := loadDocuments(db, kind, batch)
+		if err != nil {
+			return indexed, err
+		}
+		if err := store.Put(docs...); err != nil {
+			return indexed, err
+		}
+		indexed += len(docs)
+	}
+	return indexed, nil
+}
+
+// loadDocuments reads the rows of kind with ids along with the related names their documents hold
+func loadDocuments(db *gorm.DB, kind string, ids []uuid.UUID) ([]Document, error) {
+	docs := []Document{}
+	switch kind {
+	case KindSong:
+		var songs []models.Song
+		err := db.Preload("Artist").Preload("Album").Preload("Genre").Preload("Credits.Artist").
+			Where("id IN ?", ids).
+			Find(&songs).
+			Error
+		if err != nil {
+			return nil, err
+		}
+		for _, song := range songs {
+			docs = append(docs, songDocument(song))
+		}
+	case KindAlbum:
+		var albums []models.Album
+		if err := db.Preload("Artist").Preload("Genre").Where("id IN ?", ids).Find(&albums).Error; err != nil {
+			return nil, err
+		}
+		for _, album := range albums {
+			docs = append(docs, albumDocument(album))
+		}
+	case KindArtist:
+		var artists []models.Artist
+		if err := db.Where("id IN ?", ids).Find(&artists).Error; err != nil {
+			return nil, err
+		}
+		for _, artist := range artists {
+			docs = append(docs, Document{Kind: KindArtist, ID: artist.ID, Fields: []Field{
+				{Text: artist.ArtistName, Weight: WeightA},
+			}})
+		}
+	case KindPlaylist:
+		var playlists []models.Playlist
+		if err := db.Where("id IN ?", ids).Find(&playlists).Error; err != nil {
+			return nil, err
+		}
+		for _, playlist := range playlists {
+			docs = append(docs, Document{Kind: KindPlaylist, ID: playlist.ID, Fields: []Field{
+				{Text: playlist.Title, Weight: WeightA},
+				{Text: playlist.Description, Weight: WeightC},
+			}})
+		}
+	default:
+		return nil, fmt.Errorf("unknown search index kind %q", kind)
+	}
+	return docs, nil
+}
+
+// songDocument weights a song like songSearchVector does: title, then artists,
+// album and genre
+func songDocument(song models.Song) Document {
+	fields := []Field{
+		{Text: song.Title, Weight: WeightA},
+		{Text: song.Artist.ArtistName, Weight: WeightB},
+	}
+	for _, credit := range song.Credits {
+		if credit.Artist != nil {
+			fields = append(fields, Field{Text: credit.Artist.ArtistName, Weight: WeightB})
+		}
+	}
+	if song.Album != nil {
+		fields = append(fields, Field{Text: song.Album.Title, Weight: WeightC})
+	}
+	if song.Genre != nil {
+		fields = append(fields, Field{Text: song.Genre.Name, Weight: WeightD})
+	}
+	return Document{Kind: KindSong, ID: song.ID, Fields: fields}
+}
+
+func albumDocument(album models.Album) Document {
+	fields := []Field{
+		{Text: album.Title, Weight: WeightA},
+		{Text: album.Artist.ArtistName, Weight: WeightB},
+		{Text: album.Description, Weight: WeightC},
+	}
+	if album.Genre != nil {
+		fields = append(fields, Field{Text: album.Genre.Name, Weight: WeightD})
+	}
+	return Document{Kind: KindAlbum, ID: album.ID, Fields: fields}
+}
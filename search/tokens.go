@@ -0,0 +1,59 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxTermLength drops runaway tokens (long URLs, keyboard mashing) from the index
+const maxTermLength = 64
+
+// tokenize splits text into lower-cased words with accents removed, matching the
+// simple dictionary and search_unaccent used by the PostgreSQL backend
+func tokenize(text string) []string {
+	// Transformer chains keep state, so each call needs its own
+	foldAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(foldAccents, strings.ToLower(text))
+	if err != nil {
+		folded = strings.ToLower(text)
+	}
+
+	words := strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := words[:0]
+	for _, word := range words {
+		if len(word) <= maxTermLength {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// editDistance is the Levenshtein distance between a and b, counted in runes
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
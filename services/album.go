@@ -5,44 +5,61 @@ import (
 	"crawl/api"
 	"crawl/models"
 	"crawl/repositories"
+	"crawl/search"
 	"errors"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type AlbumService interface {
-	SearchAlbums(ctx context.Context, query *string, artist *string, genre *string, sort *string, page *int, limit *int) ([]models.Album, error)
+	SearchAlbums(ctx context.Context, query *string, artist *string, genre *string, sort *string, page *int, limit *int, country string) ([]models.Album, int64, error)
 	CreateAlbum(ctx context.Context, album models.Album) (*models.Album, error)
-	GetAllAlbums(ctx context.Context, params api.GetAlbumsParams) ([]models.Album, error)
+	GetAllAlbums(ctx context.Context, params api.GetAlbumsParams, country string) ([]models.Album, error)
 	GetAlbumByID(ctx context.Context, albumID uuid.UUID) (*models.Album, error)
 	GetAllArtistAlbums(ctx context.Context, artistID uuid.UUID, page int, limit int) ([]models.Album, error)
 	UpdateAlbum(ctx context.Context, albumID uuid.UUID, album *models.Album) (*models.Album, error)
 	DeleteAlbum(ctx context.Context, albumID uuid.UUID) error
 	GetAlbumContributors(ctx context.Context, albumID uuid.UUID) ([]models.AlbumContributor, error)
 	AddAlbumContributor(ctx context.Context, albumID uuid.UUID, contributor *models.AlbumContributor) error
-	GetAlbumSongs(ctx context.Context, albumID uuid.UUID) ([]models.Song, error)
+	SearchAlbumFacets(ctx context.Context, query *string, country string) (*models.SearchFacets, error)
+	GetAlbumSongs(ctx context.Context, albumID uuid.UUID, country string) ([]models.Song, error)
 }
 
 type albumService struct {
 	albumRepo            repositories.IAlbumRepository
 	albumContributorRepo repositories.IAlbumContributorRepository
 	songRepo             repositories.ISongRepository
+	index                search.SearchIndex
 }
 
 func NewAlbumService(
 	albumRepo repositories.IAlbumRepository,
 	albumContributorRepo repositories.IAlbumContributorRepository,
 	songRepo repositories.ISongRepository,
+	index search.SearchIndex,
 ) AlbumService {
 	return &albumService{
 		albumRepo:            albumRepo,
 		albumContributorRepo: albumContributorRepo,
 		songRepo:             songRepo,
+		index:                index,
 	}
 }
 
-func (s *albumService) SearchAlbums(ctx context.Context, query *string, artist *string, genre *string, sort *string, page *int, limit *int) ([]models.Album, error) {
-	return s.albumRepo.SearchAlbums(query, artist, genre, sort, page, limit)
+func (s *albumService) SearchAlbums(ctx context.Context, query *string, artist *string, genre *string, sort *string, page *int, limit *int, country string) ([]models.Album, int64, error) {
+	return s.index.SearchAlbums(ctx, search.AlbumQuery{
+		Text:    query,
+		Artist:  artist,
+		Genre:   genre,
+		Sort:    sort,
+		Country: country,
+		Page:    page,
+		Limit:   limit,
+	})
+}
+
+func (s *albumService) SearchAlbumFacets(ctx context.Context, query *string, country string) (*models.SearchFacets, error) {
+	return s.albumRepo.AvailableIn(country).SearchFacets(ctx, query)
 }
 
 func (s *albumService) CreateAlbum(ctx context.Context, album models.Album) (*models.Album, error) {
@@ -54,7 +71,7 @@ func (s *albumService) CreateAlbum(ctx context.Context, album models.Album) (*mo
 		return nil, errors.New("artist ID is required")
 	}
 
-	return s.albumRepo.Create(&album)
+	return s.albumRepo.WithContext(ctx).Create(&album)
 }
 
 func (s *albumService) GetAlbumByID(ctx context.Context, albumID uuid.UUID) (*models.Album, error) {
@@ -68,8 +85,15 @@ func (s *albumService) GetAlbumByID(ctx context.Context, albumID uuid.UUID) (*mo
 	return album, nil
 }
 
-func (s *albumService) GetAllAlbums(ctx context.Context, params api.GetAlbumsParams) ([]models.Album, error) {
-	return s.albumRepo.GetAll(*params.Page, *params.Limit)
+func (s *albumService) GetAllAlbums(ctx context.Context, params api.GetAlbumsParams, country string) ([]models.Album, error) {
+	var offset int
+	limit := 20
+	if params.Page != nil && params.Limit != nil {
+		limit = *params.Limit
+		offset = (*params.Page - 1) * limit
+	}
+
+	return s.albumRepo.AvailableIn(country).GetFiltered(params.Artist, params.Genre, parseTagFilter(params.Tags), offset, limit)
 }
 
 func (s *albumService) GetAllArtistAlbums(ctx context.Context, artist uuid.UUID, page int, limit int) ([]models.Album, error) {
@@ -96,7 +120,7 @@ func (s *albumService) UpdateAlbum(ctx context.Context, albumID uuid.UUID, album
 	existingAlbum.GenreID = album.GenreID
 	existingAlbum.IsFlagged = album.IsFlagged
 
-	return s.albumRepo.Update(existingAlbum)
+	return s.albumRepo.WithContext(ctx).Update(existingAlbum)
 }
 
 func (s *albumService) DeleteAlbum(ctx context.Context, albumID uuid.UUID) error {
@@ -109,7 +133,7 @@ func (s *albumService) DeleteAlbum(ctx context.Context, albumID uuid.UUID) error
 		return err
 	}
 
-	return s.albumRepo.Delete(albumID)
+	return s.albumRepo.WithContext(ctx).Delete(albumID)
 }
 
 func (s *albumService) GetAlbumContributors(ctx context.Context, albumID uuid.UUID) ([]models.AlbumContributor, error) {
@@ -121,8 +145,23 @@ func (s *albumService) AddAlbumContributor(ctx context.Context, albumID uuid.UUI
 	if contributor.ArtistID == uuid.Nil {
 		return errors.New("artist ID is required")
 	}
-	if contributor.ContributionType == "" {
-		return errors.New("contribution type is required")
+	role, err := normalizeCreditRole(contributor.ContributionType)
+	if err != nil {
+		return err
+	}
+	contributor.ContributionType = role
+
+	album, err := s.albumRepo.GetByID(albumID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("album not found")
+		}
+		return err
+	}
+
+	// The owning artist is already the lead; crediting them again would duplicate the display name
+	if contributor.ArtistID == album.ArtistID && (role == models.CreditPrimary || role == models.CreditFeatured) {
+		return ErrPrimaryArtistCredit
 	}
 
 	// Set the album ID
@@ -131,10 +170,9 @@ func (s *albumService) AddAlbumContributor(ctx context.Context, albumID uuid.UUI
 	return s.albumContributorRepo.AddContributor(contributor)
 }
 
-func (s *albumService) GetAlbumSongs(ctx context.Context, albumID uuid.UUID) ([]models.Song, error) {
-	album, err := s.albumRepo.GetWithSongs(albumID)
-	if err != nil {
+func (s *albumService) GetAlbumSongs(ctx context.Context, albumID uuid.UUID, country string) ([]models.Song, error) {
+	if _, err := s.albumRepo.GetByID(albumID); err != nil {
 		return nil, err
 	}
-	return album.Songs, nil
+	return s.songRepo.AvailableIn(country).GetFiltered(nil, nil, &albumID, nil, 0, 0)
 }
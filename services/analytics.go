@@ -0,0 +1,418 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"errors"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// Granularities of an analytics series
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week" // starting on Monday
+	GranularityMonth = "month"
+)
+
+const (
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 366
+)
+
+var (
+	ErrInvalidAnalyticsRange = errors.New("analytics range must start on or before its end, end by today and span at most 366 days")
+	ErrInvalidGranularity    = errors.New("granularity must be 'day', 'week' or 'month'")
+)
+
+// AnalyticsRange picks the UTC days analytics cover: From through To, or the
+// Days days through To when From isn't set. To defaults to today.
+type AnalyticsRange struct {
+	From        *time.Time
+	To          *time.Time
+	Days        int
+	Granularity string
+}
+
+// ArtistAnalytics is an artist's audience and sales over the UTC days From
+// through To, compared with as many days just before them
+type ArtistAnalytics struct {
+	ArtistID         uuid.UUID `json:"artist_id"`
+	From             string    `json:"from"`
+	To               string    `json:"to"`
+	PreviousFrom     string    `json:"previous_from"`
+	PreviousTo       string    `json:"previous_to"`
+	Granularity      string    `json:"granularity"`
+	MonthlyListeners int       `json:"monthly_listeners"`
+	// MonthlyListenersHistory is the artist's monthly listeners as of each day of the range
+	MonthlyListenersHistory []models.DailyListenerCount `json:"monthly_listeners_history"`
+	Totals                  AnalyticsTotals             `json:"totals"`
+	Previous                AnalyticsTotals             `json:"previous"`
+	Change                  AnalyticsChange             `json:"change"`
+	Series                  []AnalyticsPoint            `json:"series"`
+	Songs                   []SongAnalytics             `json:"songs"`
+	Countries               []models.StreamShare        `json:"countries"`
+	Devices                 []models.StreamShare        `json:"devices"`
+}
+
+// AnalyticsTotals sums a period. Revenue is from completed purchases and tips,
+// per currency. Listens are tracked plays and skips; the rates are shares of them.
+type AnalyticsTotals struct {
+	Streams        int64              `json:"streams"`
+	Listeners      int64              `json:"listeners"`
+	Purchases      int64              `json:"purchases"`
+	Tips           int64              `json:"tips"`
+	Revenue        map[string]float64 `json:"revenue"`
+	Listens        int64              `json:"listens"`
+	CompletionRate float64            `json:"completion_rate"`
+	SkipRate       float64            `json:"skip_rate"`
+}
+
+// AnalyticsChange is how much each total moved from the previous period, as a
+// share of the previous total: 0.5 is half as much again. A change is left out
+// when the previous period had none to compare against.
+type AnalyticsChange struct {
+	Streams   *float64           `json:"streams,omitempty"`
+	Listeners *float64           `json:"listeners,omitempty"`
+	Purchases *float64           `json:"purchases,omitempty"`
+	Tips      *float64           `json:"tips,omitempty"`
+	Revenue   map[string]float64 `json:"revenue"`
+}
+
+// AnalyticsPoint is one day, week or month of a series, starting on Period.
+// The first and last may reach outside the range; only days in it are counted.
+type AnalyticsPoint struct {
+	Period    string             `json:"period"`
+	Streams   int64              `json:"streams"`
+	Listeners int64              `json:"listeners"`
+	Purchases int64              `json:"purchases"`
+	Tips      int64              `json:"tips"`
+	Revenue   map[string]float64 `json:"revenue"`
+}
+
+// SongAnalytics is one song's streams, sales and listening over the range
+type SongAnalytics struct {
+	SongID          uuid.UUID          `json:"song_id"`
+	Title           string             `json:"title"`
+	Streams         int64              `json:"streams"`
+	Purchases       int64              `json:"purchases"`
+	Revenue         map[string]float64 `json:"revenue"`
+	Listens         int64              `json:"listens"`
+	Completions     int64              `json:"completions"`
+	Skips           int64              `json:"skips"`
+	CompletionRate  float64            `json:"completion_rate"`
+	SkipRate        float64            `json:"skip_rate"`
+	AverageListened float64            `json:"average_listened_seconds"`
+}
+
+type AnalyticsService interface {
+	GetArtistAnalytics(ctx context.Context, artistID uuid.UUID, span AnalyticsRange) (*ArtistAnalytics, error)
+}
+
+type analyticsService struct {
+	artistRepo    repositories.IArtistRepository
+	streamRepo    repositories.IStreamRepository
+	salesRepo     repositories.IArtistSalesRepository
+	listenersRepo repositories.IMonthlyListenersRepository
+}
+
+func NewAnalyticsService(
+	artistRepo repositories.IArtistRepository,
+	streamRepo repositories.IStreamRepository,
+	salesRepo repositories.IArtistSalesRepository,
+	listenersRepo repositories.IMonthlyListenersRepository,
+) AnalyticsService {
+	return &analyticsService{
+		artistRepo:    artistRepo,
+		streamRepo:    streamRepo,
+		salesRepo:     salesRepo,
+		listenersRepo: listenersRepo,
+	}
+}
+
+// resolve returns the first day of the range and the start of the day after its last
+func (span AnalyticsRange) resolve() (from time.Time, until time.Time, err error) {
+	today := repositories.UTCDay(time.Now())
+	last := today
+	if span.To != nil {
+		last = repositories.UTCDay(*span.To)
+	}
+
+	if span.From != nil {
+		from = repositories.UTCDay(*span.From)
+	} else {
+		days := span.Days
+		if days <= 0 {
+			days = defaultAnalyticsDays
+		}
+		from = last.AddDate(0, 0, 1-days)
+	}
+
+	until = last.AddDate(0, 0, 1)
+	if from.After(last) || last.After(today) || until.After(from.AddDate(0, 0, maxAnalyticsDays)) {
+		return time.Time{}, time.Time{}, ErrInvalidAnalyticsRange
+	}
+	return from, until, nil
+}
+
+// periodStart returns the start of the day, week or month holding day
+func periodStart(day time.Time, granularity string) time.Time {
+	switch granularity {
+	case GranularityWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case GranularityMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+func nextPeriod(period time.Time, granularity string) time.Time {
+	switch granularity {
+	case GranularityWeek:
+		return period.AddDate(0, 0, 7)
+	case GranularityMonth:
+		return period.AddDate(0, 1, 0)
+	}
+	return period.AddDate(0, 0, 1)
+}
+
+func (s *analyticsService) GetArtistAnalytics(ctx context.Context, artistID uuid.UUID, span AnalyticsRange) (*ArtistAnalytics, error) {
+	granularity := span.Granularity
+	switch granularity {
+	case "":
+		granularity = GranularityDay
+	case GranularityDay, GranularityWeek, GranularityMonth:
+	default:
+		return nil, ErrInvalidGranularity
+	}
+	from, until, err := span.resolve()
+	if err != nil {
+		return nil, err
+	}
+	previousFrom := from.Add(-until.Sub(from))
+
+	artist, err := s.artistRepo.GetByID(artistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrArtistNotFound
+		}
+		return nil, err
+	}
+
+	streams, err := s.streamRepo.GetArtistStreamSeries(artistID, from, until, granularity)
+	if err != nil {
+		return nil, err
+	}
+	sales, err := s.salesRepo.GetSalesSeries(artistID, from, until, granularity)
+	if err != nil {
+		return nil, err
+	}
+	listens, err := s.streamRepo.GetArtistListenStats(artistID, from, until)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.totals(artistID, from, until, sales, listens)
+	if err != nil {
+		return nil, err
+	}
+
+	previousSales, err := s.salesRepo.GetSalesSeries(artistID, previousFrom, from, granularity)
+	if err != nil {
+		return nil, err
+	}
+	previousListens, err := s.streamRepo.GetArtistListenStats(artistID, previousFrom, from)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.totals(artistID, previousFrom, from, previousSales, previousListens)
+	if err != nil {
+		return nil, err
+	}
+
+	songs, err := s.songs(artistID, from, until, listens)
+	if err != nil {
+		return nil, err
+	}
+	countries, err := s.shares(artistID, from, until, "country")
+	if err != nil {
+		return nil, err
+	}
+	devices, err := s.shares(artistID, from, until, "device")
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.listenersRepo.GetArtistHistory(artistID, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArtistAnalytics{
+		ArtistID:                artistID,
+		From:                    from.Format("2006-01-02"),
+		To:                      until.AddDate(0, 0, -1).Format("2006-01-02"),
+		PreviousFrom:            previousFrom.Format("2006-01-02"),
+		PreviousTo:              from.AddDate(0, 0, -1).Format("2006-01-02"),
+		Granularity:             granularity,
+		MonthlyListeners:        artist.MonthlyListeners,
+		MonthlyListenersHistory: history,
+		Totals:                  totals,
+		Previous:                previous,
+		Change:                  compareTotals(totals, previous),
+		Series:                  series(from, until, granularity, streams, sales),
+		Songs:                   songs,
+		Countries:               countries,
+		Devices:                 devices,
+	}, nil
+}
+
+// totals sums a period from its sales and listening, counting its distinct listeners
+func (s *analyticsService) totals(
+	artistID uuid.UUID,
+	from, until time.Time,
+	sales []models.PeriodSales,
+	listens []models.SongListenStats,
+) (AnalyticsTotals, error) {
+	totals := AnalyticsTotals{Revenue: map[string]float64{}}
+
+	var err error
+	totals.Streams, totals.Listeners, err = s.streamRepo.GetArtistStreamStats(artistID, from, until)
+	if err != nil {
+		return totals, err
+	}
+
+	for _, period := range sales {
+		totals.Purchases += period.Purchases
+		totals.Tips += period.Tips
+		totals.Revenue[period.Currency] += period.Revenue
+	}
+
+	var completions, skips int64
+	for _, song := range listens {
+		totals.Listens += song.Listens
+		completions += song.Completions
+		skips += song.Skips
+	}
+	if totals.Listens > 0 {
+		totals.CompletionRate = float64(completions) / float64(totals.Listens)
+		totals.SkipRate = float64(skips) / float64(totals.Listens)
+	}
+	return totals, nil
+}
+
+func compareTotals(current, previous AnalyticsTotals) AnalyticsChange {
+	change := AnalyticsChange{
+		Streams:   relativeChange(float64(current.Streams), float64(previous.Streams)),
+		Listeners: relativeChange(float64(current.Listeners), float64(previous.Listeners)),
+		Purchases: relativeChange(float64(current.Purchases), float64(previous.Purchases)),
+		Tips:      relativeChange(float64(current.Tips), float64(previous.Tips)),
+		Revenue:   map[string]float64{},
+	}
+	for currency, amount := range previous.Revenue {
+		if moved := relativeChange(current.Revenue[currency], amount); moved != nil {
+			change.Revenue[currency] = *moved
+		}
+	}
+	return change
+}
+
+func relativeChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := (current - previous) / previous
+	return &change
+}
+
+// series lays streams and sales out over every period of the range, quiet ones included
+func series(from, until time.Time, granularity string, streams []models.PeriodStreams, sales []models.PeriodSales) []AnalyticsPoint {
+	points := []AnalyticsPoint{}
+	index := map[string]int{}
+	for period := periodStart(from, granularity); period.Before(until); period = nextPeriod(period, granularity) {
+		key := period.Format("2006-01-02")
+		index[key] = len(points)
+		points = append(points, AnalyticsPoint{Period: key, Revenue: map[string]float64{}})
+	}
+
+	for _, period := range streams {
+		if i, ok := index[period.Period.Format("2006-01-02")]; ok {
+			points[i].Streams = period.Streams
+			points[i].Listeners = period.Listeners
+		}
+	}
+	for _, period := range sales {
+		if i, ok := index[period.Period.Format("2006-01-02")]; ok {
+			points[i].Purchases += period.Purchases
+			points[i].Tips += period.Tips
+			points[i].Revenue[period.Currency] += period.Revenue
+		}
+	}
+	return points
+}
+
+// songs breaks the range down by song, most streamed first
+func (s *analyticsService) songs(artistID uuid.UUID, from, until time.Time, listens []models.SongListenStats) ([]SongAnalytics, error) {
+	streams, err := s.streamRepo.GetArtistSongStreams(artistID, from, until)
+	if err != nil {
+		return nil, err
+	}
+	sales, err := s.salesRepo.GetSongSales(artistID, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	songs := []SongAnalytics{}
+	index := map[uuid.UUID]int{}
+	song := func(songID uuid.UUID, title string) *SongAnalytics {
+		i, ok := index[songID]
+		if !ok {
+			i = len(songs)
+			index[songID] = i
+			songs = append(songs, SongAnalytics{SongID: songID, Title: title, Revenue: map[string]float64{}})
+		}
+		return &songs[i]
+	}
+
+	for _, count := range streams {
+		song(count.SongID, count.Title).Streams = count.Streams
+	}
+	for _, sale := range sales {
+		entry := song(sale.SongID, sale.Title)
+		entry.Purchases += sale.Purchases
+		entry.Revenue[sale.Currency] += sale.Revenue
+	}
+	for _, stats := range listens {
+		entry := song(stats.SongID, stats.Title)
+		entry.Listens = stats.Listens
+		entry.Completions = stats.Completions
+		entry.Skips = stats.Skips
+		entry.CompletionRate = stats.CompletionRate
+		entry.SkipRate = stats.SkipRate
+		entry.AverageListened = stats.AverageListened
+	}
+
+	sort.SliceStable(songs, func(i, j int) bool {
+		if songs[i].Streams != songs[j].Streams {
+			return songs[i].Streams > songs[j].Streams
+		}
+		return songs[i].Purchases > songs[j].Purchases
+	})
+	return songs, nil
+}
+
+// shares breaks the range's streams down by country or device
+func (s *analyticsService) shares(artistID uuid.UUID, from, until time.Time, by string) ([]models.StreamShare, error) {
+	shares, err := s.streamRepo.GetArtistStreamShares(artistID, from, until, by)
+	if err != nil {
+		return nil, err
+	}
+	var total int64
+	for _, share := range shares {
+		total += share.Streams
+	}
+	for i := range shares {
+		shares[i].Share = float64(shares[i].Streams) / float64(total)
+	}
+	return shares, nil
+}
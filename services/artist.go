@@ -4,6 +4,7 @@ import (
 	"context"
 	"crawl/models"
 	"crawl/repositories"
+	"crawl/search"
 	"errors"
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
@@ -11,34 +12,41 @@ import (
 )
 
 type ArtistService interface {
-	SearchArtistsByName(ctx context.Context, query string, page int, limit int) ([]models.Artist, error)
+	SearchArtistsByName(ctx context.Context, query string, page int, limit int) ([]models.Artist, int64, error)
 	CreateArtist(ctx context.Context, artist *models.Artist) (*models.Artist, error)
 	GetArtistByID(ctx context.Context, artistID uuid.UUID) (*models.Artist, error)
 	GetAllArtists(ctx context.Context, page *int, limit *int) ([]models.Artist, error)
 	UpdateArtist(ctx context.Context, artistID uuid.UUID, artist *models.Artist) (*models.Artist, error)
-	GetArtistSongs(ctx context.Context, artistID uuid.UUID, page *int, limit *int) ([]models.Song, error)
+	GetArtistSongs(ctx context.Context, artistID uuid.UUID, page *int, limit *int, country string) ([]models.Song, error)
 }
 
 type artistService struct {
 	artistRepo repositories.IArtistRepository
 	songRepo   repositories.ISongRepository
 	userRepo   repositories.IUserRepository
+	index      search.SearchIndex
 }
 
 func NewArtistService(
 	artistRepo repositories.IArtistRepository,
 	songRepo repositories.ISongRepository,
 	userRepo repositories.IUserRepository,
+	index search.SearchIndex,
 ) ArtistService {
 	return &artistService{
 		artistRepo: artistRepo,
 		songRepo:   songRepo,
 		userRepo:   userRepo,
+		index:      index,
 	}
 }
 
-func (s *artistService) SearchArtistsByName(ctx context.Context, query string, page int, limit int) ([]models.Artist, error) {
-	return s.artistRepo.SearchByName(query, limit, page)
+func (s *artistService) SearchArtistsByName(ctx context.Context, query string, page int, limit int) ([]models.Artist, int64, error) {
+	var offset int
+	if page > 0 {
+		offset = (page - 1) * limit
+	}
+	return s.index.SearchArtists(ctx, search.ArtistQuery{Text: query, Offset: offset, Limit: limit})
 }
 
 func (s *artistService) CreateArtist(ctx context.Context, artist *models.Artist) (*models.Artist, error) {
@@ -46,7 +54,7 @@ func (s *artistService) CreateArtist(ctx context.Context, artist *models.Artist)
 		return nil, errors.New("artist name is required")
 	}
 
-	newArtist, err := s.artistRepo.Create(artist)
+	newArtist, err := s.artistRepo.WithContext(ctx).Create(artist)
 	if err == nil {
 		err := s.userRepo.SetUserAsArtist(newArtist.UserID)
 		if err != nil {
@@ -88,32 +96,35 @@ func (s *artistService) UpdateArtist(ctx context.Context, artistID uuid.UUID, ar
 		return nil, err
 	}
 
-	// Update fields
+	// Update fields; Verified is only changed through the verification workflow and
+	// MonthlyListeners by the monthly listeners job
 	existingArtist.ArtistName = artist.ArtistName
 	existingArtist.WalletBalance = artist.WalletBalance
 	existingArtist.StripeAccountID = artist.StripeAccountID
-	existingArtist.Verified = artist.Verified
-	existingArtist.MonthlyListeners = artist.MonthlyListeners
 
-	return s.artistRepo.Update(existingArtist)
+	return s.artistRepo.WithContext(ctx).Update(existingArtist)
 }
 
-func (s *artistService) GetArtistSongs(ctx context.Context, artistID uuid.UUID, page *int, limit *int) ([]models.Song, error) {
+func (s *artistService) GetArtistSongs(ctx context.Context, artistID uuid.UUID, page *int, limit *int, country string) ([]models.Song, error) {
 	// First verify artist exists
 	_, err := s.artistRepo.GetByID(artistID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("artist not found")
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrArtistNotFound
 		}
 		return nil, err
 	}
 
-	//var offset int
-	/*if page != nil && limit != nil {
+	var offset int
+	if page != nil && limit != nil {
 		offset = (*page - 1) * *limit
-	}*/
+	} else {
+		limit = new(int)
+		*limit = 20
+	}
 
-	songs, err := s.songRepo.GetByArtist(artistID)
+	// Songs the artist leads or is featured on both belong on their page
+	songs, err := s.songRepo.AvailableIn(country).GetCreditedTo(artistID, []string{models.CreditPrimary, models.CreditFeatured}, offset, *limit)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"errors"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// ChartSize is how many songs each weekly chart holds
+	ChartSize = 50
+
+	// Song trending compares the streams of the last day against the week before it
+	songTrendingWindow   = 24 * time.Hour
+	songTrendingBaseline = 7 * 24 * time.Hour
+	// minTrendingListeners keeps songs only a handful of people played off the list
+	minTrendingListeners = 5
+	// trendingKept songs are stored per refresh, enough to filter by genre
+	trendingKept        = 500
+	maxTrendingResults  = 100
+	defaultTrendingSize = 20
+)
+
+var (
+	ErrChartNotFound        = errors.New("chart not found")
+	ErrInvalidChartWeek     = errors.New("chart weeks start on a Monday and must be over before they're published")
+	ErrChartWeekNotRolledUp = errors.New("the chart week's streams aren't all rolled up yet")
+	ErrInvalidChartCountry  = errors.New("country must be a two-letter ISO 3166 code")
+	ErrInvalidChartKind     = errors.New("chart kind must be 'global', 'country' or 'genre'")
+	ErrChartSongNotFound    = errors.New("song not found")
+	ErrChartGenreNotFound   = errors.New("genre not found")
+)
+
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Chart is one weekly chart, top position first
+type Chart struct {
+	Kind    string              `json:"kind"`
+	Scope   string              `json:"scope,omitempty"` // country code or genre ID
+	Week    string              `json:"week"`            // Monday the chart week starts
+	Entries []models.ChartEntry `json:"entries"`
+}
+
+type ChartService interface {
+	// PublishWeek publishes the charts of the week starting on week and returns their entries
+	PublishWeek(ctx context.Context, week time.Time) (int64, error)
+	// PublishRecent publishes the weeks that ended since the latest one published, once their streams are rolled up
+	PublishRecent(ctx context.Context) error
+	RefreshTrending(ctx context.Context) error
+	// GetChart returns a chart of the week holding week, or of the latest week published when week is nil
+	GetChart(ctx context.Context, kind, scope string, week *time.Time, country string) (*Chart, error)
+	GetTrending(ctx context.Context, genreID *uuid.UUID, limit *int, country string) ([]models.TrendingSong, error)
+	// GetSongHistory lists every chart place the song has had, latest week first
+	GetSongHistory(ctx context.Context, songID uuid.UUID) ([]models.ChartEntry, error)
+}
+
+type chartService struct {
+	chartRepo  repositories.IChartRepository
+	rollupRepo repositories.IStreamRollupRepository
+	songRepo   repositories.ISongRepository
+	genreRepo  repositories.IGenreRepository
+}
+
+func NewChartService(
+	chartRepo repositories.IChartRepository,
+	rollupRepo repositories.IStreamRollupRepository,
+	songRepo repositories.ISongRepository,
+	genreRepo repositories.IGenreRepository,
+) ChartService {
+	return &chartService{
+		chartRepo:  chartRepo,
+		rollupRepo: rollupRepo,
+		songRepo:   songRepo,
+		genreRepo:  genreRepo,
+	}
+}
+
+func (s *chartService) PublishWeek(ctx context.Context, week time.Time) (int64, error) {
+	start := repositories.UTCDay(week)
+	next := start.AddDate(0, 0, 7)
+	if periodStart(start, GranularityWeek) != start || next.After(repositories.UTCDay(time.Now())) {
+		return 0, ErrInvalidChartWeek
+	}
+
+	// Charts are counted from the rollups, so every day of the week must be in them
+	missing, err := s.rollupRepo.MissingDays(start, next)
+	if err != nil {
+		return 0, err
+	}
+	if len(missing) > 0 {
+		return 0, ErrChartWeekNotRolledUp
+	}
+
+	entries, err := s.chartRepo.PublishWeek(start, ChartSize)
+	if err != nil {
+		return 0, err
+	}
+	log.Infof("Published %d chart entries for the week of %s", entries, start.Format("2006-01-02"))
+	return entries, nil
+}
+
+func (s *chartService) PublishRecent(ctx context.Context) error {
+	last := periodStart(repositories.UTCDay(time.Now()), GranularityWeek).AddDate(0, 0, -7)
+	latest, err := s.chartRepo.LatestWeek()
+	if err != nil {
+		return err
+	}
+
+	// Catch up on the weeks missed while the server was down, so previous positions carry over
+	week := last
+	if !latest.IsZero() {
+		week = latest.AddDate(0, 0, 7)
+	}
+	for ; !week.After(last); week = week.AddDate(0, 0, 7) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := s.PublishWeek(ctx, week); err != nil {
+			if errors.Is(err, ErrChartWeekNotRolledUp) {
+				// The rollups will catch up; try again on the next run
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *chartService) RefreshTrending(ctx context.Context) error {
+	recent := time.Now().Add(-songTrendingWindow)
+	songs, err := s.chartRepo.RefreshTrending(recent, recent.Add(-songTrendingBaseline), minTrendingListeners, trendingKept)
+	if err != nil {
+		return err
+	}
+	log.Infof("Refreshed %d trending songs", songs)
+	return nil
+}
+
+func (s *chartService) GetChart(ctx context.Context, kind, scope string, week *time.Time, country string) (*Chart, error) {
+	switch kind {
+	case models.ChartGlobal:
+		scope = ""
+	case models.ChartCountry:
+		scope = strings.ToUpper(scope)
+		if !countryCodePattern.MatchString(scope) {
+			return nil, ErrInvalidChartCountry
+		}
+	case models.ChartGenre:
+		genreID, err := uuid.Parse(scope)
+		if err != nil {
+			return nil, ErrChartGenreNotFound
+		}
+		if _, err := s.genreRepo.GetByID(genreID); err != nil {
+			if errors.Is(err, repositories.ErrRecordNotFound) {
+				return nil, ErrChartGenreNotFound
+			}
+			return nil, err
+		}
+	default:
+		return nil, ErrInvalidChartKind
+	}
+
+	var start time.Time
+	if week != nil {
+		start = periodStart(repositories.UTCDay(*week), GranularityWeek)
+		published, err := s.chartRepo.IsPublished(start)
+		if err != nil {
+			return nil, err
+		}
+		if !published {
+			return nil, ErrChartNotFound
+		}
+	} else {
+		latest, err := s.chartRepo.LatestWeek()
+		if err != nil {
+			return nil, err
+		}
+		if latest.IsZero() {
+			return nil, ErrChartNotFound
+		}
+		start = latest
+	}
+
+	entries, err := s.chartRepo.GetEntries(kind, scope, start, country)
+	if err != nil {
+		return nil, err
+	}
+	return &Chart{
+		Kind:    kind,
+		Scope:   scope,
+		Week:    start.Format("2006-01-02"),
+		Entries: entries,
+	}, nil
+}
+
+func (s *chartService) GetTrending(ctx context.Context, genreID *uuid.UUID, limit *int, country string) ([]models.TrendingSong, error) {
+	if genreID != nil {
+		if _, err := s.genreRepo.GetByID(*genreID); err != nil {
+			if errors.Is(err, repositories.ErrRecordNotFound) {
+				return nil, ErrChartGenreNotFound
+			}
+			return nil, err
+		}
+	}
+	return s.chartRepo.GetTrending(genreID, reportLimit(limit, defaultTrendingSize, maxTrendingResults), country)
+}
+
+func (s *chartService) GetSongHistory(ctx context.Context, songID uuid.UUID) ([]models.ChartEntry, error) {
+	if _, err := s.songRepo.GetByID(songID); err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrChartSongNotFound
+		}
+		return nil, err
+	}
+	return s.chartRepo.GetSongEntries(songID)
+}
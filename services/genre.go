@@ -6,14 +6,25 @@ import (
 	"crawl/repositories"
 	"errors"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
+	"strings"
+)
+
+var (
+	ErrGenreNotFound      = errors.New("genre not found")
+	ErrGenreExists        = errors.New("genre already exists")
+	ErrGenreNameRequired  = errors.New("genre name is required")
+	ErrInvalidGenreParent = errors.New("genre cannot be nested under itself or its sub-genres")
 )
 
 type GenreService interface {
 	GetAllGenres(ctx context.Context) ([]models.Genre, error)
 	GetGenreByID(ctx context.Context, genreID uuid.UUID) (*models.Genre, error)
+	GetSubgenres(ctx context.Context, genreID uuid.UUID) ([]models.Genre, error)
 	GetPopularGenres(ctx context.Context, limit int) ([]models.Genre, error)
 	SearchGenres(ctx context.Context, query *string, sort *string) ([]models.Genre, error)
+	CreateGenre(ctx context.Context, genre *models.Genre) (*models.Genre, error)
+	UpdateGenre(ctx context.Context, genreID uuid.UUID, genre *models.Genre) (*models.Genre, error)
+	DeleteGenre(ctx context.Context, genreID uuid.UUID) error
 }
 type genreService struct {
 	genreRepo repositories.IGenreRepository
@@ -43,8 +54,8 @@ func (s *genreService) GetAllGenres(ctx context.Context) ([]models.Genre, error)
 func (s *genreService) GetGenreByID(ctx context.Context, genreID uuid.UUID) (*models.Genre, error) {
 	genre, err := s.genreRepo.GetByID(genreID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("genre not found")
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrGenreNotFound
 		}
 		return nil, err
 	}
@@ -52,10 +63,116 @@ func (s *genreService) GetGenreByID(ctx context.Context, genreID uuid.UUID) (*mo
 	return genre, nil
 }
 
+func (s *genreService) GetSubgenres(ctx context.Context, genreID uuid.UUID) ([]models.Genre, error) {
+	if _, err := s.GetGenreByID(ctx, genreID); err != nil {
+		return nil, err
+	}
+
+	genres, err := s.genreRepo.GetChildren(genreID)
+	if err != nil {
+		return nil, err
+	}
+
+	if genres == nil {
+		return []models.Genre{}, nil
+	}
+
+	return genres, nil
+}
+
 func (s *genreService) GetPopularGenres(ctx context.Context, limit int) ([]models.Genre, error) {
 	return s.genreRepo.GetPopular(limit)
 }
 
 func (s *genreService) SearchGenres(ctx context.Context, query *string, sort *string) ([]models.Genre, error) {
-	return s.genreRepo.SearchGenres(query, sort)
+	return s.genreRepo.SearchGenres(ctx, query, sort)
+}
+
+func (s *genreService) CreateGenre(ctx context.Context, genre *models.Genre) (*models.Genre, error) {
+	genre.Name = strings.TrimSpace(genre.Name)
+	if genre.Name == "" {
+		return nil, ErrGenreNameRequired
+	}
+
+	if err := s.checkNameAvailable(genre.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	if genre.ParentID != nil {
+		if _, err := s.GetGenreByID(ctx, *genre.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.genreRepo.Create(genre)
+}
+
+func (s *genreService) UpdateGenre(ctx context.Context, genreID uuid.UUID, genre *models.Genre) (*models.Genre, error) {
+	existingGenre, err := s.GetGenreByID(ctx, genreID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(genre.Name)
+	if name == "" {
+		return nil, ErrGenreNameRequired
+	}
+	if err := s.checkNameAvailable(name, genreID); err != nil {
+		return nil, err
+	}
+
+	// A genre can't be moved beneath itself or one of its own sub-genres
+	if genre.ParentID != nil {
+		if _, err := s.GetGenreByID(ctx, *genre.ParentID); err != nil {
+			return nil, err
+		}
+		cyclic, err := s.genreRepo.IsInSubtree(genreID, *genre.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if cyclic {
+			return nil, ErrInvalidGenreParent
+		}
+	}
+
+	// Updates skips nil fields, so the parent is written on its own to allow
+	// moving a sub-genre back to the top level
+	if err := s.genreRepo.SetParent(genreID, genre.ParentID); err != nil {
+		return nil, err
+	}
+
+	existingGenre.Name = name
+	existingGenre.Description = genre.Description
+	existingGenre.ImageURL = genre.ImageURL
+	existingGenre.ParentID = genre.ParentID
+
+	return s.genreRepo.Update(existingGenre)
+}
+
+func (s *genreService) DeleteGenre(ctx context.Context, genreID uuid.UUID) error {
+	genre, err := s.GetGenreByID(ctx, genreID)
+	if err != nil {
+		return err
+	}
+
+	// Keep the hierarchy connected by moving sub-genres up a level
+	if err := s.genreRepo.ReparentChildren(genreID, genre.ParentID); err != nil {
+		return err
+	}
+
+	return s.genreRepo.Delete(genreID)
+}
+
+func (s *genreService) checkNameAvailable(name string, genreID uuid.UUID) error {
+	existing, err := s.genreRepo.FindByName(name)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != genreID {
+		return ErrGenreExists
+	}
+	return nil
 }
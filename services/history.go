@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"errors"
+	"github.com/google/uuid"
+)
+
+var ErrVersionNotFound = errors.New("version not found")
+
+type HistoryService interface {
+	GetHistory(ctx context.Context, entityType string, entityID uuid.UUID, page *int, limit *int) ([]models.EntityVersion, error)
+	Rollback(ctx context.Context, entityType string, entityID uuid.UUID, version int) error
+}
+
+type historyService struct {
+	entityVersionRepo repositories.IEntityVersionRepository
+}
+
+func NewHistoryService(entityVersionRepo repositories.IEntityVersionRepository) HistoryService {
+	return &historyService{
+		entityVersionRepo: entityVersionRepo,
+	}
+}
+
+func (s *historyService) GetHistory(ctx context.Context, entityType string, entityID uuid.UUID, page *int, limit *int) ([]models.EntityVersion, error) {
+	var offset int
+	if page != nil && limit != nil {
+		offset = (*page - 1) * *limit
+	} else {
+		limit = new(int)
+		*limit = 20
+	}
+
+	versions, err := s.entityVersionRepo.GetHistory(entityType, entityID, offset, *limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no versions found, return empty slice rather than nil
+	if versions == nil {
+		return []models.EntityVersion{}, nil
+	}
+
+	return versions, nil
+}
+
+func (s *historyService) Rollback(ctx context.Context, entityType string, entityID uuid.UUID, version int) error {
+	target, err := s.entityVersionRepo.FindVersion(entityType, entityID, version)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return ErrVersionNotFound
+		}
+		return err
+	}
+
+	return s.entityVersionRepo.Restore(ctx, entityType, entityID, target.Snapshot)
+}
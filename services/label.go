@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+)
+
+var (
+	ErrLabelNotFound       = errors.New("label not found")
+	ErrLabelNameRequired   = errors.New("label name is required")
+	ErrNotLabelMember      = errors.New("user is not a member of this label")
+	ErrInvalidLabelRole    = errors.New("role must be 'manager' or 'member'")
+	ErrLabelOwnerImmutable = errors.New("the label owner cannot be changed or removed")
+	ErrArtistLinkExists    = errors.New("artist is already linked to this label")
+	ErrArtistLinkNotFound  = errors.New("artist is not linked to this label")
+	ErrUserNotFound        = errors.New("user not found")
+)
+
+// LabelRoyaltyReport aggregates the royalties of every artist who shares them with a label
+type LabelRoyaltyReport struct {
+	LabelID uuid.UUID                   `json:"label_id"`
+	Year    *int                        `json:"year,omitempty"`
+	Month   *int                        `json:"month,omitempty"`
+	Totals  map[string]int64            `json:"totals"` // amount per currency
+	Artists []models.ArtistRoyaltyTotal `json:"artists"`
+}
+
+type LabelService interface {
+	CreateLabel(ctx context.Context, ownerID uuid.UUID, label *models.Label) (*models.Label, error)
+	GetLabel(ctx context.Context, labelID uuid.UUID) (*models.Label, error)
+	GetUserLabels(ctx context.Context, userID uuid.UUID) ([]models.Label, error)
+	UpdateLabel(ctx context.Context, labelID uuid.UUID, label *models.Label) (*models.Label, error)
+	DeleteLabel(ctx context.Context, labelID uuid.UUID) error
+	GetMemberRole(ctx context.Context, labelID uuid.UUID, userID uuid.UUID) (string, error)
+	GetMembers(ctx context.Context, labelID uuid.UUID) ([]models.LabelMember, error)
+	AddMember(ctx context.Context, labelID uuid.UUID, userID uuid.UUID, role string) (*models.LabelMember, error)
+	RemoveMember(ctx context.Context, labelID uuid.UUID, userID uuid.UUID) error
+	GetArtistLinks(ctx context.Context, labelID uuid.UUID) ([]models.LabelArtist, error)
+	InviteArtist(ctx context.Context, labelID uuid.UUID, link *models.LabelArtist) (*models.LabelArtist, error)
+	RemoveArtist(ctx context.Context, labelID uuid.UUID, artistID uuid.UUID) error
+	GetArtistLabels(ctx context.Context, artistID uuid.UUID) ([]models.LabelArtist, error)
+	AcceptArtistLink(ctx context.Context, labelID uuid.UUID, artistID uuid.UUID) (*models.LabelArtist, error)
+	UpdateArtistPermissions(ctx context.Context, labelID uuid.UUID, artistID uuid.UUID, permissions *models.LabelArtist) (*models.LabelArtist, error)
+	CanActForArtist(ctx context.Context, userID uuid.UUID, artistID uuid.UUID, permission string) (bool, error)
+	GetRoyaltyReport(ctx context.Context, labelID uuid.UUID, year *int, month *int) (*LabelRoyaltyReport, error)
+}
+
+type labelService struct {
+	labelRepo   repositories.ILabelRepository
+	userRepo    repositories.IUserRepository
+	artistRepo  repositories.IArtistRepository
+	royaltyRepo repositories.IMonthlyRoyaltyRepository
+}
+
+func NewLabelService(
+	labelRepo repositories.ILabelRepository,
+	userRepo repositories.IUserRepository,
+	artistRepo repositories.IArtistRepository,
+	royaltyRepo repositories.IMonthlyRoyaltyRepository,
+) LabelService {
+	return &labelService{
+		labelRepo:   labelRepo,
+		userRepo:    userRepo,
+		artistRepo:  artistRepo,
+		royaltyRepo: royaltyRepo,
+	}
+}
+
+func (s *labelService) CreateLabel(ctx context.Context, ownerID uuid.UUID, label *models.Label) (*models.Label, error) {
+	label.Name = strings.TrimSpace(label.Name)
+	if label.Name == "" {
+		return nil, ErrLabelNameRequired
+	}
+
+	created, err := s.labelRepo.Create(label)
+	if err != nil {
+		return nil, err
+	}
+
+	// Whoever creates the label owns it
+	err = s.labelRepo.SaveMember(&models.LabelMember{
+		LabelID: created.ID,
+		UserID:  ownerID,
+		Role:    models.LabelRoleOwner,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *labelService) GetLabel(ctx context.Context, labelID uuid.UUID) (*models.Label, error) {
+	label, err := s.labelRepo.GetWithArtists(labelID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, err
+	}
+	return label, nil
+}
+
+func (s *labelService) GetUserLabels(ctx context.Context, userID uuid.UUID) ([]models.Label, error) {
+	labels, err := s.labelRepo.GetUserLabels(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no labels found, return empty slice rather than nil
+	if labels == nil {
+		return []models.Label{}, nil
+	}
+
+	return labels, nil
+}
+
+func (s *labelService) UpdateLabel(ctx context.Context, labelID uuid.UUID, label *models.Label) (*models.Label, error) {
+	existingLabel, err := s.labelRepo.GetByID(labelID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, err
+	}
+
+	name := strings.TrimSpace(label.Name)
+	if name == "" {
+		return nil, ErrLabelNameRequired
+	}
+
+	existingLabel.Name = name
+	existingLabel.Description = label.Description
+	existingLabel.LogoURL = label.LogoURL
+
+	return s.labelRepo.Update(existingLabel)
+}
+
+func (s *labelService) DeleteLabel(ctx context.Context, labelID uuid.UUID) error {
+	err := s.labelRepo.Delete(labelID)
+	if errors.Is(err, repositories.ErrRecordNotFound) {
+		return ErrLabelNotFound
+	}
+	return err
+}
+
+func (s *labelService) GetMemberRole(ctx context.Context, labelID uuid.UUID, userID uuid.UUID) (string, error) {
+	member, err := s.labelRepo.GetMember(labelID, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return "", ErrNotLabelMember
+		}
+		return "", err
+	}
+	return member.Role, nil
+}
+
+func (s *labelService) GetMembers(ctx context.Context, labelID uuid.UUID) ([]models.LabelMember, error) {
+	members, err := s.labelRepo.GetMembers(labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if members == nil {
+		return []models.LabelMember{}, nil
+	}
+
+	return members, nil
+}
+
+func (s *labelService) AddMember(ctx context.Context, labelID uuid.UUID, userID uuid.UUID, role string) (*models.LabelMember, error) {
+	if role == "" {
+		role = models.LabelRoleMember
+	}
+	if role != models.LabelRoleManager && role != models.LabelRoleMember {
+		return nil, ErrInvalidLabelRole
+	}
+
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if currentRole, err := s.GetMemberRole(ctx, labelID, userID); err == nil && currentRole == models.LabelRoleOwner {
+		return nil, ErrLabelOwnerImmutable
+	}
+
+	member := &models.LabelMember{LabelID: labelID, UserID: userID, Role: role}
+	if err := s.labelRepo.SaveMember(member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *labelService) RemoveMember(ctx context.Context, labelID uuid.UUID, userID uuid.UUID) error {
+	role, err := s.GetMemberRole(ctx, labelID, userID)
+	if err != nil {
+		return err
+	}
+	if role == models.LabelRoleOwner {
+		return ErrLabelOwnerImmutable
+	}
+
+	return s.labelRepo.RemoveMember(labelID, userID)
+}
+
+func (s *labelService) GetArtistLinks(ctx context.Context, labelID uuid.UUID) ([]models.LabelArtist, error) {
+	links, err := s.labelRepo.GetArtistLinks(labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if links == nil {
+		return []models.LabelArtist{}, nil
+	}
+
+	return links, nil
+}
+
+func (s *labelService) InviteArtist(ctx context.Context, labelID uuid.UUID, link *models.LabelArtist) (*models.LabelArtist, error) {
+	if _, err := s.artistRepo.GetByID(link.ArtistID); err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrArtistNotFound
+		}
+		return nil, err
+	}
+
+	_, err := s.labelRepo.GetArtistLink(labelID, link.ArtistID)
+	if err == nil {
+		return nil, ErrArtistLinkExists
+	}
+	if !errors.Is(err, repositories.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// The link stays inactive until the artist accepts it
+	link.LabelID = labelID
+	link.Status = models.LabelArtistPending
+	if err := s.labelRepo.CreateArtistLink(link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (s *labelService) RemoveArtist(ctx context.Context, labelID uuid.UUID, artistID uuid.UUID) error {
+	err := s.labelRepo.RemoveArtistLink(labelID, artistID)
+	if errors.Is(err, repositories.ErrRecordNotFound) {
+		return ErrArtistLinkNotFound
+	}
+	return err
+}
+
+func (s *labelService) GetArtistLabels(ctx context.Context, artistID uuid.UUID) ([]models.LabelArtist, error) {
+	links, err := s.labelRepo.GetArtistLabels(artistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if links == nil {
+		return []models.LabelArtist{}, nil
+	}
+
+	return links, nil
+}
+
+func (s *labelService) AcceptArtistLink(ctx context.Context, labelID uuid.UUID, artistID uuid.UUID) (*models.LabelArtist, error) {
+	link, err := s.getArtistLink(labelID, artistID)
+	if err != nil {
+		return nil, err
+	}
+
+	link.Status = models.LabelArtistActive
+	if err := s.labelRepo.UpdateArtistLink(link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (s *labelService) UpdateArtistPermissions(ctx context.Context, labelID uuid.UUID, artistID uuid.UUID, permissions *models.LabelArtist) (*models.LabelArtist, error) {
+	link, err := s.getArtistLink(labelID, artistID)
+	if err != nil {
+		return nil, err
+	}
+
+	link.CanManageCatalog = permissions.CanManageCatalog
+	link.CanViewAnalytics = permissions.CanViewAnalytics
+	link.CanViewRoyalties = permissions.CanViewRoyalties
+	if err := s.labelRepo.UpdateArtistLink(link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (s *labelService) CanActForArtist(ctx context.Context, userID uuid.UUID, artistID uuid.UUID, permission string) (bool, error) {
+	return s.labelRepo.HasArtistPermission(userID, artistID, permission)
+}
+
+func (s *labelService) GetRoyaltyReport(ctx context.Context, labelID uuid.UUID, year *int, month *int) (*LabelRoyaltyReport, error) {
+	if _, err := s.labelRepo.GetByID(labelID); err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, err
+	}
+
+	// Only artists who granted the royalties permission are included
+	artistIDs, err := s.labelRepo.GetPermittedArtistIDs(labelID, models.LabelPermissionRoyalties)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.royaltyRepo.SumForArtists(artistIDs, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LabelRoyaltyReport{
+		LabelID: labelID,
+		Year:    year,
+		Month:   month,
+		Totals:  map[string]int64{},
+		Artists: []models.ArtistRoyaltyTotal{},
+	}
+	for _, row := range rows {
+		report.Totals[row.Currency] += row.Amount
+		report.Artists = append(report.Artists, row)
+	}
+	return report, nil
+}
+
+func (s *labelService) getArtistLink(labelID uuid.UUID, artistID uuid.UUID) (*models.LabelArtist, error) {
+	link, err := s.labelRepo.GetArtistLink(labelID, artistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrArtistLinkNotFound
+		}
+		return nil, err
+	}
+	return link, nil
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// Recently played looks back over the last 90 days of plays
+	recentlyPlayedWindow  = 90 * 24 * time.Hour
+	defaultRecentlyPlayed = 20
+	maxRecentlyPlayed     = 50
+)
+
+var (
+	ErrHistoryItemNotFound = errors.New("listening history item not found")
+	ErrHistoryUserNotFound = errors.New("user not found")
+)
+
+// ListeningHistorySettings is how a listener's plays are kept in their history
+type ListeningHistorySettings struct {
+	Paused bool `json:"paused"` // new plays are left out of the history and recommendations
+}
+
+type ListeningHistoryService interface {
+	// GetHistory lists the user's plays, latest first
+	GetHistory(ctx context.Context, userID uuid.UUID, page *int, limit *int) ([]models.ListeningHistoryItem, error)
+	DeleteItem(ctx context.Context, userID, streamID uuid.UUID) error
+	// ClearHistory removes every play so far from the user's history and returns how many it removed
+	ClearHistory(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetRecentlyPlayed(ctx context.Context, userID uuid.UUID, limit *int, country string) ([]models.RecentPlay, error)
+	GetSettings(ctx context.Context, userID uuid.UUID) (*ListeningHistorySettings, error)
+	UpdateSettings(ctx context.Context, userID uuid.UUID, settings ListeningHistorySettings) (*ListeningHistorySettings, error)
+}
+
+type listeningHistoryService struct {
+	historyRepo repositories.IListeningHistoryRepository
+	userRepo    repositories.IUserRepository
+}
+
+func NewListeningHistoryService(
+	historyRepo repositories.IListeningHistoryRepository,
+	userRepo repositories.IUserRepository,
+) ListeningHistoryService {
+	return &listeningHistoryService{
+		historyRepo: historyRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (s *listeningHistoryService) GetHistory(ctx context.Context, userID uuid.UUID, page *int, limit *int) ([]models.ListeningHistoryItem, error) {
+	var offset int
+	if page != nil && limit != nil {
+		offset = (*page - 1) * *limit
+	} else {
+		limit = new(int)
+		*limit = 50
+	}
+
+	streams, err := s.historyRepo.GetHistory(userID, offset, *limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only what the listener played is returned, not how the play was judged
+	items := make([]models.ListeningHistoryItem, len(streams))
+	for i, stream := range streams {
+		items[i] = models.ListeningHistoryItem{
+			StreamID:        stream.ID,
+			PlayedAt:        stream.CreatedAt,
+			SongID:          stream.SongID,
+			ContextType:     stream.ContextType,
+			ContextID:       stream.ContextID,
+			DeviceType:      stream.DeviceType,
+			ListenedSeconds: stream.ListenedSeconds,
+			Completed:       stream.Completed,
+			Song:            stream.Song,
+		}
+	}
+	return items, nil
+}
+
+func (s *listeningHistoryService) DeleteItem(ctx context.Context, userID, streamID uuid.UUID) error {
+	found, err := s.historyRepo.HideStream(userID, streamID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrHistoryItemNotFound
+	}
+	return nil
+}
+
+func (s *listeningHistoryService) ClearHistory(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return s.historyRepo.HideAll(userID, time.Now())
+}
+
+func (s *listeningHistoryService) GetRecentlyPlayed(ctx context.Context, userID uuid.UUID, limit *int, country string) ([]models.RecentPlay, error) {
+	since := time.Now().Add(-recentlyPlayedWindow)
+	return s.historyRepo.RecentlyPlayed(userID, since, reportLimit(limit, defaultRecentlyPlayed, maxRecentlyPlayed), country)
+}
+
+func (s *listeningHistoryService) GetSettings(ctx context.Context, userID uuid.UUID) (*ListeningHistorySettings, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrHistoryUserNotFound
+		}
+		return nil, err
+	}
+	return &ListeningHistorySettings{Paused: user.HistoryPaused}, nil
+}
+
+func (s *listeningHistoryService) UpdateSettings(ctx context.Context, userID uuid.UUID, settings ListeningHistorySettings) (*ListeningHistorySettings, error) {
+	found, err := s.historyRepo.SetHistoryPaused(userID, settings.Paused)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrHistoryUserNotFound
+	}
+	return &settings, nil
+}
@@ -0,0 +1,9 @@
+package services
+
+import "crawl/live"
+
+// LiveFeed passes plays, tips and purchases on to the artists watching them
+// live. Publishing must not block.
+type LiveFeed interface {
+	Publish(event live.Event)
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"crawl/repositories"
+	"errors"
+	"github.com/gofiber/fiber/v2/log"
+	"time"
+)
+
+// monthlyListenersLookback is how many finished days every scheduled run computes
+// again, picking up late streams and reviewed streams the way rollups do
+const monthlyListenersLookback = rollupLookback
+
+var ErrInvalidMonthlyListenersRange = errors.New("monthly listeners range must start on or before its end and can't reach past yesterday")
+
+type MonthlyListenersService interface {
+	// ComputeRange computes every artist's monthly listeners as of each UTC day
+	// from from through to, updates the artists to the latest day computed and
+	// returns how many days it computed
+	ComputeRange(ctx context.Context, from, to time.Time) (int, error)
+	// ComputeRecent computes the last few finished days again, along with any
+	// days missed since the latest one computed
+	ComputeRecent(ctx context.Context) error
+}
+
+type monthlyListenersService struct {
+	listenersRepo repositories.IMonthlyListenersRepository
+}
+
+func NewMonthlyListenersService(listenersRepo repositories.IMonthlyListenersRepository) MonthlyListenersService {
+	return &monthlyListenersService{listenersRepo: listenersRepo}
+}
+
+func (s *monthlyListenersService) ComputeRange(ctx context.Context, from, to time.Time) (int, error) {
+	first, last := repositories.UTCDay(from), repositories.UTCDay(to)
+	// Today isn't over, so its 28 days aren't either
+	if last.Before(first) || !last.Before(repositories.UTCDay(time.Now())) {
+		return 0, ErrInvalidMonthlyListenersRange
+	}
+
+	days := 0
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if err := ctx.Err(); err != nil {
+			return days, err
+		}
+		artists, err := s.listenersRepo.ComputeDay(day)
+		if err != nil {
+			return days, err
+		}
+		log.Infof("Computed monthly listeners of %d artists as of %s", artists, day.Format("2006-01-02"))
+		days++
+	}
+
+	// A backfill of older days leaves the artists at the latest count
+	latest, err := s.listenersRepo.LatestDay()
+	if err != nil {
+		return days, err
+	}
+	return days, s.listenersRepo.UpdateArtists(latest)
+}
+
+func (s *monthlyListenersService) ComputeRecent(ctx context.Context) error {
+	yesterday := repositories.UTCDay(time.Now()).AddDate(0, 0, -1)
+	from := yesterday.AddDate(0, 0, 1-monthlyListenersLookback)
+
+	// Catch up on the days missed since the latest one computed, so the history has no gaps
+	latest, err := s.listenersRepo.LatestDay()
+	if err != nil {
+		return err
+	}
+	if !latest.IsZero() && latest.Before(from) {
+		from = latest.AddDate(0, 0, 1)
+	}
+
+	_, err = s.ComputeRange(ctx, from, yesterday)
+	return err
+}
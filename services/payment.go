@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"crawl/live"
+	"crawl/models"
+	"crawl/payments"
+	"crawl/repositories"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknownPaymentProvider = errors.New("unknown payment provider")
+	ErrPaymentNotFound        = errors.New("payment not found")
+	ErrPaymentDeclined        = errors.New("payment provider declined the payment")
+	ErrInvalidWebhook         = errors.New("webhook signature is invalid")
+)
+
+// paymentResumeWindow is how long a pending payment is handed back to finish
+// instead of charging again. Older ones are taken for abandoned.
+const paymentResumeWindow = time.Hour
+
+// PaymentCharge is what a user is about to pay for
+type PaymentCharge struct {
+	UserID uuid.UUID
+	// Provider charges the payment; "" picks the default provider
+	Provider string
+	Purpose  string
+	TargetID uuid.UUID
+	Amount   float64 // in major units of Currency, e.g. naira
+	Currency string
+	// PaymentMethodID is a card or authorization the user saved with the
+	// provider, charged without them; without one they finish paying on the
+	// payment's checkout page or in their app
+	PaymentMethodID string
+	Description     string
+}
+
+type PaymentService interface {
+	// Charge starts a pending payment for a purchase or tip. What it pays for
+	// stays pending until the provider's webhook settles it, unless the
+	// provider settles it on the spot, e.g. charging a saved card. A payment
+	// declined on the spot fails with ErrPaymentDeclined.
+	Charge(ctx context.Context, charge PaymentCharge) (*models.Payment, error)
+	// HandleWebhook verifies a provider's webhook and settles the payment it
+	// reports on. Purchases and tips are only completed here, when the
+	// provider reports that they were paid.
+	HandleWebhook(ctx context.Context, provider string, header func(string) string, body []byte) error
+	// Resumable finds the payment for purpose and target still pending, for
+	// the payer to finish instead of being charged again, or nil when there
+	// is none
+	Resumable(ctx context.Context, purpose string, targetID uuid.UUID) (*models.Payment, error)
+	GetPayment(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error)
+}
+
+type paymentService struct {
+	providers         *payments.Registry
+	paymentRepo       repositories.IPaymentRepository
+	userRepo          repositories.IUserRepository
+	songPurchaseRepo  repositories.ISongPurchaseRepository
+	albumPurchaseRepo repositories.IAlbumPurchaseRepository
+	tipRepo           repositories.ITipRepository
+	songRepo          repositories.ISongRepository
+	albumRepo         repositories.IAlbumRepository
+	feed              LiveFeed
+}
+
+// NewPaymentService charges through providers and publishes paid purchases
+// and tips to feed
+func NewPaymentService(
+	providers *payments.Registry,
+	paymentRepo repositories.IPaymentRepository,
+	userRepo repositories.IUserRepository,
+	songPurchaseRepo repositories.ISongPurchaseRepository,
+	albumPurchaseRepo repositories.IAlbumPurchaseRepository,
+	tipRepo repositories.ITipRepository,
+	songRepo repositories.ISongRepository,
+	albumRepo repositories.IAlbumRepository,
+	feed LiveFeed,
+) PaymentService {
+	return &paymentService{
+		providers:         providers,
+		paymentRepo:       paymentRepo,
+		userRepo:          userRepo,
+		songPurchaseRepo:  songPurchaseRepo,
+		albumPurchaseRepo: albumPurchaseRepo,
+		tipRepo:           tipRepo,
+		songRepo:          songRepo,
+		albumRepo:         albumRepo,
+		feed:              feed,
+	}
+}
+
+func (s *paymentService) Charge(ctx context.Context, charge PaymentCharge) (*models.Payment, error) {
+	provider, ok := s.providers.Get(charge.Provider)
+	if !ok {
+		return nil, ErrUnknownPaymentProvider
+	}
+	user, err := s.userRepo.GetByID(charge.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	payment := &models.Payment{
+		UserID:   charge.UserID,
+		Provider: provider.Name(),
+		Purpose:  charge.Purpose,
+		TargetID: charge.TargetID,
+		Amount:   payments.MinorUnits(charge.Amount, charge.Currency),
+		Currency: charge.Currency,
+	}
+	if err := s.paymentRepo.Create(payment); err != nil {
+		return nil, err
+	}
+
+	started, err := provider.CreatePayment(ctx, payments.Charge{
+		Reference:       payment.ID.String(),
+		Amount:          payment.Amount,
+		Currency:        payment.Currency,
+		Email:           user.Email,
+		PaymentMethodID: charge.PaymentMethodID,
+		Description:     charge.Description,
+	})
+	if err != nil {
+		log.Warnf("Failed to start %s payment %s: %s", provider.Name(), payment.ID, err.Error())
+		if _, err := s.paymentRepo.Settle(payment, models.PaymentFailed, truncate(err.Error(), 255)); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", ErrPaymentDeclined, err.Error())
+	}
+
+	payment.ProviderRef = started.ProviderRef
+	payment.CheckoutURL = started.CheckoutURL
+	if err := s.paymentRepo.SetProviderRef(payment); err != nil {
+		return nil, err
+	}
+	payment.ClientSecret = started.ClientSecret
+
+	// Settled like a webhook would; the webhook that may follow then finds the
+	// payment settled already
+	switch started.Status {
+	case payments.StatusFailed:
+		if err := s.settle(payment, models.PaymentFailed, "declined when charged"); err != nil {
+			return nil, err
+		}
+		return nil, ErrPaymentDeclined
+	case payments.StatusSucceeded:
+		if err := s.settle(payment, models.PaymentCompleted, ""); err != nil {
+			return nil, err
+		}
+	}
+	return payment, nil
+}
+
+func (s *paymentService) HandleWebhook(ctx context.Context, provider string, header func(string) string, body []byte) error {
+	if provider == "" {
+		return ErrUnknownPaymentProvider
+	}
+	p, ok := s.providers.Get(provider)
+	if !ok {
+		return ErrUnknownPaymentProvider
+	}
+	event, err := p.ParseWebhook(ctx, header, body)
+	if errors.Is(err, payments.ErrInvalidSignature) {
+		return ErrInvalidWebhook
+	}
+	if err != nil || event == nil {
+		return err
+	}
+
+	payment, err := s.findPayment(provider, event)
+	if errors.Is(err, repositories.ErrRecordNotFound) {
+		// Not one of ours, e.g. made on the provider's dashboard; there's
+		// nothing to settle, and retrying won't change that
+		log.Warnf("Ignoring %s webhook for unknown payment %q", provider, event.Reference)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	status, reason := models.PaymentFailed, event.Reason
+	if event.Status == payments.StatusSucceeded {
+		status = models.PaymentCompleted
+		if event.Amount != payment.Amount || !strings.EqualFold(event.Currency, payment.Currency) {
+			status = models.PaymentFailed
+			reason = fmt.Sprintf("paid %d %s, expected %d %s", event.Amount, event.Currency, payment.Amount, payment.Currency)
+			log.Warnf("Payment %s: %s", payment.ID, reason)
+		}
+	}
+
+	return s.settle(payment, status, reason)
+}
+
+// settle settles payment unless it already is. Once paid, the artist watching
+// live is told, unless it paid for something already paid for.
+func (s *paymentService) settle(payment *models.Payment, status, reason string) error {
+	settled, err := s.paymentRepo.Settle(payment, status, truncate(reason, 255))
+	if err != nil || !settled || status != models.PaymentCompleted {
+		return err
+	}
+	if payment.RefundDue {
+		log.Warnf("Payment %s paid for %s %s, which was already paid for; a refund is due",
+			payment.ID, payment.Purpose, payment.TargetID)
+		return nil
+	}
+	s.publish(payment)
+	return nil
+}
+
+func (s *paymentService) Resumable(ctx context.Context, purpose string, targetID uuid.UUID) (*models.Payment, error) {
+	return s.paymentRepo.FindPending(purpose, targetID, time.Now().Add(-paymentResumeWindow))
+}
+
+// findPayment finds the payment a webhook is about, by our reference or else
+// by the provider's
+func (s *paymentService) findPayment(provider string, event *payments.Event) (*models.Payment, error) {
+	if id, err := uuid.Parse(event.Reference); err == nil {
+		payment, err := s.paymentRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if payment.Provider != provider {
+			return nil, repositories.ErrRecordNotFound
+		}
+		return payment, nil
+	}
+	if event.ProviderRef == "" {
+		return nil, repositories.ErrRecordNotFound
+	}
+	return s.paymentRepo.FindByProviderRef(provider, event.ProviderRef)
+}
+
+// publish tells the artist watching live about a purchase or tip just paid for
+func (s *paymentService) publish(payment *models.Payment) {
+	event := live.Event{Amount: payments.MajorUnits(payment.Amount, payment.Currency)}
+	switch payment.Purpose {
+	case models.PaymentForSong:
+		purchase, err := s.songPurchaseRepo.GetByID(payment.TargetID)
+		if err != nil {
+			return
+		}
+		song, err := s.songRepo.GetByID(purchase.SongID)
+		if err != nil {
+			return
+		}
+		event.Kind, event.ArtistID, event.SongID = live.EventPurchase, song.ArtistID, &song.ID
+	case models.PaymentForAlbum:
+		purchase, err := s.albumPurchaseRepo.GetByID(payment.TargetID)
+		if err != nil {
+			return
+		}
+		album, err := s.albumRepo.GetByID(purchase.AlbumID)
+		if err != nil {
+			return
+		}
+		event.Kind, event.ArtistID, event.AlbumID = live.EventPurchase, album.ArtistID, &album.ID
+	case models.PaymentForTip:
+		tip, err := s.tipRepo.GetByID(payment.TargetID)
+		if err != nil {
+			return
+		}
+		event.Kind, event.ArtistID = live.EventTip, tip.ArtistID
+	default:
+		return
+	}
+	s.feed.Publish(event)
+}
+
+func (s *paymentService) GetPayment(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error) {
+	payment, err := s.paymentRepo.GetByID(paymentID)
+	if errors.Is(err, repositories.ErrRecordNotFound) {
+		return nil, ErrPaymentNotFound
+	}
+	return payment, err
+}
+
+// truncate cuts s to at most n bytes, for columns of that size, without
+// splitting a character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
+
+// stringValue is *s, or "" for nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"crawl/live"
+	"crawl/models"
+	"crawl/payments"
+	"crawl/repositories"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// settlingProvider starts every payment with status
+type settlingProvider struct {
+	status string
+}
+
+func (settlingProvider) Name() string { return "test" }
+
+func (p settlingProvider) CreatePayment(ctx context.Context, charge payments.Charge) (*payments.Payment, error) {
+	return &payments.Payment{ProviderRef: "ref_" + charge.Reference, Status: p.status}, nil
+}
+
+func (settlingProvider) ParseWebhook(ctx context.Context, header func(string) string, body []byte) (*payments.Event, error) {
+	return nil, nil
+}
+
+// fakePayments keeps payments in memory and settles them like the repository,
+// once each
+type fakePayments struct {
+	repositories.IPaymentRepository
+	payments map[uuid.UUID]*models.Payment
+}
+
+func (f *fakePayments) Create(payment *models.Payment) error {
+	payment.ID = uuid.New()
+	payment.Status = models.PaymentPending
+	f.payments[payment.ID] = payment
+	return nil
+}
+
+func (f *fakePayments) SetProviderRef(payment *models.Payment) error {
+	return nil
+}
+
+func (f *fakePayments) Settle(payment *models.Payment, status, reason string) (bool, error) {
+	if f.payments[payment.ID].Status != models.PaymentPending {
+		return false, nil
+	}
+	payment.Status, payment.FailureReason = status, reason
+	return true, nil
+}
+
+type fakeUsers struct {
+	repositories.IUserRepository
+}
+
+func (fakeUsers) GetByID(id uuid.UUID) (*models.User, error) {
+	return &models.User{Email: "fan@example.com"}, nil
+}
+
+type fakeTips struct {
+	repositories.ITipRepository
+	artistID uuid.UUID
+}
+
+func (f fakeTips) GetByID(id uuid.UUID) (*models.ArtistTip, error) {
+	return &models.ArtistTip{ArtistID: f.artistID}, nil
+}
+
+type recordingFeed struct {
+	events []live.Event
+}
+
+func (f *recordingFeed) Publish(event live.Event) {
+	f.events = append(f.events, event)
+}
+
+func TestChargeSettlesOnTheSpot(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		wantErr    error
+		wantStatus string
+		published  int
+	}{
+		{name: "pending until the webhook", status: payments.StatusPending, wantStatus: models.PaymentPending},
+		{name: "paid on the spot", status: payments.StatusSucceeded, wantStatus: models.PaymentCompleted, published: 1},
+		{name: "declined on the spot", status: payments.StatusFailed, wantErr: ErrPaymentDeclined, wantStatus: models.PaymentFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePayments{payments: map[uuid.UUID]*models.Payment{}}
+			feed := &recordingFeed{}
+			s := &paymentService{
+				providers:   payments.NewRegistry(settlingProvider{status: tt.status}),
+				paymentRepo: repo,
+				userRepo:    fakeUsers{},
+				tipRepo:     fakeTips{artistID: uuid.New()},
+				feed:        feed,
+			}
+
+			payment, err := s.Charge(context.Background(), PaymentCharge{
+				UserID:   uuid.New(),
+				Purpose:  models.PaymentForTip,
+				TargetID: uuid.New(),
+				Amount:   500,
+				Currency: "NGN",
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && payment.Status != tt.wantStatus {
+				t.Errorf("payment is %s, want %s", payment.Status, tt.wantStatus)
+			}
+			if len(repo.payments) != 1 {
+				t.Fatalf("created %d payments, want 1", len(repo.payments))
+			}
+			for _, stored := range repo.payments {
+				if stored.Status != tt.wantStatus {
+					t.Errorf("stored payment is %s, want %s", stored.Status, tt.wantStatus)
+				}
+			}
+			if len(feed.events) != tt.published {
+				t.Errorf("published %d events, want %d", len(feed.events), tt.published)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"errors"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Playback events reported by players while a song plays
+const (
+	PlaybackStart    = "start"
+	PlaybackProgress = "progress"
+	PlaybackEnd      = "end"
+)
+
+const (
+	// playbackSessionTimeout ends a session whose player stopped reporting, e.g. an app closed mid-song
+	playbackSessionTimeout = 30 * time.Minute
+	playbackSweepInterval  = time.Minute
+	// playbackClockSlack is how far a session's listened time may run ahead of the wall clock
+	playbackClockSlack = 5 * time.Second
+	// maxPlaybackSessions is how many plays one listener can have open at once,
+	// across their devices
+	maxPlaybackSessions = 5
+	// completionShare of a song must be reached for its play to be completed
+	completionShare = 0.95
+)
+
+var (
+	ErrInvalidPlaybackEvent    = errors.New("event must be 'start', 'progress' or 'end'")
+	ErrPlaybackSessionRequired = errors.New("session ID is required for progress and end events")
+	ErrPlaybackSessionNotFound = errors.New("playback session not found")
+	ErrInvalidPlaybackPosition = errors.New("position and listened seconds can't be negative")
+	ErrTooManyPlaybackSessions = errors.New("too many songs playing at once")
+)
+
+// PlaybackEvent reports a playing song. Stream says who is playing what and
+// where, as resolved by the server; its ListenedSeconds is the time actually played since the session
+// started, and PositionSeconds the current position in the song.
+type PlaybackEvent struct {
+	Event     string
+	SessionID uuid.UUID // set by the start event's response
+	Stream    models.Stream
+}
+
+// playbackSession is an unfinished play; it becomes a stream when it ends
+type playbackSession struct {
+	stream   models.Stream
+	artistID uuid.UUID
+	duration int // of the song, in seconds
+	lastSeen time.Time
+}
+
+// RecordPlayback tracks a play through its start, progress and end events and
+// returns its session ID. The play is recorded as a stream when it ends, or
+// when its player stops reporting. Sessions are rebuilt from any event that
+// arrives for an unknown session, e.g. after a restart, so heartbeats are
+// never rejected for that; only time listened after the rebuild counts.
+func (s *streamService) RecordPlayback(ctx context.Context, event PlaybackEvent) (uuid.UUID, error) {
+	switch event.Event {
+	case PlaybackStart, PlaybackProgress, PlaybackEnd:
+	default:
+		return uuid.Nil, ErrInvalidPlaybackEvent
+	}
+	if event.Event != PlaybackStart && event.SessionID == uuid.Nil {
+		return uuid.Nil, ErrPlaybackSessionRequired
+	}
+	if event.Stream.SongID == uuid.Nil {
+		return uuid.Nil, ErrSongIDRequired
+	}
+	if !validPlayContext(event.Stream) {
+		return uuid.Nil, ErrInvalidPlayContext
+	}
+	if event.Stream.ListenedSeconds < 0 || event.Stream.PositionSeconds < 0 {
+		return uuid.Nil, ErrInvalidPlaybackPosition
+	}
+
+	s.sessionsMu.Lock()
+	session, ok := s.sessions[event.SessionID]
+	s.sessionsMu.Unlock()
+
+	if !ok {
+		song, err := s.songRepo.GetByID(event.Stream.SongID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrRecordNotFound) {
+				return uuid.Nil, ErrStreamSongNotFound
+			}
+			return uuid.Nil, err
+		}
+		// Availability is checked once per session, in the resolved country, so a
+		// play started in time isn't cut off
+		if err := checkSongTerritory(s.territoryRepo, event.Stream.SongID, event.Stream.CountryCode); err != nil {
+			return uuid.Nil, err
+		}
+
+		sessionID := event.SessionID
+		if event.Event == PlaybackStart {
+			sessionID = uuid.New()
+		}
+		session = &playbackSession{stream: event.Stream, artistID: song.ArtistID, duration: song.Duration}
+		// The stream takes the session's ID, so a session ended twice is only stored once
+		session.stream.ID = sessionID
+		// The listened time a rebuilding event reports can't be checked against
+		// the clock, so a rebuilt session starts from nothing, now
+		session.stream.CreatedAt = time.Now()
+		session.stream.ListenedSeconds = 0
+		session.stream.PositionSeconds = 0
+	}
+
+	s.sessionsMu.Lock()
+	// Another event may have rebuilt the session meanwhile
+	if existing, ok := s.sessions[session.stream.ID]; ok {
+		session = existing
+	}
+	if !sameListener(session.stream.UserID, event.Stream.UserID) || session.stream.SongID != event.Stream.SongID {
+		s.sessionsMu.Unlock()
+		return uuid.Nil, ErrPlaybackSessionNotFound
+	}
+	if _, open := s.sessions[session.stream.ID]; !open && s.openSessions(session.stream.UserID) >= maxPlaybackSessions {
+		s.sessionsMu.Unlock()
+		return uuid.Nil, ErrTooManyPlaybackSessions
+	}
+
+	now := time.Now()
+	// Listened time only grows, and never faster than the clock since the session began
+	elapsed := int((now.Sub(session.stream.CreatedAt) + playbackClockSlack) / time.Second)
+	session.stream.ListenedSeconds = max(session.stream.ListenedSeconds, min(event.Stream.ListenedSeconds, elapsed))
+	session.stream.PositionSeconds = event.Stream.PositionSeconds
+	session.lastSeen = now
+	if event.Event == PlaybackEnd {
+		delete(s.sessions, session.stream.ID)
+	} else {
+		s.sessions[session.stream.ID] = session
+	}
+	s.sessionsMu.Unlock()
+
+	if event.Event != PlaybackEnd {
+		return session.stream.ID, nil
+	}
+
+	if err := s.finishPlayback(ctx, session); err != nil {
+		// Keep the session so the player can send its end event again
+		s.keepSession(session)
+		return uuid.Nil, err
+	}
+	return session.stream.ID, nil
+}
+
+// keepSession puts back a session that couldn't be recorded, unless a newer event rebuilt it
+func (s *streamService) keepSession(session *playbackSession) {
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+	if _, ok := s.sessions[session.stream.ID]; !ok {
+		s.sessions[session.stream.ID] = session
+	}
+}
+
+// openSessions counts the open sessions of listener; sessionsMu must be held
+func (s *streamService) openSessions(listener *uuid.UUID) int {
+	if listener == nil {
+		return 0
+	}
+	open := 0
+	for _, session := range s.sessions {
+		if sameListener(session.stream.UserID, listener) {
+			open++
+		}
+	}
+	return open
+}
+
+func sameListener(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// finishPlayback queues the stream of a session already taken out of the open sessions
+func (s *streamService) finishPlayback(ctx context.Context, session *playbackSession) error {
+	stream := session.stream
+	stream.Tracked = true
+	stream.Completed = session.duration > 0 && float64(stream.PositionSeconds) >= completionShare*float64(session.duration)
+
+	return s.enqueue(ctx, stream, session.artistID)
+}
+
+// sweepPlayback ends the sessions whose players stopped reporting
+func (s *streamService) sweepPlayback() {
+	ticker := time.NewTicker(playbackSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.endSessions(time.Now().Add(-playbackSessionTimeout))
+		case <-s.stopSweep:
+			return
+		}
+	}
+}
+
+// endSessions records every session last heard from before cutoff. Sessions the
+// queue can't take yet are kept for the next sweep.
+func (s *streamService) endSessions(cutoff time.Time) {
+	var ended []*playbackSession
+	s.sessionsMu.Lock()
+	for id, session := range s.sessions {
+		if session.lastSeen.Before(cutoff) {
+			ended = append(ended, session)
+			delete(s.sessions, id)
+		}
+	}
+	s.sessionsMu.Unlock()
+
+	for _, session := range ended {
+		if err := s.finishPlayback(context.Background(), session); err != nil {
+			log.Warnf("Failed to record abandoned playback session %s: %s", session.stream.ID, err.Error())
+			s.keepSession(session)
+		}
+	}
+}
+
+// Close stops the session sweeper and records every open session as it stands
+func (s *streamService) Close() {
+	s.closeOnce.Do(func() {
+		close(s.stopSweep)
+		s.endSessions(time.Now().Add(time.Second))
+	})
+}
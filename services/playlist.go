@@ -4,6 +4,7 @@ import (
 	"context"
 	"crawl/models"
 	"crawl/repositories"
+	"crawl/search"
 	"errors"
 	"github.com/google/uuid"
 )
@@ -13,7 +14,7 @@ type PlaylistService interface {
 	GetAllPlaylists(ctx context.Context) ([]models.Playlist, error)
 	UpdatePlaylist(ctx context.Context, playlistID uuid.UUID, playlist *models.Playlist) (*models.Playlist, error)
 	DeletePlaylist(ctx context.Context, playlistID uuid.UUID) error
-	GetPlaylistSongs(ctx context.Context, playlistID uuid.UUID) ([]models.Song, error)
+	GetPlaylistSongs(ctx context.Context, playlistID uuid.UUID, country string) ([]models.Song, error)
 	AddSongToPlaylist(ctx context.Context, playlistID uuid.UUID, songID uuid.UUID) error
 	RemoveSongFromPlaylist(ctx context.Context, playlistID uuid.UUID, songID uuid.UUID) error
 	SearchPlaylists(
@@ -31,17 +32,20 @@ type playlistService struct {
 	playlistRepo     repositories.IPlaylistRepository
 	playlistSongRepo repositories.IPlaylistSongRepository
 	songRepo         repositories.ISongRepository
+	index            search.SearchIndex
 }
 
 func NewPlaylistService(
 	playlistRepo repositories.IPlaylistRepository,
 	playlistSongRepo repositories.IPlaylistSongRepository,
 	songRepo repositories.ISongRepository,
+	index search.SearchIndex,
 ) PlaylistService {
 	return &playlistService{
 		playlistRepo:     playlistRepo,
 		playlistSongRepo: playlistSongRepo,
 		songRepo:         songRepo,
+		index:            index,
 	}
 }
 
@@ -87,7 +91,7 @@ func (s *playlistService) UpdatePlaylist(ctx context.Context, playlistID uuid.UU
 	existingPlaylist.CoverImageURL = playlist.CoverImageURL
 	existingPlaylist.IsPublic = playlist.IsPublic
 
-	return s.playlistRepo.Update(existingPlaylist)
+	return s.playlistRepo.WithContext(ctx).Update(existingPlaylist)
 }
 
 func (s *playlistService) DeletePlaylist(ctx context.Context, playlistID uuid.UUID) error {
@@ -100,10 +104,10 @@ func (s *playlistService) DeletePlaylist(ctx context.Context, playlistID uuid.UU
 		return err
 	}
 
-	return s.playlistRepo.Delete(playlistID)
+	return s.playlistRepo.WithContext(ctx).Delete(playlistID)
 }
 
-func (s *playlistService) GetPlaylistSongs(ctx context.Context, playlistID uuid.UUID) ([]models.Song, error) {
+func (s *playlistService) GetPlaylistSongs(ctx context.Context, playlistID uuid.UUID, country string) ([]models.Song, error) {
 	// Verify playlist exists first
 	_, err := s.playlistRepo.GetByID(playlistID)
 	if err != nil {
@@ -113,7 +117,7 @@ func (s *playlistService) GetPlaylistSongs(ctx context.Context, playlistID uuid.
 		return nil, err
 	}
 
-	return s.playlistSongRepo.GetPlaylistSongs(playlistID)
+	return s.playlistSongRepo.GetPlaylistSongs(playlistID, country)
 }
 
 func (s *playlistService) AddSongToPlaylist(ctx context.Context, playlistID uuid.UUID, songID uuid.UUID) error {
@@ -178,5 +182,12 @@ func (s *playlistService) SearchPlaylists(
 	page int,
 	limit int,
 ) ([]models.Playlist, int64, error) {
-	return s.playlistRepo.SearchPlaylists(query, owner, isPublic, sort, page, limit)
+	return s.index.SearchPlaylists(ctx, search.PlaylistQuery{
+		Text:     query,
+		Owner:    owner,
+		IsPublic: isPublic,
+		Sort:     sort,
+		Page:     page,
+		Limit:    limit,
+	})
 }
@@ -9,8 +9,11 @@ import (
 	"github.com/google/uuid"
 )
 
+var ErrAlreadyPurchased = errors.New("already purchased")
+
 type PurchaseService interface {
-	PurchaseAlbum(ctx context.Context, purchase api.PostPurchasesAlbumsJSONBody) (*models.AlbumPurchase, error)
+	// PurchaseAlbum buys an album for a buyer in the country countryCode
+	PurchaseAlbum(ctx context.Context, purchase api.PostPurchasesAlbumsJSONBody, countryCode string) (*models.AlbumPurchase, error)
 	UpdatePurchaseAlbum(ctx context.Context, albumPurchase models.AlbumPurchase) (*models.AlbumPurchase, error)
 	GetAllPurchaseAlbumByUser(ctx context.Context, userID uuid.UUID) ([]models.AlbumPurchase, error)
 	GetPurchaseAlbumByIdByUser(ctx context.Context, purchaseAlbumID uuid.UUID, userID uuid.UUID) (*models.AlbumPurchase, error)
@@ -18,7 +21,8 @@ type PurchaseService interface {
 	GetPurchaseAlbumById(ctx context.Context, albumPurchaseID uuid.UUID) (*models.AlbumPurchase, error)
 	GetPurchasedAlbum(ctx context.Context, userID uuid.UUID) ([]models.Album, error)
 
-	PurchaseSong(ctx context.Context, purchase api.PostPurchasesSongsJSONBody) (*models.SongPurchase, error)
+	// PurchaseSong buys a song for a buyer in the country countryCode
+	PurchaseSong(ctx context.Context, purchase api.PostPurchasesSongsJSONBody, countryCode string) (*models.SongPurchase, error)
 	UpdatePurchaseSong(ctx context.Context, songPurchase models.SongPurchase) (*models.SongPurchase, error)
 	GetAllPurchaseSongs(ctx context.Context, userID uuid.UUID) ([]models.SongPurchase, error)
 	GetPurchaseSongById(ctx context.Context, songPurchaseID uuid.UUID) (*models.SongPurchase, error)
@@ -32,6 +36,8 @@ type purchaseService struct {
 	songPurchaseRepo  repositories.ISongPurchaseRepository
 	albumRepo         repositories.IAlbumRepository
 	songRepo          repositories.ISongRepository
+	territoryRepo     repositories.ITerritoryRepository
+	payments          PaymentService
 }
 
 func NewPurchaseService(
@@ -39,44 +45,91 @@ func NewPurchaseService(
 	songPurchaseRepo repositories.ISongPurchaseRepository,
 	albumRepo repositories.IAlbumRepository,
 	songRepo repositories.ISongRepository,
+	territoryRepo repositories.ITerritoryRepository,
+	payments PaymentService,
 ) PurchaseService {
 	return &purchaseService{
 		albumPurchaseRepo: albumPurchaseRepo,
 		songPurchaseRepo:  songPurchaseRepo,
 		albumRepo:         albumRepo,
 		songRepo:          songRepo,
+		territoryRepo:     territoryRepo,
+		payments:          payments,
 	}
 }
 
 // Album Purchase Methods
 
-func (s *purchaseService) PurchaseAlbum(ctx context.Context, purchase api.PostPurchasesAlbumsJSONBody) (*models.AlbumPurchase, error) {
+func (s *purchaseService) PurchaseAlbum(ctx context.Context, purchase api.PostPurchasesAlbumsJSONBody, countryCode string) (*models.AlbumPurchase, error) {
 	// Check if album exists
 	album, err := s.albumRepo.GetByID(purchase.AlbumId)
 	if err != nil {
 		return nil, errors.New("album not found")
 	}
+	if err := checkAlbumTerritory(s.territoryRepo, album.ID, countryCode); err != nil {
+		return nil, err
+	}
 
-	// Check if user already purchased this album
-	hasPurchased, err := s.albumPurchaseRepo.HasPurchasedAlbum(purchase.UserId, purchase.AlbumId)
+	// Check if user already purchased this album. A purchase whose payment
+	// is still pending hands that payment back to finish instead of charging
+	// twice; one whose payment failed or was abandoned is paid for again.
+	existing, err := s.albumPurchaseRepo.FindByUserAndAlbum(purchase.UserId, purchase.AlbumId)
 	if err != nil {
 		return nil, err
 	}
-	if hasPurchased {
-		return nil, errors.New("user already purchased this album")
+	if existing != nil && existing.PaymentStatus == models.PaymentCompleted {
+		return nil, ErrAlreadyPurchased
+	}
+	if existing != nil && existing.PaymentStatus == models.PaymentPending {
+		payment, err := s.payments.Resumable(ctx, models.PaymentForAlbum, existing.ID)
+		if err != nil {
+			return nil, err
+		}
+		if payment != nil {
+			existing.PaymentID = &payment.ID
+			existing.Payment = payment
+			return existing, nil
+		}
 	}
 
-	// Create new purchase
-	newPurchase := &models.AlbumPurchase{
-		UserID:              purchase.UserId,
-		AlbumID:             purchase.AlbumId,
-		PurchasePrice:       float64(album.Price),
-		Currency:            "NGN",
-		PaymentStatus:       "completed",
-		StripeTransactionID: purchase.PaymentMethodId,
+	newPurchase := existing
+	if newPurchase == nil {
+		newPurchase = &models.AlbumPurchase{UserID: purchase.UserId, AlbumID: purchase.AlbumId}
+	}
+	newPurchase.PurchasePrice = float64(album.Price)
+	newPurchase.Currency = "NGN"
+	newPurchase.CountryCode = countryCode
+	newPurchase.PaymentStatus = models.PaymentPending
+	if album.Price <= 0 {
+		// Nothing to charge for free albums
+		newPurchase.PaymentStatus = models.PaymentCompleted
+	}
+	if existing == nil {
+		newPurchase, err = s.albumPurchaseRepo.Create(newPurchase)
+	} else {
+		newPurchase, err = s.albumPurchaseRepo.Update(newPurchase)
+	}
+	if err != nil || album.Price <= 0 {
+		return newPurchase, err
 	}
 
-	return s.albumPurchaseRepo.Create(newPurchase)
+	payment, err := s.payments.Charge(ctx, PaymentCharge{
+		UserID:          purchase.UserId,
+		Provider:        stringValue(purchase.Provider),
+		Purpose:         models.PaymentForAlbum,
+		TargetID:        newPurchase.ID,
+		Amount:          newPurchase.PurchasePrice,
+		Currency:        newPurchase.Currency,
+		PaymentMethodID: stringValue(purchase.PaymentMethodId),
+		Description:     "Album: " + album.Title,
+	})
+	if err != nil {
+		return nil, err
+	}
+	newPurchase.PaymentID = &payment.ID
+	newPurchase.PaymentStatus = payment.Status
+	newPurchase.Payment = payment
+	return newPurchase, nil
 }
 
 func (s *purchaseService) UpdatePurchaseAlbum(ctx context.Context, albumPurchase models.AlbumPurchase) (*models.AlbumPurchase, error) {
@@ -137,9 +190,11 @@ func (s *purchaseService) GetPurchasedAlbum(ctx context.Context, userID uuid.UUI
 		return nil, err
 	}
 
-	albums := make([]models.Album, len(purchases))
-	for i, p := range purchases {
-		albums[i] = p.Album
+	albums := []models.Album{}
+	for _, p := range purchases {
+		if p.PaymentStatus == models.PaymentCompleted {
+			albums = append(albums, p.Album)
+		}
 	}
 
 	return albums, nil
@@ -147,34 +202,77 @@ func (s *purchaseService) GetPurchasedAlbum(ctx context.Context, userID uuid.UUI
 
 // Song Purchase Methods
 
-func (s *purchaseService) PurchaseSong(ctx context.Context, purchase api.PostPurchasesSongsJSONBody) (*models.SongPurchase, error) {
+func (s *purchaseService) PurchaseSong(ctx context.Context, purchase api.PostPurchasesSongsJSONBody, countryCode string) (*models.SongPurchase, error) {
 	// Check if song exists
 	var song *models.Song
 	song, err := s.songRepo.GetByID(purchase.SongId)
 	if err != nil {
 		return nil, errors.New("song not found")
 	}
+	if err := checkSongTerritory(s.territoryRepo, song.ID, countryCode); err != nil {
+		return nil, err
+	}
 
-	// Check if user already purchased this song
-	hasPurchased, err := s.songPurchaseRepo.HasPurchasedSong(purchase.UserId, purchase.SongId)
+	// Check if user already purchased this song. A purchase whose payment
+	// is still pending hands that payment back to finish instead of charging
+	// twice; one whose payment failed or was abandoned is paid for again.
+	existing, err := s.songPurchaseRepo.FindByUserAndSong(purchase.UserId, purchase.SongId)
 	if err != nil {
 		return nil, err
 	}
-	if hasPurchased {
-		return nil, errors.New("user already purchased this song")
+	if existing != nil && existing.PaymentStatus == models.PaymentCompleted {
+		return nil, ErrAlreadyPurchased
+	}
+	if existing != nil && existing.PaymentStatus == models.PaymentPending {
+		payment, err := s.payments.Resumable(ctx, models.PaymentForSong, existing.ID)
+		if err != nil {
+			return nil, err
+		}
+		if payment != nil {
+			existing.PaymentID = &payment.ID
+			existing.Payment = payment
+			return existing, nil
+		}
 	}
 
-	// Create new purchase
-	newPurchase := &models.SongPurchase{
-		UserID:              purchase.UserId,
-		SongID:              purchase.SongId,
-		PurchasePrice:       float64(song.Price),
-		Currency:            "NGN",
-		PaymentStatus:       "completed",
-		StripeTransactionID: purchase.PaymentMethodId,
+	newPurchase := existing
+	if newPurchase == nil {
+		newPurchase = &models.SongPurchase{UserID: purchase.UserId, SongID: purchase.SongId}
+	}
+	newPurchase.PurchasePrice = float64(song.Price)
+	newPurchase.Currency = "NGN"
+	newPurchase.CountryCode = countryCode
+	newPurchase.PaymentStatus = models.PaymentPending
+	if song.Price <= 0 {
+		// Nothing to charge for free songs
+		newPurchase.PaymentStatus = models.PaymentCompleted
+	}
+	if existing == nil {
+		newPurchase, err = s.songPurchaseRepo.Create(newPurchase)
+	} else {
+		newPurchase, err = s.songPurchaseRepo.Update(newPurchase)
+	}
+	if err != nil || song.Price <= 0 {
+		return newPurchase, err
 	}
 
-	return s.songPurchaseRepo.Create(newPurchase)
+	payment, err := s.payments.Charge(ctx, PaymentCharge{
+		UserID:          purchase.UserId,
+		Provider:        stringValue(purchase.Provider),
+		Purpose:         models.PaymentForSong,
+		TargetID:        newPurchase.ID,
+		Amount:          newPurchase.PurchasePrice,
+		Currency:        newPurchase.Currency,
+		PaymentMethodID: stringValue(purchase.PaymentMethodId),
+		Description:     "Song: " + song.Title,
+	})
+	if err != nil {
+		return nil, err
+	}
+	newPurchase.PaymentID = &payment.ID
+	newPurchase.PaymentStatus = payment.Status
+	newPurchase.Payment = payment
+	return newPurchase, nil
 }
 
 func (s *purchaseService) UpdatePurchaseSong(ctx context.Context, songPurchase models.SongPurchase) (*models.SongPurchase, error) {
@@ -235,9 +333,11 @@ func (s *purchaseService) GetPurchasedSong(ctx context.Context, userID uuid.UUID
 		return nil, err
 	}
 
-	songs := make([]models.Song, len(purchases))
-	for i, p := range purchases {
-		songs[i] = p.Song
+	songs := []models.Song{}
+	for _, p := range purchases {
+		if p.PaymentStatus == models.PaymentCompleted {
+			songs = append(songs, p.Song)
+		}
 	}
 
 	return songs, nil
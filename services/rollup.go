@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"crawl/repositories"
+	"errors"
+	"github.com/gofiber/fiber/v2/log"
+	"time"
+)
+
+// rollupLookback is how many finished days every scheduled run rolls up again,
+// picking up streams that arrive late from playback sessions and the ingestion log
+const rollupLookback = 2
+
+var ErrInvalidRollupRange = errors.New("rollup range must start on or before its end and can't reach past today")
+
+type RollupService interface {
+	// RollupRange rolls up every UTC day from from through to and returns how many it rolled up
+	RollupRange(ctx context.Context, from, to time.Time) (int, error)
+	// RollupRecent rolls up the last few finished days
+	RollupRecent(ctx context.Context) error
+}
+
+type rollupService struct {
+	rollupRepo repositories.IStreamRollupRepository
+}
+
+func NewRollupService(rollupRepo repositories.IStreamRollupRepository) RollupService {
+	return &rollupService{rollupRepo: rollupRepo}
+}
+
+func (s *rollupService) RollupRange(ctx context.Context, from, to time.Time) (int, error) {
+	first, last := repositories.UTCDay(from), repositories.UTCDay(to)
+	// Today is still being streamed; reads take it from the raw streams
+	if last.Before(first) || !last.Before(repositories.UTCDay(time.Now())) {
+		return 0, ErrInvalidRollupRange
+	}
+
+	days := 0
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if err := ctx.Err(); err != nil {
+			return days, err
+		}
+		streams, err := s.rollupRepo.RollupDay(day)
+		if err != nil {
+			return days, err
+		}
+		log.Infof("Rolled up %d streams for %s", streams, day.Format("2006-01-02"))
+		days++
+	}
+	return days, nil
+}
+
+func (s *rollupService) RollupRecent(ctx context.Context) error {
+	yesterday := repositories.UTCDay(time.Now()).AddDate(0, 0, -1)
+	from := yesterday.AddDate(0, 0, 1-rollupLookback)
+
+	// Catch up on the days missed since the latest rollup, e.g. while the server was down
+	through, err := s.rollupRepo.RolledUpThrough()
+	if err != nil {
+		return err
+	}
+	if !through.IsZero() && through.Before(from) {
+		from = through
+	}
+
+	_, err = s.RollupRange(ctx, from, yesterday)
+	return err
+}
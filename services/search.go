@@ -0,0 +1,408 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxDidYouMean caps how many corrections are offered for one query
+	maxDidYouMean = 5
+	// suggestTimeout is the latency budget for one autocomplete request
+	suggestTimeout = 150 * time.Millisecond
+	maxSuggestions = 20
+	// federatedSearchTimeout bounds a federated search; sections still running are reported as failed
+	federatedSearchTimeout = 2 * time.Second
+	maxSearchLimit         = 50
+)
+
+// Federated search section types
+const (
+	SectionSongs     = "songs"
+	SectionAlbums    = "albums"
+	SectionArtists   = "artists"
+	SectionPlaylists = "playlists"
+	SectionGenres    = "genres"
+)
+
+var searchSections = map[string]bool{
+	SectionSongs:     true,
+	SectionAlbums:    true,
+	SectionArtists:   true,
+	SectionPlaylists: true,
+	SectionGenres:    true,
+}
+
+var defaultSearchSections = []string{SectionSongs, SectionAlbums, SectionArtists}
+
+var (
+	ErrInvalidSuggestionType = errors.New("types must be a comma-separated list of songs, albums, artists or playlists")
+	ErrSearchQueryRequired   = errors.New("search query is required")
+	ErrInvalidSearchType     = errors.New("types must be a comma-separated list of songs, albums, artists, playlists or genres")
+	ErrInvalidCursor         = errors.New("invalid cursor")
+)
+
+// FederatedSearchRequest asks for one page of results across several content types
+type FederatedSearchRequest struct {
+	Query   string
+	Types   *string // comma-separated section types, defaults to songs, albums and artists
+	Page    *int
+	Limit   *int
+	Cursor  *string // continues a single section from a previous response's next_cursor
+	Facets  bool    // count song and album matches by genre, release year and price range
+	Country string  // where the client is; songs and albums that can't be played there are left out
+}
+
+// SearchSection is one content type's slice of a federated search
+type SearchSection[T any] struct {
+	Items      []T                  `json:"items"`
+	Total      int64                `json:"total"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+	Facets     *models.SearchFacets `json:"facets,omitempty"`
+	Failed     bool                 `json:"failed,omitempty"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// FederatedSearchResult holds a section for every requested type; Partial is set
+// when any of them failed or ran out of time
+type FederatedSearchResult struct {
+	Query      string                          `json:"query"`
+	Songs      *SearchSection[models.Song]     `json:"songs,omitempty"`
+	Albums     *SearchSection[models.Album]    `json:"albums,omitempty"`
+	Artists    *SearchSection[models.Artist]   `json:"artists,omitempty"`
+	Playlists  *SearchSection[models.Playlist] `json:"playlists,omitempty"`
+	Genres     *SearchSection[models.Genre]    `json:"genres,omitempty"`
+	DidYouMean []models.SearchSuggestion       `json:"did_you_mean,omitempty"`
+	Partial    bool                            `json:"partial"`
+	SearchID   *uuid.UUID                      `json:"search_id,omitempty"` // set by the caller once the search is logged
+}
+
+// TotalResults sums the totals of every section searched
+func (r *FederatedSearchResult) TotalResults() int64 {
+	var total int64
+	if r.Songs != nil {
+		total += r.Songs.Total
+	}
+	if r.Albums != nil {
+		total += r.Albums.Total
+	}
+	if r.Artists != nil {
+		total += r.Artists.Total
+	}
+	if r.Playlists != nil {
+		total += r.Playlists.Total
+	}
+	if r.Genres != nil {
+		total += r.Genres.Total
+	}
+	return total
+}
+
+// suggestionTypes maps the plural names used by the API to suggestion types
+var suggestionTypes = map[string]string{
+	"songs":     models.SuggestionSong,
+	"albums":    models.SuggestionAlbum,
+	"artists":   models.SuggestionArtist,
+	"playlists": models.SuggestionPlaylist,
+}
+
+type SearchService interface {
+	DidYouMean(ctx context.Context, query string) ([]models.SearchSuggestion, error)
+	Suggest(ctx context.Context, query string, types *string, limit *int, country string) ([]models.SearchSuggestion, error)
+	Search(ctx context.Context, req FederatedSearchRequest) (*FederatedSearchResult, error)
+}
+
+type searchService struct {
+	searchRepo   repositories.ISearchRepository
+	suggestBelow float64
+	songs        SongService
+	albums       AlbumService
+	artists      ArtistService
+	playlists    PlaylistService
+	genres       GenreService
+}
+
+func NewSearchService(
+	searchRepo repositories.ISearchRepository,
+	fuzzy repositories.FuzzySettings,
+	songs SongService,
+	albums AlbumService,
+	artists ArtistService,
+	playlists PlaylistService,
+	genres GenreService,
+) SearchService {
+	return &searchService{
+		searchRepo:   searchRepo,
+		suggestBelow: fuzzy.SuggestBelow,
+		songs:        songs,
+		albums:       albums,
+		artists:      artists,
+		playlists:    playlists,
+		genres:       genres,
+	}
+}
+
+// DidYouMean returns likely corrections for query. It returns nothing when the
+// closest catalog name is already a good match, so a correctly spelled query
+// never gets second-guessed.
+func (s *searchService) DidYouMean(ctx context.Context, query string) ([]models.SearchSuggestion, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []models.SearchSuggestion{}, nil
+	}
+
+	// Fetch extra candidates since the same name can belong to a song and an album
+	candidates, err := s.searchRepo.ClosestNames(ctx, query, maxDidYouMean*2)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]models.SearchSuggestion, 0, maxDidYouMean)
+	if len(candidates) == 0 || candidates[0].Score >= s.suggestBelow {
+		return suggestions, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		key := strings.ToLower(candidate.Text)
+		if seen[key] || strings.EqualFold(candidate.Text, query) {
+			continue
+		}
+		seen[key] = true
+		suggestions = append(suggestions, candidate)
+		if len(suggestions) == maxDidYouMean {
+			break
+		}
+	}
+
+	return suggestions, nil
+}
+
+// Suggest returns ranked autocomplete suggestions for a partially typed query.
+// A query that can't finish within the latency budget yields no suggestions
+// rather than holding up the next keystroke.
+func (s *searchService) Suggest(ctx context.Context, query string, types *string, limit *int, country string) ([]models.SearchSuggestion, error) {
+	wanted := []string{models.SuggestionSong, models.SuggestionAlbum, models.SuggestionArtist, models.SuggestionPlaylist}
+	if types != nil && strings.TrimSpace(*types) != "" {
+		wanted = wanted[:0]
+		for _, name := range strings.Split(*types, ",") {
+			suggestionType, ok := suggestionTypes[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				return nil, ErrInvalidSuggestionType
+			}
+			wanted = append(wanted, suggestionType)
+		}
+	}
+
+	maxResults := 10
+	if limit != nil && *limit > 0 {
+		maxResults = *limit
+	}
+	if maxResults > maxSuggestions {
+		maxResults = maxSuggestions
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, suggestTimeout)
+	defer cancel()
+
+	suggestions, err := s.searchRepo.Suggest(ctx, query, wanted, maxResults, country)
+	if err != nil {
+		if ctx.Err() != nil {
+			log.Warnf("Autocomplete for %q exceeded its %s budget", query, suggestTimeout)
+			return []models.SearchSuggestion{}, nil
+		}
+		return nil, err
+	}
+
+	if len(suggestions) > maxResults {
+		suggestions = suggestions[:maxResults]
+	}
+	return suggestions, nil
+}
+
+// Search runs the per-type searches concurrently and collects them into sections.
+// A section that errors or misses the deadline is flagged instead of failing the
+// whole response.
+func (s *searchService) Search(ctx context.Context, req FederatedSearchRequest) (*FederatedSearchResult, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, ErrSearchQueryRequired
+	}
+
+	types := defaultSearchSections
+	if req.Types != nil && strings.TrimSpace(*req.Types) != "" {
+		types = nil
+		for _, name := range strings.Split(*req.Types, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if !searchSections[name] {
+				return nil, ErrInvalidSearchType
+			}
+			types = append(types, name)
+		}
+	}
+
+	page, limit := 1, 20
+	if req.Page != nil && *req.Page > 0 {
+		page = *req.Page
+	}
+	if req.Limit != nil && *req.Limit > 0 {
+		limit = *req.Limit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	// A cursor continues exactly one section
+	if req.Cursor != nil && *req.Cursor != "" {
+		section, cursorPage, err := decodeSearchCursor(*req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		types, page = []string{section}, cursorPage
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, federatedSearchTimeout)
+	defer cancel()
+
+	result := &FederatedSearchResult{Query: query}
+	public := true
+	var wg sync.WaitGroup
+	run := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+
+	for _, section := range types {
+		switch section {
+		case SectionSongs:
+			run(func() {
+				result.Songs = runSection(ctx, SectionSongs, page, limit, func(out *SearchSection[models.Song]) (err error) {
+					out.Items, out.Total, err = s.songs.SearchSongs(ctx, &query, nil, nil, nil, nil, &page, &limit, req.Country)
+					if err == nil && req.Facets {
+						out.Facets, err = s.songs.SearchSongFacets(ctx, &query, req.Country)
+					}
+					return err
+				})
+			})
+		case SectionAlbums:
+			run(func() {
+				result.Albums = runSection(ctx, SectionAlbums, page, limit, func(out *SearchSection[models.Album]) (err error) {
+					out.Items, out.Total, err = s.albums.SearchAlbums(ctx, &query, nil, nil, nil, &page, &limit, req.Country)
+					if err == nil && req.Facets {
+						out.Facets, err = s.albums.SearchAlbumFacets(ctx, &query, req.Country)
+					}
+					return err
+				})
+			})
+		case SectionArtists:
+			run(func() {
+				result.Artists = runSection(ctx, SectionArtists, page, limit, func(out *SearchSection[models.Artist]) (err error) {
+					out.Items, out.Total, err = s.artists.SearchArtistsByName(ctx, query, page, limit)
+					return err
+				})
+			})
+		case SectionPlaylists:
+			run(func() {
+				result.Playlists = runSection(ctx, SectionPlaylists, page, limit, func(out *SearchSection[models.Playlist]) (err error) {
+					// Private playlists never show up in global search
+					out.Items, out.Total, err = s.playlists.SearchPlaylists(ctx, &query, nil, &public, nil, page, limit)
+					return err
+				})
+			})
+		case SectionGenres:
+			run(func() {
+				result.Genres = runSection(ctx, SectionGenres, 1, 0, func(out *SearchSection[models.Genre]) (err error) {
+					out.Items, err = s.genres.SearchGenres(ctx, &query, nil)
+					out.Total = int64(len(out.Items))
+					return err
+				})
+			})
+		}
+	}
+
+	run(func() {
+		suggestions := runSection(ctx, "did_you_mean", 1, 0, func(out *SearchSection[models.SearchSuggestion]) (err error) {
+			out.Items, err = s.DidYouMean(ctx, query)
+			return err
+		})
+		if len(suggestions.Items) > 0 {
+			result.DidYouMean = suggestions.Items
+		}
+	})
+
+	wg.Wait()
+
+	result.Partial = (result.Songs != nil && result.Songs.Failed) ||
+		(result.Albums != nil && result.Albums.Failed) ||
+		(result.Artists != nil && result.Artists.Failed) ||
+		(result.Playlists != nil && result.Playlists.Failed) ||
+		(result.Genres != nil && result.Genres.Failed)
+
+	return result, nil
+}
+
+// runSection runs fill in the background and waits for it or the deadline on ctx.
+// fill writes into its own section, so a late result is simply dropped; its
+// queries run on ctx, so the deadline also cancels them in the database.
+func runSection[T any](ctx context.Context, name string, page, limit int, fill func(*SearchSection[T]) error) *SearchSection[T] {
+	done := make(chan *SearchSection[T], 1)
+	go func() {
+		section := &SearchSection[T]{}
+		if err := fill(section); err != nil {
+			log.Errorf("Search section %s failed: %s", name, err.Error())
+			section = &SearchSection[T]{Failed: true, Error: "search failed"}
+		}
+		done <- section
+	}()
+
+	var section *SearchSection[T]
+	select {
+	case section = <-done:
+	case <-ctx.Done():
+		section = &SearchSection[T]{Failed: true, Error: "search timed out"}
+	}
+
+	if section.Items == nil {
+		section.Items = []T{}
+	}
+	if !section.Failed && limit > 0 && int64(page*limit) < section.Total {
+		section.NextCursor = encodeSearchCursor(name, page+1)
+	}
+	return section
+}
+
+// Cursors are opaque to clients: the section and page, base64 encoded
+func encodeSearchCursor(section string, page int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%d", section, page)))
+}
+
+func decodeSearchCursor(cursor string) (string, int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", 0, ErrInvalidCursor
+	}
+
+	section, pageText, ok := strings.Cut(string(raw), ":")
+	if !ok || !searchSections[section] {
+		return "", 0, ErrInvalidCursor
+	}
+
+	page, err := strconv.Atoi(pageText)
+	if err != nil || page < 1 {
+		return "", 0, ErrInvalidCursor
+	}
+	return section, page, nil
+}
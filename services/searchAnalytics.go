@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxLoggedQueryLength truncates normalized queries before they're stored
+	maxLoggedQueryLength = 200
+	defaultReportDays    = 30
+	maxReportLimit       = 500
+	// Trending compares the last day against the week before it
+	trendingWindow   = 24 * time.Hour
+	trendingBaseline = 7 * 24 * time.Hour
+	// minTrendingSearchers keeps queries only a handful of logged-in users ran
+	// off the public list
+	minTrendingSearchers = 3
+	maxTrendingSearches  = 20
+)
+
+var searchClickTypes = map[string]bool{
+	models.SuggestionSong:     true,
+	models.SuggestionAlbum:    true,
+	models.SuggestionArtist:   true,
+	models.SuggestionPlaylist: true,
+}
+
+var (
+	ErrSearchLogNotFound  = errors.New("search not found")
+	ErrInvalidClickType   = errors.New("result_type must be one of: song, album, artist, playlist")
+	ErrInvalidReportRange = errors.New("from must be before to")
+)
+
+// SearchEvent describes a search as it is logged
+type SearchEvent struct {
+	Query       string
+	Scope       string // models.SearchScope*
+	ResultCount int64
+	UserID      *uuid.UUID // nil for logged-out searches
+	ClientIP    string
+	UserAgent   string
+}
+
+// SearchClickEvent reports that a result of a logged search was opened
+type SearchClickEvent struct {
+	SearchID   uuid.UUID
+	ResultType string
+	ResultID   uuid.UUID
+	Position   int
+}
+
+// NormalizeSearchQuery lower-cases a query and collapses its whitespace, so the
+// same search typed differently is counted once
+func NormalizeSearchQuery(query string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	if runes := []rune(normalized); len(runes) > maxLoggedQueryLength {
+		normalized = string(runes[:maxLoggedQueryLength])
+	}
+	return normalized
+}
+
+type SearchAnalyticsService interface {
+	RecordSearch(ctx context.Context, event SearchEvent) uuid.UUID
+	RecordClick(ctx context.Context, click SearchClickEvent) error
+	TopQueries(ctx context.Context, from, to *time.Time, limit *int) ([]models.SearchQueryStat, error)
+	ZeroResultQueries(ctx context.Context, from, to *time.Time, limit *int) ([]models.ZeroResultQuery, error)
+	ClickThrough(ctx context.Context, from, to *time.Time) (*models.SearchClickThrough, error)
+	Trending(ctx context.Context, limit *int) ([]models.TrendingSearch, error)
+}
+
+type searchAnalyticsService struct {
+	searchLogRepo repositories.ISearchLogRepository
+
+	// The visitor hash salt lives only in memory and is replaced every UTC day
+	saltMu  sync.Mutex
+	salt    []byte
+	saltDay string
+}
+
+func NewSearchAnalyticsService(searchLogRepo repositories.ISearchLogRepository) SearchAnalyticsService {
+	return &searchAnalyticsService{searchLogRepo: searchLogRepo}
+}
+
+// visitorHash pseudonymizes a logged-out client for counting distinct searchers
+func (s *searchAnalyticsService) visitorHash(ip, userAgent string) string {
+	s.saltMu.Lock()
+	today := time.Now().UTC().Format("2006-01-02")
+	if s.saltDay != today {
+		s.salt = make([]byte, 32)
+		if _, err := rand.Read(s.salt); err != nil {
+			s.saltMu.Unlock()
+			return ""
+		}
+		s.saltDay = today
+	}
+	mac := hmac.New(sha256.New, s.salt)
+	s.saltMu.Unlock()
+
+	mac.Write([]byte(ip + "\x00" + userAgent))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// RecordSearch logs the search and returns the id clicks are reported against,
+// or uuid.Nil when the query is blank or couldn't be logged
+func (s *searchAnalyticsService) RecordSearch(ctx context.Context, event SearchEvent) uuid.UUID {
+	query := NormalizeSearchQuery(event.Query)
+	if query == "" {
+		return uuid.Nil
+	}
+
+	entry := &models.SearchLog{
+		Query:       query,
+		Scope:       event.Scope,
+		ResultCount: event.ResultCount,
+		UserID:      event.UserID,
+	}
+	entry.ID = uuid.New()
+	if event.UserID == nil {
+		entry.VisitorHash = s.visitorHash(event.ClientIP, event.UserAgent)
+	}
+
+	// Logged before the results go out, so a click on them always finds its
+	// search; a failure only costs the analytics, not the search
+	if _, err := s.searchLogRepo.WithContext(ctx).Create(entry); err != nil {
+		log.Warnf("Failed to log search: %s", err.Error())
+		return uuid.Nil
+	}
+	return entry.ID
+}
+
+func (s *searchAnalyticsService) RecordClick(ctx context.Context, click SearchClickEvent) error {
+	if !searchClickTypes[click.ResultType] {
+		return ErrInvalidClickType
+	}
+	exists, err := s.searchLogRepo.Exists(click.SearchID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSearchLogNotFound
+	}
+
+	return s.searchLogRepo.CreateClick(&models.SearchClick{
+		SearchLogID: click.SearchID,
+		ResultType:  click.ResultType,
+		ResultID:    click.ResultID,
+		Position:    max(click.Position, 0),
+	})
+}
+
+// reportRange defaults a report to the last defaultReportDays days
+func reportRange(from, to *time.Time) (time.Time, time.Time, error) {
+	end := time.Now()
+	if to != nil {
+		end = *to
+	}
+	start := end.AddDate(0, 0, -defaultReportDays)
+	if from != nil {
+		start = *from
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, ErrInvalidReportRange
+	}
+	return start, end, nil
+}
+
+func reportLimit(limit *int, fallback, maximum int) int {
+	if limit == nil || *limit < 1 {
+		return fallback
+	}
+	return min(*limit, maximum)
+}
+
+func (s *searchAnalyticsService) TopQueries(ctx context.Context, from, to *time.Time, limit *int) ([]models.SearchQueryStat, error) {
+	start, end, err := reportRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return s.searchLogRepo.TopQueries(start, end, reportLimit(limit, 50, maxReportLimit))
+}
+
+func (s *searchAnalyticsService) ZeroResultQueries(ctx context.Context, from, to *time.Time, limit *int) ([]models.ZeroResultQuery, error) {
+	start, end, err := reportRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return s.searchLogRepo.ZeroResultQueries(start, end, reportLimit(limit, 50, maxReportLimit))
+}
+
+func (s *searchAnalyticsService) ClickThrough(ctx context.Context, from, to *time.Time) (*models.SearchClickThrough, error) {
+	start, end, err := reportRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return s.searchLogRepo.ClickThrough(start, end)
+}
+
+func (s *searchAnalyticsService) Trending(ctx context.Context, limit *int) ([]models.TrendingSearch, error) {
+	recent := time.Now().Add(-trendingWindow)
+	return s.searchLogRepo.Trending(recent, recent.Add(-trendingBaseline), minTrendingSearchers, reportLimit(limit, 10, maxTrendingSearches))
+}
@@ -4,20 +4,37 @@ import (
 	"context"
 	"crawl/models"
 	"crawl/repositories"
+	"crawl/search"
 	"errors"
 	"github.com/google/uuid"
+	"strings"
 )
 
+var (
+	ErrInvalidCreditRole   = errors.New("contribution type must be one of: " + strings.Join(models.CreditRoles, ", "))
+	ErrPrimaryArtistCredit = errors.New("a song's or album's own artist cannot also be credited as primary or featured")
+)
+
+// normalizeCreditRole lower-cases the role and checks it against the credit vocabulary
+func normalizeCreditRole(role string) (string, error) {
+	role = strings.ToLower(strings.TrimSpace(role))
+	if !models.IsCreditRole(role) {
+		return "", ErrInvalidCreditRole
+	}
+	return role, nil
+}
+
 type SongService interface {
-	SearchSongs(ctx context.Context, query *string, artist *string, genre *string, sort *string, order *string, page *int, limit *int) ([]models.Song, error)
+	SearchSongs(ctx context.Context, query *string, artist *string, genre *string, sort *string, order *string, page *int, limit *int, country string) ([]models.Song, int64, error)
 	CreateSong(ctx context.Context, song *models.Song) (*models.Song, error)
 	GetSongByID(ctx context.Context, songID uuid.UUID) (*models.Song, error)
-	GetAllSongs(ctx context.Context, page *int, limit *int, genre *string, artistID *string, albumID *string) ([]models.Song, error)
+	GetAllSongs(ctx context.Context, page *int, limit *int, genre *string, artistID *string, albumID *string, tags *string, country string) ([]models.Song, error)
 	UpdateSong(ctx context.Context, songID uuid.UUID, song *models.Song) (*models.Song, error)
 	DeleteSong(ctx context.Context, songID uuid.UUID) error
 	GetSongContributors(ctx context.Context, songID uuid.UUID) ([]models.SongContributor, error)
 	AddSongContributor(ctx context.Context, songID uuid.UUID, contributor *models.SongContributor) error
 	RecordStream(ctx context.Context, stream *models.Stream) error
+	SearchSongFacets(ctx context.Context, query *string, country string) (*models.SearchFacets, error)
 }
 
 type songService struct {
@@ -27,6 +44,7 @@ type songService struct {
 	albumRepo       repositories.IAlbumRepository
 	streamRepo      repositories.IStreamRepository
 	contributorRepo repositories.ISongContributorRepository
+	index           search.SearchIndex
 }
 
 func NewSongService(
@@ -36,6 +54,7 @@ func NewSongService(
 	albumRepo repositories.IAlbumRepository,
 	streamRepo repositories.IStreamRepository,
 	contributorRepo repositories.ISongContributorRepository,
+	index search.SearchIndex,
 ) SongService {
 	return &songService{
 		songRepo:        songRepo,
@@ -44,10 +63,11 @@ func NewSongService(
 		albumRepo:       albumRepo,
 		streamRepo:      streamRepo,
 		contributorRepo: contributorRepo,
+		index:           index,
 	}
 }
 
-func (s *songService) SearchSongs(ctx context.Context, query *string, artist *string, genre *string, sort *string, order *string, page *int, limit *int) ([]models.Song, error) {
+func (s *songService) SearchSongs(ctx context.Context, query *string, artist *string, genre *string, sort *string, order *string, page *int, limit *int, country string) ([]models.Song, int64, error) {
 	var offset int
 	if page != nil && limit != nil {
 		offset = (*page - 1) * *limit
@@ -56,7 +76,20 @@ func (s *songService) SearchSongs(ctx context.Context, query *string, artist *st
 		*limit = 20
 	}
 
-	return s.songRepo.Search(query, artist, genre, sort, order, offset, *limit)
+	return s.index.SearchSongs(ctx, search.SongQuery{
+		Text:    query,
+		Artist:  artist,
+		Genre:   genre,
+		Sort:    sort,
+		Order:   order,
+		Country: country,
+		Offset:  offset,
+		Limit:   *limit,
+	})
+}
+
+func (s *songService) SearchSongFacets(ctx context.Context, query *string, country string) (*models.SearchFacets, error) {
+	return s.songRepo.AvailableIn(country).SearchFacets(ctx, query)
 }
 
 func (s *songService) CreateSong(ctx context.Context, song *models.Song) (*models.Song, error) {
@@ -93,7 +126,7 @@ func (s *songService) CreateSong(ctx context.Context, song *models.Song) (*model
 		}
 	}
 
-	return s.songRepo.Create(song)
+	return s.songRepo.WithContext(ctx).Create(song)
 }
 
 func (s *songService) GetSongByID(ctx context.Context, songID uuid.UUID) (*models.Song, error) {
@@ -107,7 +140,7 @@ func (s *songService) GetSongByID(ctx context.Context, songID uuid.UUID) (*model
 	return song, nil
 }
 
-func (s *songService) GetAllSongs(ctx context.Context, page *int, limit *int, genre *string, artistID *string, albumID *string) ([]models.Song, error) {
+func (s *songService) GetAllSongs(ctx context.Context, page *int, limit *int, genre *string, artistID *string, albumID *string, tags *string, country string) ([]models.Song, error) {
 	var offset int
 	if page != nil && limit != nil {
 		offset = (*page - 1) * *limit
@@ -116,30 +149,30 @@ func (s *songService) GetAllSongs(ctx context.Context, page *int, limit *int, ge
 		*limit = 20
 	}
 
-	// Build where conditions
-	var conditions []interface{}
+	var genreUUID, artistUUID, albumUUID *uuid.UUID
 	if genre != nil {
-		conditions = append(conditions, "genre_id = ?", *genre)
+		id, err := uuid.Parse(*genre)
+		if err != nil {
+			return nil, errors.New("invalid genre ID format")
+		}
+		genreUUID = &id
 	}
 	if artistID != nil {
 		id, err := uuid.Parse(*artistID)
 		if err != nil {
 			return nil, errors.New("invalid artist ID format")
 		}
-		conditions = append(conditions, "artist_id = ?", id)
+		artistUUID = &id
 	}
 	if albumID != nil {
 		id, err := uuid.Parse(*albumID)
 		if err != nil {
 			return nil, errors.New("invalid album ID format")
 		}
-		conditions = append(conditions, "album_id = ?", id)
+		albumUUID = &id
 	}
 
-	if len(conditions) > 0 {
-		return s.songRepo.GetAll(offset, *limit, conditions...)
-	}
-	return s.songRepo.GetAll(offset, *limit)
+	return s.songRepo.AvailableIn(country).GetFiltered(genreUUID, artistUUID, albumUUID, parseTagFilter(tags), offset, *limit)
 }
 
 func (s *songService) UpdateSong(ctx context.Context, songID uuid.UUID, song *models.Song) (*models.Song, error) {
@@ -185,7 +218,7 @@ func (s *songService) UpdateSong(ctx context.Context, songID uuid.UUID, song *mo
 		}
 	}
 
-	return s.songRepo.Update(existingSong)
+	return s.songRepo.WithContext(ctx).Update(existingSong)
 }
 
 func (s *songService) DeleteSong(ctx context.Context, songID uuid.UUID) error {
@@ -198,7 +231,7 @@ func (s *songService) DeleteSong(ctx context.Context, songID uuid.UUID) error {
 		return err
 	}
 
-	return s.songRepo.Delete(songID)
+	return s.songRepo.WithContext(ctx).Delete(songID)
 }
 
 func (s *songService) GetSongContributors(ctx context.Context, songID uuid.UUID) ([]models.SongContributor, error) {
@@ -215,8 +248,14 @@ func (s *songService) GetSongContributors(ctx context.Context, songID uuid.UUID)
 }
 
 func (s *songService) AddSongContributor(ctx context.Context, songID uuid.UUID, contributor *models.SongContributor) error {
+	role, err := normalizeCreditRole(contributor.ContributionType)
+	if err != nil {
+		return err
+	}
+	contributor.ContributionType = role
+
 	// Verify song exists
-	_, err := s.songRepo.GetByID(songID)
+	song, err := s.songRepo.GetByID(songID)
 	if err != nil {
 		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return errors.New("song not found")
@@ -224,6 +263,11 @@ func (s *songService) AddSongContributor(ctx context.Context, songID uuid.UUID,
 		return err
 	}
 
+	// The owning artist is already the lead; crediting them again would duplicate the display name
+	if contributor.ArtistID == song.ArtistID && (role == models.CreditPrimary || role == models.CreditFeatured) {
+		return ErrPrimaryArtistCredit
+	}
+
 	// Verify artist exists
 	_, err = s.artistRepo.GetByID(contributor.ArtistID)
 	if err != nil {
@@ -2,78 +2,170 @@ package services
 
 import (
 	"context"
+	"crawl/ingest"
+	"crawl/live"
 	"crawl/models"
 	"crawl/repositories"
 	"errors"
 	"github.com/google/uuid"
+	"sync"
 	"time"
 )
 
+var (
+	ErrSongIDRequired     = errors.New("song ID is required")
+	ErrStreamSongNotFound = errors.New("song not found")
+	ErrInvalidPlayContext = errors.New("play context type must be 'album', 'playlist' or 'artist' and come with its ID")
+	// ErrStreamsBusy means the ingestion queue is full or shutting down; the client should retry
+	ErrStreamsBusy = errors.New("stream ingestion is busy, retry shortly")
+
+	ErrStreamNotFound        = errors.New("stream not found")
+	ErrStreamReviewed        = errors.New("stream is not awaiting review")
+	ErrInvalidStreamDecision = errors.New("decision must be 'approve' or 'reject'")
+)
+
+// streamEndSlack is how far past the end of its song a finished play's reported
+// position and listened time may go before they're cut back to it
+const streamEndSlack = 5
+
+// StreamQueue accepts streams for recording in the background
+type StreamQueue interface {
+	Enqueue(ctx context.Context, stream models.Stream) error
+}
+
 type StreamService interface {
+	// RecordStream checks the song can be played where the stream's CountryCode
+	// says, which must be resolved by the server, never claimed by the client
 	RecordStream(ctx context.Context, stream models.Stream) error
+	// RecordPlayback checks availability like RecordStream when a session starts
+	RecordPlayback(ctx context.Context, event PlaybackEvent) (uuid.UUID, error)
 	GetStreamCount(ctx context.Context, songID uuid.UUID) (int64, error)
-	GetArtistStreams(ctx context.Context, artistID uuid.UUID) ([]models.Stream, error)
+	GetArtistStreams(ctx context.Context, artistID uuid.UUID) ([]models.DailyStreamCount, error)
 	GetStreamBySong(ctx context.Context, songID uuid.UUID) (*models.Stream, error)
+	GetSuspicious(ctx context.Context, page *int, limit *int) ([]models.Stream, error)
+	Review(ctx context.Context, streamID uuid.UUID, reviewerID uuid.UUID, decision string) (*models.Stream, error)
+	Close()
 }
 
 type streamService struct {
-	streamRepo repositories.IStreamRepository
-	songRepo   repositories.ISongRepository
+	streamRepo    repositories.IStreamRepository
+	songRepo      repositories.ISongRepository
+	rollupRepo    repositories.IStreamRollupRepository
+	territoryRepo repositories.ITerritoryRepository
+	queue         StreamQueue
+	feed          LiveFeed
+
+	// Open playback sessions by ID
+	sessionsMu sync.Mutex
+	sessions   map[uuid.UUID]*playbackSession
+	stopSweep  chan struct{}
+	closeOnce  sync.Once
 }
 
 func NewStreamService(
 	streamRepo repositories.IStreamRepository,
 	songRepo repositories.ISongRepository,
+	rollupRepo repositories.IStreamRollupRepository,
+	territoryRepo repositories.ITerritoryRepository,
+	queue StreamQueue,
+	feed LiveFeed,
 ) StreamService {
-	return &streamService{
-		streamRepo: streamRepo,
-		songRepo:   songRepo,
+	s := &streamService{
+		streamRepo:    streamRepo,
+		songRepo:      songRepo,
+		rollupRepo:    rollupRepo,
+		territoryRepo: territoryRepo,
+		queue:         queue,
+		feed:          feed,
+		sessions:      map[uuid.UUID]*playbackSession{},
+		stopSweep:     make(chan struct{}),
 	}
+	go s.sweepPlayback()
+	return s
 }
 
+// RecordStream records a single finished play, for players that don't report
+// playback events
 func (s *streamService) RecordStream(ctx context.Context, stream models.Stream) error {
 	// Validate required fields
 	if stream.SongID == uuid.Nil {
-		return errors.New("song ID is required")
+		return ErrSongIDRequired
+	}
+	if !validPlayContext(stream) {
+		return ErrInvalidPlayContext
+	}
+	if stream.ListenedSeconds < 0 || stream.PositionSeconds < 0 {
+		return ErrInvalidPlaybackPosition
 	}
 
 	// Verify song exists
-	_, err := s.songRepo.GetByID(stream.SongID)
+	song, err := s.songRepo.GetByID(stream.SongID)
 	if err != nil {
-		return errors.New("song not found")
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return ErrStreamSongNotFound
+		}
+		return err
+	}
+	// Only the resolved country counts; ClaimedCountry is for the record
+	if err := checkSongTerritory(s.territoryRepo, stream.SongID, stream.CountryCode); err != nil {
+		return err
 	}
 
 	// Set timestamp if not provided
 	if stream.CreatedAt.IsZero() {
 		stream.CreatedAt = time.Now()
 	}
-
-	// Record the stream
-	_, err = s.streamRepo.Create(&stream)
-	if err != nil {
-		return err
+	// A single play can't run much past the end of its song
+	if song.Duration > 0 {
+		stream.ListenedSeconds = min(stream.ListenedSeconds, song.Duration+streamEndSlack)
+		stream.PositionSeconds = min(stream.PositionSeconds, song.Duration+streamEndSlack)
 	}
+	// A play reported with its listened time (Tracked) can be judged as completed or skipped
+	stream.Completed = stream.Tracked && song.Duration > 0 &&
+		float64(stream.PositionSeconds) >= completionShare*float64(song.Duration)
 
-	// Increment play count in songs table
-	err = s.songRepo.AddPlayCount(stream.SongID, 1)
+	// The stream is judged against the play rules and written in the next batch
+	return s.enqueue(ctx, stream, song.ArtistID)
+}
+
+// enqueue queues stream for writing and shows the play to the artist watching
+// live. Live plays are counted as reported, before the play rules judge them.
+func (s *streamService) enqueue(ctx context.Context, stream models.Stream, artistID uuid.UUID) error {
+	err := s.queue.Enqueue(ctx, stream)
+	if errors.Is(err, ingest.ErrQueueFull) || errors.Is(err, ingest.ErrPipelineClosed) {
+		return ErrStreamsBusy
+	}
 	if err != nil {
 		return err
 	}
-
+	songID := stream.SongID
+	s.feed.Publish(live.Event{Kind: live.EventPlay, ArtistID: artistID, SongID: &songID})
 	return nil
 }
 
+// validPlayContext reports whether the stream was played from nothing or from
+// a known kind of context with an ID
+func validPlayContext(stream models.Stream) bool {
+	switch stream.ContextType {
+	case "":
+		return stream.ContextID == nil
+	case models.PlayContextAlbum, models.PlayContextPlaylist, models.PlayContextArtist:
+		return stream.ContextID != nil && *stream.ContextID != uuid.Nil
+	}
+	return false
+}
+
 func (s *streamService) GetStreamCount(ctx context.Context, songID uuid.UUID) (int64, error) {
 	// Get count from last 30 days
 	since := time.Now().AddDate(0, 0, -30)
 	return s.streamRepo.GetStreamCount(songID, since)
 }
 
-func (s *streamService) GetArtistStreams(ctx context.Context, artistID uuid.UUID) ([]models.Stream, error) {
-	// Get streams from last 30 days
+func (s *streamService) GetArtistStreams(ctx context.Context, artistID uuid.UUID) ([]models.DailyStreamCount, error) {
+	// Daily counts for the last 30 days
 	end := time.Now()
 	start := end.AddDate(0, 0, -30)
-	return s.streamRepo.GetArtistStreams(artistID, start, end)
+	return s.streamRepo.GetArtistDailyStreams(artistID, start, end)
 }
 
 func (s *streamService) GetStreamBySong(ctx context.Context, songID uuid.UUID) (*models.Stream, error) {
@@ -85,3 +177,60 @@ func (s *streamService) GetStreamBySong(ctx context.Context, songID uuid.UUID) (
 
 	return s.streamRepo.GetStreamBySong(songID)
 }
+
+func (s *streamService) GetSuspicious(ctx context.Context, page *int, limit *int) ([]models.Stream, error) {
+	var offset int
+	if page != nil && limit != nil {
+		offset = (*page - 1) * *limit
+	} else {
+		limit = new(int)
+		*limit = 50
+	}
+
+	return s.streamRepo.GetSuspicious(offset, *limit)
+}
+
+// Review settles a stream held back as suspicious. Approved streams count as
+// plays from then on; rejected ones stay stored but never count.
+func (s *streamService) Review(ctx context.Context, streamID uuid.UUID, reviewerID uuid.UUID, decision string) (*models.Stream, error) {
+	var status string
+	switch decision {
+	case "approve":
+		status = models.StreamQualified
+	case "reject":
+		status = models.StreamRejected
+	default:
+		return nil, ErrInvalidStreamDecision
+	}
+
+	reviewed, err := s.streamRepo.Review(streamID, status, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := s.streamRepo.GetByID(streamID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrStreamNotFound
+		}
+		return nil, err
+	}
+	if !reviewed {
+		return nil, ErrStreamReviewed
+	}
+
+	// An approved stream from a day already rolled up has to be counted there too
+	if status == models.StreamQualified {
+		day := repositories.UTCDay(stream.CreatedAt)
+		missing, err := s.rollupRepo.MissingDays(day, day.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) == 0 {
+			if _, err := s.rollupRepo.RollupDay(stream.CreatedAt); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stream, nil
+}
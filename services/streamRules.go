@@ -0,0 +1,310 @@
+package services
+
+import (
+	"crawl/ingest"
+	"crawl/models"
+	"crawl/repositories"
+	"fmt"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StreamRules decide which streams count as plays and which are held back as likely fraud
+type StreamRules struct {
+	// MinListenSeconds is how long a stream must play to count
+	MinListenSeconds int
+	// DedupeWindow is how soon after a counted play the same listener's next play of the song counts again
+	DedupeWindow time.Duration
+
+	// A listener playing one song more than LoopLimit times within LoopWindow is looping it
+	LoopWindow time.Duration
+	LoopLimit  int
+	// An IP address or device with more plays than its limit within BurstWindow is bursting
+	BurstWindow      time.Duration
+	IPBurstLimit     int
+	DeviceBurstLimit int
+	// InactiveWindow is how far back an account's streams are checked for anything besides one artist
+	InactiveWindow time.Duration
+
+	// SuspiciousScore is the fraud score at which a stream is held back for review
+	SuspiciousScore float64
+}
+
+var DefaultStreamRules = StreamRules{
+	MinListenSeconds: 30,
+	DedupeWindow:     2 * time.Minute,
+	LoopWindow:       24 * time.Hour,
+	LoopLimit:        25,
+	BurstWindow:      10 * time.Minute,
+	// Shared networks put many honest listeners behind one address
+	IPBurstLimit: 120,
+	// Ten minutes of 30 second plays is only 20
+	DeviceBurstLimit: 25,
+	InactiveWindow:   30 * 24 * time.Hour,
+	SuspiciousScore:  0.5,
+}
+
+// fraudWeights is what each signal adds to a stream's fraud score. Any two
+// together reach the default threshold. Looping adds its weight in proportion
+// to the listener's plays over the loop limit, so it reaches the threshold on
+// its own at a quarter past the limit.
+var fraudWeights = map[string]float64{
+	models.FraudSignalLooping:     0.4,
+	models.FraudSignalDeviceBurst: 0.4,
+	models.FraudSignalIPBurst:     0.25,
+	models.FraudSignalInactive:    0.25,
+}
+
+type listenerSong struct {
+	userID uuid.UUID
+	songID uuid.UUID
+}
+
+// playWindow counts plays per key over a sliding window as a batch is judged in
+// order. Stored plays are only known for the window before the batch's first
+// stream, so they stop counting once the window has moved past it.
+type playWindow[K comparable] struct {
+	window time.Duration
+	first  time.Time
+	stored map[K]int64
+	played map[K][]time.Time
+}
+
+func newPlayWindow[K comparable](window time.Duration, first time.Time, stored map[K]int64) *playWindow[K] {
+	return &playWindow[K]{window: window, first: first, stored: stored, played: map[K][]time.Time{}}
+}
+
+// add records a play of key at and returns the plays of key within the window ending at it
+func (w *playWindow[K]) add(key K, at time.Time) int64 {
+	cutoff := at.Add(-w.window)
+	times := append(w.played[key], at)
+	for times[0].Before(cutoff) {
+		times = times[1:]
+	}
+	w.played[key] = times
+
+	count := int64(len(times))
+	if at.Before(w.first.Add(w.window)) {
+		count += w.stored[key]
+	}
+	return count
+}
+
+// streamQualifier is the ingestion sink that classifies each batch before it's stored
+type streamQualifier struct {
+	streamRepo repositories.IStreamRepository
+	rules      StreamRules
+}
+
+// NewStreamQualifier returns an ingestion sink that marks every stream as
+// qualified, invalid or suspicious against rules and then stores the batch, so
+// only qualified streams reach play counts
+func NewStreamQualifier(streamRepo repositories.IStreamRepository, rules StreamRules) ingest.Sink {
+	return &streamQualifier{streamRepo: streamRepo, rules: rules}
+}
+
+func (q *streamQualifier) CreateBatch(streams []models.Stream) error {
+	if err := q.classify(streams); err != nil {
+		return err
+	}
+	if err := q.hidePaused(streams); err != nil {
+		return err
+	}
+	err := q.streamRepo.CreateBatch(streams)
+	if repositories.IsInvalidData(err) {
+		return fmt.Errorf("%w: %w", ingest.ErrRejected, err)
+	}
+	return err
+}
+
+// hidePaused keeps the streams of listeners who paused their listening history
+// out of it. They still count as plays.
+func (q *streamQualifier) hidePaused(streams []models.Stream) error {
+	userIDs := []uuid.UUID{}
+	seen := map[uuid.UUID]bool{}
+	for _, stream := range streams {
+		if stream.UserID != nil && !seen[*stream.UserID] {
+			seen[*stream.UserID] = true
+			userIDs = append(userIDs, *stream.UserID)
+		}
+	}
+	paused, err := q.streamRepo.HistoryPausedListeners(userIDs)
+	if err != nil {
+		return err
+	}
+	if len(paused) == 0 {
+		return nil
+	}
+
+	hidden := map[uuid.UUID]bool{}
+	for _, userID := range paused {
+		hidden[userID] = true
+	}
+	for i := range streams {
+		if streams[i].UserID != nil && hidden[*streams[i].UserID] {
+			streams[i].HiddenFromHistory = true
+		}
+	}
+	return nil
+}
+
+// classify sets the status of every stream in place. Streams are judged in the
+// order they were played, each against the stored history plus the batch streams
+// before it.
+func (q *streamQualifier) classify(streams []models.Stream) error {
+	if len(streams) == 0 {
+		return nil
+	}
+	sort.SliceStable(streams, func(i, j int) bool {
+		return streams[i].CreatedAt.Before(streams[j].CreatedAt)
+	})
+	first := streams[0].CreatedAt
+
+	var userIDs, songIDs []uuid.UUID
+	var ips, devices []string
+	seen := map[string]bool{}
+	collect := func(key string) bool {
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+		return true
+	}
+	for _, stream := range streams {
+		if stream.UserID != nil && collect("user:"+stream.UserID.String()) {
+			userIDs = append(userIDs, *stream.UserID)
+		}
+		if collect("song:" + stream.SongID.String()) {
+			songIDs = append(songIDs, stream.SongID)
+		}
+		if stream.ClientIP != "" && collect("ip:"+stream.ClientIP) {
+			ips = append(ips, stream.ClientIP)
+		}
+		if stream.DeviceID != "" && collect("device:"+stream.DeviceID) {
+			devices = append(devices, stream.DeviceID)
+		}
+	}
+
+	history, err := q.streamRepo.ListenerSongPlays(userIDs, songIDs, first.Add(-q.rules.LoopWindow))
+	if err != nil {
+		return err
+	}
+	storedPlays := make(map[listenerSong]int64, len(history))
+	lastCounted := make(map[listenerSong]time.Time, len(history))
+	for _, row := range history {
+		key := listenerSong{row.UserID, row.SongID}
+		storedPlays[key] = row.Plays
+		if row.LastCountedAt != nil {
+			lastCounted[key] = *row.LastCountedAt
+		}
+	}
+	loops := newPlayWindow(q.rules.LoopWindow, first, storedPlays)
+
+	ipPlays, err := q.streamRepo.CountPlaysBy("client_ip", ips, first.Add(-q.rules.BurstWindow))
+	if err != nil {
+		return err
+	}
+	ipBursts := newPlayWindow(q.rules.BurstWindow, first, ipPlays)
+	devicePlays, err := q.streamRepo.CountPlaysBy("device_id", devices, first.Add(-q.rules.BurstWindow))
+	if err != nil {
+		return err
+	}
+	deviceBursts := newPlayWindow(q.rules.BurstWindow, first, devicePlays)
+
+	inactiveIDs, err := q.streamRepo.InactiveListeners(userIDs, first.Add(-q.rules.InactiveWindow))
+	if err != nil {
+		return err
+	}
+	inactive := make(map[uuid.UUID]bool, len(inactiveIDs))
+	for _, id := range inactiveIDs {
+		inactive[id] = true
+	}
+
+	minListen := time.Duration(q.rules.MinListenSeconds) * time.Second
+	lastPlayed, err := q.streamRepo.LastPlays(userIDs, first.Add(-minListen))
+	if err != nil {
+		return err
+	}
+
+	for i := range streams {
+		stream := &streams[i]
+		stream.Status = models.StreamQualified
+		stream.InvalidReason = ""
+		stream.FraudScore = 0
+		stream.FraudSignals = ""
+		// A tracked play that stopped short was skipped; the play rules decide how short
+		stream.Skipped = stream.Tracked && !stream.IsPreview && stream.ListenedSeconds < q.rules.MinListenSeconds
+
+		var key listenerSong
+		// sinceLast is how long before this stream the listener reported the
+		// previous one, when hadLast
+		var sinceLast time.Duration
+		var hadLast bool
+		if stream.UserID != nil {
+			key = listenerSong{*stream.UserID, stream.SongID}
+			var previous time.Time
+			if previous, hadLast = lastPlayed[*stream.UserID]; hadLast {
+				sinceLast = stream.CreatedAt.Sub(previous)
+			}
+			if !stream.IsPreview {
+				lastPlayed[*stream.UserID] = stream.CreatedAt
+			}
+		}
+
+		switch {
+		case stream.IsPreview:
+			stream.Status, stream.InvalidReason = models.StreamInvalid, models.StreamReasonPreview
+		case stream.Tracked && stream.ListenedSeconds < q.rules.MinListenSeconds:
+			stream.Status, stream.InvalidReason = models.StreamInvalid, models.StreamReasonTooShort
+		// Players that don't report listened time send each stream once it has
+		// played, so it can't have played longer than the time since the
+		// listener's previous one
+		case !stream.Tracked && hadLast && sinceLast < minListen:
+			stream.Status, stream.InvalidReason = models.StreamInvalid, models.StreamReasonTooShort
+		case stream.UserID != nil && !lastCounted[key].IsZero() && stream.CreatedAt.Sub(lastCounted[key]) < q.rules.DedupeWindow:
+			stream.Status, stream.InvalidReason = models.StreamInvalid, models.StreamReasonDuplicate
+		}
+		if stream.Status == models.StreamInvalid {
+			continue
+		}
+
+		var signals []string
+		var loopShare float64
+		if stream.UserID != nil {
+			if plays := loops.add(key, stream.CreatedAt); plays > int64(q.rules.LoopLimit) {
+				signals = append(signals, models.FraudSignalLooping)
+				loopShare = float64(plays) / float64(q.rules.LoopLimit)
+			}
+			if inactive[*stream.UserID] {
+				signals = append(signals, models.FraudSignalInactive)
+			}
+			lastCounted[key] = stream.CreatedAt
+		}
+		if stream.ClientIP != "" {
+			if ipBursts.add(stream.ClientIP, stream.CreatedAt) > int64(q.rules.IPBurstLimit) {
+				signals = append(signals, models.FraudSignalIPBurst)
+			}
+		}
+		if stream.DeviceID != "" {
+			if deviceBursts.add(stream.DeviceID, stream.CreatedAt) > int64(q.rules.DeviceBurstLimit) {
+				signals = append(signals, models.FraudSignalDeviceBurst)
+			}
+		}
+
+		for _, signal := range signals {
+			weight := fraudWeights[signal]
+			if signal == models.FraudSignalLooping {
+				weight *= loopShare
+			}
+			stream.FraudScore += weight
+		}
+		stream.FraudScore = min(stream.FraudScore, 1)
+		stream.FraudSignals = strings.Join(signals, ",")
+		if stream.FraudScore >= q.rules.SuspiciousScore {
+			stream.Status = models.StreamSuspicious
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"crawl/models"
+	"crawl/repositories"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeStreamHistory is the stored history classify reads; methods it doesn't
+// read panic through the nil interface
+type fakeStreamHistory struct {
+	repositories.IStreamRepository
+	plays    []models.ListenerSongPlays
+	ipPlays  map[string]int64
+	inactive []uuid.UUID
+	last     map[uuid.UUID]time.Time
+}
+
+func (f *fakeStreamHistory) ListenerSongPlays(userIDs, songIDs []uuid.UUID, since time.Time) ([]models.ListenerSongPlays, error) {
+	return f.plays, nil
+}
+
+func (f *fakeStreamHistory) CountPlaysBy(column string, values []string, since time.Time) (map[string]int64, error) {
+	if column == "client_ip" {
+		return f.ipPlays, nil
+	}
+	return map[string]int64{}, nil
+}
+
+func (f *fakeStreamHistory) LastPlays(userIDs []uuid.UUID, since time.Time) (map[uuid.UUID]time.Time, error) {
+	if f.last == nil {
+		return map[uuid.UUID]time.Time{}, nil
+	}
+	return f.last, nil
+}
+
+func (f *fakeStreamHistory) InactiveListeners(userIDs []uuid.UUID, since time.Time) ([]uuid.UUID, error) {
+	return f.inactive, nil
+}
+
+func TestClassify(t *testing.T) {
+	listener := uuid.New()
+	song := uuid.New()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	minute := func(m int) time.Time { return now.Add(time.Duration(m) * time.Minute) }
+
+	play := func(at time.Time, listened int) models.Stream {
+		stream := models.Stream{UserID: &listener, SongID: song, ListenedSeconds: listened, Tracked: true}
+		stream.CreatedAt = at
+		return stream
+	}
+	untracked := func(at time.Time) models.Stream {
+		stream := models.Stream{UserID: &listener, SongID: song}
+		stream.CreatedAt = at
+		return stream
+	}
+	withIP := func(stream models.Stream, ip string) models.Stream {
+		stream.ClientIP = ip
+		return stream
+	}
+	preview := play(minute(0), 30)
+	preview.IsPreview = true
+
+	tests := []struct {
+		name    string
+		history fakeStreamHistory
+		streams []models.Stream
+		// want holds each stream's status and reason or signals, in play order
+		want []string
+	}{
+		{
+			name:    "tracked play long enough",
+			streams: []models.Stream{play(minute(0), 45)},
+			want:    []string{"qualified"},
+		},
+		{
+			name:    "tracked play cut short",
+			streams: []models.Stream{play(minute(0), 10)},
+			want:    []string{"invalid too_short"},
+		},
+		{
+			name:    "untracked play with nothing before it",
+			streams: []models.Stream{untracked(minute(0))},
+			want:    []string{"qualified"},
+		},
+		{
+			name:    "untracked play sent too soon after the previous one",
+			history: fakeStreamHistory{last: map[uuid.UUID]time.Time{listener: minute(0)}},
+			streams: []models.Stream{untracked(minute(0).Add(10 * time.Second))},
+			want:    []string{"invalid too_short"},
+		},
+		{
+			name:    "untracked plays in quick succession",
+			streams: []models.Stream{untracked(minute(0)), untracked(minute(0).Add(5 * time.Second))},
+			want:    []string{"qualified", "invalid too_short"},
+		},
+		{
+			name:    "untracked play long enough after the previous one",
+			history: fakeStreamHistory{last: map[uuid.UUID]time.Time{listener: minute(-3)}},
+			streams: []models.Stream{untracked(minute(0))},
+			want:    []string{"qualified"},
+		},
+		{
+			name:    "untracked replay within the dedupe window",
+			streams: []models.Stream{untracked(minute(0)), untracked(minute(1))},
+			want:    []string{"qualified", "invalid duplicate"},
+		},
+		{
+			name:    "preview",
+			streams: []models.Stream{preview},
+			want:    []string{"invalid preview"},
+		},
+		{
+			name:    "replay after the dedupe window",
+			streams: []models.Stream{play(minute(5), 60), play(minute(0), 60)},
+			want:    []string{"qualified", "qualified"},
+		},
+		{
+			name: "stored play within the dedupe window",
+			history: fakeStreamHistory{plays: []models.ListenerSongPlays{
+				{UserID: listener, SongID: song, Plays: 1, LastCountedAt: ptr(minute(-1))},
+			}},
+			streams: []models.Stream{play(minute(0), 60)},
+			want:    []string{"invalid duplicate"},
+		},
+		{
+			name: "looping just past the limit stays qualified",
+			history: fakeStreamHistory{plays: []models.ListenerSongPlays{
+				{UserID: listener, SongID: song, Plays: 26, LastCountedAt: ptr(minute(-10))},
+			}},
+			streams: []models.Stream{play(minute(0), 60)},
+			want:    []string{"qualified looping"},
+		},
+		{
+			name: "looping far past the limit is suspicious alone",
+			history: fakeStreamHistory{plays: []models.ListenerSongPlays{
+				{UserID: listener, SongID: song, Plays: 200, LastCountedAt: ptr(minute(-10))},
+			}},
+			streams: []models.Stream{play(minute(0), 60)},
+			want:    []string{"suspicious looping"},
+		},
+		{
+			name:    "inactive alone stays qualified",
+			history: fakeStreamHistory{inactive: []uuid.UUID{listener}},
+			streams: []models.Stream{play(minute(0), 60)},
+			want:    []string{"qualified inactive"},
+		},
+		{
+			name: "looping from an inactive account is suspicious",
+			history: fakeStreamHistory{
+				plays: []models.ListenerSongPlays{
+					{UserID: listener, SongID: song, Plays: 30, LastCountedAt: ptr(minute(-10))},
+				},
+				inactive: []uuid.UUID{listener},
+			},
+			streams: []models.Stream{play(minute(0), 60)},
+			want:    []string{"suspicious looping,inactive"},
+		},
+		{
+			name:    "ip burst with an inactive account is suspicious",
+			history: fakeStreamHistory{ipPlays: map[string]int64{"10.0.0.1": 500}, inactive: []uuid.UUID{listener}},
+			streams: []models.Stream{withIP(play(minute(0), 60), "10.0.0.1")},
+			want:    []string{"suspicious inactive,ip_burst"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &streamQualifier{streamRepo: &tt.history, rules: DefaultStreamRules}
+			streams := append([]models.Stream(nil), tt.streams...)
+			if err := q.classify(streams); err != nil {
+				t.Fatalf("classify: %v", err)
+			}
+			if len(streams) != len(tt.want) {
+				t.Fatalf("got %d streams, want %d", len(streams), len(tt.want))
+			}
+			for i, stream := range streams {
+				got := stream.Status
+				if detail := stream.InvalidReason + stream.FraudSignals; detail != "" {
+					got += " " + detail
+				}
+				if got != tt.want[i] {
+					t.Errorf("stream %d: got %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"crawl/live"
+	"crawl/models"
+	"crawl/repositories"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeSongs holds the one song RecordStream looks up
+type fakeSongs struct {
+	repositories.ISongRepository
+	song models.Song
+}
+
+func (f *fakeSongs) GetByID(id uuid.UUID) (*models.Song, error) {
+	if id != f.song.ID {
+		return nil, repositories.ErrRecordNotFound
+	}
+	song := f.song
+	return &song, nil
+}
+
+// openTerritories makes every song available everywhere
+type openTerritories struct {
+	repositories.ITerritoryRepository
+}
+
+func (openTerritories) UnavailableSongs(songIDs []uuid.UUID, country string, at time.Time) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+type fakeQueue struct {
+	streams []models.Stream
+}
+
+func (q *fakeQueue) Enqueue(ctx context.Context, stream models.Stream) error {
+	q.streams = append(q.streams, stream)
+	return nil
+}
+
+type quietFeed struct{}
+
+func (quietFeed) Publish(live.Event) {}
+
+func TestRecordStream(t *testing.T) {
+	song := models.Song{Duration: 200}
+	song.ID = uuid.New()
+	listener := uuid.New()
+
+	tests := []struct {
+		name     string
+		listened int
+		position int
+		wantErr  error
+		// The listened time and position queued, and whether the play completed
+		wantListened int
+		wantPosition int
+		completed    bool
+	}{
+		{name: "within the song", listened: 120, position: 120, wantListened: 120, wantPosition: 120},
+		{name: "played to the end", listened: 200, position: 198, wantListened: 200, wantPosition: 198, completed: true},
+		{name: "a little past the end", listened: 203, position: 203, wantListened: 203, wantPosition: 203, completed: true},
+		{name: "far past the end", listened: 100000, position: 5000, wantListened: 205, wantPosition: 205, completed: true},
+		{name: "negative listened time", listened: -1, position: 10, wantErr: ErrInvalidPlaybackPosition},
+		{name: "negative position", listened: 10, position: -30, wantErr: ErrInvalidPlaybackPosition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := &fakeQueue{}
+			s := &streamService{
+				songRepo:      &fakeSongs{song: song},
+				territoryRepo: openTerritories{},
+				queue:         queue,
+				feed:          quietFeed{},
+			}
+			stream := models.Stream{
+				UserID:          &listener,
+				SongID:          song.ID,
+				ListenedSeconds: tt.listened,
+				PositionSeconds: tt.position,
+				Tracked:         true,
+			}
+
+			err := s.RecordStream(context.Background(), stream)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(queue.streams) != 0 {
+					t.Errorf("queued %d streams, want none", len(queue.streams))
+				}
+				return
+			}
+			if len(queue.streams) != 1 {
+				t.Fatalf("queued %d streams, want 1", len(queue.streams))
+			}
+			got := queue.streams[0]
+			if got.ListenedSeconds != tt.wantListened || got.PositionSeconds != tt.wantPosition || got.Completed != tt.completed {
+				t.Errorf("queued listened %d, position %d, completed %t; want %d, %d, %t",
+					got.ListenedSeconds, got.PositionSeconds, got.Completed, tt.wantListened, tt.wantPosition, tt.completed)
+			}
+		})
+	}
+}
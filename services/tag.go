@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"unicode"
+)
+
+// Upper bound on the tags and moods a single song or album can carry
+const maxTagsPerItem = 20
+
+var (
+	ErrTagNotFound        = errors.New("tag not found")
+	ErrTagExists          = errors.New("tag already exists")
+	ErrInvalidTag         = errors.New("tag names must contain letters or digits and be at most 50 characters")
+	ErrInvalidKind        = errors.New("tag kind must be \"tag\" or \"mood\"")
+	ErrTooManyTags        = errors.New("too many tags")
+	ErrTaggedItemNotFound = errors.New("tagged item not found")
+)
+
+type TagService interface {
+	GetTags(ctx context.Context, kind *string) ([]models.Tag, error)
+	CreateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error)
+	DeleteTag(ctx context.Context, tagID uuid.UUID) error
+	GetSongTags(ctx context.Context, songID uuid.UUID) ([]models.Tag, error)
+	SetSongTags(ctx context.Context, songID uuid.UUID, tags []string, moods []string) ([]models.Tag, error)
+	GetAlbumTags(ctx context.Context, albumID uuid.UUID) ([]models.Tag, error)
+	SetAlbumTags(ctx context.Context, albumID uuid.UUID, tags []string, moods []string) ([]models.Tag, error)
+}
+
+type tagService struct {
+	tagRepo   repositories.ITagRepository
+	songRepo  repositories.ISongRepository
+	albumRepo repositories.IAlbumRepository
+}
+
+func NewTagService(
+	tagRepo repositories.ITagRepository,
+	songRepo repositories.ISongRepository,
+	albumRepo repositories.IAlbumRepository,
+) TagService {
+	return &tagService{
+		tagRepo:   tagRepo,
+		songRepo:  songRepo,
+		albumRepo: albumRepo,
+	}
+}
+
+// slugify lowercases name and joins its words with dashes, e.g. "Late  Night!" becomes "late-night"
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// parseTagFilter turns a comma-separated tag query parameter into slugs
+func parseTagFilter(tags *string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	var slugs []string
+	for _, name := range strings.Split(*tags, ",") {
+		if slug := slugify(name); slug != "" {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
+
+func (s *tagService) GetTags(ctx context.Context, kind *string) ([]models.Tag, error) {
+	tags, err := s.tagRepo.GetByKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if tags == nil {
+		return []models.Tag{}, nil
+	}
+
+	return tags, nil
+}
+
+func (s *tagService) CreateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	if tag.Kind == "" {
+		tag.Kind = models.TagKindTag
+	}
+	if err := prepareTag(tag); err != nil {
+		return nil, err
+	}
+
+	_, err := s.tagRepo.GetBySlug(tag.Kind, tag.Slug)
+	if err == nil {
+		return nil, ErrTagExists
+	}
+	if !errors.Is(err, repositories.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return s.tagRepo.Create(tag)
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, tagID uuid.UUID) error {
+	err := s.tagRepo.Delete(tagID)
+	if errors.Is(err, repositories.ErrRecordNotFound) {
+		return ErrTagNotFound
+	}
+	return err
+}
+
+func (s *tagService) GetSongTags(ctx context.Context, songID uuid.UUID) ([]models.Tag, error) {
+	if _, err := s.songRepo.GetByID(songID); err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrTaggedItemNotFound
+		}
+		return nil, err
+	}
+
+	tags, err := s.tagRepo.GetSongTags(songID)
+	if err != nil {
+		return nil, err
+	}
+	if tags == nil {
+		return []models.Tag{}, nil
+	}
+	return tags, nil
+}
+
+func (s *tagService) SetSongTags(ctx context.Context, songID uuid.UUID, tags []string, moods []string) ([]models.Tag, error) {
+	if _, err := s.songRepo.GetByID(songID); err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrTaggedItemNotFound
+		}
+		return nil, err
+	}
+
+	tagIDs, err := s.resolveTags(tags, moods)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.ReplaceSongTags(songID, tagIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetSongTags(ctx, songID)
+}
+
+func (s *tagService) GetAlbumTags(ctx context.Context, albumID uuid.UUID) ([]models.Tag, error) {
+	if _, err := s.albumRepo.GetByID(albumID); err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrTaggedItemNotFound
+		}
+		return nil, err
+	}
+
+	tags, err := s.tagRepo.GetAlbumTags(albumID)
+	if err != nil {
+		return nil, err
+	}
+	if tags == nil {
+		return []models.Tag{}, nil
+	}
+	return tags, nil
+}
+
+func (s *tagService) SetAlbumTags(ctx context.Context, albumID uuid.UUID, tags []string, moods []string) ([]models.Tag, error) {
+	if _, err := s.albumRepo.GetByID(albumID); err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrTaggedItemNotFound
+		}
+		return nil, err
+	}
+
+	tagIDs, err := s.resolveTags(tags, moods)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.ReplaceAlbumTags(albumID, tagIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetAlbumTags(ctx, albumID)
+}
+
+// resolveTags finds or creates the named tags and moods, dropping duplicates
+func (s *tagService) resolveTags(tags []string, moods []string) ([]uuid.UUID, error) {
+	if len(tags)+len(moods) > maxTagsPerItem {
+		return nil, ErrTooManyTags
+	}
+
+	var ids []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	add := func(kind string, names []string) error {
+		for _, name := range names {
+			tag := &models.Tag{Name: name, Kind: kind}
+			if err := prepareTag(tag); err != nil {
+				return err
+			}
+
+			stored, err := s.tagRepo.FindOrCreate(tag)
+			if err != nil {
+				return err
+			}
+			if !seen[stored.ID] {
+				seen[stored.ID] = true
+				ids = append(ids, stored.ID)
+			}
+		}
+		return nil
+	}
+
+	if err := add(models.TagKindTag, tags); err != nil {
+		return nil, err
+	}
+	if err := add(models.TagKindMood, moods); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func prepareTag(tag *models.Tag) error {
+	if tag.Kind != models.TagKindTag && tag.Kind != models.TagKindMood {
+		return ErrInvalidKind
+	}
+
+	tag.Name = strings.TrimSpace(tag.Name)
+	tag.Slug = slugify(tag.Name)
+	if tag.Slug == "" || len(tag.Name) > 50 || len(tag.Slug) > 50 {
+		return ErrInvalidTag
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotAvailableInRegion   = errors.New("not available in your region")
+	ErrInvalidTerritoryMode   = errors.New("territory mode must be 'allow' or 'deny'")
+	ErrInvalidTerritory       = errors.New("territories must be two-letter ISO 3166 country codes")
+	ErrInvalidTerritoryRange  = errors.New("territory rule must end after it starts")
+	ErrTerritoryRuleNotFound  = errors.New("territory rule not found")
+	ErrTerritorySongNotFound  = errors.New("song not found")
+	ErrTerritoryAlbumNotFound = errors.New("album not found")
+)
+
+// TerritoryRules adds rules in one mode for each of a set of countries
+type TerritoryRules struct {
+	Mode      string
+	Countries []string
+	StartsAt  *time.Time
+	EndsAt    *time.Time
+}
+
+type TerritoryService interface {
+	AddSongRules(ctx context.Context, songID uuid.UUID, rules TerritoryRules, createdBy uuid.UUID) ([]models.TerritoryRule, error)
+	AddAlbumRules(ctx context.Context, albumID uuid.UUID, rules TerritoryRules, createdBy uuid.UUID) ([]models.TerritoryRule, error)
+	GetSongRules(ctx context.Context, songID uuid.UUID) ([]models.TerritoryRule, error)
+	GetAlbumRules(ctx context.Context, albumID uuid.UUID) ([]models.TerritoryRule, error)
+	GetRule(ctx context.Context, ruleID uuid.UUID) (*models.TerritoryRule, error)
+	DeleteRule(ctx context.Context, ruleID uuid.UUID) error
+
+	// CheckSong returns ErrNotAvailableInRegion when the song can't be played in country now
+	CheckSong(ctx context.Context, songID uuid.UUID, country string) error
+	// CheckAlbum returns ErrNotAvailableInRegion when the album can't be played in country now
+	CheckAlbum(ctx context.Context, albumID uuid.UUID, country string) error
+}
+
+type territoryService struct {
+	territoryRepo repositories.ITerritoryRepository
+	songRepo      repositories.ISongRepository
+	albumRepo     repositories.IAlbumRepository
+}
+
+func NewTerritoryService(
+	territoryRepo repositories.ITerritoryRepository,
+	songRepo repositories.ISongRepository,
+	albumRepo repositories.IAlbumRepository,
+) TerritoryService {
+	return &territoryService{
+		territoryRepo: territoryRepo,
+		songRepo:      songRepo,
+		albumRepo:     albumRepo,
+	}
+}
+
+// newRules validates rules and makes one rule per country, each country once
+func newRules(rules TerritoryRules, createdBy uuid.UUID) ([]models.TerritoryRule, error) {
+	if rules.Mode != models.TerritoryAllow && rules.Mode != models.TerritoryDeny {
+		return nil, ErrInvalidTerritoryMode
+	}
+	if len(rules.Countries) == 0 {
+		return nil, ErrInvalidTerritory
+	}
+	if rules.StartsAt != nil && rules.EndsAt != nil && !rules.EndsAt.After(*rules.StartsAt) {
+		return nil, ErrInvalidTerritoryRange
+	}
+
+	created := []models.TerritoryRule{}
+	seen := map[string]bool{}
+	for _, country := range rules.Countries {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if !countryCodePattern.MatchString(country) {
+			return nil, ErrInvalidTerritory
+		}
+		if seen[country] {
+			continue
+		}
+		seen[country] = true
+		created = append(created, models.TerritoryRule{
+			Mode:        rules.Mode,
+			CountryCode: country,
+			StartsAt:    rules.StartsAt,
+			EndsAt:      rules.EndsAt,
+			CreatedByID: &createdBy,
+		})
+	}
+	return created, nil
+}
+
+func (s *territoryService) AddSongRules(ctx context.Context, songID uuid.UUID, rules TerritoryRules, createdBy uuid.UUID) ([]models.TerritoryRule, error) {
+	if _, err := s.songRepo.GetByID(songID); err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrTerritorySongNotFound
+		}
+		return nil, err
+	}
+	created, err := newRules(rules, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	for i := range created {
+		created[i].SongID = &songID
+	}
+	return created, s.territoryRepo.CreateRules(created)
+}
+
+func (s *territoryService) AddAlbumRules(ctx context.Context, albumID uuid.UUID, rules TerritoryRules, createdBy uuid.UUID) ([]models.TerritoryRule, error) {
+	if _, err := s.albumRepo.GetByID(albumID); err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrTerritoryAlbumNotFound
+		}
+		return nil, err
+	}
+	created, err := newRules(rules, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	for i := range created {
+		created[i].AlbumID = &albumID
+	}
+	return created, s.territoryRepo.CreateRules(created)
+}
+
+func (s *territoryService) GetSongRules(ctx context.Context, songID uuid.UUID) ([]models.TerritoryRule, error) {
+	return s.territoryRepo.GetRules(&songID, nil)
+}
+
+func (s *territoryService) GetAlbumRules(ctx context.Context, albumID uuid.UUID) ([]models.TerritoryRule, error) {
+	return s.territoryRepo.GetRules(nil, &albumID)
+}
+
+func (s *territoryService) GetRule(ctx context.Context, ruleID uuid.UUID) (*models.TerritoryRule, error) {
+	rule, err := s.territoryRepo.GetRule(ruleID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrTerritoryRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *territoryService) DeleteRule(ctx context.Context, ruleID uuid.UUID) error {
+	if _, err := s.GetRule(ctx, ruleID); err != nil {
+		return err
+	}
+	return s.territoryRepo.DeleteRule(ruleID)
+}
+
+func (s *territoryService) CheckSong(ctx context.Context, songID uuid.UUID, country string) error {
+	return checkSongTerritory(s.territoryRepo, songID, country)
+}
+
+func (s *territoryService) CheckAlbum(ctx context.Context, albumID uuid.UUID, country string) error {
+	return checkAlbumTerritory(s.territoryRepo, albumID, country)
+}
+
+// checkSongTerritory returns ErrNotAvailableInRegion when the song can't be
+// played in country now, for the services that stream and sell songs
+func checkSongTerritory(territoryRepo repositories.ITerritoryRepository, songID uuid.UUID, country string) error {
+	unavailable, err := territoryRepo.UnavailableSongs([]uuid.UUID{songID}, country, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(unavailable) > 0 {
+		return ErrNotAvailableInRegion
+	}
+	return nil
+}
+
+// checkAlbumTerritory returns ErrNotAvailableInRegion when the album can't be
+// played in country now
+func checkAlbumTerritory(territoryRepo repositories.ITerritoryRepository, albumID uuid.UUID, country string) error {
+	unavailable, err := territoryRepo.UnavailableAlbums([]uuid.UUID{albumID}, country, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(unavailable) > 0 {
+		return ErrNotAvailableInRegion
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"crawl/repositories"
 	"errors"
 	"github.com/google/uuid"
+	"time"
 )
 
 type TipService interface {
@@ -18,17 +19,20 @@ type tipService struct {
 	tipRepo    repositories.ITipRepository
 	userRepo   repositories.IUserRepository
 	artistRepo repositories.IArtistRepository
+	payments   PaymentService
 }
 
 func NewTipService(
 	tipRepo repositories.ITipRepository,
 	userRepo repositories.IUserRepository,
 	artistRepo repositories.IArtistRepository,
+	payments PaymentService,
 ) TipService {
 	return &tipService{
 		tipRepo:    tipRepo,
 		userRepo:   userRepo,
 		artistRepo: artistRepo,
+		payments:   payments,
 	}
 }
 
@@ -54,7 +58,7 @@ func (s *tipService) SendTip(ctx context.Context, tip api.PostTipsJSONBody, send
 	}
 
 	// Verify artist exists
-	_, err = s.artistRepo.GetByID(tip.ArtistId)
+	artist, err := s.artistRepo.GetByID(tip.ArtistId)
 	if err != nil {
 		if errors.Is(err, repositories.ErrRecordNotFound) {
 			return nil, errors.New("artist not found")
@@ -62,18 +66,57 @@ func (s *tipService) SendTip(ctx context.Context, tip api.PostTipsJSONBody, send
 		return nil, err
 	}
 
-	// Create new tip
+	// The same tip sent again while its payment is pending, e.g. retried
+	// after a timeout, hands that payment back instead of charging twice
+	message := stringValue(tip.Message)
+	pending, err := s.tipRepo.FindPending(senderID, tip.ArtistId, tip.Amount, message, time.Now().Add(-paymentResumeWindow))
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		payment, err := s.payments.Resumable(ctx, models.PaymentForTip, pending.ID)
+		if err != nil {
+			return nil, err
+		}
+		if payment != nil {
+			pending.PaymentID = &payment.ID
+			pending.Payment = payment
+			return pending, nil
+		}
+	}
+
+	// The tip is pending until its payment goes through
 	newTip := &models.ArtistTip{
-		SenderID:            senderID,
-		ArtistID:            tip.ArtistId,
-		Amount:              tip.Amount,
-		Message:             *tip.Message,
-		Currency:            "NGN",
-		PaymentStatus:       "completed",
-		StripeTransactionID: tip.PaymentMethodId,
+		SenderID:      senderID,
+		ArtistID:      tip.ArtistId,
+		Amount:        tip.Amount,
+		Message:       message,
+		Currency:      "NGN",
+		PaymentStatus: models.PaymentPending,
 	}
 
-	return s.tipRepo.Create(newTip)
+	created, err := s.tipRepo.Create(newTip)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.payments.Charge(ctx, PaymentCharge{
+		UserID:          senderID,
+		Provider:        stringValue(tip.Provider),
+		Purpose:         models.PaymentForTip,
+		TargetID:        created.ID,
+		Amount:          float64(created.Amount),
+		Currency:        created.Currency,
+		PaymentMethodID: stringValue(tip.PaymentMethodId),
+		Description:     "Tip for " + artist.ArtistName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	created.PaymentID = &payment.ID
+	created.PaymentStatus = payment.Status
+	created.Payment = payment
+	return created, nil
 }
 
 func (s *tipService) GetArtistTips(ctx context.Context, artistID uuid.UUID, limit int) ([]models.ArtistTip, error) {
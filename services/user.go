@@ -19,12 +19,13 @@ type UserService interface {
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	GetArtistByUserId(ctx context.Context, userID uuid.UUID) (*models.Artist, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
-	GetUserPurchasedAlbums(ctx context.Context, userID uuid.UUID) ([]models.Album, error)
+	GetUserPurchasedAlbums(ctx context.Context, userID uuid.UUID, country string) ([]models.Album, error)
 	GetUserPurchaseHistory(ctx context.Context, userID uuid.UUID, params api.GetUsersUserIdLibraryPurchasesParams) ([]models.SongPurchase, error)
-	GetUserPurchasedSongs(ctx context.Context, userID uuid.UUID) ([]models.Song, error)
+	GetUserPurchasedSongs(ctx context.Context, userID uuid.UUID, country string) ([]models.Song, error)
 	GetUserPublicPlaylists(ctx context.Context, userID uuid.UUID) ([]models.Playlist, error)
 	GetUserPlaylists(ctx context.Context, userID uuid.UUID) ([]models.Playlist, error)
 	CreatePlaylist(ctx context.Context, userID uuid.UUID, playlist *models.Playlist) (*models.Playlist, error)
+	IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error)
 }
 
 type userService struct {
@@ -117,36 +118,16 @@ func (s *userService) GetUserByEmail(ctx context.Context, email string) (*models
 	return s.userRepo.FindByEmail(email)
 }
 
-func (s *userService) GetUserPurchasedAlbums(ctx context.Context, userID uuid.UUID) ([]models.Album, error) {
-	purchases, err := s.albumPurchaseRepo.GetUserAlbumPurchases(userID)
-	if err != nil {
-		return nil, err
-	}
-
-	albums := make([]models.Album, len(purchases))
-	for i, p := range purchases {
-		albums[i] = p.Album
-	}
-
-	return albums, nil
+func (s *userService) GetUserPurchasedAlbums(ctx context.Context, userID uuid.UUID, country string) ([]models.Album, error) {
+	return s.albumPurchaseRepo.GetLibraryAlbums(userID, country)
 }
 
 func (s *userService) GetUserPurchaseHistory(ctx context.Context, userID uuid.UUID, params api.GetUsersUserIdLibraryPurchasesParams) ([]models.SongPurchase, error) {
 	return s.songPurchaseRepo.GetUserSongPurchases(userID)
 }
 
-func (s *userService) GetUserPurchasedSongs(ctx context.Context, userID uuid.UUID) ([]models.Song, error) {
-	purchases, err := s.songPurchaseRepo.GetUserSongPurchases(userID)
-	if err != nil {
-		return nil, err
-	}
-
-	songs := make([]models.Song, len(purchases))
-	for i, p := range purchases {
-		songs[i] = p.Song
-	}
-
-	return songs, nil
+func (s *userService) GetUserPurchasedSongs(ctx context.Context, userID uuid.UUID, country string) ([]models.Song, error) {
+	return s.songPurchaseRepo.GetLibrarySongs(userID, country)
 }
 
 func (s *userService) GetUserPlaylists(ctx context.Context, userID uuid.UUID) ([]models.Playlist, error) {
@@ -159,9 +140,23 @@ func (s *userService) GetUserPublicPlaylists(ctx context.Context, userID uuid.UU
 
 func (s *userService) CreatePlaylist(ctx context.Context, userID uuid.UUID, playlist *models.Playlist) (*models.Playlist, error) {
 	playlist.UserID = userID
-	return s.playlistRepo.Create(playlist)
+	return s.playlistRepo.WithContext(ctx).Create(playlist)
 }
 
 func (s *userService) GetArtistByUserId(ctx context.Context, userID uuid.UUID) (*models.Artist, error) {
 	return s.artistRepo.GetWithUserId(userID)
 }
+
+func (s *userService) IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error) {
+	user, err := s.userRepo.GetWithRoles(userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range user.Roles {
+		if role.Name == "admin" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
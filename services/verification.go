@@ -0,0 +1,371 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"crawl/storage"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Limits on the evidence attached to a single submission
+const (
+	maxVerificationLinks     = 10
+	maxVerificationDocuments = 5
+	MaxVerificationDocSize   = 10 << 20
+)
+
+// Document types accepted as verification evidence
+var verificationDocumentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+}
+
+var (
+	ErrArtistNotFound       = errors.New("artist not found")
+	ErrVerificationNotFound = errors.New("verification request not found")
+	ErrEvidenceNotFound     = errors.New("evidence not found")
+	ErrAlreadyVerified      = errors.New("artist is already verified")
+	ErrNotVerified          = errors.New("artist is not verified")
+	ErrVerificationPending  = errors.New("a verification request is already awaiting review")
+	ErrVerificationClosed   = errors.New("verification request has already been decided")
+	ErrNoEvidence           = errors.New("at least one link or document is required")
+	ErrInvalidEvidence      = errors.New("invalid evidence")
+	ErrInvalidDecision      = errors.New("decision must be 'approve', 'reject' or 'needs_info'")
+	ErrReviewNotesRequired  = errors.New("notes are required to ask for more information or revoke verification")
+)
+
+// EvidenceUpload is a document submitted alongside a verification request
+type EvidenceUpload struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Content     io.Reader
+}
+
+type VerificationService interface {
+	SubmitRequest(ctx context.Context, artistID uuid.UUID, actorID uuid.UUID, message string, links []string, documents []EvidenceUpload) (*models.VerificationRequest, error)
+	GetArtistRequests(ctx context.Context, artistID uuid.UUID) ([]models.VerificationRequest, error)
+	GetQueue(ctx context.Context, status *string, page *int, limit *int) ([]models.VerificationRequest, error)
+	GetRequest(ctx context.Context, requestID uuid.UUID) (*models.VerificationRequest, error)
+	Review(ctx context.Context, requestID uuid.UUID, reviewerID uuid.UUID, decision string, notes string) (*models.VerificationRequest, error)
+	Revoke(ctx context.Context, artistID uuid.UUID, reviewerID uuid.UUID, notes string) error
+	OpenDocument(ctx context.Context, requestID uuid.UUID, evidenceID uuid.UUID) (*models.VerificationEvidence, io.ReadCloser, error)
+}
+
+type verificationService struct {
+	verificationRepo repositories.IVerificationRepository
+	artistRepo       repositories.IArtistRepository
+	blobs            storage.BlobStore
+}
+
+func NewVerificationService(
+	verificationRepo repositories.IVerificationRepository,
+	artistRepo repositories.IArtistRepository,
+	blobs storage.BlobStore,
+) VerificationService {
+	return &verificationService{
+		verificationRepo: verificationRepo,
+		artistRepo:       artistRepo,
+		blobs:            blobs,
+	}
+}
+
+func (s *verificationService) SubmitRequest(ctx context.Context, artistID uuid.UUID, actorID uuid.UUID, message string, links []string, documents []EvidenceUpload) (*models.VerificationRequest, error) {
+	artist, err := s.artistRepo.GetByID(artistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrArtistNotFound
+		}
+		return nil, err
+	}
+	if artist.Verified {
+		return nil, ErrAlreadyVerified
+	}
+
+	if len(links) == 0 && len(documents) == 0 {
+		return nil, ErrNoEvidence
+	}
+	if err := validateEvidence(links, documents); err != nil {
+		return nil, err
+	}
+
+	// An artist asked for more information answers on the same request;
+	// otherwise only one request may wait in the queue at a time
+	request, err := s.verificationRepo.GetLatestByStatus(artistID, models.VerificationPending, models.VerificationNeedsInfo)
+	action := "resubmitted"
+	switch {
+	case err == nil && request.Status == models.VerificationPending:
+		return nil, ErrVerificationPending
+	case err == nil:
+		if err := s.verificationRepo.UpdateStatus(request.ID, models.VerificationPending, nil, ""); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, repositories.ErrRecordNotFound):
+		action = "submitted"
+		request, err = s.verificationRepo.Create(&models.VerificationRequest{
+			ArtistID: artistID,
+			Status:   models.VerificationPending,
+			Message:  message,
+		})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	evidence, err := s.storeEvidence(ctx, request.ID, links, documents)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verificationRepo.AddEvidence(evidence); err != nil {
+		s.discardDocuments(ctx, evidence)
+		return nil, err
+	}
+
+	err = s.verificationRepo.AddEvent(&models.VerificationEvent{
+		ArtistID:  artistID,
+		RequestID: &request.ID,
+		ActorID:   actorID,
+		Action:    action,
+		Notes:     message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.verificationRepo.GetWithDetails(request.ID)
+}
+
+func (s *verificationService) GetArtistRequests(ctx context.Context, artistID uuid.UUID) ([]models.VerificationRequest, error) {
+	requests, err := s.verificationRepo.GetByArtist(artistID)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no requests found, return empty slice rather than nil
+	if requests == nil {
+		return []models.VerificationRequest{}, nil
+	}
+
+	return requests, nil
+}
+
+func (s *verificationService) GetQueue(ctx context.Context, status *string, page *int, limit *int) ([]models.VerificationRequest, error) {
+	var offset int
+	if page != nil && limit != nil {
+		offset = (*page - 1) * *limit
+	} else {
+		limit = new(int)
+		*limit = 20
+	}
+
+	// The queue defaults to everything still awaiting a decision
+	statuses := []string{models.VerificationPending, models.VerificationNeedsInfo}
+	if status != nil && *status != "" {
+		statuses = []string{*status}
+	}
+
+	requests, err := s.verificationRepo.GetQueue(statuses, offset, *limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if requests == nil {
+		return []models.VerificationRequest{}, nil
+	}
+
+	return requests, nil
+}
+
+func (s *verificationService) GetRequest(ctx context.Context, requestID uuid.UUID) (*models.VerificationRequest, error) {
+	request, err := s.verificationRepo.GetWithDetails(requestID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrVerificationNotFound
+		}
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *verificationService) Review(ctx context.Context, requestID uuid.UUID, reviewerID uuid.UUID, decision string, notes string) (*models.VerificationRequest, error) {
+	var status string
+	switch decision {
+	case "approve":
+		status = models.VerificationApproved
+	case "reject":
+		status = models.VerificationRejected
+	case "needs_info":
+		status = models.VerificationNeedsInfo
+		if strings.TrimSpace(notes) == "" {
+			return nil, ErrReviewNotesRequired
+		}
+	default:
+		return nil, ErrInvalidDecision
+	}
+
+	request, err := s.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != models.VerificationPending && request.Status != models.VerificationNeedsInfo {
+		return nil, ErrVerificationClosed
+	}
+
+	if err := s.verificationRepo.UpdateStatus(requestID, status, &reviewerID, notes); err != nil {
+		return nil, err
+	}
+
+	if status == models.VerificationApproved {
+		if err := s.artistRepo.SetVerified(ctx, request.ArtistID, true); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.verificationRepo.AddEvent(&models.VerificationEvent{
+		ArtistID:  request.ArtistID,
+		RequestID: &request.ID,
+		ActorID:   reviewerID,
+		Action:    status,
+		Notes:     notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetRequest(ctx, requestID)
+}
+
+func (s *verificationService) Revoke(ctx context.Context, artistID uuid.UUID, reviewerID uuid.UUID, notes string) error {
+	if strings.TrimSpace(notes) == "" {
+		return ErrReviewNotesRequired
+	}
+
+	artist, err := s.artistRepo.GetByID(artistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return ErrArtistNotFound
+		}
+		return err
+	}
+	if !artist.Verified {
+		return ErrNotVerified
+	}
+
+	if err := s.artistRepo.SetVerified(ctx, artistID, false); err != nil {
+		return err
+	}
+
+	// Artists verified before the workflow existed have no approved request to close
+	event := &models.VerificationEvent{
+		ArtistID: artistID,
+		ActorID:  reviewerID,
+		Action:   models.VerificationRevoked,
+		Notes:    notes,
+	}
+	approved, err := s.verificationRepo.GetLatestByStatus(artistID, models.VerificationApproved)
+	if err == nil {
+		if err := s.verificationRepo.UpdateStatus(approved.ID, models.VerificationRevoked, &reviewerID, notes); err != nil {
+			return err
+		}
+		event.RequestID = &approved.ID
+	} else if !errors.Is(err, repositories.ErrRecordNotFound) {
+		return err
+	}
+
+	return s.verificationRepo.AddEvent(event)
+}
+
+func (s *verificationService) OpenDocument(ctx context.Context, requestID uuid.UUID, evidenceID uuid.UUID) (*models.VerificationEvidence, io.ReadCloser, error) {
+	evidence, err := s.verificationRepo.GetEvidence(requestID, evidenceID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, nil, ErrEvidenceNotFound
+		}
+		return nil, nil, err
+	}
+	if evidence.Kind != models.EvidenceDocument {
+		return nil, nil, ErrEvidenceNotFound
+	}
+
+	content, err := s.blobs.Open(ctx, evidence.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, nil, ErrEvidenceNotFound
+		}
+		return nil, nil, err
+	}
+	return evidence, content, nil
+}
+
+func validateEvidence(links []string, documents []EvidenceUpload) error {
+	if len(links) > maxVerificationLinks {
+		return fmt.Errorf("%w: at most %d links", ErrInvalidEvidence, maxVerificationLinks)
+	}
+	for _, link := range links {
+		u, err := url.Parse(strings.TrimSpace(link))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(link) > 500 {
+			return fmt.Errorf("%w: %q is not a valid link", ErrInvalidEvidence, link)
+		}
+	}
+
+	if len(documents) > maxVerificationDocuments {
+		return fmt.Errorf("%w: at most %d documents", ErrInvalidEvidence, maxVerificationDocuments)
+	}
+	for _, doc := range documents {
+		if !verificationDocumentTypes[doc.ContentType] {
+			return fmt.Errorf("%w: %s must be a PDF, PNG or JPEG", ErrInvalidEvidence, doc.FileName)
+		}
+		if doc.Size > MaxVerificationDocSize {
+			return fmt.Errorf("%w: %s is larger than 10MB", ErrInvalidEvidence, doc.FileName)
+		}
+	}
+	return nil
+}
+
+// storeEvidence uploads the documents and returns the evidence rows to save
+func (s *verificationService) storeEvidence(ctx context.Context, requestID uuid.UUID, links []string, documents []EvidenceUpload) ([]models.VerificationEvidence, error) {
+	evidence := make([]models.VerificationEvidence, 0, len(links)+len(documents))
+	for _, link := range links {
+		evidence = append(evidence, models.VerificationEvidence{
+			RequestID: requestID,
+			Kind:      models.EvidenceLink,
+			URL:       strings.TrimSpace(link),
+		})
+	}
+
+	for _, doc := range documents {
+		key := path.Join("verification", requestID.String(), uuid.NewString())
+		if err := s.blobs.Put(ctx, key, io.LimitReader(doc.Content, MaxVerificationDocSize)); err != nil {
+			s.discardDocuments(ctx, evidence)
+			return nil, err
+		}
+
+		evidence = append(evidence, models.VerificationEvidence{
+			RequestID:   requestID,
+			Kind:        models.EvidenceDocument,
+			FileName:    path.Base(doc.FileName),
+			ContentType: doc.ContentType,
+			Size:        doc.Size,
+			StorageKey:  key,
+		})
+	}
+	return evidence, nil
+}
+
+func (s *verificationService) discardDocuments(ctx context.Context, evidence []models.VerificationEvidence) {
+	for _, e := range evidence {
+		if e.StorageKey != "" {
+			_ = s.blobs.Delete(ctx, e.StorageKey)
+		}
+	}
+}
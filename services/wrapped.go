@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"crawl/models"
+	"crawl/repositories"
+	"errors"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// wrappedBatch subjects are computed and stored at a time
+	wrappedBatch = 500
+	// wrappedTop items of each kind are listed on a report
+	wrappedTop = 5
+	// firstWrappedYear is the earliest year reports can be made for
+	firstWrappedYear = 2000
+)
+
+var (
+	ErrInvalidWrappedYear = errors.New("year in review can only be made for a year that has started")
+	ErrWrappedNotFound    = errors.New("year in review not found")
+)
+
+// WrappedRun counts the reports one run made
+type WrappedRun struct {
+	Year      int `json:"year"`
+	Listeners int `json:"listeners"`
+	Artists   int `json:"artists"`
+}
+
+type WrappedService interface {
+	// GenerateYear makes every listener's and artist's report for year from the
+	// streams so far, replacing the reports of the current version made before
+	GenerateYear(ctx context.Context, year int) (WrappedRun, error)
+	// GeneratePending makes the reports of the year just ended, until a run of
+	// them finishes
+	GeneratePending(ctx context.Context) error
+	GetListenerReport(ctx context.Context, userID uuid.UUID, year int) (*models.WrappedReport, error)
+	GetArtistReport(ctx context.Context, artistID uuid.UUID, year int) (*models.WrappedReport, error)
+}
+
+type wrappedService struct {
+	wrappedRepo repositories.IWrappedRepository
+}
+
+func NewWrappedService(wrappedRepo repositories.IWrappedRepository) WrappedService {
+	return &wrappedService{wrappedRepo: wrappedRepo}
+}
+
+func (s *wrappedService) GenerateYear(ctx context.Context, year int) (WrappedRun, error) {
+	run := WrappedRun{Year: year}
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// Reports made before the year ends count up to the start of today
+	until := from.AddDate(1, 0, 0)
+	if today := repositories.UTCDay(time.Now()); today.Before(until) {
+		until = today
+	}
+	if year < firstWrappedYear || !until.After(from) {
+		return run, ErrInvalidWrappedYear
+	}
+
+	document := models.WrappedDocument{
+		From:    from.Format("2006-01-02"),
+		Through: until.AddDate(0, 0, -1).Format("2006-01-02"),
+	}
+	generated := time.Now()
+
+	// Fans are ranked among all of an artist's listeners, not one batch of them
+	if err := s.wrappedRepo.RankFans(from, until); err != nil {
+		return run, err
+	}
+	for after := uuid.Nil; ; {
+		if err := ctx.Err(); err != nil {
+			return run, err
+		}
+		userIDs, err := s.wrappedRepo.ListenerIDs(from, until, after, wrappedBatch)
+		if err != nil {
+			return run, err
+		}
+		if len(userIDs) == 0 {
+			break
+		}
+		reports, err := s.listenerReports(userIDs, from, until, document, generated)
+		if err != nil {
+			return run, err
+		}
+		if err := s.wrappedRepo.SaveReports(reports); err != nil {
+			return run, err
+		}
+		run.Listeners += len(reports)
+		after = userIDs[len(userIDs)-1]
+	}
+	if err := s.wrappedRepo.ClearFans(year); err != nil {
+		return run, err
+	}
+
+	for after := uuid.Nil; ; {
+		if err := ctx.Err(); err != nil {
+			return run, err
+		}
+		artistIDs, err := s.wrappedRepo.ArtistIDs(from, until, after, wrappedBatch)
+		if err != nil {
+			return run, err
+		}
+		if len(artistIDs) == 0 {
+			break
+		}
+		reports, err := s.artistReports(artistIDs, from, until, document, generated)
+		if err != nil {
+			return run, err
+		}
+		if err := s.wrappedRepo.SaveReports(reports); err != nil {
+			return run, err
+		}
+		run.Artists += len(reports)
+		after = artistIDs[len(artistIDs)-1]
+	}
+
+	log.Infof("Made year in review %d for %d listeners and %d artists", year, run.Listeners, run.Artists)
+
+	// Only a run over the whole year is final; one made early is made again once it ends
+	if until.Equal(from.AddDate(1, 0, 0)) {
+		err := s.wrappedRepo.CompleteYear(models.WrappedYear{
+			Year:        year,
+			Version:     models.WrappedVersion,
+			Listeners:   run.Listeners,
+			Artists:     run.Artists,
+			CompletedAt: time.Now(),
+		})
+		if err != nil {
+			return run, err
+		}
+	}
+	return run, nil
+}
+
+// listenerReports makes the reports of a batch of listeners
+func (s *wrappedService) listenerReports(
+	userIDs []uuid.UUID,
+	from, until time.Time,
+	document models.WrappedDocument,
+	generated time.Time,
+) ([]models.WrappedReport, error) {
+	wrapped := make(map[uuid.UUID]*models.ListenerWrapped, len(userIDs))
+	for _, userID := range userIDs {
+		wrapped[userID] = &models.ListenerWrapped{
+			TopSongs:   []models.WrappedSong{},
+			TopArtists: []models.WrappedTopArtist{},
+			TopGenres:  []models.WrappedGenre{},
+		}
+	}
+
+	totals, err := s.wrappedRepo.ListenerTotals(userIDs, from, until)
+	if err != nil {
+		return nil, err
+	}
+	for _, total := range totals {
+		if listener, ok := wrapped[total.SubjectID]; ok {
+			listener.Streams = total.Streams
+			listener.MinutesListened = total.Minutes
+		}
+	}
+
+	songs, err := s.wrappedRepo.ListenerTopSongs(userIDs, from, until, wrappedTop)
+	if err != nil {
+		return nil, err
+	}
+	for _, song := range songs {
+		if listener, ok := wrapped[song.SubjectID]; ok {
+			listener.TopSongs = append(listener.TopSongs, song.WrappedSong)
+		}
+	}
+
+	artists, err := s.wrappedRepo.ListenerTopArtists(userIDs, from.Year(), wrappedTop)
+	if err != nil {
+		return nil, err
+	}
+	for _, artist := range artists {
+		if listener, ok := wrapped[artist.SubjectID]; ok {
+			listener.TopArtists = append(listener.TopArtists, artist.WrappedTopArtist)
+		}
+	}
+
+	genres, err := s.wrappedRepo.ListenerTopGenres(userIDs, from, until, wrappedTop)
+	if err != nil {
+		return nil, err
+	}
+	for _, genre := range genres {
+		if listener, ok := wrapped[genre.SubjectID]; ok {
+			listener.TopGenres = append(listener.TopGenres, genre.WrappedGenre)
+		}
+	}
+
+	days, err := s.wrappedRepo.ListenerDays(userIDs, from, until)
+	if err != nil {
+		return nil, err
+	}
+	played := map[uuid.UUID][]time.Time{}
+	for _, day := range days {
+		played[day.SubjectID] = append(played[day.SubjectID], repositories.UTCDay(day.Day))
+	}
+
+	reports := make([]models.WrappedReport, 0, len(userIDs))
+	for _, userID := range userIDs {
+		listener := wrapped[userID]
+		listener.DaysListened = len(played[userID])
+		listener.LongestStreak = longestStreak(played[userID])
+
+		doc := document
+		doc.Listener = listener
+		reports = append(reports, models.WrappedReport{
+			Kind:        models.WrappedListener,
+			SubjectID:   userID,
+			Year:        from.Year(),
+			Version:     models.WrappedVersion,
+			Document:    doc,
+			GeneratedAt: generated,
+		})
+	}
+	return reports, nil
+}
+
+// artistReports makes the reports of a batch of artists
+func (s *wrappedService) artistReports(
+	artistIDs []uuid.UUID,
+	from, until time.Time,
+	document models.WrappedDocument,
+	generated time.Time,
+) ([]models.WrappedReport, error) {
+	wrapped := make(map[uuid.UUID]*models.ArtistWrapped, len(artistIDs))
+	for _, artistID := range artistIDs {
+		wrapped[artistID] = &models.ArtistWrapped{
+			TopSongs:     []models.WrappedSong{},
+			TopCountries: []models.WrappedCountry{},
+		}
+	}
+
+	totals, err := s.wrappedRepo.ArtistTotals(artistIDs, from, until)
+	if err != nil {
+		return nil, err
+	}
+	for _, total := range totals {
+		if artist, ok := wrapped[total.SubjectID]; ok {
+			artist.Streams = total.Streams
+			artist.Listeners = total.Listeners
+			artist.MinutesListened = total.Minutes
+			artist.Countries = total.Countries
+		}
+	}
+
+	songs, err := s.wrappedRepo.ArtistTopSongs(artistIDs, from, until, wrappedTop)
+	if err != nil {
+		return nil, err
+	}
+	for _, song := range songs {
+		if artist, ok := wrapped[song.SubjectID]; ok {
+			artist.TopSongs = append(artist.TopSongs, song.WrappedSong)
+		}
+	}
+
+	countries, err := s.wrappedRepo.ArtistTopCountries(artistIDs, from, until, wrappedTop)
+	if err != nil {
+		return nil, err
+	}
+	for _, country := range countries {
+		if artist, ok := wrapped[country.SubjectID]; ok {
+			artist.TopCountries = append(artist.TopCountries, country.WrappedCountry)
+		}
+	}
+
+	reports := make([]models.WrappedReport, 0, len(artistIDs))
+	for _, artistID := range artistIDs {
+		doc := document
+		doc.Artist = wrapped[artistID]
+		reports = append(reports, models.WrappedReport{
+			Kind:        models.WrappedArtist,
+			SubjectID:   artistID,
+			Year:        from.Year(),
+			Version:     models.WrappedVersion,
+			Document:    doc,
+			GeneratedAt: generated,
+		})
+	}
+	return reports, nil
+}
+
+// longestStreak finds the longest run of consecutive days in days, which are
+// in order. The earliest run wins a tie.
+func longestStreak(days []time.Time) models.WrappedStreak {
+	longest := models.WrappedStreak{}
+	start := 0
+	for i := range days {
+		if i > 0 && !days[i].Equal(days[i-1].AddDate(0, 0, 1)) {
+			start = i
+		}
+		if length := i - start + 1; length > longest.Days {
+			longest = models.WrappedStreak{
+				Days:    length,
+				From:    days[start].Format("2006-01-02"),
+				Through: days[i].Format("2006-01-02"),
+			}
+		}
+	}
+	return longest
+}
+
+func (s *wrappedService) GeneratePending(ctx context.Context) error {
+	year := time.Now().UTC().Year() - 1
+	made, err := s.wrappedRepo.YearCompleted(year)
+	if err != nil || made {
+		return err
+	}
+	_, err = s.GenerateYear(ctx, year)
+	return err
+}
+
+func (s *wrappedService) GetListenerReport(ctx context.Context, userID uuid.UUID, year int) (*models.WrappedReport, error) {
+	return s.getReport(models.WrappedListener, userID, year)
+}
+
+func (s *wrappedService) GetArtistReport(ctx context.Context, artistID uuid.UUID, year int) (*models.WrappedReport, error) {
+	return s.getReport(models.WrappedArtist, artistID, year)
+}
+
+func (s *wrappedService) getReport(kind string, subjectID uuid.UUID, year int) (*models.WrappedReport, error) {
+	report, err := s.wrappedRepo.GetReport(kind, subjectID, year)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecordNotFound) {
+			return nil, ErrWrappedNotFound
+		}
+		return nil, err
+	}
+	return report, nil
+}